@@ -0,0 +1,162 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ProviderCapabilities are the features supported by a provider, so the modules can adapt to it,
+// e.g. skipping a check executed inside the container, instead of failing at runtime
+type ProviderCapabilities uint
+
+const (
+	// CapabilityExec is the support of the execution of commands inside the containers
+	CapabilityExec ProviderCapabilities = 1 << iota
+	// CapabilityBindMounts is the support of the bind mounts of the files of the host running the tests
+	CapabilityBindMounts
+	// CapabilityPrivileged is the support of the privileged containers
+	CapabilityPrivileged
+	// CapabilityIPv6 is the support of IPv6 in the default network of the containers
+	CapabilityIPv6
+)
+
+// capabilityNames are the names of the capabilities, used by the provider.capabilities.disabled property
+var capabilityNames = map[string]ProviderCapabilities{
+	"exec":       CapabilityExec,
+	"bindmounts": CapabilityBindMounts,
+	"privileged": CapabilityPrivileged,
+	"ipv6":       CapabilityIPv6,
+}
+
+// Has returns true if all the given capabilities are supported
+func (c ProviderCapabilities) Has(capabilities ProviderCapabilities) bool {
+	return c&capabilities == capabilities
+}
+
+// String returns the names of the capabilities, separated by commas
+func (c ProviderCapabilities) String() string {
+	names := []string{}
+	for _, name := range []string{"exec", "bindmounts", "privileged", "ipv6"} {
+		if c.Has(capabilityNames[name]) {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
+
+// CapabilityProvider is implemented by the providers able to report their capabilities
+type CapabilityProvider interface {
+	Capabilities(ctx context.Context) (ProviderCapabilities, error)
+}
+
+var _ CapabilityProvider = (*DockerProvider)(nil)
+
+// Capabilities returns the capabilities of the provider of the given type, detected from its daemon,
+// without the ones disabled in the Testcontainers configuration
+func (t ProviderType) Capabilities(ctx context.Context) (ProviderCapabilities, error) {
+	provider, err := t.GetProvider()
+	if err != nil {
+		return 0, err
+	}
+	defer provider.Close()
+
+	capabilityProvider, ok := provider.(CapabilityProvider)
+	if !ok {
+		return 0, fmt.Errorf("the provider %T does not report its capabilities", provider)
+	}
+
+	return capabilityProvider.Capabilities(ctx)
+}
+
+// Capabilities returns the capabilities of the Docker daemon:
+// - the commands can always be executed in the containers.
+// - the bind mounts are not supported by the remote daemons, which can't read the files of the host running the tests.
+// - the privileged containers are not supported by the daemons remapping the user namespaces.
+// - IPv6 is supported if it's enabled in the default bridge network.
+// The capabilities disabled in the Testcontainers configuration are removed, for the daemons not reporting them properly.
+func (p *DockerProvider) Capabilities(ctx context.Context) (ProviderCapabilities, error) {
+	info, err := p.daemonInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	capabilities := CapabilityExec
+
+	if !isRemoteDockerHost(p.host) {
+		capabilities |= CapabilityBindMounts
+	}
+
+	if !hasSecurityOption(info, "userns") {
+		capabilities |= CapabilityPrivileged
+	}
+
+	bridgeNetworkName := p.defaultBridgeNetworkName
+	if bridgeNetworkName == "" {
+		bridgeNetworkName = Bridge
+	}
+
+	bridge, err := p.client.NetworkInspect(ctx, bridgeNetworkName, types.NetworkInspectOptions{})
+	if err == nil && bridge.EnableIPv6 {
+		capabilities |= CapabilityIPv6
+	}
+
+	disabled, err := parseCapabilities(p.config.DisabledCapabilities)
+	if err != nil {
+		p.Logger.Printf("ignoring the disabled capabilities of the Testcontainers configuration: %v", err)
+		disabled = 0
+	}
+
+	return capabilities &^ disabled, nil
+}
+
+// parseCapabilities parses capability names separated by commas, e.g. "exec,ipv6"
+func parseCapabilities(names string) (ProviderCapabilities, error) {
+	var capabilities ProviderCapabilities
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		capability, ok := capabilityNames[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown capability %q, expected exec, bindmounts, privileged or ipv6", name)
+		}
+
+		capabilities |= capability
+	}
+
+	return capabilities, nil
+}
+
+// isRemoteDockerHost returns true if the Docker host is reached through TCP on another machine
+func isRemoteDockerHost(host string) bool {
+	u, err := url.Parse(host)
+	if err != nil || u.Scheme != "tcp" {
+		return false
+	}
+
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return false
+	}
+
+	return true
+}
+
+// hasSecurityOption returns true if the daemon runs with the given security option, e.g. userns or rootless
+func hasSecurityOption(info types.Info, name string) bool {
+	for _, option := range info.SecurityOptions {
+		if strings.Contains(option, "name="+name) {
+			return true
+		}
+	}
+
+	return false
+}
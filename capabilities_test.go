@@ -0,0 +1,104 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// daemonInfoClient returns the given daemon info and default bridge network, without a Docker daemon
+type daemonInfoClient struct {
+	client.APIClient
+	info   types.Info
+	bridge types.NetworkResource
+}
+
+func (c *daemonInfoClient) Info(_ context.Context) (types.Info, error) {
+	return c.info, nil
+}
+
+func (c *daemonInfoClient) DaemonHost() string {
+	return "unix:///var/run/docker.sock"
+}
+
+func (c *daemonInfoClient) NetworkInspect(_ context.Context, _ string, _ types.NetworkInspectOptions) (types.NetworkResource, error) {
+	return c.bridge, nil
+}
+
+func TestDockerProviderCapabilities(t *testing.T) {
+	newProvider := func(host string, c client.APIClient, config TestcontainersConfig) *DockerProvider {
+		return &DockerProvider{
+			DockerProviderOptions: &DockerProviderOptions{
+				GenericProviderOptions: &GenericProviderOptions{Logger: Logger},
+			},
+			client: c,
+			host:   host,
+			config: config,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		host   string
+		client *daemonInfoClient
+		config TestcontainersConfig
+		want   ProviderCapabilities
+	}{
+		{
+			name:   "local daemon",
+			host:   "unix:///var/run/docker.sock",
+			client: &daemonInfoClient{},
+			want:   CapabilityExec | CapabilityBindMounts | CapabilityPrivileged,
+		},
+		{
+			name:   "remote daemon with IPv6",
+			host:   "tcp://docker.example.com:2376",
+			client: &daemonInfoClient{bridge: types.NetworkResource{EnableIPv6: true}},
+			want:   CapabilityExec | CapabilityPrivileged | CapabilityIPv6,
+		},
+		{
+			name:   "daemon remapping the user namespaces",
+			host:   "tcp://127.0.0.1:2375",
+			client: &daemonInfoClient{info: types.Info{SecurityOptions: []string{"name=seccomp,profile=default", "name=userns"}}},
+			want:   CapabilityExec | CapabilityBindMounts,
+		},
+		{
+			name:   "capabilities disabled by the configuration",
+			host:   "unix:///var/run/docker.sock",
+			client: &daemonInfoClient{},
+			config: TestcontainersConfig{DisabledCapabilities: "exec, Privileged"},
+			want:   CapabilityBindMounts,
+		},
+		{
+			name:   "unknown capabilities disabled by the configuration are ignored",
+			host:   "unix:///var/run/docker.sock",
+			client: &daemonInfoClient{},
+			config: TestcontainersConfig{DisabledCapabilities: "exec,gpu"},
+			want:   CapabilityExec | CapabilityBindMounts | CapabilityPrivileged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetRuntimeInfoCache(t)
+
+			capabilities, err := newProvider(tt.host, tt.client, tt.config).Capabilities(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.String(), capabilities.String())
+		})
+	}
+}
+
+func TestProviderCapabilitiesHas(t *testing.T) {
+	capabilities := CapabilityExec | CapabilityIPv6
+
+	assert.True(t, capabilities.Has(CapabilityExec))
+	assert.True(t, capabilities.Has(CapabilityExec|CapabilityIPv6))
+	assert.False(t, capabilities.Has(CapabilityExec|CapabilityBindMounts))
+	assert.Equal(t, "exec,ipv6", capabilities.String())
+}
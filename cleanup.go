@@ -0,0 +1,81 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// CleanupPolicy defines when a container is removed after the test creating it
+type CleanupPolicy string
+
+const (
+	// CleanupAlways removes the container after the test, and when the session ends, which is the default
+	CleanupAlways CleanupPolicy = "Always"
+	// CleanupOnSuccess removes the container after the test only if it succeeds, keeping it running for inspection otherwise
+	CleanupOnSuccess CleanupPolicy = "OnSuccess"
+	// CleanupNever keeps the container running after the test, for manual inspection
+	CleanupNever CleanupPolicy = "Never"
+)
+
+// cleanupPolicies holds the cleanup policy of the containers created by the current process which could be kept
+// running, indexed by container ID, as the containers returned by the modules wrap the Docker container
+var cleanupPolicies sync.Map
+
+// CleanupContainer registers a cleanup function in the given test, which terminates the container according to the
+// cleanup policy of its request: with CleanupOnSuccess, the container is kept running if the test failed, and with
+// CleanupNever, it's always kept running. The containers kept running are not removed by the reaper when the session
+// ends, so it's a responsibility of the user to remove them after the inspection.
+func CleanupContainer(tb testing.TB, c Container) {
+	tb.Helper()
+
+	tb.Cleanup(func() {
+		policy := cleanupPolicyOf(c.GetContainerID())
+		if policy == CleanupNever || (policy == CleanupOnSuccess && tb.Failed()) {
+			tb.Logf("🔍 keeping container %s running for inspection, as its cleanup policy is %s", c.GetContainerID(), policy)
+			return
+		}
+
+		cleanupPolicies.Delete(c.GetContainerID())
+
+		if err := c.Terminate(context.Background()); err != nil {
+			tb.Errorf("failed to terminate container %s: %s", c.GetContainerID(), err)
+		}
+	})
+}
+
+// cleanupPolicyOf returns the cleanup policy of the container with the given ID
+func cleanupPolicyOf(containerID string) CleanupPolicy {
+	if policy, ok := cleanupPolicies.Load(containerID); ok {
+		return policy.(CleanupPolicy)
+	}
+
+	return CleanupAlways
+}
+
+// cleanupPolicy returns the cleanup policy of the request, falling back to the given default policy
+func (c *ContainerRequest) cleanupPolicy(defaultPolicy CleanupPolicy) CleanupPolicy {
+	if c.CleanupPolicy != "" {
+		return c.CleanupPolicy
+	}
+
+	if defaultPolicy != "" {
+		return defaultPolicy
+	}
+
+	return CleanupAlways
+}
+
+func (c *ContainerRequest) validateCleanupPolicy() error {
+	return validateCleanupPolicy(c.CleanupPolicy)
+}
+
+func validateCleanupPolicy(policy CleanupPolicy) error {
+	switch policy {
+	case "", CleanupAlways, CleanupOnSuccess, CleanupNever:
+		return nil
+	}
+
+	return fmt.Errorf("invalid cleanup policy %s, it must be one of %s, %s or %s", policy, CleanupAlways, CleanupOnSuccess, CleanupNever)
+}
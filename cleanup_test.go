@@ -0,0 +1,125 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+)
+
+// fakeTB records the cleanup functions and the failure of a test
+type fakeTB struct {
+	testing.TB
+	cleanups []func()
+	failed   bool
+}
+
+func (f *fakeTB) Helper()                                   {}
+func (f *fakeTB) Cleanup(fn func())                         { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Failed() bool                              { return f.failed }
+func (f *fakeTB) Logf(format string, args ...interface{})   {}
+func (f *fakeTB) Errorf(format string, args ...interface{}) { f.failed = true }
+
+func (f *fakeTB) runCleanups() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+// fakeContainer records whether it was terminated
+type fakeContainer struct {
+	Container
+	id         string
+	terminated bool
+}
+
+func (c *fakeContainer) GetContainerID() string { return c.id }
+
+func (c *fakeContainer) Terminate(_ context.Context, _ ...TerminateOption) error {
+	c.terminated = true
+	return nil
+}
+
+func TestCleanupContainer(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         CleanupPolicy
+		failed         bool
+		wantTerminated bool
+	}{
+		{name: "always, test succeeded", policy: CleanupAlways, wantTerminated: true},
+		{name: "always, test failed", policy: CleanupAlways, failed: true, wantTerminated: true},
+		{name: "on success, test succeeded", policy: CleanupOnSuccess, wantTerminated: true},
+		{name: "on success, test failed", policy: CleanupOnSuccess, failed: true, wantTerminated: false},
+		{name: "never, test succeeded", policy: CleanupNever, wantTerminated: false},
+		{name: "never, test failed", policy: CleanupNever, failed: true, wantTerminated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeContainer{id: t.Name()}
+			if tt.policy != CleanupAlways {
+				cleanupPolicies.Store(c.id, tt.policy)
+				defer cleanupPolicies.Delete(c.id)
+			}
+
+			tb := &fakeTB{failed: tt.failed}
+			CleanupContainer(tb, c)
+			tb.runCleanups()
+
+			assert.Equal(t, tt.wantTerminated, c.terminated)
+		})
+	}
+}
+
+func TestCleanupPolicyOfRequest(t *testing.T) {
+	assert.Equal(t, CleanupAlways, (&ContainerRequest{}).cleanupPolicy(""))
+	assert.Equal(t, CleanupNever, (&ContainerRequest{}).cleanupPolicy(CleanupNever))
+	assert.Equal(t, CleanupOnSuccess, (&ContainerRequest{CleanupPolicy: CleanupOnSuccess}).cleanupPolicy(CleanupNever))
+
+	require.Error(t, (&ContainerRequest{Image: nginxAlpineImage, CleanupPolicy: "Sometimes"}).Validate())
+}
+
+func TestCleanupPolicyLabels(t *testing.T) {
+	plan, err := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:         nginxAlpineImage,
+			Labels:        map[string]string{testcontainersdocker.LabelReap: "true"},
+			CleanupPolicy: CleanupNever,
+		},
+	}.DryRun()
+	require.NoError(t, err)
+
+	// the container keeps the labels of its session, without the label of the resources to reap
+	assert.NotContains(t, plan.Config.Labels, testcontainersdocker.LabelReap)
+	assert.Equal(t, SessionID(), plan.Config.Labels[testcontainersdocker.LabelSessionID])
+	assert.Equal(t, string(CleanupNever), plan.Config.Labels[testcontainersdocker.LabelCleanupPolicy])
+}
+
+func TestContainerKeptOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	// cleanupPolicy {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:         nginxAlpineImage,
+			CleanupPolicy: CleanupOnSuccess,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	CleanupContainer(t, c)
+	// }
+
+	inspect, err := c.(*DockerContainer).inspectContainer(ctx)
+	require.NoError(t, err)
+
+	// the reaper must not remove the container when the session ends, which is still labeled with the session
+	assert.NotContains(t, inspect.Config.Labels, testcontainersdocker.LabelReap)
+	assert.Equal(t, SessionID(), inspect.Config.Labels[testcontainersdocker.LabelSessionID])
+	assert.Equal(t, string(CleanupOnSuccess), inspect.Config.Labels[testcontainersdocker.LabelCleanupPolicy])
+}
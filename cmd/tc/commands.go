@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+)
+
+// session is a test session, identified by the session ID label of its resources
+type session struct {
+	id         string
+	containers int
+	// reaper is true if the reaper of the session is running, removing its resources when the session ends
+	reaper  bool
+	created time.Time
+}
+
+// removedResources counts the resources removed when a session is terminated
+type removedResources struct {
+	containers int
+	networks   int
+	volumes    int
+}
+
+// sessionFilters returns the filters matching the resources created by Testcontainers for Go,
+// only the ones of the given session if its ID is not empty
+func sessionFilters(sessionID string) filters.Args {
+	args := filters.NewArgs(filters.Arg("label", testcontainersdocker.LabelBase+"=true"))
+	if sessionID != "" {
+		args.Add("label", testcontainersdocker.LabelSessionID+"="+sessionID)
+	}
+
+	return args
+}
+
+// listContainers returns the running containers of the test sessions, only the ones of the given session if not empty,
+// sorted by creation date
+func listContainers(ctx context.Context, cli client.APIClient, sessionID string) ([]types.Container, error) {
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{
+		Filters: sessionFilters(sessionID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not list the containers", err)
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		return containers[i].Created < containers[j].Created
+	})
+
+	return containers, nil
+}
+
+// listSessions returns the test sessions with running containers, sorted by the creation date of their first container
+func listSessions(ctx context.Context, cli client.APIClient) ([]session, error) {
+	containers, err := listContainers(ctx, cli, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []session{}
+	indexes := map[string]int{}
+	for _, c := range containers {
+		id := c.Labels[testcontainersdocker.LabelSessionID]
+
+		i, ok := indexes[id]
+		if !ok {
+			i = len(sessions)
+			indexes[id] = i
+			sessions = append(sessions, session{id: id, created: time.Unix(c.Created, 0)})
+		}
+
+		sessions[i].containers++
+		if c.Labels[testcontainersdocker.LabelReaper] == "true" {
+			sessions[i].reaper = true
+		}
+	}
+
+	return sessions, nil
+}
+
+// terminateSession removes the containers, the networks and the volumes of the given session, as its reaper would do
+func terminateSession(ctx context.Context, cli client.APIClient, sessionID string) (removedResources, error) {
+	removed := removedResources{}
+	args := sessionFilters(sessionID)
+
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return removed, fmt.Errorf("%w: could not list the containers", err)
+	}
+
+	for _, c := range containers {
+		if err := cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			return removed, fmt.Errorf("%w: could not remove the container %s", err, shortID(c.ID))
+		}
+		removed.containers++
+	}
+
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return removed, fmt.Errorf("%w: could not list the networks", err)
+	}
+
+	for _, n := range networks {
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil {
+			return removed, fmt.Errorf("%w: could not remove the network %s", err, n.Name)
+		}
+		removed.networks++
+	}
+
+	volumes, err := cli.VolumeList(ctx, args)
+	if err != nil {
+		return removed, fmt.Errorf("%w: could not list the volumes", err)
+	}
+
+	for _, v := range volumes.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil {
+			return removed, fmt.Errorf("%w: could not remove the volume %s", err, v.Name)
+		}
+		removed.volumes++
+	}
+
+	return removed, nil
+}
+
+// printLogs prints the logs of the container, which must belong to a test session
+func printLogs(ctx context.Context, cli client.APIClient, containerID string, follow bool, tail string, stdout, stderr io.Writer) error {
+	info, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("%w: could not inspect the container %s", err, containerID)
+	}
+
+	if info.Config == nil || info.Config.Labels[testcontainersdocker.LabelBase] != "true" {
+		return fmt.Errorf("the container %s was not created by Testcontainers for Go", containerID)
+	}
+
+	logs, err := cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+		Tail:       tail,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: could not read the logs of the container %s", err, containerID)
+	}
+	defer logs.Close()
+
+	if info.Config.Tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+
+	return err
+}
+
+func printSessions(w io.Writer, sessions []session) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SESSION\tCONTAINERS\tREAPER\tSTARTED")
+
+	for _, s := range sessions {
+		reaper := "no"
+		if s.reaper {
+			reaper = "yes"
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", s.id, s.containers, reaper, s.created.Format(time.RFC3339))
+	}
+
+	return tw.Flush()
+}
+
+func printContainers(w io.Writer, containers []types.Container, showLabels bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	header := "CONTAINER\tIMAGE\tNAME\tSESSION\tPORTS"
+	if showLabels {
+		header += "\tLABELS"
+	}
+	fmt.Fprintln(tw, header)
+
+	for _, c := range containers {
+		name := ""
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", shortID(c.ID), c.Image, name, c.Labels[testcontainersdocker.LabelSessionID], formatPorts(c.Ports))
+		if showLabels {
+			line += "\t" + formatLabels(c.Labels)
+		}
+		fmt.Fprintln(tw, line)
+	}
+
+	return tw.Flush()
+}
+
+// formatPorts formats the ports published on the host, e.g. "0.0.0.0:49153->80/tcp", sorted by private port
+func formatPorts(ports []types.Port) string {
+	sorted := make([]types.Port, len(ports))
+	copy(sorted, ports)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PrivatePort < sorted[j].PrivatePort
+	})
+
+	formatted := []string{}
+	for _, p := range sorted {
+		if p.PublicPort == 0 {
+			continue
+		}
+
+		formatted = append(formatted, fmt.Sprintf("%s:%d->%d/%s", p.IP, p.PublicPort, p.PrivatePort, p.Type))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// formatLabels formats the labels as key=value pairs, sorted by key
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+
+	return id
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+)
+
+// fakeClient is a Docker client returning the given resources, and recording the removed ones.
+// The methods not overridden panic, as the embedded client is nil.
+type fakeClient struct {
+	client.APIClient
+
+	containers []types.Container
+	networks   []types.NetworkResource
+	volumes    []*volume.Volume
+
+	containerFilters filters.Args
+	removed          []string
+}
+
+func (c *fakeClient) ContainerList(_ context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	c.containerFilters = options.Filters
+	return c.containers, nil
+}
+
+func (c *fakeClient) ContainerRemove(_ context.Context, id string, options types.ContainerRemoveOptions) error {
+	if !options.Force || !options.RemoveVolumes {
+		return errUnexpectedRemoveOptions
+	}
+
+	c.removed = append(c.removed, "container "+id)
+	return nil
+}
+
+func (c *fakeClient) NetworkList(_ context.Context, _ types.NetworkListOptions) ([]types.NetworkResource, error) {
+	return c.networks, nil
+}
+
+func (c *fakeClient) NetworkRemove(_ context.Context, id string) error {
+	c.removed = append(c.removed, "network "+id)
+	return nil
+}
+
+func (c *fakeClient) VolumeList(_ context.Context, _ filters.Args) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: c.volumes}, nil
+}
+
+func (c *fakeClient) VolumeRemove(_ context.Context, id string, _ bool) error {
+	c.removed = append(c.removed, "volume "+id)
+	return nil
+}
+
+var errUnexpectedRemoveOptions = errors.New("the containers must be force removed with their anonymous volumes")
+
+func sessionLabels(sessionID string, reaper bool) map[string]string {
+	labels := map[string]string{
+		testcontainersdocker.LabelBase:      "true",
+		testcontainersdocker.LabelSessionID: sessionID,
+	}
+	if reaper {
+		labels[testcontainersdocker.LabelReaper] = "true"
+	}
+
+	return labels
+}
+
+func TestListSessions(t *testing.T) {
+	cli := &fakeClient{
+		containers: []types.Container{
+			{ID: "c3", Created: 30, Labels: sessionLabels("session-2", false)},
+			{ID: "c1", Created: 10, Labels: sessionLabels("session-1", false)},
+			{ID: "c2", Created: 20, Labels: sessionLabels("session-1", true)},
+		},
+	}
+
+	sessions, err := listSessions(context.Background(), cli)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+
+	if sessions[0].id != "session-1" || sessions[0].containers != 2 || !sessions[0].reaper || sessions[0].created.Unix() != 10 {
+		t.Fatalf("unexpected first session: %+v", sessions[0])
+	}
+
+	if sessions[1].id != "session-2" || sessions[1].containers != 1 || sessions[1].reaper {
+		t.Fatalf("unexpected second session: %+v", sessions[1])
+	}
+
+	if !cli.containerFilters.ExactMatch("label", testcontainersdocker.LabelBase+"=true") {
+		t.Fatalf("expected the containers to be filtered by the label %s", testcontainersdocker.LabelBase)
+	}
+}
+
+func TestListContainersOfSession(t *testing.T) {
+	cli := &fakeClient{}
+
+	if _, err := listContainers(context.Background(), cli, "session-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := cli.containerFilters.Get("label")
+	if len(labels) != 2 {
+		t.Fatalf("expected 2 label filters, got %v", labels)
+	}
+
+	if !cli.containerFilters.ExactMatch("label", testcontainersdocker.LabelSessionID+"=session-1") {
+		t.Fatalf("expected the containers to be filtered by the session ID, got %v", labels)
+	}
+}
+
+func TestTerminateSession(t *testing.T) {
+	cli := &fakeClient{
+		containers: []types.Container{
+			{ID: "c1", Labels: sessionLabels("session-1", true)},
+			{ID: "c2", Labels: sessionLabels("session-1", false)},
+		},
+		networks: []types.NetworkResource{{ID: "n1", Name: "network-1"}},
+		volumes:  []*volume.Volume{{Name: "v1"}},
+	}
+
+	removed, err := terminateSession(context.Background(), cli, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != (removedResources{containers: 2, networks: 1, volumes: 1}) {
+		t.Fatalf("unexpected removed resources: %+v", removed)
+	}
+
+	expected := "container c1,container c2,network n1,volume v1"
+	if got := strings.Join(cli.removed, ","); got != expected {
+		t.Fatalf("expected to remove %s, got %s", expected, got)
+	}
+}
+
+func TestFormatPorts(t *testing.T) {
+	ports := []types.Port{
+		{IP: "0.0.0.0", PrivatePort: 8080, PublicPort: 49154, Type: "tcp"},
+		{PrivatePort: 9000, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 53, PublicPort: 49153, Type: "udp"},
+	}
+
+	expected := "0.0.0.0:49153->53/udp, 0.0.0.0:49154->8080/tcp"
+	if got := formatPorts(ports); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestPrintContainers(t *testing.T) {
+	containers := []types.Container{
+		{
+			ID:     "0123456789abcdef",
+			Image:  "nginx:alpine",
+			Names:  []string{"/nginx"},
+			Labels: sessionLabels("session-1", false),
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := printContainers(buf, containers, true); err != nil {
+		t.Fatal(err)
+	}
+
+	output := buf.String()
+	for _, expected := range []string{"0123456789ab", "nginx:alpine", " nginx ", "session-1", testcontainersdocker.LabelBase + "=true"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected the output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if strings.Contains(output, "0123456789abcdef") {
+		t.Fatalf("expected the container ID to be shortened, got:\n%s", output)
+	}
+}
@@ -0,0 +1,130 @@
+// tc is a companion of Testcontainers for Go, to inspect and manage the resources of the test sessions
+// without knowing the Docker filters matching their labels:
+//
+//	tc sessions                   lists the test sessions with running containers
+//	tc ps [-session id] [-labels] lists the containers of the test sessions, with their mapped ports
+//	tc logs [-f] [-tail n] id     prints the logs of a container of a test session
+//	tc terminate id               removes the containers, networks and volumes of a test session
+//
+// It connects to the Docker host configured for Testcontainers, e.g. in the ~/.testcontainers.properties file.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const usage = `Usage: tc <command> [arguments]
+
+Commands:
+  sessions                    list the test sessions with running containers
+  ps [-session id] [-labels]  list the containers of the test sessions, with their mapped ports
+  logs [-f] [-tail n] id      print the logs of a container of a test session
+  terminate id                remove the containers, networks and volumes of a test session
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "tc: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+
+	switch command {
+	case "sessions":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+
+		cli, err := testcontainers.NewDockerClient()
+		if err != nil {
+			return err
+		}
+		defer cli.Close()
+
+		sessions, err := listSessions(ctx, cli)
+		if err != nil {
+			return err
+		}
+
+		return printSessions(os.Stdout, sessions)
+	case "ps":
+		sessionID := flags.String("session", "", "only list the containers of the given test session")
+		showLabels := flags.Bool("labels", false, "print the labels of the containers")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+
+		cli, err := testcontainers.NewDockerClient()
+		if err != nil {
+			return err
+		}
+		defer cli.Close()
+
+		containers, err := listContainers(ctx, cli, *sessionID)
+		if err != nil {
+			return err
+		}
+
+		return printContainers(os.Stdout, containers, *showLabels)
+	case "logs":
+		follow := flags.Bool("f", false, "follow the logs")
+		tail := flags.String("tail", "all", "number of lines to print from the end of the logs")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return fmt.Errorf("the logs command expects the ID of a container")
+		}
+
+		cli, err := testcontainers.NewDockerClient()
+		if err != nil {
+			return err
+		}
+		defer cli.Close()
+
+		return printLogs(ctx, cli, flags.Arg(0), *follow, *tail, os.Stdout, os.Stderr)
+	case "terminate":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return fmt.Errorf("the terminate command expects the ID of a test session")
+		}
+
+		cli, err := testcontainers.NewDockerClient()
+		if err != nil {
+			return err
+		}
+		defer cli.Close()
+
+		removed, err := terminateSession(ctx, cli, flags.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("removed %d containers, %d networks and %d volumes\n", removed.containers, removed.networks, removed.volumes)
+		return nil
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return nil
+	}
+
+	return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+}
@@ -1,13 +1,15 @@
 package testcontainers
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/magiconair/properties"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
 )
 
 var tcConfig TestcontainersConfig
@@ -21,6 +23,56 @@ type TestcontainersConfig struct {
 	CertPath       string `properties:"docker.cert.path,default="`
 	RyukDisabled   bool   `properties:"ryuk.disabled,default=false"`
 	RyukPrivileged bool   `properties:"ryuk.container.privileged,default=false"`
+
+	// HubImageNamePrefix is prepended to the names of the Docker Hub images, e.g. to pull them from a registry mirror
+	HubImageNamePrefix string `properties:"hub.image.name.prefix,default="`
+
+	// ImageSubstitutions replaces the images of the requests, with the format <image>=<substitute>, separated by semicolons,
+	// e.g. "postgres:15=registry.example.com/postgres:15;redis:7=registry.example.com/redis:7"
+	ImageSubstitutions string `properties:"image.substitutions,default="`
+
+	// PullPolicy is the pull policy of the requests not defining one, PullIfNotPresent if empty
+	PullPolicy ImagePullPolicy `properties:"pull.policy,default="`
+
+	// CleanupPolicy is the cleanup policy of the requests not defining one, CleanupAlways if empty
+	CleanupPolicy CleanupPolicy `properties:"cleanup.policy,default="`
+
+	// MetadataFile is the path of a JSON file describing the running containers, to be read by external tools
+	MetadataFile string `properties:"metadata.file,default="`
+
+	// DockerAPIRateLimit is the maximum number of inspections and logs requests sent to the Docker API per second,
+	// shared by all the containers of the session. There is no limit if it's zero.
+	DockerAPIRateLimit float64 `properties:"docker.api.rate.limit,default=0"`
+
+	// DockerAPIRateBurst is the number of requests sent at once above the rate limit, the rate limit rounded up if zero
+	DockerAPIRateBurst int `properties:"docker.api.rate.burst,default=0"`
+
+	// DockerAPIMaxRetries is the maximum number of retries of the inspections, the logs and the executions requests
+	// failing with a transient error of the Docker daemon, e.g. a connection reset. They are not retried if it's zero.
+	DockerAPIMaxRetries int `properties:"docker.api.max.retries,default=0"`
+
+	// DisabledCapabilities are the capabilities of the provider not supported by the daemon, separated by commas,
+	// e.g. "exec,ipv6", for the daemons not reporting them properly
+	DisabledCapabilities string `properties:"provider.capabilities.disabled,default="`
+
+	// ProviderType is the name of the provider used by the requests with the default provider type,
+	// e.g. "podman" or the name of a registered provider, auto-detected from the Docker host if empty
+	ProviderType string `properties:"provider.type,default="`
+
+	// PortTunnel defines when the mapped ports of the containers are forwarded to local ports through the Docker API,
+	// for the Docker hosts whose mapped ports are not reachable from the tests. They are never forwarded if it's empty.
+	PortTunnel PortTunnelMode `properties:"port.tunnel,default="`
+
+	// StartupRetryAttempts is the maximum number of attempts to create the containers of the requests without a startup
+	// retry policy, retrying the transient errors of the registries and of the daemon. They are not retried if it's lower than 2.
+	StartupRetryAttempts int `properties:"startup.retry.attempts,default=0"`
+
+	// HTTPProxy, HTTPSProxy and NoProxy are the proxies of the requests to the remote Docker daemons and of the image builds,
+	// overriding the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables, e.g. http://proxy.example.com:3128
+	// or socks5://proxy.example.com:1080. The images are pulled by the Docker daemon, which must be configured with its own proxy.
+	HTTPProxy  string `properties:"proxy.http,default="`
+	HTTPSProxy string `properties:"proxy.https,default="`
+	NoProxy    string `properties:"proxy.no,default="`
 }
 
 // }
@@ -55,7 +107,18 @@ func readConfig() TestcontainersConfig {
 			config.Host = dockerHostEnv
 		}
 		if config.Host == "" {
-			config.Host = "unix:///var/run/docker.sock"
+			// auto-detect the container runtime, using Podman if Docker is not available
+			config.Host = testcontainersdocker.DefaultDockerHost()
+		}
+
+		if tlsVerify, err := strconv.ParseBool(os.Getenv("DOCKER_TLS_VERIFY")); err == nil {
+			config.TLSVerify = 0
+			if tlsVerify {
+				config.TLSVerify = 1
+			}
+		}
+		if certPathEnv := os.Getenv("DOCKER_CERT_PATH"); certPathEnv != "" {
+			config.CertPath = certPathEnv
 		}
 
 		ryukDisabledEnv := os.Getenv("TESTCONTAINERS_RYUK_DISABLED")
@@ -68,6 +131,108 @@ func readConfig() TestcontainersConfig {
 			config.RyukPrivileged = ryukPrivilegedEnv == "true"
 		}
 
+		if hubImageNamePrefixEnv := os.Getenv("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX"); hubImageNamePrefixEnv != "" {
+			config.HubImageNamePrefix = hubImageNamePrefixEnv
+		}
+
+		if imageSubstitutionsEnv := os.Getenv("TESTCONTAINERS_IMAGE_SUBSTITUTIONS"); imageSubstitutionsEnv != "" {
+			config.ImageSubstitutions = imageSubstitutionsEnv
+		}
+
+		if pullPolicyEnv := os.Getenv("TESTCONTAINERS_PULL_POLICY"); pullPolicyEnv != "" {
+			config.PullPolicy = ImagePullPolicy(pullPolicyEnv)
+		}
+
+		if err := (&ContainerRequest{ImagePullPolicy: config.PullPolicy}).validateImagePullPolicy(); err != nil {
+			Logger.Printf("ignoring the pull policy of the Testcontainers configuration: %v", err)
+			config.PullPolicy = ""
+		}
+
+		if cleanupPolicyEnv := os.Getenv("TESTCONTAINERS_CLEANUP_POLICY"); cleanupPolicyEnv != "" {
+			config.CleanupPolicy = CleanupPolicy(cleanupPolicyEnv)
+		}
+
+		if metadataFileEnv := os.Getenv("TESTCONTAINERS_METADATA_FILE"); metadataFileEnv != "" {
+			config.MetadataFile = metadataFileEnv
+		}
+
+		if err := validateCleanupPolicy(config.CleanupPolicy); err != nil {
+			Logger.Printf("ignoring the cleanup policy of the Testcontainers configuration: %v", err)
+			config.CleanupPolicy = ""
+		}
+
+		if rateLimitEnv := os.Getenv("TESTCONTAINERS_DOCKER_API_RATE_LIMIT"); rateLimitEnv != "" {
+			rateLimit, err := strconv.ParseFloat(rateLimitEnv, 64)
+			if err != nil || rateLimit < 0 {
+				Logger.Printf("ignoring the invalid Docker API rate limit of the environment: %s", rateLimitEnv)
+			} else {
+				config.DockerAPIRateLimit = rateLimit
+			}
+		}
+
+		if rateBurstEnv := os.Getenv("TESTCONTAINERS_DOCKER_API_RATE_BURST"); rateBurstEnv != "" {
+			rateBurst, err := strconv.Atoi(rateBurstEnv)
+			if err != nil || rateBurst < 0 {
+				Logger.Printf("ignoring the invalid Docker API rate burst of the environment: %s", rateBurstEnv)
+			} else {
+				config.DockerAPIRateBurst = rateBurst
+			}
+		}
+
+		if maxRetriesEnv := os.Getenv("TESTCONTAINERS_DOCKER_API_MAX_RETRIES"); maxRetriesEnv != "" {
+			maxRetries, err := strconv.Atoi(maxRetriesEnv)
+			if err != nil || maxRetries < 0 {
+				Logger.Printf("ignoring the invalid Docker API max retries of the environment: %s", maxRetriesEnv)
+			} else {
+				config.DockerAPIMaxRetries = maxRetries
+			}
+		}
+
+		if disabledCapabilitiesEnv := os.Getenv("TESTCONTAINERS_PROVIDER_CAPABILITIES_DISABLED"); disabledCapabilitiesEnv != "" {
+			config.DisabledCapabilities = disabledCapabilitiesEnv
+		}
+
+		if providerTypeEnv := os.Getenv("TESTCONTAINERS_PROVIDER_TYPE"); providerTypeEnv != "" {
+			config.ProviderType = providerTypeEnv
+		}
+
+		if portTunnelEnv := os.Getenv("TESTCONTAINERS_PORT_TUNNEL"); portTunnelEnv != "" {
+			config.PortTunnel = PortTunnelMode(portTunnelEnv)
+		}
+
+		if startupRetryAttemptsEnv := os.Getenv("TESTCONTAINERS_STARTUP_RETRY_ATTEMPTS"); startupRetryAttemptsEnv != "" {
+			attempts, err := strconv.Atoi(startupRetryAttemptsEnv)
+			if err != nil || attempts < 0 {
+				Logger.Printf("ignoring the invalid startup retry attempts of the environment: %s", startupRetryAttemptsEnv)
+			} else {
+				config.StartupRetryAttempts = attempts
+			}
+		}
+
+		if httpProxyEnv := os.Getenv("TESTCONTAINERS_HTTP_PROXY"); httpProxyEnv != "" {
+			config.HTTPProxy = httpProxyEnv
+		}
+		if httpsProxyEnv := os.Getenv("TESTCONTAINERS_HTTPS_PROXY"); httpsProxyEnv != "" {
+			config.HTTPSProxy = httpsProxyEnv
+		}
+		if noProxyEnv := os.Getenv("TESTCONTAINERS_NO_PROXY"); noProxyEnv != "" {
+			config.NoProxy = noProxyEnv
+		}
+
+		if err := validateProxyURL(config.HTTPProxy); err != nil {
+			Logger.Printf("ignoring the HTTP proxy of the Testcontainers configuration: %v", err)
+			config.HTTPProxy = ""
+		}
+		if err := validateProxyURL(config.HTTPSProxy); err != nil {
+			Logger.Printf("ignoring the HTTPS proxy of the Testcontainers configuration: %v", err)
+			config.HTTPSProxy = ""
+		}
+
+		if err := validatePortTunnelMode(config.PortTunnel); err != nil {
+			Logger.Printf("ignoring the port tunnel mode of the Testcontainers configuration: %v", err)
+			config.PortTunnel = ""
+		}
+
 		return config
 	}
 
@@ -84,15 +249,43 @@ func readConfig() TestcontainersConfig {
 	}
 
 	if err := properties.Decode(&config); err != nil {
-		fmt.Printf("invalid testcontainers properties file, returning an empty Testcontainers configuration: %v\n", err)
+		Logger.Printf("invalid testcontainers properties file, returning an empty Testcontainers configuration: %v", err)
 		return applyEnvironmentConfiguration(config)
 	}
 
-	fmt.Printf("Testcontainers properties file has been found: %s\n", tcProp)
+	Logger.Printf("Testcontainers properties file has been found: %s", tcProp)
 
 	return applyEnvironmentConfiguration(config)
 }
 
+// substituteImage returns the image to be used instead of the given one: the substitute defined in the ImageSubstitutions,
+// if any, prefixed with the HubImageNamePrefix if it's a Docker Hub image
+func (c TestcontainersConfig) substituteImage(image string) string {
+	for _, s := range strings.Split(c.ImageSubstitutions, ";") {
+		original, substitute, ok := strings.Cut(strings.TrimSpace(s), "=")
+		if ok && strings.TrimSpace(original) == image {
+			image = strings.TrimSpace(substitute)
+			break
+		}
+	}
+
+	if c.HubImageNamePrefix != "" && isDockerHubImage(image) {
+		image = strings.TrimSuffix(c.HubImageNamePrefix, "/") + "/" + strings.TrimPrefix(image, "docker.io/")
+	}
+
+	return image
+}
+
+// isDockerHubImage returns true if the image does not define a registry, or if the registry is docker.io
+func isDockerHubImage(image string) bool {
+	registry, _, ok := strings.Cut(image, "/")
+	if !ok || registry == "docker.io" {
+		return true
+	}
+
+	return !strings.ContainsAny(registry, ".:") && registry != "localhost"
+}
+
 func parseBool(input string) bool {
 	if _, err := strconv.ParseBool(input); err == nil {
 		return true
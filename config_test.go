@@ -21,6 +21,23 @@ const (
 func resetTestEnv(t *testing.T) {
 	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONTAINER_PRIVILEGED", "")
+	t.Setenv("TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX", "")
+	t.Setenv("TESTCONTAINERS_IMAGE_SUBSTITUTIONS", "")
+	t.Setenv("TESTCONTAINERS_PULL_POLICY", "")
+	t.Setenv("TESTCONTAINERS_CLEANUP_POLICY", "")
+	t.Setenv("TESTCONTAINERS_METADATA_FILE", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_API_RATE_LIMIT", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_API_RATE_BURST", "")
+	t.Setenv("TESTCONTAINERS_DOCKER_API_MAX_RETRIES", "")
+	t.Setenv("TESTCONTAINERS_PROVIDER_CAPABILITIES_DISABLED", "")
+	t.Setenv("TESTCONTAINERS_PROVIDER_TYPE", "")
+	t.Setenv("TESTCONTAINERS_PORT_TUNNEL", "")
+	t.Setenv("TESTCONTAINERS_STARTUP_RETRY_ATTEMPTS", "")
+	t.Setenv("TESTCONTAINERS_HTTP_PROXY", "")
+	t.Setenv("TESTCONTAINERS_HTTPS_PROXY", "")
+	t.Setenv("TESTCONTAINERS_NO_PROXY", "")
+	t.Setenv("DOCKER_TLS_VERIFY", "")
+	t.Setenv("DOCKER_CERT_PATH", "")
 }
 
 func TestReadConfig(t *testing.T) {
@@ -405,6 +422,223 @@ func TestReadTCConfig(t *testing.T) {
 					RyukPrivileged: false,
 				},
 			},
+			{
+				"With TLS verify and cert path using env vars. Env vars win",
+				`docker.tls.verify = 0
+				docker.cert.path = /tmp/certs`,
+				map[string]string{
+					"DOCKER_TLS_VERIFY": "1",
+					"DOCKER_CERT_PATH":  "/home/user/.docker",
+				},
+				TestcontainersConfig{
+					Host:      dockerSock,
+					TLSVerify: 1,
+					CertPath:  "/home/user/.docker",
+				},
+			},
+			{
+				"With image settings using properties",
+				`hub.image.name.prefix = registry.example.com/mirror
+				image.substitutions = redis:7=registry.example.com/redis:7
+				pull.policy = Always`,
+				map[string]string{},
+				TestcontainersConfig{
+					Host:               dockerSock,
+					HubImageNamePrefix: "registry.example.com/mirror",
+					ImageSubstitutions: "redis:7=registry.example.com/redis:7",
+					PullPolicy:         PullAlways,
+				},
+			},
+			{
+				"With image settings using env vars. Env vars win",
+				`hub.image.name.prefix = registry.example.com/mirror
+				pull.policy = Always`,
+				map[string]string{
+					"TESTCONTAINERS_HUB_IMAGE_NAME_PREFIX": "mirror.example.com",
+					"TESTCONTAINERS_IMAGE_SUBSTITUTIONS":   "postgres:15=mirror.example.com/postgres:15",
+					"TESTCONTAINERS_PULL_POLICY":           "Never",
+				},
+				TestcontainersConfig{
+					Host:               dockerSock,
+					HubImageNamePrefix: "mirror.example.com",
+					ImageSubstitutions: "postgres:15=mirror.example.com/postgres:15",
+					PullPolicy:         PullNever,
+				},
+			},
+			{
+				"With cleanup policy using an env var and properties. Env var wins",
+				`cleanup.policy = Never`,
+				map[string]string{
+					"TESTCONTAINERS_CLEANUP_POLICY": "OnSuccess",
+				},
+				TestcontainersConfig{
+					Host:          dockerSock,
+					CleanupPolicy: CleanupOnSuccess,
+				},
+			},
+			{
+				"With metadata file using an env var and properties. Env var wins",
+				`metadata.file = /tmp/properties.json`,
+				map[string]string{
+					"TESTCONTAINERS_METADATA_FILE": "/tmp/env.json",
+				},
+				TestcontainersConfig{
+					Host:         dockerSock,
+					MetadataFile: "/tmp/env.json",
+				},
+			},
+			{
+				"With Docker API rate limit using properties",
+				`docker.api.rate.limit = 2.5
+				docker.api.rate.burst = 5`,
+				map[string]string{},
+				TestcontainersConfig{
+					Host:               dockerSock,
+					DockerAPIRateLimit: 2.5,
+					DockerAPIRateBurst: 5,
+				},
+			},
+			{
+				"With Docker API rate limit using an env var and properties. Env var wins",
+				`docker.api.rate.limit = 2.5`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_API_RATE_LIMIT": "10",
+					"TESTCONTAINERS_DOCKER_API_RATE_BURST": "20",
+				},
+				TestcontainersConfig{
+					Host:               dockerSock,
+					DockerAPIRateLimit: 10,
+					DockerAPIRateBurst: 20,
+				},
+			},
+			{
+				"With an invalid Docker API rate limit using an env var, which is ignored",
+				`docker.api.rate.limit = 2.5`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_API_RATE_LIMIT": "fast",
+				},
+				TestcontainersConfig{
+					Host:               dockerSock,
+					DockerAPIRateLimit: 2.5,
+				},
+			},
+			{
+				"With Docker API max retries using an env var and properties. Env var wins",
+				`docker.api.max.retries = 2`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_API_MAX_RETRIES": "5",
+				},
+				TestcontainersConfig{
+					Host:                dockerSock,
+					DockerAPIMaxRetries: 5,
+				},
+			},
+			{
+				"With invalid Docker API max retries using an env var, which are ignored",
+				`docker.api.max.retries = 2`,
+				map[string]string{
+					"TESTCONTAINERS_DOCKER_API_MAX_RETRIES": "-1",
+				},
+				TestcontainersConfig{
+					Host:                dockerSock,
+					DockerAPIMaxRetries: 2,
+				},
+			},
+			{
+				"With disabled capabilities using an env var and properties. Env var wins",
+				`provider.capabilities.disabled = ipv6`,
+				map[string]string{
+					"TESTCONTAINERS_PROVIDER_CAPABILITIES_DISABLED": "exec,ipv6",
+				},
+				TestcontainersConfig{
+					Host:                 dockerSock,
+					DisabledCapabilities: "exec,ipv6",
+				},
+			},
+			{
+				"With a provider type using an env var and properties. Env var wins",
+				`provider.type = docker`,
+				map[string]string{
+					"TESTCONTAINERS_PROVIDER_TYPE": "farm",
+				},
+				TestcontainersConfig{
+					Host:         dockerSock,
+					ProviderType: "farm",
+				},
+			},
+			{
+				"With a port tunnel mode using an env var and properties. Env var wins",
+				`port.tunnel = always`,
+				map[string]string{
+					"TESTCONTAINERS_PORT_TUNNEL": "auto",
+				},
+				TestcontainersConfig{
+					Host:       dockerSock,
+					PortTunnel: PortTunnelAuto,
+				},
+			},
+			{
+				"With an invalid port tunnel mode, which is ignored",
+				`port.tunnel = sometimes`,
+				map[string]string{},
+				TestcontainersConfig{
+					Host: dockerSock,
+				},
+			},
+			{
+				"With startup retry attempts using an env var and properties. Env var wins",
+				`startup.retry.attempts = 3`,
+				map[string]string{
+					"TESTCONTAINERS_STARTUP_RETRY_ATTEMPTS": "5",
+				},
+				TestcontainersConfig{
+					Host:                 dockerSock,
+					StartupRetryAttempts: 5,
+				},
+			},
+			{
+				"With invalid startup retry attempts using an env var, which are ignored",
+				`startup.retry.attempts = 3`,
+				map[string]string{
+					"TESTCONTAINERS_STARTUP_RETRY_ATTEMPTS": "often",
+				},
+				TestcontainersConfig{
+					Host:                 dockerSock,
+					StartupRetryAttempts: 3,
+				},
+			},
+			{
+				"With proxies using env vars and properties. Env vars win",
+				`proxy.http = http://proxy.example.com:3128
+proxy.https = http://proxy.example.com:3128
+proxy.no = localhost`,
+				map[string]string{
+					"TESTCONTAINERS_HTTPS_PROXY": "socks5://proxy.example.com:1080",
+					"TESTCONTAINERS_NO_PROXY":    "localhost,.example.com",
+				},
+				TestcontainersConfig{
+					Host:       dockerSock,
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "socks5://proxy.example.com:1080",
+					NoProxy:    "localhost,.example.com",
+				},
+			},
+			{
+				"With an invalid proxy, which is ignored",
+				`proxy.http = ftp://proxy.example.com`,
+				map[string]string{},
+				TestcontainersConfig{
+					Host: dockerSock,
+				},
+			},
+			{
+				"With an invalid pull policy, which is ignored",
+				`pull.policy = sometimes`,
+				map[string]string{},
+				TestcontainersConfig{
+					Host: dockerSock,
+				},
+			},
 		}
 		for _, tt := range tests {
 			t.Run(fmt.Sprintf(tt.name), func(t *testing.T) {
@@ -425,3 +659,31 @@ func TestReadTCConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestSubstituteImage(t *testing.T) {
+	config := TestcontainersConfig{
+		HubImageNamePrefix: "mirror.example.com/",
+		ImageSubstitutions: "redis:7=bitnami/redis:7; postgres:15 = registry.example.com/postgres:15",
+	}
+
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "nginx:alpine", expected: "mirror.example.com/nginx:alpine"},
+		{image: "docker.io/library/nginx:alpine", expected: "mirror.example.com/library/nginx:alpine"},
+		{image: "redis:7", expected: "mirror.example.com/bitnami/redis:7"},
+		{image: "postgres:15", expected: "registry.example.com/postgres:15"},
+		{image: "quay.io/keycloak/keycloak:21.0", expected: "quay.io/keycloak/keycloak:21.0"},
+		{image: "localhost/my-image:latest", expected: "localhost/my-image:latest"},
+		{image: "localhost:5000/my-image:latest", expected: "localhost:5000/my-image:latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.expected, config.substituteImage(tt.image))
+		})
+	}
+
+	assert.Equal(t, "nginx:alpine", TestcontainersConfig{}.substituteImage("nginx:alpine"))
+}
@@ -5,20 +5,37 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/containerd/containerd/platforms"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
+var (
+	// windowsAbsPath matches the absolute paths of the Windows containers, e.g. C:\app
+	windowsAbsPath = regexp.MustCompile(`^[a-zA-Z]:[\\/]`)
+	// linuxCapability matches the names of the Linux capabilities, with or without the CAP_ prefix, case-insensitively as the daemon
+	linuxCapability = regexp.MustCompile(`^(?i:(CAP_)?[A-Z][A-Z0-9_]*)$`)
+)
+
+// normalizeCapability returns the name of the capability in upper case, without the CAP_ prefix
+func normalizeCapability(capability string) string {
+	return strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+}
+
 // DeprecatedContainer shows methods that were supported before, but are now deprecated
 // Deprecated: Use Container
 type DeprecatedContainer interface {
@@ -28,20 +45,34 @@ type DeprecatedContainer interface {
 	Terminate(ctx context.Context) error
 }
 
+// ContainerHandle is the minimal set of methods needed to use a running container, e.g. from application code that
+// receives containers as test fixtures. Accepting a ContainerHandle instead of a Container allows to unit test that code
+// without Docker, using the mock implementation of the mocks package.
+//
+//go:generate mockery --name ContainerHandle --output ./mocks --outpkg mocks
+type ContainerHandle interface {
+	Host(context.Context) (string, error)                   // get host where the container port is exposed
+	MappedPort(context.Context, nat.Port) (nat.Port, error) // get externally mapped port for a container port
+	Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error)
+	Logs(context.Context) (io.ReadCloser, error)                  // Get logs of the container
+	Terminate(ctx context.Context, opts ...TerminateOption) error // terminate the container
+}
+
 // Container allows getting info about and controlling a single container instance
 type Container interface {
+	ContainerHandle
+
 	GetContainerID() string                                         // get the container id from the provider
 	Endpoint(context.Context, string) (string, error)               // get proto://ip:port string for the first exposed port
 	PortEndpoint(context.Context, nat.Port, string) (string, error) // get proto://ip:port string for the given exposed port
-	Host(context.Context) (string, error)                           // get host where the container port is exposed
-	MappedPort(context.Context, nat.Port) (nat.Port, error)         // get externally mapped port for a container port
 	Ports(context.Context) (nat.PortMap, error)                     // get all exposed ports
 	SessionID() string                                              // get session id
 	IsRunning() bool
-	Start(context.Context) error                 // start the container
-	Stop(context.Context, *time.Duration) error  // stop the container
-	Terminate(context.Context) error             // terminate the container
-	Logs(context.Context) (io.ReadCloser, error) // Get logs of the container
+	Start(context.Context) error                           // start the container
+	Stop(context.Context, *time.Duration) error            // stop the container
+	Pause(context.Context) error                           // pause all the processes of the container
+	Resume(context.Context) error                          // resume the processes of a paused container
+	Events(context.Context) (<-chan ContainerEvent, error) // subscribe to the lifecycle events of the container
 	FollowOutput(LogConsumer)
 	StartLogProducer(context.Context) error
 	StopLogProducer() error
@@ -49,13 +80,20 @@ type Container interface {
 	State(context.Context) (*types.ContainerState, error)        // returns container's running state
 	Networks(context.Context) ([]string, error)                  // get container networks
 	NetworkAliases(context.Context) (map[string][]string, error) // get container network aliases for a network
-	Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error)
-	ContainerIP(context.Context) (string, error)    // get container ip
-	ContainerIPs(context.Context) ([]string, error) // get all container IPs
+	ContainerIP(context.Context) (string, error)                 // get container ip
+	ContainerIPs(context.Context) ([]string, error)              // get all container IPs
 	CopyToContainer(ctx context.Context, fileContent []byte, containerFilePath string, fileMode int64) error
 	CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64) error
 	CopyFileToContainer(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64) error
 	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
+	Commit(ctx context.Context, tag string) (string, error)                                      // create an image from the current state of the container
+	OS(context.Context) (string, error)                                                          // get the operating system of the container, e.g. linux or windows
+	LogEntries(ctx context.Context, opts ...LogsOption) ([]LogEntry, error)                      // get the lines of the logs, demultiplexed into stdout and stderr
+	Stats(context.Context) (*ContainerStats, error)                                              // get a sample of the resource usage of the container
+	StatsStream(context.Context) (<-chan ContainerStats, error)                                  // stream the samples of the resource usage of the container
+	MountPoints(context.Context) ([]types.MountPoint, error)                                     // get the mounts of the container, with the names of their volumes
+	Describe(context.Context) (*ContainerDescription, error)                                     // get a serializable description of the container, e.g. its endpoints
+	ExecWithRetry(ctx context.Context, cmd []string, policy ExecRetryPolicy) (ExecResult, error) // execute a command until it succeeds, as defined by the policy
 }
 
 // ImageBuildInfo defines what is needed to build an image
@@ -79,6 +117,18 @@ type FromDockerfile struct {
 	AuthConfigs    map[string]types.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Enable auth configs to be able to pull from an authenticated docker registry
 }
 
+// ImagePullPolicy defines when the image of a container is pulled
+type ImagePullPolicy string
+
+const (
+	// PullIfNotPresent pulls the image only if it's not present locally, which is the default policy
+	PullIfNotPresent ImagePullPolicy = "IfNotPresent"
+	// PullAlways pulls the image before creating every container
+	PullAlways ImagePullPolicy = "Always"
+	// PullNever never pulls the image, failing if it's not present locally
+	PullNever ImagePullPolicy = "Never"
+)
+
 type ContainerFile struct {
 	HostFilePath      string
 	ContainerFilePath string
@@ -88,39 +138,58 @@ type ContainerFile struct {
 // ContainerRequest represents the parameters used to get a running container
 type ContainerRequest struct {
 	FromDockerfile
-	Image                   string
-	Entrypoint              []string
-	Env                     map[string]string
-	ExposedPorts            []string // allow specifying protocol info
-	Cmd                     []string
-	Labels                  map[string]string
-	Mounts                  ContainerMounts
-	Tmpfs                   map[string]string
-	RegistryCred            string // Deprecated: Testcontainers will detect registry credentials automatically
-	WaitingFor              wait.Strategy
-	Name                    string // for specifying container name
-	Hostname                string
-	ExtraHosts              []string                                   // Deprecated: Use HostConfigModifier instead
-	Privileged              bool                                       // For starting privileged container
-	Networks                []string                                   // for specifying network names
-	NetworkAliases          map[string][]string                        // for specifying network aliases
-	NetworkMode             container.NetworkMode                      // Deprecated: Use HostConfigModifier instead
-	Resources               container.Resources                        // Deprecated: Use HostConfigModifier instead
-	Files                   []ContainerFile                            // files which will be copied when container starts
-	User                    string                                     // for specifying uid:gid
-	SkipReaper              bool                                       // Deprecated: The reaper is globally controlled by the .testcontainers.properties file or the TESTCONTAINERS_RYUK_DISABLED environment variable
-	ReaperImage             string                                     // Deprecated: use WithImageName ContainerOption instead. Alternative reaper image
-	ReaperOptions           []ContainerOption                          // options for the reaper
-	AutoRemove              bool                                       // Deprecated: Use HostConfigModifier instead. If set to true, the container will be removed from the host when stopped
-	AlwaysPullImage         bool                                       // Always pull image
-	ImagePlatform           string                                     // ImagePlatform describes the platform which the image runs on.
-	Binds                   []string                                   // Deprecated: Use HostConfigModifier instead
-	ShmSize                 int64                                      // Amount of memory shared with the host (in bytes)
-	CapAdd                  []string                                   // Deprecated: Use HostConfigModifier instead. Add Linux capabilities
-	CapDrop                 []string                                   // Deprecated: Use HostConfigModifier instead. Drop Linux capabilities
-	ConfigModifier          func(*container.Config)                    // Modifier for the config before container creation
-	HostConfigModifier      func(*container.HostConfig)                // Modifier for the host config before container creation
-	EnpointSettingsModifier func(map[string]*network.EndpointSettings) // Modifier for the network settings before container creation
+	Image                    string
+	Entrypoint               []string          // replaces the ENTRYPOINT of the image, in exec form, and resets its CMD if Cmd is empty
+	Env                      map[string]string // environment variables of the container, overriding the ones of the EnvFiles
+	EnvFiles                 []string          // paths of env files with the environment variables of the container, in the docker-compose env_file format, the later overriding the earlier
+	ExposedPorts             []string          // allow specifying protocol info
+	Cmd                      []string          // replaces the CMD of the image, in exec form, passed as arguments to the entrypoint if any
+	Labels                   map[string]string
+	Mounts                   ContainerMounts
+	Tmpfs                    map[string]string
+	RegistryCred             string // base64 encoded registry credentials, as returned by EncodeRegistryCred, overriding the ones detected from the Docker config
+	WaitingFor               wait.Strategy
+	StartupTimeout           time.Duration // startup timeout of the wait strategies not defining their own, instead of 60 seconds
+	Name                     string        // for specifying container name
+	Hostname                 string
+	ExtraHosts               []string                                   // Deprecated: Use HostConfigModifier instead
+	Privileged               bool                                       // For starting privileged container
+	ReadOnlyRootFS           bool                                       // mounts the root filesystem of the container as read only, the writable paths must be mounted, e.g. with tmpfs
+	WorkingDir               string                                     // absolute path of the working directory of the processes of the container, the one of the image if empty
+	Networks                 []string                                   // for specifying network names
+	NetworkAliases           map[string][]string                        // for specifying network aliases
+	NetworkMode              container.NetworkMode                      // Deprecated: Use HostConfigModifier instead
+	Resources                container.Resources                        // Deprecated: Use HostConfigModifier instead
+	Files                    []ContainerFile                            // files which will be copied when container starts
+	User                     string                                     // for specifying the user running the processes of the container: user, uid, user:group or uid:gid
+	SkipReaper               bool                                       // Deprecated: The reaper is globally controlled by the .testcontainers.properties file or the TESTCONTAINERS_RYUK_DISABLED environment variable
+	ReaperImage              string                                     // Deprecated: use WithImageName ContainerOption instead. Alternative reaper image
+	ReaperOptions            []ContainerOption                          // options for the reaper
+	AutoRemove               bool                                       // Deprecated: Use HostConfigModifier instead. If set to true, the container will be removed from the host when stopped
+	AlwaysPullImage          bool                                       // Deprecated: Use ImagePullPolicy instead. Always pull image
+	ImagePullPolicy          ImagePullPolicy                            // policy to pull the image before creating the container, defaults to PullIfNotPresent
+	ImagePlatform            string                                     // ImagePlatform describes the platform which the image runs on.
+	ImagePlatformFallback    string                                     // platform used, possibly emulated, when the image is not available for the ImagePlatform or the host platform, e.g. "linux/amd64"
+	ImagePullProgress        func(PullProgress)                         // called with the progress of the pulls of the image, which is logged every 10 seconds if nil
+	Binds                    []string                                   // Deprecated: Use HostConfigModifier instead
+	ShmSize                  int64                                      // Amount of memory shared with the host (in bytes)
+	Memory                   int64                                      // Memory limit (in bytes)
+	MemorySwap               int64                                      // Total memory limit (memory + swap) in bytes. Set it to -1 to enable unlimited swap
+	CPUShares                int64                                      // CPU shares (relative weight vs. other containers)
+	CPUPeriod                int64                                      // CPU CFS (Completely Fair Scheduler) period, in microseconds
+	CPUQuota                 int64                                      // CPU CFS (Completely Fair Scheduler) quota, in microseconds
+	Ulimits                  []*units.Ulimit                            // List of ulimits to be set in the container
+	CapAdd                   []string                                   // Linux capabilities added to the container, e.g. NET_ADMIN, added to the ones set by the HostConfigModifier
+	CapDrop                  []string                                   // Linux capabilities dropped from the container, e.g. ALL, added to the ones set by the HostConfigModifier
+	ConfigModifier           func(*container.Config)                    // Modifier for the config before container creation
+	HostConfigModifier       func(*container.HostConfig)                // Modifier for the host config before container creation
+	EndpointSettingsModifier func(map[string]*network.EndpointSettings) // Modifier for the network settings before container creation
+	EnpointSettingsModifier  func(map[string]*network.EndpointSettings) // Deprecated: Use EndpointSettingsModifier instead
+	FailureHooks             []FailureHook                              // hooks invoked when the wait strategy or the termination of the container fails
+	ReservedPorts            []*PortReservation                         // host ports reserved in advance, released right before the container is started
+	FatalLogPatterns         []string                                   // regular expressions matching log lines after which the container won't be ready, aborting the wait strategy
+	CleanupPolicy            CleanupPolicy                              // when the container is removed after the test creating it, defaults to CleanupAlways
+	Metadata                 map[string]string                          // metadata written to the metadata file for external tools, e.g. the credentials of a database
 }
 
 // containerOptions functional options for a container
@@ -154,6 +223,17 @@ func (c *ContainerRequest) Validate() error {
 		c.validateContextAndImage,
 		c.validateContextOrImageIsSpecified,
 		c.validateMounts,
+		c.validateImagePullPolicy,
+		c.validateFatalLogPatterns,
+		c.validateCleanupPolicy,
+		c.validateExposedPorts,
+		c.validateImagePlatforms,
+		c.validateWorkingDir,
+		c.validateUser,
+		c.validateCapabilities,
+		c.validateEnvFiles,
+		c.validateStartupTimeout,
+		c.validateCommand,
 	}
 
 	var err error
@@ -247,6 +327,131 @@ func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
 	return nil
 }
 
+func (c *ContainerRequest) validateImagePullPolicy() error {
+	switch c.ImagePullPolicy {
+	case "", PullIfNotPresent, PullAlways, PullNever:
+		return nil
+	}
+
+	return fmt.Errorf("invalid image pull policy %s, it must be one of %s, %s or %s", c.ImagePullPolicy, PullIfNotPresent, PullAlways, PullNever)
+}
+
+func (c *ContainerRequest) validateExposedPorts() error {
+	_, _, err := nat.ParsePortSpecs(c.ExposedPorts)
+	return err
+}
+
+func (c *ContainerRequest) validateImagePlatforms() error {
+	for _, platform := range []string{c.ImagePlatform, c.ImagePlatformFallback} {
+		if platform == "" {
+			continue
+		}
+
+		if _, err := platforms.Parse(platform); err != nil {
+			return fmt.Errorf("invalid platform %s: %w", platform, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ContainerRequest) validateWorkingDir() error {
+	if c.WorkingDir == "" || path.IsAbs(c.WorkingDir) || windowsAbsPath.MatchString(c.WorkingDir) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid working directory %s, it must be an absolute path", c.WorkingDir)
+}
+
+func (c *ContainerRequest) validateUser() error {
+	if c.User == "" {
+		return nil
+	}
+
+	parts := strings.Split(c.User, ":")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid user %s, it must be one of user, uid, user:group or uid:gid", c.User)
+	}
+
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, " \t") {
+			return fmt.Errorf("invalid user %s, it must be one of user, uid, user:group or uid:gid", c.User)
+		}
+	}
+
+	return nil
+}
+
+func (c *ContainerRequest) validateCapabilities() error {
+	added := make(map[string]bool, len(c.CapAdd))
+	for _, capability := range c.CapAdd {
+		if !linuxCapability.MatchString(capability) {
+			return fmt.Errorf("invalid capability %s to add, e.g. NET_ADMIN or CAP_NET_ADMIN", capability)
+		}
+		added[normalizeCapability(capability)] = true
+	}
+
+	for _, capability := range c.CapDrop {
+		if !linuxCapability.MatchString(capability) {
+			return fmt.Errorf("invalid capability %s to drop, e.g. NET_ADMIN or CAP_NET_ADMIN", capability)
+		}
+
+		normalized := normalizeCapability(capability)
+		if added[normalized] && normalized != "ALL" {
+			return fmt.Errorf("the capability %s cannot be both added and dropped", capability)
+		}
+	}
+
+	return nil
+}
+
+func (c *ContainerRequest) validateStartupTimeout() error {
+	if c.StartupTimeout < 0 {
+		return fmt.Errorf("invalid startup timeout %s, it must not be negative", c.StartupTimeout)
+	}
+
+	return nil
+}
+
+// validateCommand rejects the entrypoints and commands passed as a single shell-form string, e.g. "redis-server --port 6380",
+// which the Docker daemon runs as the name of an executable. A shell-form command is only valid as the script
+// of an entrypoint running a shell with -c, e.g. sh -c, which can't be checked for the entrypoint of the image.
+func (c *ContainerRequest) validateCommand() error {
+	if isShellForm(c.Entrypoint) {
+		return fmt.Errorf("invalid entrypoint %q, it must be in exec form, with the executable and its arguments as separate elements", c.Entrypoint[0])
+	}
+
+	if len(c.Entrypoint) > 0 && c.Entrypoint[len(c.Entrypoint)-1] != "-c" && isShellForm(c.Cmd) {
+		return fmt.Errorf("invalid command %q, it must be in exec form, with the arguments of the entrypoint as separate elements", c.Cmd[0])
+	}
+
+	return nil
+}
+
+// isShellForm returns true if the command is a single string with whitespaces, except a Windows path,
+// e.g. C:\Program Files\app.exe
+func isShellForm(command []string) bool {
+	return len(command) == 1 && strings.ContainsAny(strings.TrimSpace(command[0]), " \t\n") && !windowsAbsPath.MatchString(command[0])
+}
+
+func (c *ContainerRequest) validateFatalLogPatterns() error {
+	_, err := compileFatalLogPatterns(c.FatalLogPatterns)
+	return err
+}
+
+// imagePullPolicy returns the pull policy of the request, taking into account the deprecated AlwaysPullImage field
+func (c *ContainerRequest) imagePullPolicy() ImagePullPolicy {
+	if c.ImagePullPolicy != "" {
+		return c.ImagePullPolicy
+	}
+
+	if c.AlwaysPullImage {
+		return PullAlways
+	}
+
+	return PullIfNotPresent
+}
+
 func (c *ContainerRequest) validateMounts() error {
 	targets := make(map[string]bool, len(c.Mounts))
 
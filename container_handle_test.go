@@ -0,0 +1,48 @@
+package testcontainers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/mocks"
+)
+
+// the mock must implement the interface it was generated from
+var _ testcontainers.ContainerHandle = (*mocks.ContainerHandle)(nil)
+
+// redisAddress is an example of application code receiving a container as a test fixture
+func redisAddress(ctx context.Context, c testcontainers.ContainerHandle) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, "6379/tcp")
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, port.Port()), nil
+}
+
+func TestContainerHandleMock(t *testing.T) {
+	ctx := context.Background()
+
+	// containerHandleMock {
+	handle := mocks.NewContainerHandle(t)
+	handle.On("Host", mock.Anything).Return("localhost", nil)
+	handle.On("MappedPort", mock.Anything, nat.Port("6379/tcp")).Return(nat.Port("49153/tcp"), nil)
+
+	address, err := redisAddress(ctx, handle)
+	// }
+
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:49153", address)
+}
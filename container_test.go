@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types/mount"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -59,6 +62,22 @@ func Test_ContainerValidation(t *testing.T) {
 				Mounts: Mounts(BindMount("/data", "/srv"), BindMount("/data", "/data")),
 			},
 		},
+		{
+			Name:          "can set a valid image pull policy",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:           "redis:latest",
+				ImagePullPolicy: PullNever,
+			},
+		},
+		{
+			Name:          "cannot set an invalid image pull policy",
+			ExpectedError: errors.New("invalid image pull policy Sometimes, it must be one of IfNotPresent, Always or Never"),
+			ContainerRequest: ContainerRequest{
+				Image:           "redis:latest",
+				ImagePullPolicy: "Sometimes",
+			},
+		},
 		{
 			Name:          "Cannot mount multiple sources to same target",
 			ExpectedError: errors.New("duplicate mount target detected: /data"),
@@ -67,6 +86,123 @@ func Test_ContainerValidation(t *testing.T) {
 				Mounts: Mounts(BindMount("/srv", "/data"), BindMount("/data", "/data")),
 			},
 		},
+		{
+			Name:          "cannot expose an invalid port",
+			ExpectedError: errors.New("Invalid containerPort: http"),
+			ContainerRequest: ContainerRequest{
+				Image:        "redis:latest",
+				ExposedPorts: []string{"6379/tcp", "http"},
+			},
+		},
+		{
+			Name:          "can set an absolute working directory and a user",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:      "redis:latest",
+				WorkingDir: "/data",
+				User:       "999:999",
+			},
+		},
+		{
+			Name:          "cannot set a relative working directory",
+			ExpectedError: errors.New("invalid working directory data, it must be an absolute path"),
+			ContainerRequest: ContainerRequest{
+				Image:      "redis:latest",
+				WorkingDir: "data",
+			},
+		},
+		{
+			Name:          "cannot set an invalid user",
+			ExpectedError: errors.New("invalid user redis:, it must be one of user, uid, user:group or uid:gid"),
+			ContainerRequest: ContainerRequest{
+				Image: "redis:latest",
+				User:  "redis:",
+			},
+		},
+		{
+			Name:          "can drop all the capabilities but the added ones",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:   "redis:latest",
+				CapAdd:  []string{"NET_ADMIN", "cap_sys_time"},
+				CapDrop: []string{"ALL"},
+			},
+		},
+		{
+			Name:          "cannot add an invalid capability",
+			ExpectedError: errors.New("invalid capability NET-ADMIN to add, e.g. NET_ADMIN or CAP_NET_ADMIN"),
+			ContainerRequest: ContainerRequest{
+				Image:  "redis:latest",
+				CapAdd: []string{"NET-ADMIN"},
+			},
+		},
+		{
+			Name:          "cannot add and drop the same capability",
+			ExpectedError: errors.New("the capability NET_ADMIN cannot be both added and dropped"),
+			ContainerRequest: ContainerRequest{
+				Image:   "redis:latest",
+				CapAdd:  []string{"CAP_NET_ADMIN"},
+				CapDrop: []string{"NET_ADMIN"},
+			},
+		},
+		{
+			Name:          "cannot set a negative startup timeout",
+			ExpectedError: errors.New("invalid startup timeout -1s, it must not be negative"),
+			ContainerRequest: ContainerRequest{
+				Image:          "redis:latest",
+				StartupTimeout: -time.Second,
+			},
+		},
+		{
+			Name:          "cannot set an invalid platform",
+			ExpectedError: errors.New("invalid platform linux/amd64/v2/extra: \"linux/amd64/v2/extra\": cannot parse platform specifier: invalid argument"),
+			ContainerRequest: ContainerRequest{
+				Image:                 "redis:latest",
+				ImagePlatformFallback: "linux/amd64/v2/extra",
+			},
+		},
+		{
+			Name:          "cannot set a shell-form entrypoint",
+			ExpectedError: errors.New("invalid entrypoint \"redis-server --port 6380\", it must be in exec form, with the executable and its arguments as separate elements"),
+			ContainerRequest: ContainerRequest{
+				Image:      "redis:latest",
+				Entrypoint: []string{"redis-server --port 6380"},
+			},
+		},
+		{
+			Name:          "cannot set a shell-form command with an exec-form entrypoint",
+			ExpectedError: errors.New("invalid command \"--port 6380\", it must be in exec form, with the arguments of the entrypoint as separate elements"),
+			ContainerRequest: ContainerRequest{
+				Image:      "redis:latest",
+				Entrypoint: []string{"redis-server"},
+				Cmd:        []string{"--port 6380"},
+			},
+		},
+		{
+			Name:          "can set a shell-form command as the script of a shell entrypoint",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:      "redis:latest",
+				Entrypoint: []string{"sh", "-c"},
+				Cmd:        []string{"redis-server --port 6380"},
+			},
+		},
+		{
+			Name:          "can set a shell-form command with the entrypoint of the image",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image: "redis:latest",
+				Cmd:   []string{"redis-server --port 6380"},
+			},
+		},
+		{
+			Name:          "can set a Windows entrypoint with whitespaces",
+			ExpectedError: nil,
+			ContainerRequest: ContainerRequest{
+				Image:      "mcr.microsoft.com/windows/nanoserver:ltsc2022",
+				Entrypoint: []string{`C:\Program Files\app.exe`},
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -85,6 +221,26 @@ func Test_ContainerValidation(t *testing.T) {
 	}
 }
 
+func Test_ImagePullPolicy(t *testing.T) {
+	assert.Equal(t, PullIfNotPresent, (&ContainerRequest{}).imagePullPolicy())
+	assert.Equal(t, PullAlways, (&ContainerRequest{AlwaysPullImage: true}).imagePullPolicy())
+	assert.Equal(t, PullNever, (&ContainerRequest{ImagePullPolicy: PullNever, AlwaysPullImage: true}).imagePullPolicy())
+}
+
+func Test_ImagePullPolicyNeverWithMissingImage(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:           "docker.io/testcontainers/not-present-locally:latest",
+			ImagePullPolicy: PullNever,
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not present locally")
+}
+
 func Test_GetDockerfile(t *testing.T) {
 	type TestCase struct {
 		name                   string
@@ -419,3 +575,35 @@ func TestVolumeMount(t *testing.T) {
 		})
 	}
 }
+
+func TestTmpfsMount(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		mountTarget ContainerMountTarget
+		sizeBytes   int64
+		mode        os.FileMode
+	}
+	tests := []struct {
+		name string
+		args args
+		want ContainerMount
+	}{
+		{
+			name: "/tmp with default options",
+			args: args{mountTarget: "/tmp"},
+			want: ContainerMount{Source: DockerTmpfsMountSource{TmpfsOptions: &mount.TmpfsOptions{}}, Target: "/tmp"},
+		},
+		{
+			name: "/var/cache limited to 64MB",
+			args: args{mountTarget: "/var/cache", sizeBytes: 64 * 1024 * 1024, mode: 0o700},
+			want: ContainerMount{Source: DockerTmpfsMountSource{TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 64 * 1024 * 1024, Mode: 0o700}}, Target: "/var/cache"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equalf(t, tt.want, TmpfsMount(tt.args.mountTarget, tt.args.sizeBytes, tt.args.mode), "TmpfsMount(%v, %v, %v)", tt.args.mountTarget, tt.args.sizeBytes, tt.args.mode)
+		})
+	}
+}
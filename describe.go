@@ -0,0 +1,131 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerDescription is a serializable description of a container, so the external processes of a pipeline,
+// e.g. the end-to-end tests of a frontend run by another tool, can use the containers started by the tests
+type ContainerDescription struct {
+	ID        string              `json:"id"`
+	Name      string              `json:"name"`
+	Image     string              `json:"image"`
+	SessionID string              `json:"sessionId"`
+	Host      string              `json:"host"`               // host where the ports are exposed
+	Ports     map[string]string   `json:"ports"`              // host ports indexed by container port, e.g. "5432/tcp": "49153"
+	Endpoints map[string]string   `json:"endpoints"`          // addresses indexed by container port, e.g. "5432/tcp": "localhost:49153"
+	Env       map[string]string   `json:"env"`                // environment variables of the container, including the ones of the image
+	Networks  map[string][]string `json:"networks"`           // network aliases indexed by network
+	Metadata  map[string]string   `json:"metadata,omitempty"` // metadata of the request, e.g. the credentials of a database
+}
+
+// Describe returns the description of the container, with the addresses of its mapped ports
+func (c *DockerContainer) Describe(ctx context.Context) (*ContainerDescription, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ContainerDescription{
+		ID:        c.ID,
+		Name:      strings.TrimPrefix(inspect.Name, "/"),
+		Image:     c.Image,
+		SessionID: c.SessionID(),
+		Host:      host,
+		Ports:     map[string]string{},
+		Endpoints: map[string]string{},
+		Env:       map[string]string{},
+		Networks:  map[string][]string{},
+		Metadata:  c.metadata,
+	}
+
+	for port := range inspect.NetworkSettings.Ports {
+		mapped, err := c.MappedPort(ctx, port)
+		if err != nil {
+			// the port is exposed but not published
+			continue
+		}
+		d.Ports[string(port)] = mapped.Port()
+		d.Endpoints[string(port)] = net.JoinHostPort(host, mapped.Port())
+	}
+
+	for _, kv := range inspect.Config.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		d.Env[k] = v
+	}
+
+	for name, network := range inspect.NetworkSettings.Networks {
+		d.Networks[name] = append([]string{}, network.Aliases...)
+	}
+
+	return d, nil
+}
+
+// WriteContainerDescriptions writes the descriptions of the containers to a JSON file, indexed by the given names,
+// e.g. {"postgres": {...}, "redis": {...}}, replacing the file atomically so the processes reading it never see
+// a partial content
+func WriteContainerDescriptions(ctx context.Context, path string, containers map[string]Container) error {
+	descriptions := make(map[string]*ContainerDescription, len(containers))
+	for name, c := range containers {
+		d, err := c.Describe(ctx)
+		if err != nil {
+			return err
+		}
+		descriptions[name] = d
+	}
+
+	content, err := json.MarshalIndent(descriptions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(path, content)
+}
+
+// ReadContainerDescriptions reads the descriptions of the containers written by WriteContainerDescriptions
+func ReadContainerDescriptions(path string) (map[string]*ContainerDescription, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := map[string]*ContainerDescription{}
+	if err := json.Unmarshal(content, &descriptions); err != nil {
+		return nil, err
+	}
+
+	return descriptions, nil
+}
+
+// writeFileAtomically writes the content to a temporary file of the directory of the path, then renames it to the path
+func writeFileAtomically(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
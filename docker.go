@@ -5,15 +5,15 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/binary"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -46,6 +46,7 @@ var (
 
 	logOnce                 sync.Once
 	ErrDuplicateMountTarget = errors.New("duplicate mount target detected")
+	ErrHostPortAllocated    = errors.New("host port already allocated")
 )
 
 const (
@@ -71,6 +72,12 @@ type DockerContainer struct {
 	raw               *types.ContainerJSON
 	stopProducer      chan bool
 	logger            Logging
+	failureHooks      []FailureHook
+	reservedPorts     []*PortReservation
+	fatalLogPatterns  []*regexp.Regexp
+	startupTimeout    time.Duration
+	metadata          map[string]string
+	tunnel            *portTunnel
 }
 
 // SetLogger sets the logger for the container
@@ -112,7 +119,7 @@ func (c *DockerContainer) Endpoint(ctx context.Context, proto string) (string, e
 // PortEndpoint gets proto://host:port string for the given exposed port
 // Will returns just host:port if proto is ""
 func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto string) (string, error) {
-	host, err := c.Host(ctx)
+	host, err := c.HostForPort(ctx, port)
 	if err != nil {
 		return "", err
 	}
@@ -133,7 +140,14 @@ func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto
 // Host gets host (ip or name) of the docker daemon where the container port is exposed
 // Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
 // You can use the "TC_HOST" env variable to set this yourself
+// When all the mapped ports are forwarded by a port tunnel, it's the loopback address of the host running the tests.
+// If only some of them are forwarded, e.g. the TCP ports of a container also mapping UDP ports, it's the Docker host,
+// and HostForPort and PortEndpoint return the address reaching each port.
 func (c *DockerContainer) Host(ctx context.Context) (string, error) {
+	if c.tunnel != nil && c.tunnel.complete {
+		return portTunnelHost, nil
+	}
+
 	host, err := c.provider.DaemonHost(ctx)
 	if err != nil {
 		return "", err
@@ -141,8 +155,27 @@ func (c *DockerContainer) Host(ctx context.Context) (string, error) {
 	return host, nil
 }
 
-// MappedPort gets externally mapped port for a container port
+// HostForPort returns the host reaching the given port of the container: the loopback address of the host running
+// the tests if the port is forwarded by a port tunnel, else the Docker host
+func (c *DockerContainer) HostForPort(ctx context.Context, port nat.Port) (string, error) {
+	if c.tunnel != nil {
+		if _, ok := c.tunnel.localPort(port); ok {
+			return portTunnelHost, nil
+		}
+	}
+
+	return c.provider.DaemonHost(ctx)
+}
+
+// MappedPort gets externally mapped port for a container port,
+// or the local port forwarding it when the mapped ports are forwarded by a port tunnel
 func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	if c.tunnel != nil {
+		if localPort, ok := c.tunnel.localPort(port); ok {
+			return localPort, nil
+		}
+	}
+
 	inspect, err := c.inspectContainer(ctx)
 	if err != nil {
 		return "", err
@@ -150,12 +183,8 @@ func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Po
 	if inspect.ContainerJSONBase.HostConfig.NetworkMode == "host" {
 		return port, nil
 	}
-	ports, err := c.Ports(ctx)
-	if err != nil {
-		return "", err
-	}
 
-	for k, p := range ports {
+	for k, p := range inspect.NetworkSettings.Ports {
 		if k.Port() != port.Port() {
 			continue
 		}
@@ -168,9 +197,25 @@ func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Po
 		return nat.NewPort(k.Proto(), p[0].HostPort)
 	}
 
+	if !isExposed(inspect.Config.ExposedPorts, port) {
+		// the port will never be mapped, so the wait strategies must not wait for it
+		return "", wait.Permanent(portNotExposedError(port))
+	}
+
 	return "", errors.New("port not found")
 }
 
+// isExposed returns true if the port is exposed by the container, with any protocol if the port has none
+func isExposed(exposedPorts nat.PortSet, port nat.Port) bool {
+	for k := range exposedPorts {
+		if k.Port() == port.Port() && (port.Proto() == "" || k.Proto() == port.Proto()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Ports gets the exposed ports for the container.
 func (c *DockerContainer) Ports(ctx context.Context) (nat.PortMap, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -185,28 +230,134 @@ func (c *DockerContainer) SessionID() string {
 	return c.sessionID.String()
 }
 
-// Start will start an already created container
+// Start will start an already created container, or restart a stopped one.
+// The wait strategy of the container is executed again on every start, and, as the Docker daemon
+// could bind different random host ports on a restart, the mapped ports must be read again after it.
 func (c *DockerContainer) Start(ctx context.Context) error {
+	ctx, end := startOperation(ctx, Operation{Name: OperationContainerStart, Image: c.Image, ContainerID: c.ID})
+	return end(c.start(ctx))
+}
+
+func (c *DockerContainer) start(ctx context.Context) error {
 	shortID := c.ID[:12]
 	c.logger.Printf("Starting container id: %s image: %s", shortID, c.Image)
 
+	// hand over the reserved host ports to the Docker daemon
+	if err := releasePorts(c.reservedPorts); err != nil {
+		return fmt.Errorf("%w: could not release the reserved ports", err)
+	}
+
+	// abort the wait strategy as soon as the container dies, unless it's waiting for the container to exit
+	waitCtx, cancelWait := context.WithCancel(ctx)
+	defer cancelWait()
+
+	var died <-chan ContainerEvent
+	if c.WaitingFor != nil && !expectsExit(c.WaitingFor) {
+		var err error
+		if died, err = c.watchDie(waitCtx, cancelWait); err != nil {
+			c.logger.Printf("%s: the wait strategy will not be aborted if the container dies", err)
+		}
+	}
+
 	if err := c.provider.client.ContainerStart(ctx, c.ID, types.ContainerStartOptions{}); err != nil {
+		if isHostPortAllocatedError(err) {
+			return fmt.Errorf("%w: the fixed host ports %v of the container cannot be bound: %s", ErrHostPortAllocated, c.fixedHostPorts(ctx), err)
+		}
 		return err
 	}
 	defer c.provider.Close()
 
+	// the wait strategy could need the mapped ports, which must be reachable from the tests
+	if err := c.startPortTunnel(ctx); err != nil {
+		return fmt.Errorf("%w: could not forward the mapped ports of the container", err)
+	}
+
+	// abort the wait strategy as soon as the container logs a fatal line
+	var fatal <-chan string
+	if c.WaitingFor != nil && len(c.fatalLogPatterns) > 0 {
+		fatal = c.watchFatalLogs(waitCtx, cancelWait)
+	}
+
 	// if a Wait Strategy has been specified, wait before returning
 	if c.WaitingFor != nil {
 		c.logger.Printf("Waiting for container id %s image: %s", shortID, c.Image)
-		if err := c.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+		opCtx, endWait := startOperation(waitCtx, Operation{Name: OperationWait, Image: c.Image, ContainerID: c.ID, Strategy: strategyName(c.WaitingFor)})
+		if c.startupTimeout > 0 {
+			// the strategies not defining their own timeout inherit the one of the request
+			opCtx = wait.WithDefaultStartupTimeout(opCtx, c.startupTimeout)
+		}
+		err := endWait(c.WaitingFor.WaitUntilReady(opCtx, c))
+		if err != nil {
+			select {
+			case line := <-fatal:
+				err = fmt.Errorf("%w: %q: %s", ErrFatalLogPattern, line, err)
+			default:
+				select {
+				case e := <-died:
+					err = c.exitedError(ctx, e.ExitCode, fmt.Errorf("%w: exit code %d: %s", ErrContainerDied, e.ExitCode, err))
+				default:
+					if state, stateErr := c.State(ctx); stateErr == nil && state.Status == "exited" {
+						err = c.exitedError(ctx, state.ExitCode, err)
+					}
+				}
+			}
+			runFailureHooks(ctx, c, c.failureHooks, err)
 			return err
 		}
 	}
 	c.logger.Printf("Container is ready id: %s image: %s", shortID, c.Image)
 	c.isRunning = true
+
+	if err := c.publishMetadata(ctx); err != nil {
+		c.logger.Printf("%s: could not write the container to the metadata file", err)
+	}
+
 	return nil
 }
 
+// exitedError returns the ErrContainerExited error of the container, with the last lines of its logs
+func (c *DockerContainer) exitedError(ctx context.Context, exitCode int, err error) error {
+	exited := &ErrContainerExited{ContainerID: c.ID, Image: c.Image, ExitCode: exitCode, Err: err}
+
+	entries, logsErr := c.LogEntries(ctx, WithLogsTail(containerExitedLogLines))
+	if logsErr != nil {
+		c.logger.Printf("%s: could not read the logs of the exited container", logsErr)
+		return exited
+	}
+	for _, e := range entries {
+		exited.Logs = append(exited.Logs, e.Content)
+	}
+
+	return exited
+}
+
+// fixedHostPorts returns the host ports explicitly bound to the container, as "hostPort->containerPort"
+func (c *DockerContainer) fixedHostPorts(ctx context.Context) []string {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var ports []string
+	for containerPort, bindings := range inspect.HostConfig.PortBindings {
+		for _, b := range bindings {
+			if b.HostPort != "" && b.HostPort != "0" {
+				ports = append(ports, b.HostPort+"->"+string(containerPort))
+			}
+		}
+	}
+	sort.Strings(ports)
+
+	return ports
+}
+
+// isHostPortAllocatedError returns true if the error was returned by the Docker daemon
+// because a host port of the container is already in use
+func isHostPortAllocatedError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "port is already allocated") || strings.Contains(msg, "address already in use")
+}
+
 // Stop will stop an already started container
 //
 // In case the container fails to stop
@@ -237,18 +388,68 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	return nil
 }
 
+// Pause suspends all the processes of the container, which keeps its state, its network
+// configuration and its mapped ports, but does not answer any request until it's resumed.
+// It's useful to simulate an unresponsive service, e.g. to verify the timeouts of a client.
+func (c *DockerContainer) Pause(ctx context.Context) error {
+	shortID := c.ID[:12]
+	c.logger.Printf("Pausing container id: %s image: %s", shortID, c.Image)
+
+	if err := c.provider.client.ContainerPause(ctx, c.ID); err != nil {
+		return err
+	}
+	defer c.provider.Close()
+
+	c.logger.Printf("Container is paused id: %s image: %s", shortID, c.Image)
+	return nil
+}
+
+// Resume resumes all the processes of a paused container
+func (c *DockerContainer) Resume(ctx context.Context) error {
+	shortID := c.ID[:12]
+	c.logger.Printf("Resuming container id: %s image: %s", shortID, c.Image)
+
+	if err := c.provider.client.ContainerUnpause(ctx, c.ID); err != nil {
+		return err
+	}
+	defer c.provider.Close()
+
+	c.logger.Printf("Container is resumed id: %s image: %s", shortID, c.Image)
+	return nil
+}
+
 // Terminate is used to kill the container. It is usually triggered by as defer function.
-func (c *DockerContainer) Terminate(ctx context.Context) error {
+// The container is removed with its anonymous volumes, and the image built from its Dockerfile, if any,
+// unless the options define otherwise.
+func (c *DockerContainer) Terminate(ctx context.Context, opts ...TerminateOption) error {
+	err := c.terminate(ctx, newTerminateOptions(opts...))
+	if err != nil {
+		runFailureHooks(ctx, c, c.failureHooks, err)
+	}
+
+	return err
+}
+
+func (c *DockerContainer) terminate(ctx context.Context, options TerminateOptions) error {
 	err := c.StopLogProducer()
 	if err != nil {
 		return err
 	}
 
+	if options.stopTimeout != nil {
+		if err := c.Stop(ctx, options.stopTimeout); err != nil {
+			return fmt.Errorf("%w: could not stop the container before removing it", err)
+		}
+	}
+
 	select {
 	// close reaper if it was created
 	case c.terminationSignal <- true:
 	default:
 	}
+
+	c.closePortTunnel(ctx)
+
 	err = c.provider.client.ContainerRemove(ctx, c.GetContainerID(), types.ContainerRemoveOptions{
 		RemoveVolumes: true,
 		Force:         true,
@@ -257,7 +458,7 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		return err
 	}
 
-	if c.imageWasBuilt {
+	if c.imageWasBuilt && options.removeBuiltImage {
 		_, err := c.provider.client.ImageRemove(ctx, c.Image, types.ImageRemoveOptions{
 			Force:         true,
 			PruneChildren: true,
@@ -267,6 +468,16 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		}
 	}
 
+	for _, volume := range options.volumes {
+		if err := c.provider.client.VolumeRemove(ctx, volume, true); err != nil {
+			return fmt.Errorf("%w: could not remove the volume %s", err, volume)
+		}
+	}
+
+	if err := c.unpublishMetadata(); err != nil {
+		c.logger.Printf("%s: could not remove the container from the metadata file", err)
+	}
+
 	if err := c.provider.client.Close(); err != nil {
 		return err
 	}
@@ -319,6 +530,9 @@ func (c *DockerContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
 	r := bufio.NewReader(rc)
 
 	go func() {
+		// the logs stream is released as soon as the reader is closed, even if it was not fully read
+		defer rc.Close()
+
 		var lineStarted = true
 		for err == nil {
 			line, isPrefix, err := r.ReadLine()
@@ -398,6 +612,16 @@ func (c *DockerContainer) Networks(ctx context.Context) ([]string, error) {
 	return n, nil
 }
 
+// MountPoints gets the mounts of the container, as reported by the daemon, e.g. to find where a volume is mounted
+func (c *DockerContainer) MountPoints(ctx context.Context) ([]types.MountPoint, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inspect.Mounts, nil
+}
+
 // ContainerIP gets the IP address of the primary network within the container.
 func (c *DockerContainer) ContainerIP(ctx context.Context) (string, error) {
 	inspect, err := c.inspectContainer(ctx)
@@ -419,6 +643,20 @@ func (c *DockerContainer) ContainerIP(ctx context.Context) (string, error) {
 	return ip, nil
 }
 
+// networkIP gets the IP address of the container in the given network, empty if it's not attached to it
+func (c *DockerContainer) networkIP(ctx context.Context, network string) (string, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if endpoint, ok := inspect.NetworkSettings.Networks[network]; ok {
+		return endpoint.IPAddress, nil
+	}
+
+	return "", nil
+}
+
 // ContainerIPs gets the IP addresses of all the networks within the container.
 func (c *DockerContainer) ContainerIPs(ctx context.Context) ([]string, error) {
 	ips := make([]string, 0)
@@ -497,6 +735,65 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 	return exitCode, opt.Reader, nil
 }
 
+// Commit creates a new image from the current state of the container, tagged with the given reference,
+// returning the reference of the image, which can be used as the Image of a new ContainerRequest. If the
+// tag is empty, a random one will be used. The container is paused while the image is committed.
+// The image inherits the labels of the container, so it will be removed by the reaper at the end of the
+// test session.
+func (c *DockerContainer) Commit(ctx context.Context, tag string) (string, error) {
+	if tag == "" {
+		// not seeded, so the processes running at the same time don't remove the images of each other
+		tag = fmt.Sprintf("%s:%s", uuid.New(), uuid.New())
+	}
+
+	_, err := c.provider.client.ContainerCommit(ctx, c.ID, types.ContainerCommitOptions{
+		Reference: tag,
+		Pause:     true,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer c.provider.Close()
+
+	return tag, nil
+}
+
+// Platform returns the platform of the image the container was created from, with the os/arch[/variant] format,
+// e.g. "linux/amd64". It allows to check if an image platform fallback was used.
+func (c *DockerContainer) Platform(ctx context.Context) (string, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	image, _, err := c.provider.client.ImageInspectWithRaw(ctx, inspect.Image)
+	if err != nil {
+		return "", err
+	}
+	defer c.provider.Close()
+
+	return platforms.Format(specs.Platform{
+		OS:           image.Os,
+		Architecture: image.Architecture,
+		Variant:      image.Variant,
+	}), nil
+}
+
+// OS returns the operating system of the container, e.g. linux, or windows for the Windows containers,
+// so the commands executed inside the container can use its shell, with exec.ShellCommand
+func (c *DockerContainer) OS(ctx context.Context) (string, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if inspect.Platform == "" {
+		return "linux", nil
+	}
+
+	return inspect.Platform, nil
+}
+
 type FileFromContainer struct {
 	underlying *io.ReadCloser
 	tarreader  *tar.Reader
@@ -777,26 +1074,75 @@ func NewDockerClient() (cli *client.Client, err error) {
 		}),
 	)
 
+	opts = append(opts, withProxy(tcConfig))
+
 	cli, err = client.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = cli.Ping(context.TODO())
+	if reused, err := useNegotiatedAPIVersion(cli); err != nil || reused {
+		return cli, err
+	}
+
+	ping, err := cli.Ping(context.TODO())
 	if err != nil {
 		// fallback to environment
 		cli, err = testcontainersdocker.NewClient(context.Background())
 		if err != nil {
 			return nil, err
 		}
+	} else {
+		cacheNegotiatedAPIVersion(cli, ping)
 	}
 	defer cli.Close()
 
 	return cli, nil
 }
 
+// newDockerClientForHost creates a client of the Docker daemon at the given host, ignoring the Docker host
+// of the Testcontainers configuration and of the environment. The TLS client certificates are read from the certPath
+// directory, if not empty.
+func newDockerClientForHost(host string, certPath string) (*client.Client, error) {
+	opts := []client.Opt{client.WithHost(host), client.WithAPIVersionNegotiation()}
+
+	if certPath != "" {
+		cacertPath := filepath.Join(certPath, "ca.pem")
+		certFile := filepath.Join(certPath, "cert.pem")
+		keyPath := filepath.Join(certPath, "key.pem")
+
+		opts = append(opts, client.WithTLSClientConfig(cacertPath, certFile, keyPath))
+	}
+
+	opts = append(opts, client.WithHTTPHeaders(
+		map[string]string{
+			"x-tc-sid": testcontainerssession.String(),
+		}),
+	)
+
+	opts = append(opts, withProxy(ReadConfig()))
+
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if reused, err := useNegotiatedAPIVersion(cli); err != nil || reused {
+		return cli, err
+	}
+
+	ping, err := cli.Ping(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("%w: the Docker daemon at %s is not reachable", err, host)
+	}
+	cacheNegotiatedAPIVersion(cli, ping)
+
+	return cli, nil
+}
+
 // BuildImage will build and image from context and Dockerfile, then return the tag
 func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
+	// not seeded, so the processes running at the same time don't remove the images of each other
 	repo := uuid.New()
 	tag := uuid.New()
 
@@ -808,7 +1154,7 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 	}
 
 	buildOptions := types.ImageBuildOptions{
-		BuildArgs:   img.GetBuildArgs(),
+		BuildArgs:   proxyBuildArgs(p.config, img.GetBuildArgs()),
 		Dockerfile:  img.GetDockerfile(),
 		AuthConfigs: img.GetAuthConfigs(),
 		Context:     buildContext,
@@ -858,11 +1204,23 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 
 // CreateContainer fulfills a request for a container without starting it
 func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	ctx, end := startOperation(ctx, Operation{Name: OperationContainerCreate, Image: req.Image})
+	c, err := p.createContainer(ctx, req)
+	err = end(err)
+
+	return c, err
+}
+
+func (p *DockerProvider) createContainer(ctx context.Context, req ContainerRequest) (Container, error) {
 	var err error
 
 	// defer the close of the Docker client connection the soonest
 	defer p.Close()
 
+	if req.WaitingFor == nil {
+		req.WaitingFor = p.DefaultWaitStrategy
+	}
+
 	// Make sure that bridge network exists
 	// In case it is disabled we will create reaper_default network
 	if p.DefaultNetwork == "" {
@@ -889,9 +1247,9 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		}
 	}
 
-	env := []string{}
-	for envKey, envVar := range req.Env {
-		env = append(env, envKey+"="+envVar)
+	env, err := req.environment()
+	if err != nil {
+		return nil, err
 	}
 
 	if req.Labels == nil {
@@ -910,6 +1268,11 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	var termSignal chan bool
 	// the reaper does not need to start a reaper for itself
 	isReaperContainer := strings.EqualFold(req.Image, reaperImage(reaperOpts.ImageName))
+	// the reaper is not labeled with the session ID, otherwise it would reap itself
+	if !isReaperContainer {
+		testcontainersdocker.AddDefaultLabels(req.Labels, testcontainerssession.String())
+	}
+
 	if !tcConfig.RyukDisabled && !isReaperContainer {
 		r, err := reuseOrCreateReaper(context.WithValue(ctx, testcontainersdocker.DockerHostContextKey, p.host), testcontainerssession.String(), p, req.ReaperOptions...)
 		if err != nil {
@@ -926,6 +1289,14 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		}
 	}
 
+	cleanupPolicy := req.cleanupPolicy(tcConfig.CleanupPolicy)
+	if cleanupPolicy != CleanupAlways {
+		// the reaper only removes the resources of the session labeled to be reaped, so the containers which could be
+		// kept running for inspection keep the session labels, e.g. to be listed by the tc CLI, without being reaped
+		delete(req.Labels, testcontainersdocker.LabelReap)
+		req.Labels[testcontainersdocker.LabelCleanupPolicy] = string(cleanupPolicy)
+	}
+
 	if err = req.Validate(); err != nil {
 		return nil, err
 	}
@@ -939,7 +1310,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			return nil, err
 		}
 	} else {
-		tag = req.Image
+		tag = tcConfig.substituteImage(req.Image)
 
 		if req.ImagePlatform != "" {
 			p, err := platforms.Parse(req.ImagePlatform)
@@ -949,43 +1320,51 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			platform = &p
 		}
 
+		if req.ImagePlatform != "" || req.ImagePlatformFallback != "" {
+			if err := p.requireAPIVersion(ctx, featurePlatform); err != nil {
+				return nil, err
+			}
+		}
+
 		var shouldPullImage bool
 
-		if req.AlwaysPullImage {
+		pullPolicy := req.imagePullPolicy()
+		if req.ImagePullPolicy == "" && !req.AlwaysPullImage && tcConfig.PullPolicy != "" {
+			// the pull policy of the configuration applies to the requests not defining one
+			pullPolicy = tcConfig.PullPolicy
+		}
+		if pullPolicy == PullAlways {
 			shouldPullImage = true // If requested always attempt to pull image
 		} else {
 			image, _, err := p.client.ImageInspectWithRaw(ctx, tag)
 			if err != nil {
-				if client.IsErrNotFound(err) {
-					shouldPullImage = true
-				} else {
+				if !client.IsErrNotFound(err) {
 					return nil, err
 				}
+				if pullPolicy == PullNever {
+					return nil, fmt.Errorf("%w: image %s is not present locally, and the pull policy is %s", err, tag, PullNever)
+				}
+				shouldPullImage = true
 			}
-			if platform != nil && (image.Architecture != platform.Architecture || image.Os != platform.OS) {
+			if pullPolicy != PullNever && platform != nil && (image.Architecture != platform.Architecture || image.Os != platform.OS) {
 				shouldPullImage = true
 			}
 		}
 
 		if shouldPullImage {
 			pullOpt := types.ImagePullOptions{
-				Platform: req.ImagePlatform, // may be empty
+				Platform:     req.ImagePlatform, // may be empty
+				RegistryAuth: p.registryAuth(ctx, req, tag),
 			}
 
-			registry, imageAuth, err := DockerImageAuth(ctx, req.Image)
-			if err != nil {
-				p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is:%s", registry, req.Image, err)
-			} else {
-				// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
-				encodedJSON, err := json.Marshal(imageAuth)
-				if err != nil {
-					p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is:%s", req.Image, err)
-				} else {
-					pullOpt.RegistryAuth = base64.URLEncoding.EncodeToString(encodedJSON)
-				}
+			if err := p.attemptToPullImage(ctx, tag, pullOpt, p.pullProgressHandler(req)); err != nil {
+				return nil, platformError(err, tag, req.ImagePlatform)
 			}
+		}
 
-			if err := p.attemptToPullImage(ctx, tag, pullOpt); err != nil {
+		if req.ImagePlatformFallback != "" {
+			platform, err = p.fallbackImagePlatform(ctx, req, tag, platform)
+			if err != nil {
 				return nil, err
 			}
 		}
@@ -999,12 +1378,14 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		Cmd:        req.Cmd,
 		Hostname:   req.Hostname,
 		User:       req.User,
+		WorkingDir: req.WorkingDir,
 	}
 
 	hostConfig := &container.HostConfig{
-		Privileged: req.Privileged,
-		ShmSize:    req.ShmSize,
-		Tmpfs:      req.Tmpfs,
+		Privileged:     req.Privileged,
+		ReadonlyRootfs: req.ReadOnlyRootFS,
+		ShmSize:        req.ShmSize,
+		Tmpfs:          req.Tmpfs,
 	}
 
 	networkingConfig := &network.NetworkingConfig{}
@@ -1014,9 +1395,17 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
+	p.injectHostGateway(ctx, hostConfig)
+
+	if usesHostGateway(hostConfig) {
+		if err := p.requireAPIVersion(ctx, featureHostGateway); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
 	if err != nil {
-		return nil, err
+		return nil, platformError(err, tag, req.ImagePlatform)
 	}
 
 	// #248: If there is more than one network specified in the request attach newly created container to them one by one
@@ -1031,6 +1420,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 				}
 				err = p.client.NetworkConnect(ctx, nw.ID, resp.ID, &endpointSetting)
 				if err != nil {
+					p.removeContainer(ctx, resp.ID)
 					return nil, err
 				}
 			}
@@ -1047,11 +1437,23 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		terminationSignal: termSignal,
 		stopProducer:      nil,
 		logger:            p.Logger,
+		failureHooks:      req.FailureHooks,
+		reservedPorts:     req.ReservedPorts,
+		metadata:          req.Metadata,
+		startupTimeout:    req.StartupTimeout,
+	}
+
+	// the patterns were already validated with the request
+	c.fatalLogPatterns, _ = compileFatalLogPatterns(req.FatalLogPatterns)
+
+	if cleanupPolicy != CleanupAlways {
+		cleanupPolicies.Store(c.ID, cleanupPolicy)
 	}
 
 	for _, f := range req.Files {
 		err := c.CopyFileToContainer(ctx, f.HostFilePath, f.ContainerFilePath, f.FileMode)
 		if err != nil {
+			p.removeContainer(ctx, resp.ID)
 			return nil, fmt.Errorf("can't copy %s to container: %w", f.HostFilePath, err)
 		}
 	}
@@ -1059,6 +1461,18 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	return c, nil
 }
 
+// removeContainer removes a container which could not be returned to the caller, e.g. when the request
+// failed after its creation, with its anonymous volumes, so they don't leak until the end of the session
+func (p *DockerProvider) removeContainer(ctx context.Context, id string) {
+	err := p.client.ContainerRemove(ctx, id, types.ContainerRemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	})
+	if err != nil {
+		p.Logger.Printf("%s: could not remove the container id: %s", err, id[:12])
+	}
+}
+
 func (p *DockerProvider) findContainerByName(ctx context.Context, name string) (*types.Container, error) {
 	if name == "" {
 		return nil, nil
@@ -1101,28 +1515,109 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		}
 	}
 
+	waitingFor := req.WaitingFor
+	if waitingFor == nil {
+		waitingFor = p.DefaultWaitStrategy
+	}
+
 	dc := &DockerContainer{
 		ID:                c.ID,
-		WaitingFor:        req.WaitingFor,
+		WaitingFor:        waitingFor,
 		Image:             c.Image,
 		sessionID:         testcontainerssession.ID(),
 		provider:          p,
 		terminationSignal: termSignal,
 		stopProducer:      nil,
 		logger:            p.Logger,
+		failureHooks:      req.FailureHooks,
+		reservedPorts:     req.ReservedPorts,
+		metadata:          req.Metadata,
+		startupTimeout:    req.StartupTimeout,
 		isRunning:         c.State == "running",
 	}
 
+	if dc.fatalLogPatterns, err = compileFatalLogPatterns(req.FatalLogPatterns); err != nil {
+		return nil, err
+	}
+
+	if cleanupPolicy := req.cleanupPolicy(tcConfig.CleanupPolicy); cleanupPolicy != CleanupAlways {
+		cleanupPolicies.Store(dc.ID, cleanupPolicy)
+	}
+
 	return dc, nil
 }
 
+// registryAuth returns the encoded credentials of the registry of the image pulled for the request, which is the
+// image of the request after the substitutions of the configuration, e.g. on a private registry mirror.
+// The credentials of the request take precedence over the ones detected from the Docker config.
+// It returns empty credentials if they cannot be retrieved.
+func (p *DockerProvider) registryAuth(ctx context.Context, req ContainerRequest, image string) string {
+	if req.RegistryCred != "" {
+		return req.RegistryCred
+	}
+
+	registry, imageAuth, err := DockerImageAuth(ctx, image)
+	if err != nil {
+		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is:%s", registry, image, err)
+		return ""
+	}
+
+	encoded, err := EncodeRegistryCred(imageAuth)
+	if err != nil {
+		p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is:%s", image, err)
+		return ""
+	}
+
+	return encoded
+}
+
+// fallbackImagePlatform checks that the image is available for the preferred platform, which is the platform
+// of the request or the host platform if empty. If it's not, the image is pulled for the fallback platform of
+// the request, logging a warning as it could run emulated, and the fallback platform is returned.
+func (p *DockerProvider) fallbackImagePlatform(ctx context.Context, req ContainerRequest, tag string, platform *specs.Platform) (*specs.Platform, error) {
+	fallback, err := platforms.Parse(req.ImagePlatformFallback)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fallback platform %s: %w", req.ImagePlatformFallback, err)
+	}
+
+	image, _, err := p.client.ImageInspectWithRaw(ctx, tag)
+	if err == nil && (platform == nil || (image.Architecture == platform.Architecture && image.Os == platform.OS)) {
+		// the image is available for the preferred platform
+		return platform, nil
+	}
+	if err != nil && !client.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	preferred := "host"
+	if platform != nil {
+		preferred = platforms.Format(*platform)
+	}
+
+	p.Logger.Printf("⚠️ Image %s is not available for the %s platform, falling back to the %s platform, which could run emulated", tag, preferred, platforms.Format(fallback))
+
+	pullOpt := types.ImagePullOptions{
+		Platform:     platforms.Format(fallback),
+		RegistryAuth: p.registryAuth(ctx, req, tag),
+	}
+
+	if err := p.attemptToPullImage(ctx, tag, pullOpt, p.pullProgressHandler(req)); err != nil {
+		return nil, platformError(err, tag, pullOpt.Platform)
+	}
+
+	return &fallback, nil
+}
+
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
 // Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
-func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions) error {
-	var (
-		err  error
-		pull io.ReadCloser
-	)
+// The progress of the pull is reported to the progress handler, if any.
+func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt types.ImagePullOptions, progress func(PullProgress)) (err error) {
+	ctx, end := startOperation(ctx, Operation{Name: OperationImagePull, Image: tag})
+	defer func() {
+		err = end(err)
+	}()
+
+	var pull io.ReadCloser
 	err = backoff.Retry(func() error {
 		pull, err = p.client.ImagePull(ctx, tag, pullOpt)
 		if err != nil {
@@ -1137,13 +1632,27 @@ func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pul
 		return nil
 	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
 	if err != nil {
-		return err
+		return &imagePullError{image: tag, err: err, hint: proxyPullHint(p.config, err)}
 	}
 	defer pull.Close()
 
-	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
+	// download of docker image finishes at EOF of the pull request. The errors of the registry, e.g. a 503 Service Unavailable
+	// while downloading a layer, are only reported in the stream, so they must be returned to be retried.
+	if err := readPullProgress(tag, pull, progress); err != nil {
+		return &imagePullError{image: tag, err: err, hint: proxyPullHint(p.config, err)}
+	}
+
+	return nil
+}
+
+// pullProgressHandler returns the progress handler of the pulls of the image of the request,
+// logging the progress with the logger of the provider if the request has no handler
+func (p *DockerProvider) pullProgressHandler(req ContainerRequest) func(PullProgress) {
+	if req.ImagePullProgress != nil {
+		return req.ImagePullProgress
+	}
+
+	return PullProgressLogger(p.Logger, defaultPullProgressLogInterval)
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1193,8 +1702,22 @@ func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 		return p.hostCache, nil
 	}
 
+	// the host inferred from the Docker host is cached for the session, as it could require inspecting a network
+	dockerHost := p.client.DaemonHost()
+	if cached := runtimeInfoCache.get(dockerHost).daemonHost; cached != "" {
+		p.hostCache = cached
+		return p.hostCache, nil
+	}
+	defer func() {
+		if p.hostCache != "" {
+			runtimeInfoCache.update(dockerHost, func(e *runtimeCacheEntry) {
+				e.daemonHost = p.hostCache
+			})
+		}
+	}()
+
 	// infer from Docker host
-	url, err := url.Parse(p.client.DaemonHost())
+	url, err := url.Parse(dockerHost)
 	if err != nil {
 		return "", err
 	}
@@ -1241,6 +1764,7 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 	if req.Labels == nil {
 		req.Labels = make(map[string]string)
 	}
+	testcontainersdocker.AddDefaultLabels(req.Labels, testcontainerssession.String())
 
 	tcConfig := p.Config()
 
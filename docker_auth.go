@@ -30,6 +30,18 @@ func DockerImageAuth(ctx context.Context, image string) (string, types.AuthConfi
 	return registry, types.AuthConfig{}, dockercfg.ErrCredentialsNotFound
 }
 
+// EncodeRegistryCred encodes the given auth config, to be used as the registry credentials of a container request,
+// in the format expected by the Docker daemon.
+func EncodeRegistryCred(authConfig types.AuthConfig) (string, error) {
+	// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
+	encodedJSON, err := json.Marshal(authConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(encodedJSON), nil
+}
+
 // defaultRegistry returns the default registry to use when pulling images
 // It will use the docker daemon to get the default registry, returning "https://index.docker.io/v1/" if
 // it fails to get the information from the daemon
@@ -40,7 +52,7 @@ func defaultRegistry(ctx context.Context) string {
 	}
 	defer p.Close()
 
-	info, err := p.client.Info(ctx)
+	info, err := p.daemonInfo(ctx)
 	if err != nil {
 		return testcontainersdocker.IndexDockerIO
 	}
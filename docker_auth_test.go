@@ -2,6 +2,8 @@ package testcontainers
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -237,6 +239,82 @@ func TestCreateContainerFromPrivateRegistry(t *testing.T) {
 	terminateContainerOnEnd(t, ctx, redisContainer)
 }
 
+func TestCreateContainerFromPrivateRegistryWithRegistryCred(t *testing.T) {
+	// the Docker config does not contain the credentials for the registry
+	t.Setenv("DOCKER_AUTH_CONFIG", `{}`)
+
+	prepareLocalRegistryWithAuth(t)
+
+	ctx := context.Background()
+
+	// registryCred {
+	registryCred, err := EncodeRegistryCred(types.AuthConfig{
+		Username: "testuser",
+		Password: "testpassword",
+	})
+	require.Nil(t, err)
+
+	req := ContainerRequest{
+		Image:           "localhost:5000/redis:5.0-alpine",
+		ImagePullPolicy: PullAlways, // make sure the authentication takes place
+		RegistryCred:    registryCred,
+		ExposedPorts:    []string{"6379/tcp"},
+		WaitingFor:      wait.ForLog("Ready to accept connections"),
+	}
+	// }
+
+	redisContainer, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.Nil(t, err)
+	terminateContainerOnEnd(t, ctx, redisContainer)
+}
+
+func TestRegistryAuthOfSubstitutedImage(t *testing.T) {
+	t.Setenv("DOCKER_AUTH_CONFIG", `{
+		"auths": {
+			"registry.example.com": { "username": "mirror", "password": "secret" }
+		}
+	}`)
+
+	config := TestcontainersConfig{ImageSubstitutions: "redis:7=registry.example.com/redis:7"}
+	req := ContainerRequest{Image: "redis:7"}
+
+	provider := &DockerProvider{DockerProviderOptions: &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{Logger: TestLogger(t)}}}
+
+	encoded := provider.registryAuth(context.Background(), req, config.substituteImage(req.Image))
+	require.NotEmpty(t, encoded, "the credentials of the registry of the substituted image must be used")
+
+	decoded, err := base64.URLEncoding.DecodeString(encoded)
+	require.Nil(t, err)
+
+	var authConfig types.AuthConfig
+	require.Nil(t, json.Unmarshal(decoded, &authConfig))
+
+	assert.Equal(t, "mirror", authConfig.Username)
+	assert.Equal(t, "secret", authConfig.Password)
+}
+
+func TestEncodeRegistryCred(t *testing.T) {
+	encoded, err := EncodeRegistryCred(types.AuthConfig{
+		Username:      "testuser",
+		Password:      "testpassword",
+		ServerAddress: "localhost:5000",
+	})
+	require.Nil(t, err)
+
+	decoded, err := base64.URLEncoding.DecodeString(encoded)
+	require.Nil(t, err)
+
+	var authConfig types.AuthConfig
+	require.Nil(t, json.Unmarshal(decoded, &authConfig))
+
+	assert.Equal(t, "testuser", authConfig.Username)
+	assert.Equal(t, "testpassword", authConfig.Password)
+	assert.Equal(t, "localhost:5000", authConfig.ServerAddress)
+}
+
 func prepareLocalRegistryWithAuth(t *testing.T) {
 	ctx := context.Background()
 	wd, err := os.Getwd()
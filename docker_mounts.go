@@ -1,6 +1,13 @@
 package testcontainers
 
-import "github.com/docker/docker/api/types/mount"
+import (
+	"os"
+	"strings"
+
+	"github.com/docker/docker/api/types/mount"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+)
 
 var (
 	mountTypeMapping = map[MountType]mount.Type{
@@ -78,6 +85,23 @@ func (s DockerTmpfsMountSource) GetTmpfsOptions() *mount.TmpfsOptions {
 	return s.TmpfsOptions
 }
 
+// TmpfsMount returns a new ContainerMount with a DockerTmpfsMountSource as source,
+// limited to the given size in bytes and created with the given file mode.
+// A size of zero means that the size of the mount is not limited, and a mode of zero
+// uses the default mode of the Docker daemon (1777).
+// This is a convenience method to cover typical use cases.
+func TmpfsMount(mountTarget ContainerMountTarget, sizeBytes int64, mode os.FileMode) ContainerMount {
+	return ContainerMount{
+		Source: DockerTmpfsMountSource{
+			TmpfsOptions: &mount.TmpfsOptions{
+				SizeBytes: sizeBytes,
+				Mode:      mode,
+			},
+		},
+		Target: mountTarget,
+	}
+}
+
 // mapToDockerMounts maps the given []ContainerMount to the corresponding
 // []mount.Mount for further processing
 func mapToDockerMounts(containerMounts ContainerMounts) []mount.Mount {
@@ -114,3 +138,37 @@ func mapToDockerMounts(containerMounts ContainerMounts) []mount.Mount {
 
 	return mounts
 }
+
+// labelVolumeMounts adds the Testcontainers labels present in the given labels to the volume mounts,
+// so that the named volumes created on the fly by the Docker daemon can be garbage collected by the reaper
+func labelVolumeMounts(mounts []mount.Mount, labels map[string]string) {
+	for idx := range mounts {
+		if mounts[idx].Type != mount.TypeVolume {
+			continue
+		}
+
+		volumeOptions := mount.VolumeOptions{}
+		if mounts[idx].VolumeOptions != nil {
+			// copy the options, as they could be shared with the request
+			volumeOptions = *mounts[idx].VolumeOptions
+		}
+
+		volumeLabels := make(map[string]string, len(volumeOptions.Labels)+len(labels))
+		for k, v := range volumeOptions.Labels {
+			volumeLabels[k] = v
+		}
+
+		for k, v := range labels {
+			if !strings.HasPrefix(k, testcontainersdocker.LabelBase) {
+				continue
+			}
+
+			if _, ok := volumeLabels[k]; !ok {
+				volumeLabels[k] = v
+			}
+		}
+
+		volumeOptions.Labels = volumeLabels
+		mounts[idx].VolumeOptions = &volumeOptions
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/go-units"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 
+	"github.com/testcontainers/testcontainers-go/internal"
 	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -539,6 +542,117 @@ func TestContainerStopWithReaper(t *testing.T) {
 	}
 }
 
+func TestContainerLabels(t *testing.T) {
+	ctx := context.Background()
+
+	// containerLabels {
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+		Labels: map[string]string{
+			"com.example.team": "payments",
+		},
+	}
+	// }
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	inspect, err := nginxC.(*DockerContainer).inspectContainer(ctx)
+	require.NoError(t, err)
+
+	labels := inspect.Config.Labels
+	assert.Equal(t, "payments", labels["com.example.team"])
+	assert.Equal(t, "true", labels[testcontainersdocker.LabelBase])
+	assert.Equal(t, "go", labels[testcontainersdocker.LabelLang])
+	assert.Equal(t, internal.Version, labels[testcontainersdocker.LabelVersion])
+	assert.Equal(t, SessionID(), labels[testcontainersdocker.LabelSessionID])
+}
+
+func TestContainerStopAndStart(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	// stopAndStart {
+	timeout := 10 * time.Second
+	err = nginxC.Stop(ctx, &timeout)
+	require.NoError(t, err)
+	require.False(t, nginxC.IsRunning())
+
+	// the wait strategy is executed again, so the container is ready to be used
+	err = nginxC.Start(ctx)
+	require.NoError(t, err)
+	require.True(t, nginxC.IsRunning())
+
+	// the mapped port could have changed after the restart
+	endpoint, err := nginxC.PortEndpoint(ctx, nginxDefaultPort, "http")
+	// }
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestContainerPauseAndResume(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	endpoint, err := nginxC.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	// pauseAndResume {
+	err = nginxC.Pause(ctx)
+	require.NoError(t, err)
+
+	state, err := nginxC.State(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Paused)
+
+	// the paused container does not answer any request
+	client := http.Client{Timeout: time.Second}
+	_, err = client.Get(endpoint)
+	require.Error(t, err)
+
+	err = nginxC.Resume(ctx)
+	require.NoError(t, err)
+	// }
+
+	resp, err := client.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func TestContainerTerminationWithReaper(t *testing.T) {
 	tcConfig := readConfig() // read the config using the private method to avoid the sync.Once
 	if tcConfig.RyukDisabled {
@@ -1035,6 +1149,33 @@ func TestContainerCreationWaitsForLogContextTimeout(t *testing.T) {
 	terminateContainerOnEnd(t, ctx, c)
 }
 
+func TestContainerCreationWaitsWithRequestStartupTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	// startupTimeout {
+	req := ContainerRequest{
+		Image:          nginxAlpineImage,
+		ExposedPorts:   []string{nginxDefaultPort},
+		StartupTimeout: 2 * time.Second,
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(nginxDefaultPort),
+			wait.ForLog("never logged"),
+		),
+	}
+	// }
+
+	start := time.Now()
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 60*time.Second)
+}
+
 func TestContainerCreationWaitsForLog(t *testing.T) {
 	ctx := context.Background()
 	req := ContainerRequest{
@@ -1406,6 +1547,34 @@ func TestContainerCreationWithBindAndVolume(t *testing.T) {
 	require.NoError(t, bashC.Terminate(ctx))
 }
 
+func TestContainerWaitsForVolumeContent(t *testing.T) {
+	ctx := context.Background()
+	volumeName := "tc-artifacts-" + uuid.NewString()
+
+	// waitForVolume {
+	initJob, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Mounts:     Mounts(VolumeMount(volumeName, "/artifacts")),
+			Cmd:        []string{"sh", "-c", "sleep 2; mkdir -p /artifacts/build && echo built > /artifacts/build/app.txt"},
+			WaitingFor: wait.ForVolume(volumeName, "build/app.txt"),
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, initJob)
+
+	r, err := initJob.CopyFileFromContainer(ctx, "/artifacts/build/app.txt")
+	require.NoError(t, err)
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "built\n", string(content))
+}
+
 func TestContainerWithTmpFs(t *testing.T) {
 	ctx := context.Background()
 	req := ContainerRequest{
@@ -1450,6 +1619,41 @@ func TestContainerWithTmpFs(t *testing.T) {
 	}
 }
 
+func TestContainerWithTmpfsMount(t *testing.T) {
+	ctx := context.Background()
+
+	// tmpfsMount {
+	req := ContainerRequest{
+		Image:  "docker.io/busybox",
+		Cmd:    []string{"sleep", "10"},
+		Mounts: Mounts(TmpfsMount("/cache", 16*1024*1024, 0o1777)),
+	}
+	// }
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	inspect, err := container.(*DockerContainer).inspectContainer(ctx)
+	require.NoError(t, err)
+	require.Len(t, inspect.Mounts, 1)
+	assert.Equal(t, "tmpfs", string(inspect.Mounts[0].Type))
+	assert.Equal(t, "/cache", inspect.Mounts[0].Destination)
+
+	c, reader, err := container.Exec(ctx, []string{"df", "-k", "/cache"})
+	require.NoError(t, err)
+	require.Equal(t, 0, c)
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "16384")
+}
+
 func TestContainerNonExistentImage(t *testing.T) {
 	t.Run("if the image not found don't propagate the error", func(t *testing.T) {
 		_, err := GenericContainer(context.Background(), GenericContainerRequest{
@@ -1536,6 +1740,83 @@ func TestContainerCustomPlatformImage(t *testing.T) {
 	})
 }
 
+func TestContainerWithFixedHostPort(t *testing.T) {
+	ctx := context.Background()
+
+	reservation, err := ReservePorts(1)
+	require.NoError(t, err)
+	hostPort := reservation.Ports()[0]
+	require.NoError(t, reservation.Release())
+
+	// fixedHostPort {
+	req := ContainerRequest{
+		Image: nginxAlpineImage,
+		// the host port is fixed, binding it to the container port
+		ExposedPorts: []string{fmt.Sprintf("%d:%s", hostPort, nginxDefaultPort)},
+		WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+	}
+	// }
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	port, err := nginxC.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(hostPort), port.Port())
+
+	t.Run("taken host port", func(t *testing.T) {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType:     providerType,
+			ContainerRequest: req,
+			Started:          true,
+		})
+		terminateContainerOnEnd(t, ctx, c)
+
+		require.ErrorIs(t, err, ErrHostPortAllocated)
+		assert.Contains(t, err.Error(), fmt.Sprintf("%d->%s", hostPort, nginxDefaultPort))
+	})
+}
+
+func TestIsHostPortAllocatedError(t *testing.T) {
+	assert.True(t, isHostPortAllocatedError(errors.New("driver failed programming external connectivity on endpoint: Bind for 0.0.0.0:8080 failed: port is already allocated")))
+	assert.True(t, isHostPortAllocatedError(errors.New("listen tcp4 0.0.0.0:8080: bind: address already in use")))
+	assert.False(t, isHostPortAllocatedError(errors.New("no such image")))
+}
+
+func TestContainerImagePlatformFallback(t *testing.T) {
+	if providerType == ProviderPodman {
+		t.Skip("Incompatible Docker API version for Podman")
+	}
+
+	ctx := context.Background()
+
+	// imagePlatformFallback {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:                 "docker.io/mysql:5.7", // only available for linux/amd64
+			ImagePlatform:         "linux/arm64",
+			ImagePlatformFallback: "linux/amd64",
+		},
+		Started: false,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	dc, ok := c.(*DockerContainer)
+	require.True(t, ok)
+
+	platform, err := dc.Platform(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "linux/amd64", platform)
+}
+
 func TestContainerWithCustomHostname(t *testing.T) {
 	ctx := context.Background()
 	name := fmt.Sprintf("some-nginx-%s-%d", t.Name(), rand.Int())
@@ -1802,6 +2083,50 @@ func TestDockerContainerCopyToContainer(t *testing.T) {
 	}
 }
 
+func TestDockerContainerCommit(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	err = nginxC.CopyToContainer(ctx, []byte("seeded"), "/seed.txt", 700)
+	require.NoError(t, err)
+
+	// commitContainer {
+	image, err := nginxC.Commit(ctx, "")
+	require.NoError(t, err)
+
+	seededC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        image,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, seededC)
+
+	reader, err := seededC.CopyFileFromContainer(ctx, "/seed.txt")
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "seeded", string(content))
+}
+
 func TestDockerContainerCopyFileFromContainer(t *testing.T) {
 	fileContent, err := os.ReadFile("./testresources/hello.sh")
 	if err != nil {
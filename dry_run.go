@@ -0,0 +1,168 @@
+package testcontainers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// ContainerPlan is the creation plan of a container, materialized from a request without calling the Docker daemon
+type ContainerPlan struct {
+	// Name is the name of the container, generated by the daemon if empty
+	Name string
+	// Image is the image of the container, after the substitutions of the Testcontainers configuration.
+	// It's empty if the image is built from a Dockerfile, as its tag is only known once it's built.
+	Image string
+	// BuildImage is true if the image is built from the Dockerfile of the request
+	BuildImage bool
+	// Platform is the platform of the image, if any
+	Platform *specs.Platform
+	// Config is the configuration of the container, as passed to the daemon. If the request doesn't expose
+	// any port, the ports exposed by the image are only resolved when the container is created.
+	Config *container.Config
+	// HostConfig is the host configuration of the container, as passed to the daemon,
+	// except the host.testcontainers.internal alias, which needs the running forwarder of the host ports
+	HostConfig *container.HostConfig
+	// NetworkingConfig is the configuration of the network the container is created in, the first one of the request.
+	// The IDs of the networks are only resolved when the container is created.
+	NetworkingConfig *network.NetworkingConfig
+	// Networks are the networks of the request, the container being attached to the others once it's created.
+	// The default network of the provider is added when the container is created if it's not the bridge network.
+	Networks []string
+	// Files are the files copied to the container once it's created
+	Files []ContainerFile
+	// WaitingFor is the wait strategy executed when the container is started
+	WaitingFor wait.Strategy
+	// StartupTimeout is the startup timeout of the wait strategies not defining their own, 60 seconds if it's zero
+	StartupTimeout time.Duration
+}
+
+// Validate ensures that the request does not have invalid parameters, without calling the Docker daemon,
+// e.g. a reused container without a name, or an invalid exposed port
+func (r GenericContainerRequest) Validate() error {
+	if r.Reuse && r.Name == "" {
+		return ErrReuseEmptyName
+	}
+
+	return r.ContainerRequest.Validate()
+}
+
+// DryRun validates the request and returns the plan of the creation of its container, resolving the image,
+// the ports, the mounts and the networks, and applying the modifiers of the request, without calling the Docker daemon.
+// It's meant to unit test the code building the requests, e.g. the options of a module.
+func (r GenericContainerRequest) DryRun() (*ContainerPlan, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := r.ContainerRequest
+	tcConfig := ReadConfig()
+
+	plan := &ContainerPlan{
+		Name:           req.Name,
+		BuildImage:     req.ShouldBuildImage(),
+		Networks:       append([]string{}, req.Networks...),
+		Files:          req.Files,
+		WaitingFor:     req.WaitingFor,
+		StartupTimeout: req.StartupTimeout,
+	}
+
+	if !plan.BuildImage {
+		plan.Image = tcConfig.substituteImage(req.Image)
+	}
+
+	if req.ImagePlatform != "" {
+		// the platform was already validated with the request
+		p, _ := platforms.Parse(req.ImagePlatform)
+		plan.Platform = &p
+	}
+
+	// the env files were already validated with the request
+	env, _ := req.environment()
+
+	labels := make(map[string]string, len(req.Labels))
+	for k, v := range req.Labels {
+		labels[k] = v
+	}
+	reaperOpts := containerOptions{
+		ImageName: req.ReaperImage,
+	}
+	for _, opt := range req.ReaperOptions {
+		opt(&reaperOpts)
+	}
+	// the reaper is not labeled with the session ID, otherwise it would reap itself
+	if !strings.EqualFold(req.Image, reaperImage(reaperOpts.ImageName)) {
+		testcontainersdocker.AddDefaultLabels(labels, testcontainerssession.String())
+	}
+
+	cleanupPolicy := req.cleanupPolicy(tcConfig.CleanupPolicy)
+	if cleanupPolicy != CleanupAlways {
+		delete(labels, testcontainersdocker.LabelReap)
+		labels[testcontainersdocker.LabelCleanupPolicy] = string(cleanupPolicy)
+	}
+
+	plan.Config = &container.Config{
+		Entrypoint: req.Entrypoint,
+		Image:      plan.Image,
+		Env:        env,
+		Labels:     labels,
+		Cmd:        req.Cmd,
+		Hostname:   req.Hostname,
+		User:       req.User,
+		WorkingDir: req.WorkingDir,
+	}
+
+	plan.HostConfig = &container.HostConfig{
+		Privileged:     req.Privileged,
+		ReadonlyRootfs: req.ReadOnlyRootFS,
+		ShmSize:        req.ShmSize,
+		Tmpfs:          req.Tmpfs,
+		Mounts:         mapToDockerMounts(req.Mounts),
+	}
+	labelVolumeMounts(plan.HostConfig.Mounts, labels)
+
+	endpointSettings := map[string]*network.EndpointSettings{}
+	if len(req.Networks) > 0 {
+		endpointSettings[req.Networks[0]] = &network.EndpointSettings{
+			Aliases: append([]string{}, req.NetworkAliases[req.Networks[0]]...),
+		}
+	}
+
+	if req.ConfigModifier != nil {
+		req.ConfigModifier(plan.Config)
+	}
+
+	if req.HostConfigModifier == nil {
+		req.HostConfigModifier = defaultHostConfigModifier(req)
+	}
+	req.HostConfigModifier(plan.HostConfig)
+
+	applyResourceLimits(req, plan.HostConfig)
+	applyCapabilities(req, plan.HostConfig)
+
+	if req.EnpointSettingsModifier != nil {
+		req.EnpointSettingsModifier(endpointSettings)
+	}
+
+	if req.EndpointSettingsModifier != nil {
+		req.EndpointSettingsModifier(endpointSettings)
+	}
+
+	plan.NetworkingConfig = &network.NetworkingConfig{EndpointsConfig: endpointSettings}
+
+	// the exposed ports were already validated with the request
+	exposedPortSet, exposedPortMap, _ := nat.ParsePortSpecs(req.ExposedPorts)
+	plan.Config.ExposedPorts = exposedPortSet
+	plan.HostConfig.PortBindings = exposedPortMap
+
+	return plan, nil
+}
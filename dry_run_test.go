@@ -0,0 +1,162 @@
+package testcontainers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestGenericContainerRequestValidate(t *testing.T) {
+	t.Run("Reuse without a name", func(t *testing.T) {
+		err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: "redis:latest"},
+			Reuse:            true,
+		}.Validate()
+
+		assert.ErrorIs(t, err, ErrReuseEmptyName)
+	})
+
+	t.Run("Invalid container request", func(t *testing.T) {
+		err := GenericContainerRequest{}.Validate()
+
+		assert.EqualError(t, err, "you must specify either a build context or an image")
+	})
+
+	t.Run("Valid request", func(t *testing.T) {
+		err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: "redis:latest", Name: "cache"},
+			Reuse:            true,
+		}.Validate()
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("Materialized plan", func(t *testing.T) {
+		strategy := wait.ForListeningPort("6379/tcp")
+
+		// dryRun {
+		plan, err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:          "redis:7",
+				Name:           "cache",
+				ExposedPorts:   []string{"6379/tcp", "127.0.0.1:16379:16379/tcp"},
+				Env:            map[string]string{"REDIS_ARGS": "--save 60 1"},
+				Mounts:         Mounts(VolumeMount("redis-data", "/data")),
+				Networks:       []string{"backend", "monitoring"},
+				NetworkAliases: map[string][]string{"backend": {"redis"}},
+				ImagePlatform:  "linux/arm64",
+				WaitingFor:     strategy,
+				ConfigModifier: func(config *container.Config) {
+					config.WorkingDir = "/data"
+				},
+			},
+		}.DryRun()
+		// }
+		require.NoError(t, err)
+
+		assert.Equal(t, "cache", plan.Name)
+		assert.Equal(t, "redis:7", plan.Image)
+		assert.False(t, plan.BuildImage)
+		require.NotNil(t, plan.Platform)
+		assert.Equal(t, "arm64", plan.Platform.Architecture)
+		assert.Equal(t, strategy, plan.WaitingFor)
+
+		assert.Equal(t, "redis:7", plan.Config.Image)
+		assert.Equal(t, "/data", plan.Config.WorkingDir)
+		assert.Equal(t, []string{"REDIS_ARGS=--save 60 1"}, plan.Config.Env)
+		assert.Equal(t, "true", plan.Config.Labels[testcontainersdocker.LabelBase])
+		assert.Contains(t, plan.Config.ExposedPorts, nat.Port("6379/tcp"))
+		assert.Equal(t, []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "16379"}}, plan.HostConfig.PortBindings["16379/tcp"])
+
+		require.Len(t, plan.HostConfig.Mounts, 1)
+		assert.Equal(t, mount.TypeVolume, plan.HostConfig.Mounts[0].Type)
+		assert.Equal(t, "redis-data", plan.HostConfig.Mounts[0].Source)
+
+		assert.Equal(t, []string{"backend", "monitoring"}, plan.Networks)
+		require.Contains(t, plan.NetworkingConfig.EndpointsConfig, "backend")
+		assert.Equal(t, []string{"redis"}, plan.NetworkingConfig.EndpointsConfig["backend"].Aliases)
+	})
+
+	t.Run("Runtime settings", func(t *testing.T) {
+		plan, err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "ghcr.io/shopify/toxiproxy:2.5.0",
+				WorkingDir: "/app",
+				User:       "nobody",
+				Privileged: true,
+				CapAdd:     []string{"NET_ADMIN"},
+				CapDrop:    []string{"ALL"},
+				HostConfigModifier: func(hostConfig *container.HostConfig) {
+					hostConfig.CapAdd = []string{"cap_net_admin", "SYS_TIME"}
+				},
+			},
+		}.DryRun()
+		require.NoError(t, err)
+
+		assert.Equal(t, "/app", plan.Config.WorkingDir)
+		assert.Equal(t, "nobody", plan.Config.User)
+		assert.True(t, plan.HostConfig.Privileged)
+		assert.Equal(t, strslice.StrSlice{"cap_net_admin", "SYS_TIME"}, plan.HostConfig.CapAdd)
+		assert.Equal(t, strslice.StrSlice{"ALL"}, plan.HostConfig.CapDrop)
+	})
+
+	t.Run("Env files and read-only root filesystem", func(t *testing.T) {
+		// envFiles {
+		plan, err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:          "docker.io/postgres:15-alpine",
+				EnvFiles:       []string{filepath.Join("testresources", "postgres.env")},
+				Env:            map[string]string{"POSTGRES_DB": "orders"},
+				ReadOnlyRootFS: true,
+				Tmpfs:          map[string]string{"/var/run/postgresql": "rw", "/var/lib/postgresql/data": "rw"},
+			},
+		}.DryRun()
+		// }
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"POSTGRES_USER=app", "POSTGRES_PASSWORD=s3cr#t", "POSTGRES_DB=orders"}, plan.Config.Env)
+		assert.True(t, plan.HostConfig.ReadonlyRootfs)
+	})
+
+	t.Run("Built image", func(t *testing.T) {
+		plan, err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{Context: "./testresources"},
+			},
+		}.DryRun()
+		require.NoError(t, err)
+
+		assert.True(t, plan.BuildImage)
+		assert.Empty(t, plan.Image)
+	})
+
+	t.Run("The request is not modified", func(t *testing.T) {
+		labels := map[string]string{"app": "cache"}
+
+		_, err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: "redis:7", Labels: labels},
+		}.DryRun()
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"app": "cache"}, labels)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		_, err := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{Image: "redis:7", ExposedPorts: []string{"http"}},
+		}.DryRun()
+
+		assert.Error(t, err)
+	})
+}
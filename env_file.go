@@ -0,0 +1,206 @@
+package testcontainers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// envVarName matches the names of the variables of an env file
+	envVarName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]*$`)
+	// interpolatedVarName matches the names of the variables which can be interpolated in a value
+	interpolatedVarName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// environment returns the environment variables of the container, in the KEY=VALUE format: the ones of the env files,
+// in order, overridden by the ones of the Env field of the request, like the env_file and environment attributes of docker-compose
+func (c *ContainerRequest) environment() ([]string, error) {
+	vars := map[string]string{}
+	for _, path := range c.EnvFiles {
+		fileVars, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range fileVars {
+			vars[k] = v
+		}
+	}
+
+	for k, v := range c.Env {
+		vars[k] = v
+	}
+
+	env := make([]string, 0, len(vars))
+	for k, v := range vars {
+		env = append(env, k+"="+v)
+	}
+
+	return env, nil
+}
+
+func (c *ContainerRequest) validateEnvFiles() error {
+	for _, path := range c.EnvFiles {
+		if _, err := readEnvFile(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readEnvFile reads the variables of the env file at the given path, relative to the working directory of the test
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not open the env file %s", err, path)
+	}
+	defer f.Close()
+
+	vars, err := parseEnvFile(f, os.LookupEnv)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid env file %s", err, path)
+	}
+
+	return vars, nil
+}
+
+// parseEnvFile parses the variables of an env file, with the semantics of the env_file attribute of docker-compose:
+//   - the blank lines and the lines starting with # are ignored, as the export prefix of the lines
+//   - a variable without a value, e.g. VAR, takes the value of the variable in the environment of the tests, if it's set
+//   - the values between single quotes are used verbatim, while the values between double quotes support escape sequences
+//     such as \n, and can span multiple lines
+//   - the unquoted and double-quoted values are interpolated, with ${VAR} or $VAR, from the variables defined above in the file
+//     and the environment of the tests. ${VAR:-default} and ${VAR-default} define a default value.
+//   - the inline comments of the unquoted values must be preceded by a whitespace, e.g. VAR=value # comment
+func parseEnvFile(r io.Reader, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	vars := map[string]string{}
+	lookup := func(name string) (string, bool) {
+		if v, ok := vars[name]; ok {
+			return v, true
+		}
+		return lookupEnv(name)
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, hasValue := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !envVarName.MatchString(name) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, name)
+		}
+
+		if !hasValue {
+			if v, ok := lookupEnv(name); ok {
+				vars[name] = v
+			}
+			continue
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		switch {
+		case strings.HasPrefix(value, "'"):
+			raw, ok := readQuotedValue(scanner, value[1:], '\'', &lineNumber)
+			if !ok {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value of %s", lineNumber, name)
+			}
+			vars[name] = raw
+		case strings.HasPrefix(value, `"`):
+			raw, ok := readQuotedValue(scanner, value[1:], '"', &lineNumber)
+			if !ok {
+				return nil, fmt.Errorf("line %d: unterminated double-quoted value of %s", lineNumber, name)
+			}
+			vars[name] = interpolate(unescape(raw), lookup)
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			} else if i := strings.Index(value, "\t#"); i >= 0 {
+				value = value[:i]
+			}
+			vars[name] = interpolate(strings.TrimSpace(value), lookup)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// readQuotedValue reads a quoted value up to its closing quote, reading the next lines if needed.
+// It returns false if the value is not terminated.
+func readQuotedValue(scanner *bufio.Scanner, value string, quote byte, lineNumber *int) (string, bool) {
+	var sb strings.Builder
+	for {
+		if end := closingQuote(value, quote); end >= 0 {
+			sb.WriteString(value[:end])
+			return sb.String(), true
+		}
+
+		sb.WriteString(value)
+		if !scanner.Scan() {
+			return "", false
+		}
+		*lineNumber++
+		sb.WriteString("\n")
+		value = scanner.Text()
+	}
+}
+
+// closingQuote returns the index of the closing quote of the value, skipping the escaped double quotes, or -1
+func closingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unescape replaces the escape sequences of a double-quoted value
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`, `\$`, "\x00").Replace(value)
+}
+
+// interpolate replaces the ${VAR}, ${VAR:-default}, ${VAR-default} and $VAR references of a value
+func interpolate(value string, lookup func(string) (string, bool)) string {
+	interpolated := os.Expand(value, func(ref string) string {
+		if name, def, ok := strings.Cut(ref, ":-"); ok {
+			if v, found := lookup(name); found && v != "" {
+				return v
+			}
+			return def
+		}
+
+		if name, def, ok := strings.Cut(ref, "-"); ok && interpolatedVarName.MatchString(name) {
+			if v, found := lookup(name); found {
+				return v
+			}
+			return def
+		}
+
+		v, _ := lookup(ref)
+		return v
+	})
+
+	// the escaped dollar signs of the double-quoted values are restored once the value is interpolated
+	return strings.ReplaceAll(interpolated, "\x00", "$")
+}
@@ -0,0 +1,83 @@
+package testcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	hostEnv := map[string]string{"HOST_USER": "alice", "EMPTY": ""}
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := hostEnv[name]
+		return v, ok
+	}
+
+	t.Run("docker-compose semantics", func(t *testing.T) {
+		content := `
+# comment
+export EXPORTED=yes
+PLAIN = value # inline comment
+HASH=a#b
+SINGLE='$HOST_USER\n # verbatim'
+DOUBLE="line1\nline2 \"quoted\" \$HOST_USER"
+MULTILINE="first
+second"
+HOST_USER
+UNSET_IN_HOST
+INTERPOLATED=${HOST_USER}@$PLAIN
+DEFAULTS=${EMPTY:-fallback} ${EMPTY-kept} ${MISSING-missing}
+EMPTY_VALUE=
+`
+		vars, err := parseEnvFile(strings.NewReader(content), lookupEnv)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"EXPORTED":     "yes",
+			"PLAIN":        "value",
+			"HASH":         "a#b",
+			"SINGLE":       `$HOST_USER\n # verbatim`,
+			"DOUBLE":       "line1\nline2 \"quoted\" $HOST_USER",
+			"MULTILINE":    "first\nsecond",
+			"HOST_USER":    "alice",
+			"INTERPOLATED": "alice@value",
+			"DEFAULTS":     "fallback  missing",
+			"EMPTY_VALUE":  "",
+		}, vars)
+	})
+
+	t.Run("invalid variable name", func(t *testing.T) {
+		_, err := parseEnvFile(strings.NewReader("1VAR=value"), lookupEnv)
+		require.ErrorContains(t, err, "line 1")
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		_, err := parseEnvFile(strings.NewReader("A=1\nB=\"value"), lookupEnv)
+		require.ErrorContains(t, err, "unterminated double-quoted value of B")
+	})
+}
+
+func TestContainerRequestEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.env")
+	local := filepath.Join(dir, "local.env")
+	require.NoError(t, os.WriteFile(base, []byte("A=base\nB=base\nC=base\n"), 0o600))
+	require.NoError(t, os.WriteFile(local, []byte("B=local\nC=local\n"), 0o600))
+
+	req := ContainerRequest{
+		Image:    "docker.io/alpine",
+		EnvFiles: []string{base, local},
+		Env:      map[string]string{"C": "request"},
+	}
+
+	env, err := req.environment()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"A=base", "B=local", "C=request"}, env)
+
+	req.EnvFiles = append(req.EnvFiles, filepath.Join(dir, "missing.env"))
+	require.ErrorContains(t, req.Validate(), "could not open the env file")
+}
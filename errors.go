@@ -0,0 +1,88 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// containerExitedLogLines is the number of lines of the logs attached to an ErrContainerExited error
+const containerExitedLogLines = 20
+
+var (
+	// ErrImagePullFailed is returned when the image of a container can't be pulled, e.g. it doesn't exist
+	// or the registry is not reachable. The error of the Docker daemon is wrapped too.
+	ErrImagePullFailed = errors.New("could not pull the image")
+	// ErrPortNotExposed is returned when the mapped port of a port not exposed by the container is requested,
+	// neither by the request nor by the image, so waiting for the port would only time out
+	ErrPortNotExposed = errors.New("the port is not exposed by the container")
+)
+
+// imagePullError is the error returned when the image of a container can't be pulled, matching ErrImagePullFailed
+// while wrapping the error of the Docker daemon
+type imagePullError struct {
+	image string
+	err   error
+	hint  string // a hint about the cause of the error, e.g. the proxy of the Docker daemon, if any
+}
+
+func (e *imagePullError) Error() string {
+	if e.hint != "" {
+		return fmt.Sprintf("%s %s: %s (%s)", ErrImagePullFailed, e.image, e.err, e.hint)
+	}
+
+	return fmt.Sprintf("%s %s: %s", ErrImagePullFailed, e.image, e.err)
+}
+
+func (e *imagePullError) Is(target error) bool {
+	return target == ErrImagePullFailed
+}
+
+func (e *imagePullError) Unwrap() error {
+	return e.err
+}
+
+// portNotExposedError returns the ErrPortNotExposed error for the given port
+func portNotExposedError(port nat.Port) error {
+	return fmt.Errorf("%w: %s", ErrPortNotExposed, port)
+}
+
+// ErrContainerExited is returned when the container exited before its wait strategy considered it ready,
+// e.g. because of an invalid configuration, with the last lines of its logs so the cause is printed with the error
+// of the test. It matches ErrContainerDied if the exit aborted the wait strategy.
+type ErrContainerExited struct {
+	// ContainerID is the ID of the container
+	ContainerID string
+	// Image is the image of the container
+	Image string
+	// ExitCode is the exit code of the main process of the container
+	ExitCode int
+	// Logs are the last lines of the logs of the container
+	Logs []string
+	// Err is the error of the wait strategy
+	Err error
+}
+
+func (e *ErrContainerExited) Error() string {
+	msg := fmt.Sprintf("the container %s (%s) exited with code %d: %s", shortContainerID(e.ContainerID), e.Image, e.ExitCode, e.Err)
+	if len(e.Logs) == 0 {
+		return msg
+	}
+
+	return msg + "\nlast lines of the logs:\n\t" + strings.Join(e.Logs, "\n\t")
+}
+
+func (e *ErrContainerExited) Unwrap() error {
+	return e.Err
+}
+
+// shortContainerID returns the first 12 characters of the ID of a container, as displayed by the Docker CLI
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+
+	return id
+}
@@ -0,0 +1,125 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestImagePullError(t *testing.T) {
+	daemonErr := errors.New("manifest for redis:unknown not found")
+	err := fmt.Errorf("%w: failed to create container", &imagePullError{image: "redis:unknown", err: daemonErr})
+
+	assert.True(t, errors.Is(err, ErrImagePullFailed))
+	assert.True(t, errors.Is(err, daemonErr))
+	assert.EqualError(t, err, "could not pull the image redis:unknown: manifest for redis:unknown not found: failed to create container")
+}
+
+func TestPortNotExposedError(t *testing.T) {
+	err := wait.Permanent(portNotExposedError("8080/tcp"))
+
+	assert.True(t, errors.Is(err, ErrPortNotExposed))
+	assert.True(t, wait.IsPermanent(err))
+	assert.EqualError(t, err, "the port is not exposed by the container: 8080/tcp")
+}
+
+func TestIsExposed(t *testing.T) {
+	exposed := nat.PortSet{"8080/tcp": {}, "53/udp": {}}
+
+	assert.True(t, isExposed(exposed, "8080/tcp"))
+	assert.True(t, isExposed(exposed, "8080"))
+	assert.True(t, isExposed(exposed, "53/udp"))
+	assert.False(t, isExposed(exposed, "53/tcp"))
+	assert.False(t, isExposed(exposed, "9090/tcp"))
+	assert.False(t, isExposed(nil, "8080/tcp"))
+}
+
+func TestErrContainerExited(t *testing.T) {
+	waitErr := fmt.Errorf("%w: exit code 1: context canceled", ErrContainerDied)
+	err := fmt.Errorf("%w: could not start container", &ErrContainerExited{
+		ContainerID: "0123456789abcdef",
+		Image:       "postgres:15",
+		ExitCode:    1,
+		Logs:        []string{"initdb: error: invalid locale settings", "exiting"},
+		Err:         waitErr,
+	})
+
+	var exited *ErrContainerExited
+	require.True(t, errors.As(err, &exited))
+	assert.Equal(t, 1, exited.ExitCode)
+	assert.True(t, errors.Is(err, ErrContainerDied))
+
+	assert.Equal(t, "the container 0123456789ab (postgres:15) exited with code 1: the container died while waiting for it to be ready: exit code 1: context canceled\n"+
+		"last lines of the logs:\n\tinitdb: error: invalid locale settings\n\texiting", exited.Error())
+
+	withoutLogs := &ErrContainerExited{ContainerID: "0123", Image: "postgres:15", ExitCode: 2, Err: errors.New("container exited with code 2")}
+	assert.Equal(t, "the container 0123 (postgres:15) exited with code 2: container exited with code 2", withoutLogs.Error())
+}
+
+func TestContainerExitedErrorHasLogs(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Cmd:        []string{"sh", "-c", "echo 'invalid configuration'; sleep 1; exit 3"},
+			WaitingFor: wait.ForLog("this is never logged").WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.Error(t, err)
+
+	// containerExited {
+	var exited *ErrContainerExited
+	if errors.As(err, &exited) {
+		t.Logf("the container exited with code %d, last logs:\n%s", exited.ExitCode, strings.Join(exited.Logs, "\n"))
+	}
+	// }
+
+	require.NotNil(t, exited, "unexpected error: %v", err)
+	assert.Equal(t, 3, exited.ExitCode)
+	assert.Equal(t, []string{"invalid configuration"}, exited.Logs)
+	assert.True(t, errors.Is(err, ErrContainerDied))
+}
+
+func TestMappedPortOfNotExposedPort(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	_, err = c.MappedPort(ctx, "9090/tcp")
+	assert.True(t, errors.Is(err, ErrPortNotExposed), "unexpected error: %v", err)
+}
+
+func TestImagePullFailed(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/testcontainers/this-image-does-not-exist:1.0.0",
+		},
+		Started: true,
+	})
+	assert.True(t, errors.Is(err, ErrImagePullFailed), "unexpected error: %v", err)
+}
@@ -0,0 +1,148 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// The actions of the container events delivered by the Events method of a container
+const (
+	ContainerEventDie          = "die"
+	ContainerEventOOM          = "oom"
+	ContainerEventHealthStatus = "health_status"
+	ContainerEventRestart      = "restart"
+)
+
+// ErrContainerDied is returned when a container dies while its wait strategy is waiting for it to be ready
+var ErrContainerDied = errors.New("the container died while waiting for it to be ready")
+
+// ContainerEvent represents an event of the lifecycle of a container, as reported by the Docker event stream
+type ContainerEvent struct {
+	// Action is the action of the event: die, oom, health_status or restart
+	Action string
+	// HealthStatus is the new health status of the container, for health_status events
+	HealthStatus string
+	// ExitCode is the exit code of the main process of the container, for die events
+	ExitCode int
+	// Attributes are the attributes of the event, including the labels of the container
+	Attributes map[string]string
+	// Time is the moment the event happened
+	Time time.Time
+}
+
+// Events subscribes to the die, oom, health_status and restart events of the container.
+// The events are delivered in the returned channel, which is closed when the context is done,
+// so the context must be cancelled to release the subscription.
+func (c *DockerContainer) Events(ctx context.Context) (<-chan ContainerEvent, error) {
+	return c.events(ctx, ContainerEventDie, ContainerEventOOM, ContainerEventHealthStatus, ContainerEventRestart)
+}
+
+// events subscribes to the given actions of the container in the Docker event stream.
+// The subscription is established when it returns, so no event happening after it is lost.
+func (c *DockerContainer) events(ctx context.Context, actions ...string) (<-chan ContainerEvent, error) {
+	args := filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("container", c.ID),
+	)
+	for _, action := range actions {
+		args.Add("event", action)
+	}
+
+	messages, errs := c.provider.client.Events(ctx, types.EventsOptions{Filters: args})
+
+	select {
+	case err := <-errs:
+		return nil, fmt.Errorf("%w: could not subscribe to the events of the container", err)
+	default:
+	}
+
+	ch := make(chan ContainerEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case msg := <-messages:
+				select {
+				case ch <- toContainerEvent(msg):
+				case <-ctx.Done():
+					return
+				}
+			case err := <-errs:
+				if err != nil && ctx.Err() == nil {
+					c.logger.Printf("The event stream of the container %s failed: %v", c.ID[:12], err)
+				}
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// watchDie cancels the given function when the container dies, delivering the die event in the returned channel.
+// The container events are watched until the context is done.
+func (c *DockerContainer) watchDie(ctx context.Context, cancel context.CancelFunc) (<-chan ContainerEvent, error) {
+	events, err := c.events(ctx, ContainerEventDie)
+	if err != nil {
+		return nil, err
+	}
+
+	died := make(chan ContainerEvent, 1)
+	go func() {
+		if e, ok := <-events; ok {
+			died <- e
+			cancel()
+		}
+	}()
+
+	return died, nil
+}
+
+// toContainerEvent converts a message of the Docker event stream into a ContainerEvent.
+// The action of the health_status events includes the new status, e.g. "health_status: healthy".
+func toContainerEvent(msg events.Message) ContainerEvent {
+	e := ContainerEvent{
+		Action:     msg.Action,
+		Attributes: msg.Actor.Attributes,
+		Time:       time.Unix(0, msg.TimeNano),
+	}
+
+	if action, status, found := strings.Cut(msg.Action, ":"); found {
+		e.Action = action
+		e.HealthStatus = strings.TrimSpace(status)
+	}
+
+	if code, ok := msg.Actor.Attributes["exitCode"]; ok {
+		e.ExitCode, _ = strconv.Atoi(code)
+	}
+
+	return e
+}
+
+// expectsExit returns true if the wait strategy waits for the container to exit,
+// so the death of the container must not abort it
+func expectsExit(strategy wait.Strategy) bool {
+	switch s := strategy.(type) {
+	case *wait.ExitStrategy:
+		return true
+	case *wait.MultiStrategy:
+		for _, child := range s.Strategies {
+			if expectsExit(child) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestContainerEvents(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	// containerEvents {
+	eventsCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	events, err := nginxC.Events(eventsCtx)
+	require.NoError(t, err)
+
+	// simulate a crash of the container
+	_, _, err = nginxC.Exec(ctx, []string{"sh", "-c", "kill -9 1"})
+	require.NoError(t, err)
+
+	var died ContainerEvent
+	for e := range events {
+		if e.Action == ContainerEventDie {
+			died = e
+			break
+		}
+	}
+	// }
+
+	assert.Equal(t, ContainerEventDie, died.Action)
+	assert.Equal(t, 137, died.ExitCode)
+}
+
+func TestWaitStrategyIsAbortedWhenContainerDies(t *testing.T) {
+	ctx := context.Background()
+
+	start := time.Now()
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Cmd:        []string{"sh", "-c", "sleep 1; exit 3"},
+			WaitingFor: wait.ForLog("this is never logged").WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrContainerDied), "unexpected error: %v", err)
+	assert.Contains(t, err.Error(), "exit code 3")
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestToContainerEvent(t *testing.T) {
+	t.Run("die event", func(t *testing.T) {
+		e := toContainerEvent(events.Message{
+			Action:   "die",
+			Actor:    events.Actor{Attributes: map[string]string{"exitCode": "137", "image": "nginx"}},
+			TimeNano: 1680000000000000000,
+		})
+
+		assert.Equal(t, ContainerEventDie, e.Action)
+		assert.Equal(t, 137, e.ExitCode)
+		assert.Equal(t, "nginx", e.Attributes["image"])
+		assert.Equal(t, time.Unix(0, 1680000000000000000), e.Time)
+	})
+
+	t.Run("health_status event", func(t *testing.T) {
+		e := toContainerEvent(events.Message{Action: "health_status: unhealthy"})
+
+		assert.Equal(t, ContainerEventHealthStatus, e.Action)
+		assert.Equal(t, "unhealthy", e.HealthStatus)
+		assert.Zero(t, e.ExitCode)
+	})
+}
+
+func TestExpectsExit(t *testing.T) {
+	assert.True(t, expectsExit(wait.ForExit()))
+	assert.True(t, expectsExit(wait.ForAll(wait.ForLog("ready"), wait.ForExit())))
+	assert.False(t, expectsExit(wait.ForLog("ready")))
+	assert.False(t, expectsExit(wait.ForAll(wait.ForLog("ready"), wait.ForListeningPort("80/tcp"))))
+}
@@ -0,0 +1,155 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/google/uuid"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Harness runs the application under test alongside its dependencies, all of them attached to the same network,
+// so the application reaches the dependencies by their network aliases, as it would do in a real deployment
+type Harness struct {
+	network     testcontainers.Network
+	networkName string
+}
+
+// Dependency is an environment variable of the application, resolved to the address of a dependency in the network
+// of the harness when the application is started
+type Dependency struct {
+	env       string
+	container testcontainers.Container
+	port      nat.Port
+}
+
+// AppRequest is the definition of the application under test, built from its own Dockerfile
+type AppRequest struct {
+	Context    string            // the path to the build context of the application, containing its Dockerfile
+	Dockerfile string            // the path from the context to the Dockerfile, defaults to "Dockerfile"
+	Port       nat.Port          // the port the application listens to, exposed on the host
+	Env        map[string]string // the environment variables not depending on other containers
+	DependsOn  []Dependency      // the environment variables wired to the dependencies
+	WaitingFor wait.Strategy     // the readiness check of the application, waiting for its port by default
+}
+
+// App is the application under test, started by the harness
+type App struct {
+	testcontainers.Container
+	port nat.Port
+}
+
+// New creates the network shared by the application and its dependencies
+func New(ctx context.Context) (*Harness, error) {
+	name := "harness-" + uuid.NewString()
+
+	network, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{
+			Name:           name,
+			CheckDuplicate: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Harness{network: network, networkName: name}, nil
+}
+
+// WithNetwork attaches a dependency to the network of the harness, with the given alias.
+// It customizes generic containers and the containers of the modules alike.
+func (h *Harness) WithNetwork(alias string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Networks = append(req.Networks, h.networkName)
+
+		if req.NetworkAliases == nil {
+			req.NetworkAliases = map[string][]string{}
+		}
+		req.NetworkAliases[h.networkName] = append(req.NetworkAliases[h.networkName], alias)
+	}
+}
+
+// DependsOnEnv sets the environment variable of the application to the address of the given port of the container
+// in the network of the harness, e.g. DependsOnEnv("COUCHBASE_URL", couchbase, "11210/tcp") sets COUCHBASE_URL
+// to couchbase:11210. The container must have been attached to the network with WithNetwork.
+func DependsOnEnv(env string, container testcontainers.Container, port nat.Port) Dependency {
+	return Dependency{env: env, container: container, port: port}
+}
+
+// StartApp builds the image of the application and starts it in the network of the harness,
+// wiring its environment to the dependencies
+func (h *Harness) StartApp(ctx context.Context, req AppRequest) (*App, error) {
+	env := map[string]string{}
+	for k, v := range req.Env {
+		env[k] = v
+	}
+
+	for _, d := range req.DependsOn {
+		address, err := h.address(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		env[d.env] = address
+	}
+
+	waitingFor := req.WaitingFor
+	if waitingFor == nil {
+		waitingFor = wait.ForListeningPort(req.Port)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			FromDockerfile: testcontainers.FromDockerfile{
+				Context:    req.Context,
+				Dockerfile: req.Dockerfile,
+			},
+			Env:          env,
+			ExposedPorts: []string{string(req.Port)},
+			Networks:     []string{h.networkName},
+			WaitingFor:   waitingFor,
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &App{Container: container, port: req.Port}, nil
+}
+
+// address returns the address of the dependency in the network of the harness, using its first alias
+func (h *Harness) address(ctx context.Context, d Dependency) (string, error) {
+	aliases, err := d.container.NetworkAliases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(aliases[h.networkName]) == 0 {
+		return "", fmt.Errorf("the dependency of %s is not attached to the network %s", d.env, h.networkName)
+	}
+
+	return fmt.Sprintf("%s:%s", aliases[h.networkName][0], d.port.Port()), nil
+}
+
+// Terminate removes the network of the harness, once the application and its dependencies are terminated
+func (h *Harness) Terminate(ctx context.Context) error {
+	return h.network.Remove(ctx)
+}
+
+// URL returns the HTTP URL of the application, reachable from the host
+func (a *App) URL(ctx context.Context) (string, error) {
+	host, err := a.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := a.MappedPort(ctx, a.port)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
@@ -0,0 +1,97 @@
+package harness
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestIntegrationAppWithDependency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	h, err := New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the cleanups run in reverse order, so the network is removed after the containers
+	t.Cleanup(func() {
+		if err := h.Terminate(ctx); err != nil {
+			t.Fatalf("failed to remove the network: %s", err)
+		}
+	})
+
+	// startDependency {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForHTTP("/"),
+		},
+		Started: true,
+	}
+	// the containers of the modules receive the option in their StartContainer function
+	h.WithNetwork("backend").Customize(&req)
+
+	backend, err := testcontainers.GenericContainer(ctx, req)
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := backend.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// startApp {
+	app, err := h.StartApp(ctx, AppRequest{
+		Context:   "./testdata/app",
+		Port:      "8080/tcp",
+		DependsOn: []Dependency{DependsOnEnv("BACKEND_ADDR", backend, "80/tcp")},
+	})
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := app.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	url, err := app.URL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d. Got %d.", http.StatusOK, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(body), "Welcome to nginx!") {
+		t.Fatalf("Expected the page of the backend, got %s", body)
+	}
+}
@@ -0,0 +1,27 @@
+// The application under test: it proxies the requests to its backend, whose address is wired by the harness
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	backend := os.Getenv("BACKEND_ADDR")
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := http.Get("http://" + backend + r.URL.Path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	})
+
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
@@ -0,0 +1,11 @@
+package exec
+
+// ShellCommand returns the command running the given script with the shell of a container with the given
+// operating system, as returned by its OS method: cmd for the Windows containers, /bin/sh otherwise
+func ShellCommand(os string, script string) []string {
+	if os == "windows" {
+		return []string{"cmd", "/S", "/C", script}
+	}
+
+	return []string{"/bin/sh", "-c", script}
+}
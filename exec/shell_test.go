@@ -0,0 +1,16 @@
+package exec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShellCommand(t *testing.T) {
+	if got := ShellCommand("linux", "echo hello"); !reflect.DeepEqual(got, []string{"/bin/sh", "-c", "echo hello"}) {
+		t.Fatalf("expected the /bin/sh command, got %v", got)
+	}
+
+	if got := ShellCommand("windows", "echo hello"); !reflect.DeepEqual(got, []string{"cmd", "/S", "/C", "echo hello"}) {
+		t.Fatalf("expected the cmd command, got %v", got)
+	}
+}
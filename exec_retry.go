@@ -0,0 +1,188 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const (
+	// defaultExecRetryMaxAttempts is the maximum number of attempts to execute a command, including the first one
+	defaultExecRetryMaxAttempts = 10
+	// defaultExecRetryInitialInterval is the time waited before the first retry of a command
+	defaultExecRetryInitialInterval = 500 * time.Millisecond
+	// defaultExecRetryMaxInterval is the maximum time waited between two attempts to execute a command
+	defaultExecRetryMaxInterval = 10 * time.Second
+)
+
+// ExecResult is the result of a command executed in a container
+type ExecResult struct {
+	// ExitCode is the exit code of the command
+	ExitCode int
+	// Output is the standard output of the command
+	Output string
+	// Stderr is the standard error of the command
+	Stderr string
+}
+
+// ExecResultMatcher checks the result of a command executed by ExecWithRetry, returning an error if the command
+// did not succeed, in which case it's executed again. The errors wrapped with StopExecRetry are not retried,
+// e.g. for a syntax error in a query, which fails in the same way at every attempt.
+type ExecResultMatcher func(ExecResult) error
+
+// StopExecRetry wraps the error returned by an ExecResultMatcher, so that the command is not executed again
+func StopExecRetry(err error) error {
+	return backoff.Permanent(err)
+}
+
+// ExecExitCode returns a matcher checking that the command exits with the given exit code
+func ExecExitCode(exitCode int) ExecResultMatcher {
+	return func(r ExecResult) error {
+		if r.ExitCode != exitCode {
+			return fmt.Errorf("unexpected exit code %d: %s", r.ExitCode, r.Output)
+		}
+
+		return nil
+	}
+}
+
+// ExecOutputContains returns a matcher checking that the output of the command contains the given string
+func ExecOutputContains(s string) ExecResultMatcher {
+	return func(r ExecResult) error {
+		if !strings.Contains(r.Output, s) {
+			return fmt.Errorf("the output does not contain %q: %s", s, r.Output)
+		}
+
+		return nil
+	}
+}
+
+// ExecRetryPolicy defines how a command is retried by ExecWithRetry, until its result matches all the matchers of
+// the policy, e.g. a schema migration executed in a database container, which fails while the database is starting.
+// The time waited between two attempts is doubled after each retry, up to MaxInterval.
+type ExecRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts to execute the command, including the first one, 10 if zero
+	MaxAttempts int
+	// InitialInterval is the time waited before the first retry, 500 milliseconds if zero
+	InitialInterval time.Duration
+	// MaxInterval is the maximum time waited between two attempts, 10 seconds if zero
+	MaxInterval time.Duration
+	// Matchers are the matchers checking the result of the command, a zero exit code if empty
+	Matchers []ExecResultMatcher
+}
+
+// withDefaults returns the policy with the default values of its zero fields
+func (p ExecRetryPolicy) withDefaults() ExecRetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultExecRetryMaxAttempts
+	}
+
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = defaultExecRetryInitialInterval
+	}
+
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = defaultExecRetryMaxInterval
+	}
+
+	if len(p.Matchers) == 0 {
+		p.Matchers = []ExecResultMatcher{ExecExitCode(0)}
+	}
+
+	return p
+}
+
+// backOff returns the exponential backoff of the policy, capped to its maximum interval
+func (p ExecRetryPolicy) backOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	// the attempts are limited by their number, or by the context, not by the elapsed time
+	b.MaxElapsedTime = 0
+
+	return backoff.WithMaxRetries(b, uint64(p.MaxAttempts-1))
+}
+
+// match returns the error of the first matcher of the policy not matching the result
+func (p ExecRetryPolicy) match(r ExecResult) error {
+	for _, m := range p.Matchers {
+		if err := m(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExecWithRetry executes the command in the container until its result matches all the matchers of the policy,
+// the attempts of the policy are exhausted, or the context is done, in which case the number of attempts
+// is added to the last error. It returns the result of the last attempt.
+func (c *DockerContainer) ExecWithRetry(ctx context.Context, cmd []string, policy ExecRetryPolicy) (ExecResult, error) {
+	return execWithRetry(ctx, c, cmd, policy, c.logger)
+}
+
+// execWithRetry executes the command in the container until it succeeds, as defined by the policy.
+// The errors of the Docker API are retried only if they are transient. The standard error of the last attempt
+// is added to the returned error.
+func execWithRetry(ctx context.Context, c ContainerHandle, cmd []string, policy ExecRetryPolicy, logging Logging) (ExecResult, error) {
+	policy = policy.withDefaults()
+
+	var result ExecResult
+	attempts := 0
+
+	err := backoff.RetryNotify(func() error {
+		attempts++
+		result = ExecResult{}
+
+		var stdout, stderr bytes.Buffer
+		var copyErr error
+		exitCode, _, err := c.Exec(ctx, cmd, demultiplexed(&stdout, &stderr, &copyErr))
+		if err != nil {
+			if isTransientStartupError(err) {
+				return err
+			}
+			return backoff.Permanent(err)
+		}
+
+		if copyErr != nil {
+			return copyErr
+		}
+
+		result = ExecResult{ExitCode: exitCode, Output: stdout.String(), Stderr: stderr.String()}
+
+		return policy.match(result)
+	}, backoff.WithContext(policy.backOff(), ctx), func(err error, next time.Duration) {
+		logging.Printf("🔁 Attempt %d of %d to execute %v failed, retrying in %s: %s", attempts, policy.MaxAttempts, cmd, next, err)
+	})
+
+	if err == nil {
+		return result, nil
+	}
+
+	if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+		err = fmt.Errorf("%w (stderr: %s)", err, stderr)
+	}
+
+	if attempts > 1 {
+		return result, fmt.Errorf("%w: the command %v did not succeed after %d attempts", err, cmd, attempts)
+	}
+
+	return result, err
+}
+
+// demultiplexed returns a process option copying the standard output and the standard error of the command
+// to the given writers, unlike tcexec.Multiplexed, which discards the standard error. The error of the copy,
+// if any, is stored in copyErr.
+func demultiplexed(stdout, stderr *bytes.Buffer, copyErr *error) tcexec.ProcessOption {
+	return tcexec.ProcessOptionFunc(func(opts *tcexec.ProcessOptions) {
+		_, *copyErr = stdcopy.StdCopy(stdout, stderr, opts.Reader)
+		opts.Reader = stdout
+	})
+}
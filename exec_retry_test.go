@@ -0,0 +1,201 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// execResponse is the response of an execution of a command by the scriptedExecHandle
+type execResponse struct {
+	exitCode int
+	output   string
+	stderr   string
+	err      error
+}
+
+// scriptedExecHandle is a container handle returning the given responses to the executions of the commands, in order
+type scriptedExecHandle struct {
+	ContainerHandle
+	responses []execResponse
+	calls     int
+}
+
+func (h *scriptedExecHandle) Exec(_ context.Context, _ []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	r := h.responses[h.calls]
+	h.calls++
+
+	if r.err != nil {
+		return 0, nil, r.err
+	}
+
+	// the outputs are multiplexed in a single stream, as returned by the Docker API
+	var stream bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&stream, stdcopy.Stdout).Write([]byte(r.output))
+	_, _ = stdcopy.NewStdWriter(&stream, stdcopy.Stderr).Write([]byte(r.stderr))
+
+	opts := &tcexec.ProcessOptions{Reader: &stream}
+	for _, o := range options {
+		o.Apply(opts)
+	}
+
+	return r.exitCode, opts.Reader, nil
+}
+
+// fastExecRetryPolicy is a retry policy waiting a few milliseconds between the attempts
+var fastExecRetryPolicy = ExecRetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func TestExecWithRetry(t *testing.T) {
+	ctx := context.Background()
+	cmd := []string{"cbq", "-s", "CREATE PRIMARY INDEX ON `bucket`"}
+
+	t.Run("Succeeds after a failed attempt", func(t *testing.T) {
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{exitCode: 1, output: "connection refused"},
+			{exitCode: 0, output: "success"},
+		}}
+
+		result, err := execWithRetry(ctx, handle, cmd, fastExecRetryPolicy, TestLogger(t))
+		require.NoError(t, err)
+		assert.Equal(t, ExecResult{ExitCode: 0, Output: "success"}, result)
+		assert.Equal(t, 2, handle.calls)
+	})
+
+	t.Run("Fails once the attempts are exhausted", func(t *testing.T) {
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{exitCode: 1, output: "connection refused"},
+			{exitCode: 1, output: "connection refused"},
+			{exitCode: 2, output: "connection refused"},
+		}}
+
+		result, err := execWithRetry(ctx, handle, cmd, fastExecRetryPolicy, TestLogger(t))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected exit code 2")
+		assert.Contains(t, err.Error(), "after 3 attempts")
+		assert.Equal(t, 2, result.ExitCode)
+		assert.Equal(t, 3, handle.calls)
+	})
+
+	t.Run("Captures the standard error", func(t *testing.T) {
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{exitCode: 1, output: "starting", stderr: "connection refused"},
+			{exitCode: 1, output: "starting", stderr: "unknown keyspace\n"},
+		}}
+
+		policy := fastExecRetryPolicy
+		policy.MaxAttempts = 2
+
+		result, err := execWithRetry(ctx, handle, cmd, policy, TestLogger(t))
+		require.Error(t, err)
+		assert.Equal(t, ExecResult{ExitCode: 1, Output: "starting", Stderr: "unknown keyspace\n"}, result)
+		assert.Contains(t, err.Error(), "unexpected exit code 1: starting (stderr: unknown keyspace)")
+		assert.Contains(t, err.Error(), "after 2 attempts")
+	})
+
+	t.Run("Matches the output", func(t *testing.T) {
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{exitCode: 0, output: `{"status": "errors"}`},
+			{exitCode: 0, output: `{"status": "success"}`},
+		}}
+
+		policy := fastExecRetryPolicy
+		policy.Matchers = []ExecResultMatcher{ExecExitCode(0), ExecOutputContains(`"status": "success"`)}
+
+		result, err := execWithRetry(ctx, handle, cmd, policy, TestLogger(t))
+		require.NoError(t, err)
+		assert.Equal(t, `{"status": "success"}`, result.Output)
+		assert.Equal(t, 2, handle.calls)
+	})
+
+	t.Run("Stops on a permanent error of the matchers", func(t *testing.T) {
+		syntaxErr := errors.New("syntax error")
+
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{exitCode: 0, output: `{"code": 3000}`},
+		}}
+
+		policy := fastExecRetryPolicy
+		policy.Matchers = []ExecResultMatcher{func(r ExecResult) error {
+			if strings.Contains(r.Output, `"code": 3000`) {
+				return StopExecRetry(syntaxErr)
+			}
+			return nil
+		}}
+
+		_, err := execWithRetry(ctx, handle, cmd, policy, TestLogger(t))
+		require.ErrorIs(t, err, syntaxErr)
+		assert.Equal(t, 1, handle.calls)
+	})
+
+	t.Run("Retries the transient errors of the daemon only", func(t *testing.T) {
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{err: io.ErrUnexpectedEOF},
+			{err: errors.New("container is not running")},
+		}}
+
+		_, err := execWithRetry(ctx, handle, cmd, fastExecRetryPolicy, TestLogger(t))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "container is not running")
+		assert.Equal(t, 2, handle.calls)
+	})
+
+	t.Run("Stops when the context is done", func(t *testing.T) {
+		handle := &scriptedExecHandle{responses: []execResponse{
+			{exitCode: 1},
+			{exitCode: 1},
+			{exitCode: 1},
+		}}
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := execWithRetry(ctx, handle, cmd, fastExecRetryPolicy, TestLogger(t))
+		require.Error(t, err)
+		assert.Equal(t, 1, handle.calls)
+	})
+}
+
+func TestExecRetryPolicyDefaults(t *testing.T) {
+	policy := ExecRetryPolicy{}.withDefaults()
+
+	assert.Equal(t, defaultExecRetryMaxAttempts, policy.MaxAttempts)
+	assert.Equal(t, defaultExecRetryInitialInterval, policy.InitialInterval)
+	assert.Equal(t, defaultExecRetryMaxInterval, policy.MaxInterval)
+	require.Len(t, policy.Matchers, 1)
+	require.NoError(t, policy.match(ExecResult{ExitCode: 0}))
+	require.Error(t, policy.match(ExecResult{ExitCode: 1}))
+}
+
+func TestDockerContainerExecWithRetry(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, container)
+
+	// execWithRetry {
+	// the script fails at the first attempt, as a migration executed while the database is starting
+	script := "if [ -f /tmp/migrated ]; then echo done; else touch /tmp/migrated; exit 1; fi"
+
+	result, err := container.ExecWithRetry(ctx, []string{"sh", "-c", script}, ExecRetryPolicy{
+		MaxAttempts: 5,
+		Matchers:    []ExecResultMatcher{ExecExitCode(0), ExecOutputContains("done")},
+	})
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, "done\n", result.Output)
+}
@@ -0,0 +1,111 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// failureHooksTimeout bounds the time given to the failure hooks of a container, so a hook blocked on an unresponsive
+// daemon or container doesn't hang the test
+const failureHooksTimeout = 30 * time.Second
+
+// FailureHook defines a hook which is invoked when a container fails to become ready,
+// because its wait strategy failed, or when the termination of the container returns an error.
+// It allows every container to collect consistent failure forensics, e.g. logs, the inspect output
+// or a screenshot for browser containers, provided by the selenium module.
+type FailureHook interface {
+	OnFailure(ctx context.Context, c Container, err error)
+}
+
+// FailureHookFunc is a shorthand to implement the FailureHook interface
+type FailureHookFunc func(ctx context.Context, c Container, err error)
+
+// OnFailure implements FailureHook.OnFailure
+func (f FailureHookFunc) OnFailure(ctx context.Context, c Container, err error) {
+	f(ctx, c, err)
+}
+
+// LogsFailureHook returns a FailureHook that writes the logs of the failed container to the given writer
+func LogsFailureHook(w io.Writer) FailureHook {
+	return &lockedFailureHook{
+		w: w,
+		fn: func(ctx context.Context, w io.Writer, c Container, err error) {
+			_, _ = fmt.Fprintf(w, "container %s failed: %s\n", c.GetContainerID(), err)
+
+			rc, err := c.Logs(ctx)
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "could not read the logs of the container: %s\n", err)
+				return
+			}
+			defer rc.Close()
+
+			_, _ = io.Copy(w, rc)
+		},
+	}
+}
+
+// InspectFailureHook returns a FailureHook that writes the JSON representation of the failed container,
+// as returned by the "docker inspect" command, to the given writer.
+// For containers not created by the Docker provider, it writes the state of the container.
+func InspectFailureHook(w io.Writer) FailureHook {
+	return &lockedFailureHook{
+		w: w,
+		fn: func(ctx context.Context, w io.Writer, c Container, err error) {
+			_, _ = fmt.Fprintf(w, "container %s failed: %s\n", c.GetContainerID(), err)
+
+			var inspect interface{}
+			if dc, ok := c.(*DockerContainer); ok {
+				inspect, err = dc.inspectContainer(ctx)
+			} else {
+				inspect, err = c.State(ctx)
+			}
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "could not inspect the container: %s\n", err)
+				return
+			}
+
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			_ = encoder.Encode(inspect)
+		},
+	}
+}
+
+// lockedFailureHook serialises the writes to the underlying writer,
+// as a hook could be shared by containers started in parallel
+type lockedFailureHook struct {
+	mx sync.Mutex
+	w  io.Writer
+	fn func(ctx context.Context, w io.Writer, c Container, err error)
+}
+
+func (h *lockedFailureHook) OnFailure(ctx context.Context, c Container, err error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	h.fn(ctx, h.w, c, err)
+}
+
+// runFailureHooks invokes the failure hooks of the container, within failureHooksTimeout. If the given context
+// is already done, e.g. because the wait strategy consumed the deadline, a new context is used so that the hooks
+// are still able to reach the Docker daemon.
+func runFailureHooks(ctx context.Context, c Container, hooks []FailureHook, err error) {
+	if len(hooks) == 0 {
+		return
+	}
+
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, failureHooksTimeout)
+	defer cancel()
+
+	for _, hook := range hooks {
+		hook.OnFailure(ctx, c, err)
+	}
+}
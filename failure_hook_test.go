@@ -0,0 +1,66 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRunFailureHooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expectedErr := errors.New("wait strategy failed")
+
+	calls := 0
+	hook := FailureHookFunc(func(ctx context.Context, c Container, err error) {
+		calls++
+		assert.NoError(t, ctx.Err(), "hooks must receive a context which is not done")
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok, "hooks must receive a context with a deadline")
+		assert.WithinDuration(t, time.Now().Add(failureHooksTimeout), deadline, time.Second)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	runFailureHooks(ctx, nil, []FailureHook{hook, hook}, expectedErr)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestFailureHooksOnWaitStrategyFailure(t *testing.T) {
+	ctx := context.Background()
+
+	logs := &bytes.Buffer{}
+	inspect := &bytes.Buffer{}
+
+	// failureHooks {
+	req := ContainerRequest{
+		Image:        nginxAlpineImage,
+		ExposedPorts: []string{nginxDefaultPort},
+		WaitingFor:   wait.ForLog("this log line will never be written").WithStartupTimeout(3 * time.Second),
+		FailureHooks: []FailureHook{
+			LogsFailureHook(logs),
+			InspectFailureHook(inspect),
+		},
+	}
+	// }
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.Error(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	assert.Contains(t, logs.String(), "start worker process")
+	assert.Contains(t, inspect.String(), c.GetContainerID())
+}
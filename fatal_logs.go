@@ -0,0 +1,90 @@
+package testcontainers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ErrFatalLogPattern is returned when the wait strategy is aborted because the container logged a line
+// matching one of the FatalLogPatterns of the request
+var ErrFatalLogPattern = errors.New("the container logged a fatal line")
+
+// compileFatalLogPatterns compiles the fatal log patterns of a request
+func compileFatalLogPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid fatal log pattern %s", err, p)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// watchFatalLogs follows the logs of the container, calling the cancel function as soon as a line matches
+// one of the fatal log patterns. The matching line is sent to the returned channel before cancelling.
+func (c *DockerContainer) watchFatalLogs(ctx context.Context, cancel context.CancelFunc) <-chan string {
+	fatal := make(chan string, 1)
+
+	go func() {
+		inspect, err := c.inspectContainer(ctx)
+		if err != nil {
+			c.logger.Printf("%s: the fatal log patterns will not abort the wait strategy", err)
+			return
+		}
+
+		r, err := c.provider.client.ContainerLogs(ctx, c.ID, types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     true,
+		})
+		if err != nil {
+			c.logger.Printf("%s: the fatal log patterns will not abort the wait strategy", err)
+			return
+		}
+		defer r.Close()
+
+		logs := io.Reader(r)
+		if !inspect.Config.Tty {
+			// the logs of containers without a TTY are multiplexed
+			pr, pw := io.Pipe()
+			defer pr.Close()
+			go func() {
+				_, err := stdcopy.StdCopy(pw, pw, r)
+				_ = pw.CloseWithError(err)
+			}()
+			logs = pr
+		}
+
+		if line, found := matchFatalLogLine(logs, c.fatalLogPatterns); found {
+			fatal <- line
+			cancel()
+		}
+	}()
+
+	return fatal
+}
+
+// matchFatalLogLine reads the logs line by line, returning the first line matching one of the patterns
+func matchFatalLogLine(logs io.Reader, patterns []*regexp.Regexp) (string, bool) {
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for _, re := range patterns {
+			if re.MatchString(line) {
+				return line, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,70 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWaitStrategyIsAbortedByFatalLogPattern(t *testing.T) {
+	ctx := context.Background()
+
+	start := time.Now()
+	// fatalLogPatterns {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:            "docker.io/alpine",
+			Cmd:              []string{"sh", "-c", "echo 'FATAL: data directory has wrong ownership'; sleep 60"},
+			WaitingFor:       wait.ForLog("ready to accept connections").WithStartupTimeout(time.Minute),
+			FatalLogPatterns: []string{"Address already in use", "FATAL: data directory"},
+		},
+		Started: true,
+	})
+	// }
+	terminateContainerOnEnd(t, ctx, c)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrFatalLogPattern), "unexpected error: %v", err)
+	assert.Contains(t, err.Error(), "FATAL: data directory has wrong ownership")
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestInvalidFatalLogPattern(t *testing.T) {
+	req := ContainerRequest{
+		Image:            "docker.io/alpine",
+		FatalLogPatterns: []string{"("},
+	}
+
+	require.Error(t, req.Validate())
+}
+
+func TestMatchFatalLogLine(t *testing.T) {
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile("Address already in use"),
+		regexp.MustCompile(`^FATAL: .*`),
+	}
+
+	t.Run("matching line", func(t *testing.T) {
+		logs := strings.NewReader("starting\nlisten tcp :8080: bind: Address already in use\nexiting\n")
+
+		line, found := matchFatalLogLine(logs, patterns)
+		assert.True(t, found)
+		assert.Equal(t, "listen tcp :8080: bind: Address already in use", line)
+	})
+
+	t.Run("no matching line", func(t *testing.T) {
+		logs := strings.NewReader("starting\nnot FATAL: anchored\nready\n")
+
+		_, found := matchFatalLogLine(logs, patterns)
+		assert.False(t, found)
+	})
+}
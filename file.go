@@ -41,7 +41,7 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 
 	buffer := &bytes.Buffer{}
 
-	fmt.Printf(">> creating TAR file from directory: %s\n", src)
+	Logger.Printf(">> creating TAR file from directory: %s", src)
 
 	// tar > gzip > buffer
 	zr := gzip.NewWriter(buffer)
@@ -59,7 +59,7 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 
 		// if a symlink, skip file
 		if fi.Mode().Type() == os.ModeSymlink {
-			fmt.Printf(">> skipping symlink: %s\n", file)
+			Logger.Printf(">> skipping symlink: %s", file)
 			return nil
 		}
 
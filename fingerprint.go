@@ -0,0 +1,186 @@
+package testcontainers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fingerprintDirEnv overrides the directory where SkipIfUnchanged records the fingerprints of the passing tests,
+// e.g. to keep it in the cache of a CI pipeline between the runs
+const fingerprintDirEnv = "TESTCONTAINERS_FINGERPRINT_DIR"
+
+// Fingerprint returns a fingerprint of the environment of the integration tests of the current binary, which is
+// computed from the versions of the Go modules of the build and from the digests of the given images.
+// It's stable as long as neither the dependencies nor the images change, thus it can be used in the keys of a cache,
+// or to skip the tests which already passed, as SkipIfUnchanged does.
+func Fingerprint(ctx context.Context, images ...string) (string, error) {
+	provider, err := NewDockerProvider()
+	if err != nil {
+		return "", err
+	}
+	defer provider.Close()
+
+	return provider.Fingerprint(ctx, images...)
+}
+
+// Fingerprint returns a fingerprint of the versions of the Go modules of the build and of the digests of the given images,
+// after their substitutions. The digest of an image is the digest of its manifest in the registry, so a tag pushed again
+// changes the fingerprint, or the ID of the local image if the registry can't be reached, e.g. for an image built locally.
+func (p *DockerProvider) Fingerprint(ctx context.Context, images ...string) (string, error) {
+	var lines []string
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		lines = append(lines, buildModules(info)...)
+	}
+
+	for _, image := range images {
+		digest, err := p.imageDigest(ctx, image)
+		if err != nil {
+			return "", fmt.Errorf("%w: could not fingerprint the image %s", err, image)
+		}
+
+		lines = append(lines, "image "+image+" "+digest)
+	}
+
+	return fingerprintOf(lines), nil
+}
+
+// imageDigest returns the digest of the manifest of the image in its registry,
+// or the ID of the local image if the registry can't be reached
+func (p *DockerProvider) imageDigest(ctx context.Context, image string) (string, error) {
+	image = p.config.substituteImage(image)
+
+	distribution, err := p.client.DistributionInspect(ctx, image, p.registryAuth(ctx, ContainerRequest{}, image))
+	if err == nil {
+		return distribution.Descriptor.Digest.String(), nil
+	}
+
+	inspect, _, inspectErr := p.client.ImageInspectWithRaw(ctx, image)
+	if inspectErr != nil {
+		return "", fmt.Errorf("%w: the image is neither in its registry (%s) nor local", inspectErr, err)
+	}
+
+	return inspect.ID, nil
+}
+
+// buildModules returns the main module and the dependencies of the build, with their versions and checksums,
+// replacements included
+func buildModules(info *debug.BuildInfo) []string {
+	modules := []string{"module " + info.Main.Path + " " + info.Main.Version}
+
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+
+		modules = append(modules, "module "+dep.Path+" "+dep.Version+" "+dep.Sum)
+	}
+
+	return modules
+}
+
+// fingerprintOf returns the hexadecimal SHA-256 of the given lines, whatever their order
+func fingerprintOf(lines []string) string {
+	sorted := append([]string(nil), lines...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// SkipIfUnchanged skips the test if it already passed with the same fingerprint of its environment: the same test binary,
+// i.e. neither the code nor the dependencies changed, and the same digests of the given images, which are the images
+// of the containers of the test. The fingerprint is recorded when the test passes, in the testcontainers/fingerprints
+// directory of the user cache directory, or in the directory of the TESTCONTAINERS_FINGERPRINT_DIR environment variable.
+// Removing the directory runs all the tests again.
+func SkipIfUnchanged(t *testing.T, images ...string) {
+	t.Helper()
+
+	fingerprint, err := Fingerprint(context.Background(), images...)
+	if err != nil {
+		t.Logf("the test runs as its environment can't be fingerprinted: %s", err)
+		return
+	}
+
+	binary, err := executableDigest()
+	if err != nil {
+		t.Logf("the test runs as its binary can't be fingerprinted: %s", err)
+		return
+	}
+	fingerprint = fingerprintOf([]string{fingerprint, "binary " + binary})
+
+	path, err := fingerprintPath(t.Name())
+	if err != nil {
+		t.Logf("the test runs as its fingerprint can't be recorded: %s", err)
+		return
+	}
+
+	if recorded, err := os.ReadFile(path); err == nil && string(recorded) == fingerprint {
+		t.Skipf("the test already passed with the fingerprint %s", fingerprint)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() || t.Skipped() {
+			return
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Logf("could not record the fingerprint of the test: %s", err)
+			return
+		}
+
+		if err := os.WriteFile(path, []byte(fingerprint), 0o644); err != nil {
+			t.Logf("could not record the fingerprint of the test: %s", err)
+		}
+	})
+}
+
+// executableDigest returns the hexadecimal SHA-256 of the binary of the current process
+func executableDigest() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(executable)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fingerprintPath returns the path of the file recording the fingerprint of the given test of the current package,
+// which is identified by the working directory of the test binary
+func fingerprintPath(test string) (string, error) {
+	dir := os.Getenv(fingerprintDirEnv)
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(cache, "testcontainers", "fingerprints")
+	}
+
+	pkg, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, fingerprintOf([]string{pkg, test})), nil
+}
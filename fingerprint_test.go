@@ -0,0 +1,115 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// digestClient resolves the digests of the images of a registry and the IDs of the local images, without a Docker daemon
+type digestClient struct {
+	client.APIClient
+	remote map[string]string
+	local  map[string]string
+}
+
+func (c *digestClient) DistributionInspect(_ context.Context, image string, _ string) (registry.DistributionInspect, error) {
+	d, ok := c.remote[image]
+	if !ok {
+		return registry.DistributionInspect{}, errors.New("registry unreachable")
+	}
+
+	return registry.DistributionInspect{Descriptor: specs.Descriptor{Digest: digest.Digest(d)}}, nil
+}
+
+func (c *digestClient) ImageInspectWithRaw(_ context.Context, image string) (types.ImageInspect, []byte, error) {
+	id, ok := c.local[image]
+	if !ok {
+		return types.ImageInspect{}, nil, errors.New("no such image")
+	}
+
+	return types.ImageInspect{ID: id}, nil, nil
+}
+
+func TestImageDigest(t *testing.T) {
+	provider := &DockerProvider{
+		DockerProviderOptions: &DockerProviderOptions{
+			GenericProviderOptions: &GenericProviderOptions{Logger: Logger},
+		},
+		client: &digestClient{
+			remote: map[string]string{"registry.example.com/redis:7": "sha256:remote"},
+			local:  map[string]string{"redis:7": "sha256:local", "app:dev": "sha256:dev"},
+		},
+		config: TestcontainersConfig{ImageSubstitutions: "redis:7=registry.example.com/redis:7"},
+	}
+	ctx := context.Background()
+
+	digest, err := provider.imageDigest(ctx, "redis:7")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:remote", digest, "the digest of the substitute in the registry")
+
+	digest, err = provider.imageDigest(ctx, "app:dev")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:dev", digest, "the ID of the local image, unknown to the registry")
+
+	_, err = provider.imageDigest(ctx, "missing:latest")
+	assert.ErrorContains(t, err, "registry unreachable")
+
+	_, err = provider.Fingerprint(ctx, "missing:latest")
+	assert.ErrorContains(t, err, "missing:latest")
+
+	redis, err := provider.Fingerprint(ctx, "redis:7")
+	require.NoError(t, err)
+	dev, err := provider.Fingerprint(ctx, "app:dev")
+	require.NoError(t, err)
+	assert.NotEqual(t, redis, dev)
+}
+
+func TestBuildModules(t *testing.T) {
+	info := &debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/app", Version: "(devel)"},
+		Deps: []*debug.Module{
+			{Path: "github.com/testcontainers/testcontainers-go", Version: "v0.20.0", Sum: "h1:abc"},
+			{
+				Path:    "github.com/docker/docker",
+				Version: "v23.0.5+incompatible",
+				Replace: &debug.Module{Path: "github.com/fork/docker", Version: "v23.0.6", Sum: "h1:def"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"module example.com/app (devel)",
+		"module github.com/testcontainers/testcontainers-go v0.20.0 h1:abc",
+		"module github.com/fork/docker v23.0.6 h1:def",
+	}, buildModules(info))
+}
+
+func TestFingerprintOf(t *testing.T) {
+	assert.Equal(t, fingerprintOf([]string{"a", "b"}), fingerprintOf([]string{"b", "a"}))
+	assert.NotEqual(t, fingerprintOf([]string{"a", "b"}), fingerprintOf([]string{"a", "c"}))
+	assert.Len(t, fingerprintOf(nil), 64)
+}
+
+func TestFingerprintPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(fingerprintDirEnv, dir)
+
+	path, err := fingerprintPath("TestRedis")
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(path))
+
+	other, err := fingerprintPath("TestPostgres")
+	require.NoError(t, err)
+	assert.NotEqual(t, path, other)
+}
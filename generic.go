@@ -19,6 +19,9 @@ type GenericContainerRequest struct {
 	ProviderType     ProviderType // which provider to use, Docker if empty
 	Logger           Logging      // provide a container specific Logging - use default global logger if empty
 	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	// StartupRetryPolicy retries the creation of the container on transient errors, e.g. while pulling the image,
+	// using the startup.retry.attempts property of the Testcontainers configuration if nil
+	StartupRetryPolicy *StartupRetryPolicy
 }
 
 // GenericNetworkRequest represents parameters to a generic network
@@ -56,17 +59,20 @@ func GenericContainer(ctx context.Context, req GenericContainerRequest) (Contain
 		return nil, err
 	}
 
-	var c Container
 	if req.Reuse {
 		// we must protect the reusability of the container in the case it's invoked
 		// in a parallel execution, via ParallelContainers or t.Parallel()
 		reuseContainerMx.Lock()
 		defer reuseContainerMx.Unlock()
-
-		c, err = provider.ReuseOrCreateContainer(ctx, req.ContainerRequest)
-	} else {
-		c, err = provider.CreateContainer(ctx, req.ContainerRequest)
 	}
+
+	policy := req.startupRetryPolicy(ReadConfig().StartupRetryAttempts)
+	c, err := createWithRetry(ctx, provider, req, policy, logging, func() (Container, error) {
+		if req.Reuse {
+			return provider.ReuseOrCreateContainer(ctx, req.ContainerRequest)
+		}
+		return provider.CreateContainer(ctx, req.ContainerRequest)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create container", err)
 	}
@@ -0,0 +1,140 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	// HostDockerInternal is the hostname resolving to the test host inside the containers, natively with Docker Desktop,
+	// and through the host-gateway extra host added by Testcontainers to the containers of the Linux daemons
+	HostDockerInternal = "host.docker.internal"
+
+	// HostContainersInternal is the hostname resolving to the test host inside the containers created by Podman
+	HostContainersInternal = "host.containers.internal"
+)
+
+// ErrRemoteHostGateway is returned when the host gateway is requested from a daemon running on another machine,
+// as the gateway of its containers reaches the machine of the daemon, not the test host.
+// ExposeHostPorts reaches the test host from the containers of the remote daemons.
+var ErrRemoteHostGateway = errors.New("the host gateway of a remote Docker host does not reach the test host")
+
+// HostGateway returns the hostname, or the IP, reaching the test host from the containers created by the default provider,
+// e.g. to configure the URL of a server started by the test, receiving the callbacks of a container:
+//
+//   - host.docker.internal with Docker Desktop and with the Linux daemons, to which the host-gateway extra host is added;
+//   - host.containers.internal with Podman;
+//   - the IP of the gateway of the default network with the daemons not supporting the host-gateway extra host.
+//
+// It fails with ErrRemoteHostGateway if the Docker host is reached through TCP on another machine.
+//
+// Unlike HostInternal, it doesn't need a sidecar container, but the server must listen on an interface reachable from
+// the containers, not only on localhost, and the firewall of the host must accept the connections.
+func HostGateway(ctx context.Context) (string, error) {
+	provider, err := ProviderDefault.GetProvider()
+	if err != nil {
+		return "", err
+	}
+	defer provider.Close()
+
+	p, ok := provider.(*DockerProvider)
+	if !ok {
+		return "", fmt.Errorf("the provider %T does not resolve the host gateway", provider)
+	}
+
+	return p.HostGateway(ctx)
+}
+
+// HostGateway returns the hostname, or the IP, reaching the test host from the containers created by the provider
+func (p *DockerProvider) HostGateway(ctx context.Context) (string, error) {
+	gw, err := p.hostGateway(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if gw.hostname != "" {
+		return gw.hostname, nil
+	}
+
+	return p.GetGatewayIP(ctx)
+}
+
+// hostGateway describes how the test host is reached from the containers of a daemon
+type hostGateway struct {
+	// hostname is the hostname resolving to the test host, empty if the daemon doesn't define one,
+	// in which case the IP of the gateway of the default network is used
+	hostname string
+	// inject is true if the hostname must be added to the extra hosts of the containers, resolving to the host-gateway
+	inject bool
+}
+
+// hostGateway returns how the test host is reached from the containers of the daemon of the provider,
+// from the information about the daemon cached for the session
+func (p *DockerProvider) hostGateway(ctx context.Context) (hostGateway, error) {
+	version, err := p.serverVersion(ctx)
+	if err != nil {
+		return hostGateway{}, err
+	}
+
+	info, err := p.daemonInfo(ctx)
+	if err != nil {
+		return hostGateway{}, err
+	}
+
+	podman := p.defaultBridgeNetworkName == Podman || isPodmanVersion(version)
+	supported := p.requireAPIVersion(ctx, featureHostGateway) == nil
+
+	return resolveHostGateway(p.host, version, info, podman, supported)
+}
+
+// resolveHostGateway returns how the test host is reached from the containers of a daemon,
+// failing if the daemon is reached through TCP on another machine
+func resolveHostGateway(dockerHost string, version types.Version, info types.Info, podman bool, hostGatewaySupported bool) (hostGateway, error) {
+	switch {
+	case isRemoteDockerHost(dockerHost):
+		// the gateway of the containers, whatever its hostname, is the machine running the daemon
+		return hostGateway{}, fmt.Errorf("%w: %s", ErrRemoteHostGateway, dockerHost)
+	case podman:
+		// Podman adds host.containers.internal to the hosts of all the containers
+		return hostGateway{hostname: HostContainersInternal}, nil
+	case version.Os == "windows", strings.Contains(info.OperatingSystem, "Docker Desktop"):
+		// Docker Desktop resolves host.docker.internal in all the containers, Linux and Windows ones
+		return hostGateway{hostname: HostDockerInternal}, nil
+	case !hostGatewaySupported:
+		return hostGateway{}, nil
+	default:
+		return hostGateway{hostname: HostDockerInternal, inject: true}, nil
+	}
+}
+
+// isPodmanVersion returns true if the version is reported by the Docker compatible API of Podman
+func isPodmanVersion(version types.Version) bool {
+	for _, c := range version.Components {
+		if strings.Contains(strings.ToLower(c.Name), "podman") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// injectHostGateway adds the host.docker.internal extra host, resolving to the host-gateway, to a container being created
+// by a local Linux daemon, so it reaches the test host as with Docker Desktop. The failures to query the daemon are ignored,
+// as the container doesn't need the extra host to be created, and so are the remote daemons.
+func (p *DockerProvider) injectHostGateway(ctx context.Context, hostConfig *container.HostConfig) {
+	if hostConfig.NetworkMode.IsContainer() || hostConfig.NetworkMode.IsHost() || hasExtraHost(hostConfig.ExtraHosts, HostDockerInternal) {
+		return
+	}
+
+	gw, err := p.hostGateway(ctx)
+	if err != nil || !gw.inject {
+		return
+	}
+
+	hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, HostDockerInternal+":host-gateway")
+}
@@ -0,0 +1,165 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostGateway(t *testing.T) {
+	ctx := context.Background()
+
+	// the server must listen on all the interfaces, not only on localhost, to be reached through the host gateway
+	listener, err := net.Listen("tcp", "0.0.0.0:0")
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello from the host"))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	// hostGateway {
+	host, err := HostGateway(ctx)
+	require.NoError(t, err)
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	code, reader, err := c.Exec(ctx, []string{"wget", "-qO-", fmt.Sprintf("http://%s:%d", host, port)})
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "hello from the host")
+}
+
+func TestResolveHostGateway(t *testing.T) {
+	linux := types.Version{Os: "linux"}
+
+	tests := []struct {
+		name      string
+		host      string
+		version   types.Version
+		info      types.Info
+		podman    bool
+		supported bool
+		want      hostGateway
+		wantErr   error
+	}{
+		{
+			name:      "Linux daemon",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "Ubuntu 22.04.2 LTS"},
+			supported: true,
+			want:      hostGateway{hostname: HostDockerInternal, inject: true},
+		},
+		{
+			name:      "Linux daemon reached through TCP on localhost",
+			host:      "tcp://127.0.0.1:2375",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "Ubuntu 22.04.2 LTS"},
+			supported: true,
+			want:      hostGateway{hostname: HostDockerInternal, inject: true},
+		},
+		{
+			name:      "Remote Linux daemon",
+			host:      "tcp://10.0.0.5:2376",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "Ubuntu 22.04.2 LTS"},
+			supported: true,
+			wantErr:   ErrRemoteHostGateway,
+		},
+		{
+			name:      "Remote Docker Desktop",
+			host:      "tcp://docker.example.com:2376",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "Docker Desktop"},
+			supported: true,
+			wantErr:   ErrRemoteHostGateway,
+		},
+		{
+			name:      "Linux daemon without the host-gateway",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "Ubuntu 18.04.6 LTS"},
+			supported: false,
+			want:      hostGateway{},
+		},
+		{
+			name:      "Docker Desktop",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "Docker Desktop"},
+			supported: true,
+			want:      hostGateway{hostname: HostDockerInternal},
+		},
+		{
+			name:      "Windows daemon",
+			version:   types.Version{Os: "windows"},
+			info:      types.Info{OperatingSystem: "Windows Server 2022 Datacenter"},
+			supported: true,
+			want:      hostGateway{hostname: HostDockerInternal},
+		},
+		{
+			name:      "Podman",
+			version:   linux,
+			info:      types.Info{OperatingSystem: "fedora"},
+			podman:    true,
+			supported: true,
+			want:      hostGateway{hostname: HostContainersInternal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := tt.host
+			if host == "" {
+				host = "unix:///var/run/docker.sock"
+			}
+
+			gw, err := resolveHostGateway(host, tt.version, tt.info, tt.podman, tt.supported)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, gw.inject)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gw)
+		})
+	}
+}
+
+func TestIsPodmanVersion(t *testing.T) {
+	assert.True(t, isPodmanVersion(types.Version{Components: []types.ComponentVersion{{Name: "Podman Engine", Version: "4.5.0"}}}))
+	assert.False(t, isPodmanVersion(types.Version{Components: []types.ComponentVersion{{Name: "Engine", Version: "23.0.1"}}}))
+}
+
+func TestInjectHostGatewaySkipsExistingHost(t *testing.T) {
+	p := &DockerProvider{}
+
+	hostConfig := &container.HostConfig{ExtraHosts: []string{HostDockerInternal + ":10.0.0.1"}}
+	p.injectHostGateway(context.Background(), hostConfig)
+	assert.Equal(t, []string{HostDockerInternal + ":10.0.0.1"}, hostConfig.ExtraHosts)
+
+	hostConfig = &container.HostConfig{NetworkMode: "host"}
+	p.injectHostGateway(context.Background(), hostConfig)
+	assert.Empty(t, hostConfig.ExtraHosts)
+}
@@ -0,0 +1,345 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// HostInternal is the hostname resolving, inside the containers created after calling ExposeHostPorts,
+	// to the sidecar container forwarding the traffic to the exposed ports of the test host
+	HostInternal = "host.testcontainers.internal"
+
+	// SSHDDefaultImage is the image used by the sidecar container forwarding ports of the test host to the containers
+	SSHDDefaultImage = "testcontainers/sshd:1.1.0"
+
+	sshdPort = "22/tcp"
+	sshdUser = "root"
+)
+
+// activeHostPortForwarder holds the forwarder of the current process, used to inject the HostInternal alias
+// into the containers created while it's running. The exposeMtx serializes the calls to ExposeHostPorts, and it's
+// not held while reading the forwarder, as the sidecar container is created while exposing the ports.
+var activeHostPortForwarder = struct {
+	sync.Mutex
+	exposeMtx sync.Mutex
+	forwarder *HostPortForwarder
+}{}
+
+// HostPortForwarder represents a sidecar container running an SSH server, which forwards the traffic
+// sent by other containers to HostInternal to the exposed ports of the test host, through a reverse SSH tunnel
+type HostPortForwarder struct {
+	Container
+	client    sshListener
+	mtx       sync.Mutex
+	ports     map[int]net.Listener
+	closeOnce sync.Once
+}
+
+// sshListener is the subset of the SSH client used by the forwarder, listening on the ports of the sidecar
+type sshListener interface {
+	Listen(n string, addr string) (net.Listener, error)
+	Close() error
+}
+
+// Ports returns the ports of the test host forwarded by the sidecar
+func (f *HostPortForwarder) Ports() []int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	ports := make([]int, 0, len(f.ports))
+	for p := range f.ports {
+		ports = append(ports, p)
+	}
+
+	return ports
+}
+
+// ExposeHostPorts makes the given ports of the test host reachable from containers, e.g. to test webhooks or callbacks
+// sent by a container to a server started by the test. A sidecar container running an SSH server is started, and
+// a reverse SSH tunnel forwards the traffic sent to the sidecar to the same ports on localhost. The containers
+// created afterwards can reach the exposed ports using the HostInternal hostname, as in:
+//
+//	forwarder, err := ExposeHostPorts(ctx, 8080)
+//	// a container created now can send requests to http://host.testcontainers.internal:8080
+//
+// Only one forwarder runs per process: calling it again forwards the new ports through the running forwarder,
+// which is returned. Containers created before calling it can't resolve HostInternal.
+// It's a responsibility of the caller to terminate the forwarder.
+//
+// If a port can't be forwarded, a forwarder started by the call is terminated, while a running forwarder
+// is returned with the error, still forwarding the ports forwarded before.
+func ExposeHostPorts(ctx context.Context, ports ...int) (*HostPortForwarder, error) {
+	if len(ports) == 0 {
+		return nil, errors.New("at least one host port must be exposed")
+	}
+
+	return exposeHostPorts(ctx, startHostPortForwarder, ports)
+}
+
+// exposeHostPorts forwards the ports through the running forwarder, or through a new one started with the given function
+func exposeHostPorts(ctx context.Context, start func(context.Context) (*HostPortForwarder, error), ports []int) (*HostPortForwarder, error) {
+	activeHostPortForwarder.exposeMtx.Lock()
+	defer activeHostPortForwarder.exposeMtx.Unlock()
+
+	f := currentHostPortForwarder()
+	started := f == nil
+	if started {
+		var err error
+		f, err = start(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		activeHostPortForwarder.Lock()
+		activeHostPortForwarder.forwarder = f
+		activeHostPortForwarder.Unlock()
+	}
+
+	for _, p := range ports {
+		if err := f.forward(p); err != nil {
+			if !started {
+				return f, err
+			}
+
+			// the forwarder started by this call is not known by the caller, so it's terminated
+			if terminateErr := f.Terminate(ctx); terminateErr != nil {
+				return nil, fmt.Errorf("%w: could not terminate the host port forwarder: %s", err, terminateErr)
+			}
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func currentHostPortForwarder() *HostPortForwarder {
+	activeHostPortForwarder.Lock()
+	defer activeHostPortForwarder.Unlock()
+
+	return activeHostPortForwarder.forwarder
+}
+
+// startHostPortForwarder starts the SSH server container and connects to it
+func startHostPortForwarder(ctx context.Context) (*HostPortForwarder, error) {
+	password, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        SSHDDefaultImage,
+			Env:          map[string]string{"PASSWORD": password},
+			Entrypoint:   []string{"/bin/sh"},
+			Cmd:          []string{"-c", `echo "root:$PASSWORD" | chpasswd && /usr/sbin/sshd -D -o PermitRootLogin=yes -o AddressFamily=inet -o GatewayPorts=yes -o AllowTcpForwarding=yes`},
+			ExposedPorts: []string{sshdPort},
+			WaitingFor:   wait.ForListeningPort(sshdPort),
+		},
+		Started: true,
+	})
+	if err != nil {
+		if c != nil {
+			_ = c.Terminate(ctx)
+		}
+		return nil, err
+	}
+
+	client, err := dialSSHD(ctx, c, password)
+	if err != nil {
+		_ = c.Terminate(ctx)
+		return nil, err
+	}
+
+	return &HostPortForwarder{
+		Container: c,
+		client:    client,
+		ports:     map[int]net.Listener{},
+	}, nil
+}
+
+func dialSSHD(ctx context.Context, c Container, password string) (*ssh.Client, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := c.MappedPort(ctx, sshdPort)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(host, port.Port()), &ssh.ClientConfig{
+		User: sshdUser,
+		Auth: []ssh.AuthMethod{ssh.Password(password)},
+		// the host key of a disposable sidecar can't be known in advance
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not connect to the host port forwarder", err)
+	}
+
+	return client, nil
+}
+
+// forward listens on the given port of the sidecar, forwarding the accepted connections to the same port on localhost
+func (f *HostPortForwarder) forward(port int) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if _, ok := f.ports[port]; ok {
+		return nil
+	}
+
+	listener, err := f.client.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		return fmt.Errorf("%w: could not forward the host port %d", err, port)
+	}
+	f.ports[port] = listener
+
+	go forwardConnections(listener, fmt.Sprintf("localhost:%d", port))
+
+	return nil
+}
+
+// forwardConnections accepts the connections of the listener until it's closed,
+// piping each of them to a new connection to the given address
+func forwardConnections(listener net.Listener, address string) {
+	for {
+		remote, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer remote.Close()
+
+			local, err := net.Dial("tcp", address)
+			if err != nil {
+				Logger.Printf("🔥 could not forward connection to %s: %v", address, err)
+				return
+			}
+			defer local.Close()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				_, _ = io.Copy(local, remote)
+				done <- struct{}{}
+			}()
+			go func() {
+				_, _ = io.Copy(remote, local)
+				done <- struct{}{}
+			}()
+			<-done
+		}()
+	}
+}
+
+// Terminate closes the SSH tunnel and terminates the sidecar container. The containers created afterwards
+// can't resolve HostInternal anymore.
+func (f *HostPortForwarder) Terminate(ctx context.Context) error {
+	activeHostPortForwarder.Lock()
+	if activeHostPortForwarder.forwarder == f {
+		activeHostPortForwarder.forwarder = nil
+	}
+	activeHostPortForwarder.Unlock()
+
+	f.closeOnce.Do(func() {
+		f.mtx.Lock()
+		defer f.mtx.Unlock()
+
+		for _, l := range f.ports {
+			_ = l.Close()
+		}
+		_ = f.client.Close()
+	})
+
+	return f.Container.Terminate(ctx)
+}
+
+// injectHostInternal adds the HostInternal alias to the hosts of a container being created, resolving to the IP
+// of the running forwarder in the network the container is attached to, so it can reach the exposed host ports
+func (p *DockerProvider) injectHostInternal(ctx context.Context, req ContainerRequest, hostConfig *container.HostConfig) error {
+	f := currentHostPortForwarder()
+	if f == nil || hostConfig.NetworkMode.IsContainer() || hostConfig.NetworkMode.IsHost() || hasExtraHost(hostConfig.ExtraHosts, HostInternal) {
+		return nil
+	}
+
+	networkName := "bridge"
+	if len(req.Networks) > 0 {
+		networkName = req.Networks[0]
+	} else if hostConfig.NetworkMode.IsUserDefined() {
+		networkName = hostConfig.NetworkMode.NetworkName()
+	}
+
+	ip, err := p.forwarderIP(ctx, f.GetContainerID(), networkName)
+	if err != nil {
+		return fmt.Errorf("%w: could not resolve %s", err, HostInternal)
+	}
+
+	hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, HostInternal+":"+ip)
+
+	return nil
+}
+
+// forwarderIP returns the IP of the forwarder in the given network, attaching it to the network if needed
+func (p *DockerProvider) forwarderIP(ctx context.Context, id string, networkName string) (string, error) {
+	inspect, err := p.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if endpoint, ok := inspect.NetworkSettings.Networks[networkName]; ok {
+		return endpoint.IPAddress, nil
+	}
+
+	if err := p.client.NetworkConnect(ctx, networkName, id, nil); err != nil {
+		return "", err
+	}
+
+	inspect, err = p.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, ok := inspect.NetworkSettings.Networks[networkName]
+	if !ok {
+		return "", fmt.Errorf("the host port forwarder is not attached to the network %s", networkName)
+	}
+
+	return endpoint.IPAddress, nil
+}
+
+// hasExtraHost returns true if the given extra hosts, with the format <hostname>:<IP>, already define the hostname
+func hasExtraHost(extraHosts []string, hostname string) bool {
+	for _, h := range extraHosts {
+		if strings.HasPrefix(h, hostname+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func randomPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(testcontainerssession.Rand(), b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,154 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposeHostPorts(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello from the host"))
+	}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	var hostPort int
+	_, err = fmt.Sscan(port, &hostPort)
+	require.NoError(t, err)
+
+	// exposeHostPorts {
+	forwarder, err := ExposeHostPorts(ctx, hostPort)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, forwarder.Terminate(ctx))
+	}()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	code, reader, err := c.Exec(ctx, []string{"wget", "-qO-", fmt.Sprintf("http://%s:%d", HostInternal, hostPort)})
+	// }
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "hello from the host")
+	assert.Equal(t, []int{hostPort}, forwarder.Ports())
+}
+
+func TestExposeHostPortsWithoutPorts(t *testing.T) {
+	_, err := ExposeHostPorts(context.Background())
+	require.Error(t, err)
+}
+
+// refusingSSHListener is an SSH client listening on local ports, except on the refused port of the sidecar
+type refusingSSHListener struct {
+	refused int
+	closed  bool
+}
+
+func (l *refusingSSHListener) Listen(_ string, addr string) (net.Listener, error) {
+	if addr == fmt.Sprintf("0.0.0.0:%d", l.refused) {
+		return nil, errors.New("tcpip-forward request denied by peer")
+	}
+
+	return net.Listen("tcp", "127.0.0.1:0")
+}
+
+func (l *refusingSSHListener) Close() error {
+	l.closed = true
+	return nil
+}
+
+func TestExposeHostPortsWithFailingForward(t *testing.T) {
+	ctx := context.Background()
+
+	client := &refusingSSHListener{refused: 9090}
+	sidecar := &fakeContainer{id: "sidecar"}
+	start := func(context.Context) (*HostPortForwarder, error) {
+		return &HostPortForwarder{Container: sidecar, client: client, ports: map[int]net.Listener{}}, nil
+	}
+
+	t.Run("A forwarder started by the call is terminated", func(t *testing.T) {
+		f, err := exposeHostPorts(ctx, start, []int{8080, 9090})
+		require.Error(t, err)
+		assert.Nil(t, f)
+
+		assert.True(t, sidecar.terminated)
+		assert.True(t, client.closed)
+		assert.Nil(t, currentHostPortForwarder())
+	})
+
+	t.Run("A running forwarder is returned", func(t *testing.T) {
+		sidecar.terminated, client.closed = false, false
+
+		f, err := exposeHostPorts(ctx, start, []int{8080})
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, f.Terminate(ctx))
+		}()
+
+		running, err := exposeHostPorts(ctx, start, []int{8081, 9090})
+		require.Error(t, err)
+		assert.Same(t, f, running, "the caller must be able to terminate the running forwarder")
+		assert.ElementsMatch(t, []int{8080, 8081}, running.Ports())
+		assert.False(t, sidecar.terminated)
+		assert.Same(t, f, currentHostPortForwarder())
+	})
+}
+
+func TestForwardConnections(t *testing.T) {
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer target.Close()
+
+	go func() {
+		conn, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, strings.NewReader("pong"))
+	}()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go forwardConnections(listener, target.Addr().String())
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	response, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(response))
+}
+
+func TestHasExtraHost(t *testing.T) {
+	assert.True(t, hasExtraHost([]string{"foo:10.0.0.1", HostInternal + ":172.17.0.2"}, HostInternal))
+	assert.False(t, hasExtraHost([]string{"foo:10.0.0.1"}, HostInternal))
+	assert.False(t, hasExtraHost(nil, HostInternal))
+}
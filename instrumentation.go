@@ -0,0 +1,98 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// OperationImagePull is the pull of the image of a container
+	OperationImagePull = "image.pull"
+	// OperationContainerCreate is the creation of a container, including the pull or the build of its image
+	OperationContainerCreate = "container.create"
+	// OperationContainerStart is the start of a container, including its wait strategy
+	OperationContainerStart = "container.start"
+	// OperationWait is the execution of the wait strategy of a container
+	OperationWait = "container.wait"
+)
+
+// Operation is an operation of the lifecycle of a container, reported to the instrumentation
+type Operation struct {
+	// Name is the name of the operation, e.g. OperationContainerStart
+	Name string
+	// Image is the image of the container
+	Image string
+	// ContainerID is the ID of the container, empty until it's created
+	ContainerID string
+	// Strategy is the type of the wait strategy of the OperationWait operations, e.g. *wait.LogStrategy,
+	// followed by the types of the strategies of a wait.MultiStrategy
+	Strategy string
+}
+
+// Instrumentation receives the operations of the lifecycle of the containers, to measure where the time
+// of the tests is spent, e.g. as the spans of a trace. The OpenTelemetry instrumentation is provided
+// by the otel package.
+type Instrumentation interface {
+	// StartOperation is called when an operation starts. It returns the context of the operation, passed to the
+	// nested operations, e.g. the pull of the image of a container being created, and the function called
+	// when the operation ends, with its error, nil if it succeeded.
+	StartOperation(ctx context.Context, op Operation) (context.Context, func(err error))
+}
+
+var (
+	instrumentationMx sync.RWMutex
+	instrumentation   Instrumentation
+)
+
+// SetInstrumentation sets the instrumentation receiving the operations of all the containers,
+// e.g. in the TestMain function of the tests. A nil instrumentation disables it.
+func SetInstrumentation(i Instrumentation) {
+	instrumentationMx.Lock()
+	defer instrumentationMx.Unlock()
+
+	instrumentation = i
+}
+
+// startOperation reports the start of an operation to the instrumentation, if any, and to the startup budget
+// of the session, if any, which bounds the context of the operation by its deadline. The returned function reports
+// the end of the operation, returning its error, wrapped into an ErrStartupBudgetExceeded error if the operation
+// failed after the deadline of the budget.
+func startOperation(ctx context.Context, op Operation) (context.Context, func(err error) error) {
+	ctx, endBudget := currentStartupBudget().startOperation(ctx, op)
+
+	instrumentationMx.RLock()
+	i := instrumentation
+	instrumentationMx.RUnlock()
+
+	if i == nil {
+		return ctx, endBudget
+	}
+
+	ctx, end := i.StartOperation(ctx, op)
+
+	return ctx, func(err error) error {
+		end(err)
+		return endBudget(err)
+	}
+}
+
+// strategyName returns the type of the wait strategy, followed by the types of the strategies of a wait.MultiStrategy
+func strategyName(strategy wait.Strategy) string {
+	name := fmt.Sprintf("%T", strategy)
+
+	multi, ok := strategy.(*wait.MultiStrategy)
+	if !ok {
+		return name
+	}
+
+	names := make([]string, 0, len(multi.Strategies))
+	for _, s := range multi.Strategies {
+		names = append(names, strategyName(s))
+	}
+
+	return name + "(" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,60 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type operationKey struct{}
+
+// recordingInstrumentation records the operations and their errors
+type recordingInstrumentation struct {
+	started []Operation
+	ended   []error
+}
+
+func (r *recordingInstrumentation) StartOperation(ctx context.Context, op Operation) (context.Context, func(err error)) {
+	r.started = append(r.started, op)
+
+	return context.WithValue(ctx, operationKey{}, op.Name), func(err error) {
+		r.ended = append(r.ended, err)
+	}
+}
+
+func TestStartOperation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Without instrumentation", func(t *testing.T) {
+		opCtx, end := startOperation(ctx, Operation{Name: OperationContainerStart})
+		end(nil)
+
+		assert.Equal(t, ctx, opCtx)
+	})
+
+	t.Run("With instrumentation", func(t *testing.T) {
+		r := &recordingInstrumentation{}
+		SetInstrumentation(r)
+		defer SetInstrumentation(nil)
+
+		failure := errors.New("failure")
+		opCtx, end := startOperation(ctx, Operation{Name: OperationImagePull, Image: "nginx:alpine"})
+		end(failure)
+
+		assert.Equal(t, OperationImagePull, opCtx.Value(operationKey{}))
+		assert.Equal(t, []Operation{{Name: OperationImagePull, Image: "nginx:alpine"}}, r.started)
+		assert.Equal(t, []error{failure}, r.ended)
+	})
+}
+
+func TestStrategyName(t *testing.T) {
+	assert.Equal(t, "*wait.LogStrategy", strategyName(wait.ForLog("ready")))
+	assert.Equal(t,
+		"*wait.MultiStrategy(*wait.HostPortStrategy, *wait.HTTPStrategy)",
+		strategyName(wait.ForAll(wait.ForListeningPort("80/tcp"), wait.ForHTTP("/"))),
+	)
+}
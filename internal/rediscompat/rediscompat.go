@@ -0,0 +1,148 @@
+// Package rediscompat holds the configuration shared by the modules of the Redis compatible engines, e.g. Valkey or KeyDB:
+// their password, their TLS, their connection strings and their wait strategies, so the modules stay in sync.
+package rediscompat
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// Port is the port of the Redis protocol, served over TLS if it's enabled
+	Port = "6379/tcp"
+
+	// ReadyLog is the log of the servers derived from Redis once they accept the connections
+	ReadyLog = "Ready to accept connections"
+
+	tlsContainerDir     = "/tls"
+	certContainerPath   = tlsContainerDir + "/server.crt"
+	keyContainerPath    = tlsContainerDir + "/server.key"
+	caCertContainerPath = tlsContainerDir + "/ca.crt"
+)
+
+// Container is implemented by the containers of the Redis compatible modules, so the same code can use any of them
+type Container interface {
+	testcontainers.Container
+	ConnectionString(ctx context.Context) (string, error)
+	TLSConfig() *tls.Config
+}
+
+// TLS are the PEM encoded files of the certificate and the private key of the server. The CA certificate is used
+// by the clients to verify the certificate of the server, which is used instead if it's self-signed.
+type TLS struct {
+	CertFile   string
+	KeyFile    string
+	CACertFile string
+}
+
+// Settings are the settings shared by the Redis compatible modules, embedded in the options of each module
+type Settings struct {
+	// Password is the password required to authenticate the clients, if not empty
+	Password string
+	// TLS serves the Redis protocol over TLS only, if not nil
+	TLS *TLS
+}
+
+// Flags returns the command line flags of the server for the settings, as accepted by Redis and its forks.
+// The TLS port replaces the plain port, and the clients are not required to present a certificate.
+func (s Settings) Flags() []string {
+	var flags []string
+
+	if s.Password != "" {
+		flags = append(flags, "--requirepass", s.Password)
+	}
+
+	if s.TLS != nil {
+		flags = append(flags,
+			"--port", "0",
+			"--tls-port", "6379",
+			"--tls-cert-file", certContainerPath,
+			"--tls-key-file", keyContainerPath,
+			"--tls-ca-cert-file", caCertContainerPath,
+			"--tls-auth-clients", "no",
+		)
+	}
+
+	return flags
+}
+
+// Files returns the files to copy into the container for the settings, i.e. the certificates of the server
+func (s Settings) Files() []testcontainers.ContainerFile {
+	if s.TLS == nil {
+		return nil
+	}
+
+	return []testcontainers.ContainerFile{
+		{HostFilePath: s.TLS.CertFile, ContainerFilePath: certContainerPath, FileMode: 0o644},
+		{HostFilePath: s.TLS.KeyFile, ContainerFilePath: keyContainerPath, FileMode: 0o644},
+		{HostFilePath: s.caCertFile(), ContainerFilePath: caCertContainerPath, FileMode: 0o644},
+	}
+}
+
+// caCertFile returns the CA certificate of the server, which is its own certificate if it's self-signed
+func (s Settings) caCertFile() string {
+	if s.TLS.CACertFile != "" {
+		return s.TLS.CACertFile
+	}
+
+	return s.TLS.CertFile
+}
+
+// TLSConfig returns the TLS configuration of the clients, trusting the CA certificate of the server,
+// nil if TLS is not enabled
+func (s Settings) TLSConfig() (*tls.Config, error) {
+	if s.TLS == nil {
+		return nil, nil
+	}
+
+	cert, err := os.ReadFile(s.caCertFile())
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read the CA certificate of the server", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(cert) {
+		return nil, fmt.Errorf("the CA certificate of the server %s is not PEM encoded", s.caCertFile())
+	}
+
+	return &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}, nil
+}
+
+// WaitStrategy returns the wait strategy of the servers derived from Redis, which log when they accept the connections
+func WaitStrategy() wait.Strategy {
+	return wait.ForAll(
+		wait.ForLog(ReadyLog),
+		wait.ForListeningPort(Port),
+	)
+}
+
+// ConnectionString returns the connection string of the server in the container, with the format
+// redis://[:password@]<host>:<port>, or rediss:// over TLS, which can be parsed by the Redis clients
+func ConnectionString(ctx context.Context, c testcontainers.Container, s Settings) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, Port)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := "redis"
+	if s.TLS != nil {
+		scheme = "rediss"
+	}
+
+	if s.Password != "" {
+		return fmt.Sprintf("%s://:%s@%s:%s", scheme, s.Password, host, mappedPort.Port()), nil
+	}
+
+	return fmt.Sprintf("%s://%s:%s", scheme, host, mappedPort.Port()), nil
+}
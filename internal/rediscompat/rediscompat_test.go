@@ -0,0 +1,57 @@
+package rediscompat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestSettingsFlags(t *testing.T) {
+	assert.Nil(t, Settings{}.Flags())
+
+	assert.Equal(t, []string{"--requirepass", "secret"}, Settings{Password: "secret"}.Flags())
+
+	assert.Equal(t, []string{
+		"--port", "0",
+		"--tls-port", "6379",
+		"--tls-cert-file", "/tls/server.crt",
+		"--tls-key-file", "/tls/server.key",
+		"--tls-ca-cert-file", "/tls/ca.crt",
+		"--tls-auth-clients", "no",
+	}, Settings{TLS: &TLS{CertFile: "server.crt", KeyFile: "server.key"}}.Flags())
+}
+
+func TestSettingsFiles(t *testing.T) {
+	assert.Nil(t, Settings{Password: "secret"}.Files())
+
+	// the certificate of the server is its own CA if it's self-signed
+	assert.Equal(t, []testcontainers.ContainerFile{
+		{HostFilePath: "server.crt", ContainerFilePath: "/tls/server.crt", FileMode: 0o644},
+		{HostFilePath: "server.key", ContainerFilePath: "/tls/server.key", FileMode: 0o644},
+		{HostFilePath: "server.crt", ContainerFilePath: "/tls/ca.crt", FileMode: 0o644},
+	}, Settings{TLS: &TLS{CertFile: "server.crt", KeyFile: "server.key"}}.Files())
+
+	files := Settings{TLS: &TLS{CertFile: "server.crt", KeyFile: "server.key", CACertFile: "ca.crt"}}.Files()
+	require.Len(t, files, 3)
+	assert.Equal(t, "ca.crt", files[2].HostFilePath)
+}
+
+func TestSettingsTLSConfig(t *testing.T) {
+	config, err := Settings{}.TLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = Settings{TLS: &TLS{CertFile: "missing.crt"}}.TLSConfig()
+	require.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "server.crt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	_, err = Settings{TLS: &TLS{CertFile: notPEM}}.TLSConfig()
+	require.ErrorContains(t, err, "is not PEM encoded")
+}
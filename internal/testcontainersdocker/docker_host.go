@@ -6,9 +6,17 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
+const (
+	dockerSocketPath = "/var/run/docker.sock"
+	// podmanSocketName is the name of the socket of the Podman API service
+	podmanSocketName = "podman.sock"
+)
+
 type dockerHostContext string
 
 var DockerHostContextKey = dockerHostContext("docker_host")
@@ -35,7 +43,7 @@ func ExtractDockerHost(ctx context.Context) (dockerHostPath string) {
 		return dockerHostPath
 	}
 
-	dockerHostPath = "/var/run/docker.sock"
+	dockerHostPath = dockerSocketPath
 
 	var hostRawURL string
 	if h, ok := ctx.Value(DockerHostContextKey).(string); !ok || h == "" {
@@ -71,3 +79,50 @@ func inAContainer(path string) bool {
 	}
 	return false
 }
+
+// DefaultDockerHost returns the host of the container runtime to be used when it's not configured:
+// the Docker socket if it exists, otherwise the socket of a rootless or rootful Podman service, if any.
+// It falls back to the Docker socket, for backwards compatibility.
+func DefaultDockerHost() string {
+	return defaultDockerHost(dockerSocketPath, podmanSocketPaths())
+}
+
+func defaultDockerHost(dockerSocket string, podmanSockets []string) string {
+	if fileExists(dockerSocket) {
+		return "unix://" + dockerSocket
+	}
+
+	for _, s := range podmanSockets {
+		if fileExists(s) {
+			return "unix://" + s
+		}
+	}
+
+	return "unix://" + dockerSocket
+}
+
+// IsPodmanHost returns true if the given host points to the socket of a Podman API service
+func IsPodmanHost(host string) bool {
+	return strings.Contains(host, podmanSocketName)
+}
+
+// podmanSocketPaths returns the well-known locations of the Podman socket, for rootless mode first
+func podmanSocketPaths() []string {
+	var paths []string
+
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		paths = append(paths, filepath.Join(runtimeDir, "podman", podmanSocketName))
+	}
+
+	paths = append(paths,
+		filepath.Join("/run/user", strconv.Itoa(os.Getuid()), "podman", podmanSocketName),
+		filepath.Join("/run/podman", podmanSocketName),
+	)
+
+	return paths
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -67,3 +67,38 @@ func TestInAContainer(t *testing.T) {
 		assert.True(t, inAContainer(f))
 	})
 }
+
+func Test_DefaultDockerHost(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	dockerSocket := filepath.Join(tmpDir, "docker.sock")
+	podmanSocket := filepath.Join(tmpDir, "podman", "podman.sock")
+
+	t.Run("No sockets fall back to the Docker socket", func(t *testing.T) {
+		host := defaultDockerHost(dockerSocket, []string{podmanSocket})
+
+		assert.Equal(t, "unix://"+dockerSocket, host)
+	})
+
+	t.Run("Podman socket", func(t *testing.T) {
+		err := os.MkdirAll(filepath.Dir(podmanSocket), 0o755)
+		assert.Nil(t, err)
+		err = os.WriteFile(podmanSocket, []byte{}, 0o600)
+		assert.Nil(t, err)
+
+		host := defaultDockerHost(dockerSocket, []string{podmanSocket})
+
+		assert.Equal(t, "unix://"+podmanSocket, host)
+		assert.True(t, IsPodmanHost(host))
+	})
+
+	t.Run("Docker socket takes precedence", func(t *testing.T) {
+		err := os.WriteFile(dockerSocket, []byte{}, 0o600)
+		assert.Nil(t, err)
+
+		host := defaultDockerHost(dockerSocket, []string{podmanSocket})
+
+		assert.Equal(t, "unix://"+dockerSocket, host)
+		assert.False(t, IsPodmanHost(host))
+	})
+}
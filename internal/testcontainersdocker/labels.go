@@ -1,9 +1,34 @@
 package testcontainersdocker
 
+import "github.com/testcontainers/testcontainers-go/internal"
+
 const (
-	LabelBase      = "org.testcontainers"
-	LabelLang      = LabelBase + ".lang"
-	LabelReaper    = LabelBase + ".reaper"
-	LabelSessionID = LabelBase + ".sessionId"
-	LabelVersion   = LabelBase + ".version"
+	LabelBase          = "org.testcontainers"
+	LabelCleanupPolicy = LabelBase + ".cleanupPolicy"
+	LabelLang          = LabelBase + ".lang"
+	LabelReap          = LabelBase + ".reap"
+	LabelReaper        = LabelBase + ".reaper"
+	LabelSessionID     = LabelBase + ".sessionId"
+	LabelVersion       = LabelBase + ".version"
 )
+
+// DefaultLabels returns the labels identifying the resources created by Testcontainers for Go
+// in the given session: the language, the version of the library and the session ID
+func DefaultLabels(sessionID string) map[string]string {
+	return map[string]string{
+		LabelBase:      "true",
+		LabelLang:      "go",
+		LabelVersion:   internal.Version,
+		LabelSessionID: sessionID,
+	}
+}
+
+// AddDefaultLabels adds the default labels of the given session to the labels of a resource,
+// without overriding the labels already defined by the user
+func AddDefaultLabels(labels map[string]string, sessionID string) {
+	for k, v := range DefaultLabels(sessionID) {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package testcontainersdocker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/testcontainers/testcontainers-go/internal"
+)
+
+func TestAddDefaultLabels(t *testing.T) {
+	labels := map[string]string{
+		"com.example.team": "payments",
+		LabelLang:          "custom",
+	}
+
+	AddDefaultLabels(labels, "session")
+
+	assert.Equal(t, map[string]string{
+		"com.example.team": "payments",
+		LabelBase:          "true",
+		LabelLang:          "custom",
+		LabelVersion:       internal.Version,
+		LabelSessionID:     "session",
+	}, labels)
+}
@@ -1,14 +1,30 @@
 package testcontainerssession
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/google/uuid"
 )
 
+// SeedEnvVar is the environment variable seeding the random values generated by the library, e.g. the names
+// of the volumes or the passwords, so two runs with the same seed generate identical values
+const SeedEnvVar = "TESTCONTAINERS_SEED"
+
 var id uuid.UUID
 var idOnce sync.Once
 
+var random io.Reader
+var randomOnce sync.Once
+
+// ID returns the ID of the session, random for each process, even if the random values are seeded:
+// the reaper of a process removes all the resources labeled with its session ID, so the processes
+// running at the same time, e.g. the packages tested by go test ./..., must not share it.
 func ID() uuid.UUID {
 	idOnce.Do(func() {
 		id = uuid.New()
@@ -20,3 +36,75 @@ func ID() uuid.UUID {
 func String() string {
 	return ID().String()
 }
+
+// Rand returns the source of the random values of the session: a deterministic stream derived from the seed
+// if the TESTCONTAINERS_SEED environment variable is set, else crypto/rand. It's safe for concurrent use,
+// but the values only repeat across runs if they are generated in the same order.
+// The seed is scoped to the process, so the packages tested at the same time by go test ./... generate
+// different values, while each of them generates the same values at every run.
+func Rand() io.Reader {
+	randomOnce.Do(func() {
+		if seed := os.Getenv(SeedEnvVar); seed != "" {
+			random = &seededReader{seed: []byte(seed + "\x00" + processScope())}
+			return
+		}
+
+		random = rand.Reader
+	})
+
+	return random
+}
+
+// NewUUID returns a random UUID, generated from the source of the random values of the session
+func NewUUID() uuid.UUID {
+	u, err := uuid.NewRandomFromReader(Rand())
+	if err != nil {
+		// crypto/rand only fails if the OS can't provide random values, as uuid.New does
+		panic(err)
+	}
+
+	return u
+}
+
+// processScope identifies the process among the processes running with the same seed, and across runs:
+// go test runs the binary of each package in the directory of the package
+func processScope() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		wd = ""
+	}
+
+	return wd + "\x00" + filepath.Base(os.Args[0])
+}
+
+// seededReader is a deterministic stream of bytes, made of the SHA-256 hashes of the seed followed by a counter
+type seededReader struct {
+	mu      sync.Mutex
+	seed    []byte
+	counter uint64
+	buf     []byte
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			block := make([]byte, len(r.seed)+8)
+			copy(block, r.seed)
+			binary.BigEndian.PutUint64(block[len(r.seed):], r.counter)
+			r.counter++
+
+			sum := sha256.Sum256(block)
+			r.buf = sum[:]
+		}
+
+		copied := copy(p[n:], r.buf)
+		r.buf = r.buf[copied:]
+		n += copied
+	}
+
+	return n, nil
+}
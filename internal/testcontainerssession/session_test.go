@@ -0,0 +1,63 @@
+package testcontainerssession
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSeededReaderIsDeterministic(t *testing.T) {
+	read := func(seed string) []byte {
+		r := &seededReader{seed: []byte(seed)}
+
+		// reading in chunks smaller than a block must not change the stream
+		b := make([]byte, 50)
+		if _, err := io.ReadFull(r, b[:7]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.ReadFull(r, b[7:]); err != nil {
+			t.Fatal(err)
+		}
+
+		return b
+	}
+
+	first := read("commit-sha")
+	if second := read("commit-sha"); !bytes.Equal(first, second) {
+		t.Fatalf("expected the same stream for the same seed, got %x and %x", first, second)
+	}
+
+	if other := read("other-sha"); bytes.Equal(first, other) {
+		t.Fatalf("expected different streams for different seeds, got %x", first)
+	}
+}
+
+func TestIDIsNotSeeded(t *testing.T) {
+	t.Setenv(SeedEnvVar, "commit-sha")
+
+	if ID() == uuid.NewSHA1(uuid.Nil, []byte("commit-sha")) {
+		t.Fatal("expected a random session ID, shared only by the process")
+	}
+}
+
+func TestProcessScope(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope := processScope()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	// the binaries of the packages tested by go test ./... run in the directories of the packages
+	if other := processScope(); other == scope {
+		t.Fatalf("expected a different scope in another directory, got %q", other)
+	}
+}
@@ -5,12 +5,15 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/go-connections/nat"
 )
 
 func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req ContainerRequest, dockerInput *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) error {
 	// prepare mounts
 	hostConfig.Mounts = mapToDockerMounts(req.Mounts)
+	// named volumes are created by the Docker daemon, so label them to be removed by the reaper
+	labelVolumeMounts(hostConfig.Mounts, req.Labels)
 
 	endpointSettings := map[string]*network.EndpointSettings{}
 
@@ -45,10 +48,23 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 	}
 	req.HostConfigModifier(hostConfig)
 
+	// the network mode is set by the modifiers, so the alias must be injected after them
+	if err := p.injectHostInternal(ctx, req, hostConfig); err != nil {
+		return err
+	}
+
+	// resource limits defined in the request take precedence over the ones set by the modifier
+	applyResourceLimits(req, hostConfig)
+	applyCapabilities(req, hostConfig)
+
 	if req.EnpointSettingsModifier != nil {
 		req.EnpointSettingsModifier(endpointSettings)
 	}
 
+	if req.EndpointSettingsModifier != nil {
+		req.EndpointSettingsModifier(endpointSettings)
+	}
+
 	networkingConfig.EndpointsConfig = endpointSettings
 
 	exposedPorts := req.ExposedPorts
@@ -86,3 +102,51 @@ func defaultHostConfigModifier(req ContainerRequest) func(hostConfig *container.
 		hostConfig.Resources = req.Resources
 	}
 }
+
+// applyCapabilities adds the capabilities added and dropped in the request to the ones set by the modifier,
+// skipping the ones already present
+func applyCapabilities(req ContainerRequest, hostConfig *container.HostConfig) {
+	hostConfig.CapAdd = appendCapabilities(hostConfig.CapAdd, req.CapAdd)
+	hostConfig.CapDrop = appendCapabilities(hostConfig.CapDrop, req.CapDrop)
+}
+
+func appendCapabilities(capabilities strslice.StrSlice, added []string) strslice.StrSlice {
+	for _, capability := range added {
+		present := false
+		for _, c := range capabilities {
+			if normalizeCapability(c) == normalizeCapability(capability) {
+				present = true
+				break
+			}
+		}
+
+		if !present {
+			capabilities = append(capabilities, capability)
+		}
+	}
+
+	return capabilities
+}
+
+// applyResourceLimits sets the resource limits defined in the request into the host config,
+// only for those limits with a non-zero value
+func applyResourceLimits(req ContainerRequest, hostConfig *container.HostConfig) {
+	if req.Memory != 0 {
+		hostConfig.Memory = req.Memory
+	}
+	if req.MemorySwap != 0 {
+		hostConfig.MemorySwap = req.MemorySwap
+	}
+	if req.CPUShares != 0 {
+		hostConfig.CPUShares = req.CPUShares
+	}
+	if req.CPUPeriod != 0 {
+		hostConfig.CPUPeriod = req.CPUPeriod
+	}
+	if req.CPUQuota != 0 {
+		hostConfig.CPUQuota = req.CPUQuota
+	}
+	if len(req.Ulimits) > 0 {
+		hostConfig.Ulimits = req.Ulimits
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,7 +49,7 @@ func TestPreCreateModifierHook(t *testing.T) {
 					},
 				}
 			},
-			EnpointSettingsModifier: func(endpointSettings map[string]*network.EndpointSettings) {
+			EndpointSettingsModifier: func(endpointSettings map[string]*network.EndpointSettings) {
 				endpointSettings["a"] = &network.EndpointSettings{
 					Aliases: []string{"b"},
 					Links:   []string{"link1", "link2"},
@@ -298,3 +299,50 @@ func TestPreCreateModifierHook(t *testing.T) {
 		)
 	})
 }
+
+func TestApplyResourceLimits(t *testing.T) {
+	t.Run("No resource limits", func(t *testing.T) {
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{
+				Memory: 1024,
+			},
+		}
+
+		applyResourceLimits(ContainerRequest{}, hostConfig)
+
+		assert.Equal(t, int64(1024), hostConfig.Memory, "the modifier's values must be kept")
+		assert.Nil(t, hostConfig.Ulimits)
+	})
+
+	t.Run("Resource limits take precedence", func(t *testing.T) {
+		// resourceLimits {
+		req := ContainerRequest{
+			Image:      nginxAlpineImage,
+			Memory:     512 * 1024 * 1024,
+			MemorySwap: 1024 * 1024 * 1024,
+			CPUShares:  512,
+			CPUPeriod:  100000,
+			CPUQuota:   50000,
+			Ulimits: []*units.Ulimit{
+				{Name: "nofile", Soft: 65536, Hard: 65536},
+			},
+		}
+		// }
+
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{
+				Memory:    1024,
+				CPUShares: 2,
+			},
+		}
+
+		applyResourceLimits(req, hostConfig)
+
+		assert.Equal(t, int64(512*1024*1024), hostConfig.Memory)
+		assert.Equal(t, int64(1024*1024*1024), hostConfig.MemorySwap)
+		assert.Equal(t, int64(512), hostConfig.CPUShares)
+		assert.Equal(t, int64(100000), hostConfig.CPUPeriod)
+		assert.Equal(t, int64(50000), hostConfig.CPUQuota)
+		assert.Equal(t, req.Ulimits, hostConfig.Ulimits)
+	})
+}
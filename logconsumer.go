@@ -1,5 +1,7 @@
 package testcontainers
 
+import "sync"
+
 // StdoutLog is the log type for STDOUT
 const StdoutLog = "STDOUT"
 
@@ -20,3 +22,81 @@ type Log struct {
 type LogConsumer interface {
 	Accept(Log)
 }
+
+// DefaultLogRingBufferSize is the size of the LogRingBuffer created with a size lower or equal to zero
+const DefaultLogRingBufferSize = 64 * 1024
+
+// LogRingBuffer is a LogConsumer keeping only the last bytes of the logs of a container, up to its size,
+// so following the logs of a chatty container during a long test does not grow the memory of the test.
+// The full logs remain available on demand with the Logs method of the container.
+type LogRingBuffer struct {
+	mtx  sync.Mutex
+	buf  []byte
+	size int
+	// start is the index of the oldest byte in buf, once the buffer is full
+	start int
+	full  bool
+}
+
+var _ LogConsumer = (*LogRingBuffer)(nil)
+
+// NewLogRingBuffer returns a LogRingBuffer keeping the last size bytes of the logs
+func NewLogRingBuffer(size int) *LogRingBuffer {
+	if size <= 0 {
+		size = DefaultLogRingBufferSize
+	}
+
+	return &LogRingBuffer{
+		buf:  make([]byte, 0, size),
+		size: size,
+	}
+}
+
+// Accept implements LogConsumer, keeping the content of the log, and dropping the oldest bytes if the buffer is full
+func (b *LogRingBuffer) Accept(l Log) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	content := l.Content
+	if len(content) >= b.size {
+		// the log replaces the whole buffer
+		b.buf = append(b.buf[:0], content[len(content)-b.size:]...)
+		b.start = 0
+		b.full = true
+		return
+	}
+
+	if !b.full {
+		free := b.size - len(b.buf)
+		if len(content) <= free {
+			b.buf = append(b.buf, content...)
+			return
+		}
+
+		b.buf = append(b.buf, content[:free]...)
+		content = content[free:]
+		b.full = true
+	}
+
+	// the oldest bytes are overwritten, wrapping around the end of the buffer
+	for len(content) > 0 {
+		n := copy(b.buf[b.start:], content)
+		content = content[n:]
+		b.start = (b.start + n) % b.size
+	}
+}
+
+// Bytes returns a copy of the last bytes of the logs, in order
+func (b *LogRingBuffer) Bytes() []byte {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	out := make([]byte, 0, len(b.buf))
+	out = append(out, b.buf[b.start:]...)
+	return append(out, b.buf[:b.start]...)
+}
+
+// String returns the last bytes of the logs, in order
+func (b *LogRingBuffer) String() string {
+	return string(b.Bytes())
+}
@@ -409,3 +409,37 @@ func TestContainerLogsShouldBeWithoutStreamHeader(t *testing.T) {
 	}
 	assert.Equal(t, "0", strings.TrimSpace(string(b)))
 }
+
+func TestLogRingBuffer(t *testing.T) {
+	t.Run("keeps the logs smaller than the buffer", func(t *testing.T) {
+		buffer := NewLogRingBuffer(16)
+		buffer.Accept(Log{LogType: StdoutLog, Content: []byte("hello\n")})
+		buffer.Accept(Log{LogType: StderrLog, Content: []byte("world\n")})
+
+		assert.Equal(t, "hello\nworld\n", buffer.String())
+	})
+
+	t.Run("drops the oldest bytes", func(t *testing.T) {
+		buffer := NewLogRingBuffer(8)
+		for _, line := range []string{"one\n", "two\n", "three\n", "four\n"} {
+			buffer.Accept(Log{LogType: StdoutLog, Content: []byte(line)})
+		}
+
+		assert.Equal(t, "ee\nfour\n", buffer.String())
+	})
+
+	t.Run("log larger than the buffer", func(t *testing.T) {
+		buffer := NewLogRingBuffer(4)
+		buffer.Accept(Log{LogType: StdoutLog, Content: []byte("a")})
+		buffer.Accept(Log{LogType: StdoutLog, Content: []byte("0123456789")})
+
+		assert.Equal(t, "6789", buffer.String())
+	})
+
+	t.Run("default size", func(t *testing.T) {
+		buffer := NewLogRingBuffer(0)
+		buffer.Accept(Log{LogType: StdoutLog, Content: make([]byte, DefaultLogRingBufferSize+1)})
+
+		assert.Len(t, buffer.Bytes(), DefaultLogRingBufferSize)
+	})
+}
@@ -44,7 +44,7 @@ func TestLogger(tb testing.TB) Logging {
 	return testLogger{TB: tb}
 }
 
-// WithLogger is a generic option that implements GenericProviderOption, DockerProviderOption
+// WithLogger is a generic option that implements GenericProviderOption, DockerProviderOption and ContainerCustomizer
 // It replaces the global Logging implementation with a user defined one e.g. to aggregate logs from testcontainers
 // with the logs of specific test case
 func WithLogger(logger Logging) LoggerOption {
@@ -65,6 +65,11 @@ func (o LoggerOption) ApplyDockerTo(opts *DockerProviderOptions) {
 	opts.Logger = o.logger
 }
 
+// Customize implements ContainerCustomizer.Customize
+func (o LoggerOption) Customize(req *GenericContainerRequest) {
+	req.Logger = o.logger
+}
+
 type testLogger struct {
 	testing.TB
 }
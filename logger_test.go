@@ -0,0 +1,43 @@
+package testcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func TestGlobalLogger(t *testing.T) {
+	defaultLogger := Logger
+	t.Cleanup(func() {
+		Logger = defaultLogger
+	})
+
+	// globalLogger {
+	logger := &recordingLogger{}
+	Logger = logger
+	// }
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"), []byte("hello"), 0o644))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "file.txt"), filepath.Join(dir, "link.txt")))
+
+	_, err := tarDir(dir, 0o755)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		">> creating TAR file from directory: " + dir,
+		">> skipping symlink: " + filepath.Join(dir, "link.txt"),
+	}, logger.messages)
+}
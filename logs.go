@@ -0,0 +1,204 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// LogEntry is a line of the logs of a container, as returned by the LogEntries method
+type LogEntry struct {
+	// Stream is the stream the line was written to, StdoutLog or StderrLog.
+	// The containers with a TTY only have the StdoutLog stream.
+	Stream string
+	// Timestamp is the time the line was written at, only set with the WithLogsTimestamps option
+	Timestamp time.Time
+	// Content is the content of the line, without the trailing newline
+	Content string
+}
+
+// LogsOptions are the options of the LogEntries method
+type LogsOptions struct {
+	since      time.Time
+	tail       int
+	timestamps bool
+	streams    []string
+}
+
+// LogsOption is a function that configures the logs returned by the LogEntries method
+type LogsOption func(*LogsOptions)
+
+// WithLogsSince only returns the lines written since the given time
+func WithLogsSince(since time.Time) LogsOption {
+	return func(o *LogsOptions) {
+		o.since = since
+	}
+}
+
+// WithLogsTail only returns the given number of lines, from the end of the logs
+func WithLogsTail(lines int) LogsOption {
+	return func(o *LogsOptions) {
+		o.tail = lines
+	}
+}
+
+// WithLogsTimestamps sets the time each line was written at, in the Timestamp field of the entries
+func WithLogsTimestamps() LogsOption {
+	return func(o *LogsOptions) {
+		o.timestamps = true
+	}
+}
+
+// WithLogsStream only returns the lines of the given streams, StdoutLog or StderrLog. Both are returned by default.
+func WithLogsStream(streams ...string) LogsOption {
+	return func(o *LogsOptions) {
+		o.streams = append(o.streams, streams...)
+	}
+}
+
+// containerLogsOptions returns the options of the Docker API reading the logs
+func (o LogsOptions) containerLogsOptions() (types.ContainerLogsOptions, error) {
+	options := types.ContainerLogsOptions{
+		ShowStdout: len(o.streams) == 0,
+		ShowStderr: len(o.streams) == 0,
+		Timestamps: o.timestamps,
+	}
+
+	for _, stream := range o.streams {
+		switch stream {
+		case StdoutLog:
+			options.ShowStdout = true
+		case StderrLog:
+			options.ShowStderr = true
+		default:
+			return options, fmt.Errorf("invalid log stream %q, expected %s or %s", stream, StdoutLog, StderrLog)
+		}
+	}
+
+	if !o.since.IsZero() {
+		options.Since = fmt.Sprintf("%d.%09d", o.since.Unix(), int64(o.since.Nanosecond()))
+	}
+
+	if o.tail > 0 {
+		options.Tail = strconv.Itoa(o.tail)
+	}
+
+	return options, nil
+}
+
+// LogEntries returns the lines of the logs of the container, demultiplexed into the stdout and stderr streams,
+// so the tests can assert on them without parsing the frames of the Docker logs stream
+func (c *DockerContainer) LogEntries(ctx context.Context, opts ...LogsOption) ([]LogEntry, error) {
+	logsOptions := LogsOptions{}
+	for _, opt := range opts {
+		opt(&logsOptions)
+	}
+
+	options, err := logsOptions.containerLogsOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, options)
+	if err != nil {
+		return nil, err
+	}
+	defer c.provider.Close()
+	defer rc.Close()
+
+	return parseLogEntries(rc, inspect.Config.Tty, logsOptions.timestamps)
+}
+
+// parseLogEntries reads the lines of the logs, which are multiplexed into frames for the containers without a TTY:
+// a header of 8 bytes, with the stream in the first byte and the size of the frame in the last 4 bytes, then the content.
+// A line can be split across frames, so the content of each stream is buffered until the end of the line.
+func parseLogEntries(r io.Reader, tty bool, timestamps bool) ([]LogEntry, error) {
+	entries := []LogEntry{}
+
+	pending := map[string]*bytes.Buffer{StdoutLog: {}, StderrLog: {}}
+	emit := func(stream string, content []byte, flush bool) {
+		buf := pending[stream]
+		buf.Write(content)
+
+		for {
+			line, err := buf.ReadString('\n')
+			if err != nil {
+				// the line is not complete yet, unless the logs are fully read
+				if flush && line != "" {
+					entries = append(entries, newLogEntry(stream, line, timestamps))
+				} else {
+					buf.WriteString(line)
+				}
+				return
+			}
+
+			entries = append(entries, newLogEntry(stream, strings.TrimSuffix(line, "\n"), timestamps))
+		}
+	}
+
+	if tty {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		emit(StdoutLog, content, true)
+		return entries, nil
+	}
+
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		stream := StdoutLog
+		if header[0] == 2 {
+			stream = StderrLog
+		}
+
+		content := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, err
+		}
+
+		emit(stream, content, false)
+	}
+
+	emit(StdoutLog, nil, true)
+	emit(StderrLog, nil, true)
+
+	return entries, nil
+}
+
+// newLogEntry returns the entry of the given line, reading its timestamp if the logs were requested with timestamps
+func newLogEntry(stream string, line string, timestamps bool) LogEntry {
+	entry := LogEntry{Stream: stream, Content: strings.TrimSuffix(line, "\r")}
+	if !timestamps {
+		return entry
+	}
+
+	timestamp, content, _ := strings.Cut(entry.Content, " ")
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		entry.Timestamp = t
+		entry.Content = content
+	}
+
+	return entry
+}
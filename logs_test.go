@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// logFrame returns a frame of the multiplexed logs of a container, for the given stream: 1 for stdout, 2 for stderr
+func logFrame(stream byte, content string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(content)))
+
+	return append(header, content...)
+}
+
+func TestParseLogEntries(t *testing.T) {
+	t.Run("multiplexed streams", func(t *testing.T) {
+		logs := bytes.Buffer{}
+		logs.Write(logFrame(1, "starting\n"))
+		logs.Write(logFrame(2, "WARN: low "))
+		logs.Write(logFrame(1, "ready\nlast"))
+		logs.Write(logFrame(2, "memory\n"))
+
+		entries, err := parseLogEntries(&logs, false, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []LogEntry{
+			{Stream: StdoutLog, Content: "starting"},
+			{Stream: StdoutLog, Content: "ready"},
+			{Stream: StderrLog, Content: "WARN: low memory"},
+			{Stream: StdoutLog, Content: "last"},
+		}, entries)
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		logs := bytes.NewReader(logFrame(1, "2023-05-04T10:11:12.123456789Z started in 1s\n"))
+
+		entries, err := parseLogEntries(logs, false, true)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		assert.Equal(t, time.Date(2023, 5, 4, 10, 11, 12, 123456789, time.UTC), entries[0].Timestamp)
+		assert.Equal(t, "started in 1s", entries[0].Content)
+	})
+
+	t.Run("tty", func(t *testing.T) {
+		entries, err := parseLogEntries(strings.NewReader("hello\r\nworld\r\n"), true, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []LogEntry{
+			{Stream: StdoutLog, Content: "hello"},
+			{Stream: StdoutLog, Content: "world"},
+		}, entries)
+	})
+
+	t.Run("truncated frame", func(t *testing.T) {
+		frame := logFrame(1, "hello\n")
+
+		_, err := parseLogEntries(bytes.NewReader(frame[:len(frame)-2]), false, false)
+		require.Error(t, err)
+	})
+}
+
+func TestLogsOptions(t *testing.T) {
+	since := time.Unix(1683195072, 5)
+
+	o := LogsOptions{}
+	for _, opt := range []LogsOption{WithLogsSince(since), WithLogsTail(10), WithLogsTimestamps(), WithLogsStream(StderrLog)} {
+		opt(&o)
+	}
+
+	options, err := o.containerLogsOptions()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1683195072.000000005", options.Since)
+	assert.Equal(t, "10", options.Tail)
+	assert.True(t, options.Timestamps)
+	assert.False(t, options.ShowStdout)
+	assert.True(t, options.ShowStderr)
+
+	_, err = LogsOptions{streams: []string{"STDIN"}}.containerLogsOptions()
+	require.Error(t, err)
+}
+
+func TestContainerLogEntries(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Cmd:        []string{"sh", "-c", "echo starting; echo 'WARN: low memory' >&2; echo ready; sleep 30"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	// logEntries {
+	entries, err := c.LogEntries(ctx, WithLogsStream(StderrLog), WithLogsTimestamps())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, "WARN: low memory", entries[0].Content)
+	assert.False(t, entries[0].Timestamp.IsZero())
+	// }
+
+	entries, err = c.LogEntries(ctx, WithLogsTail(1))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, LogEntry{Stream: StdoutLog, Content: "ready"}, entries[0])
+}
@@ -0,0 +1,146 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// metadataFileMtx serializes the updates of the metadata file done by the current process
+var metadataFileMtx sync.Mutex
+
+// ContainersMetadata is the content of the metadata file, describing the running containers,
+// so external tools, e.g. the database panel of an IDE, can connect to them
+type ContainersMetadata struct {
+	Containers []ContainerMetadata `json:"containers"`
+}
+
+// ContainerMetadata describes a running container in the metadata file
+type ContainerMetadata struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Image     string            `json:"image"`
+	SessionID string            `json:"sessionId"`
+	Host      string            `json:"host"`
+	Ports     map[string]string `json:"ports"`              // host ports indexed by container port, e.g. "5432/tcp": "49153"
+	Metadata  map[string]string `json:"metadata,omitempty"` // metadata of the request, e.g. the credentials of a database
+	StartedAt time.Time         `json:"startedAt"`
+}
+
+// ReadMetadataFile reads the metadata file at the given path
+func ReadMetadataFile(path string) (*ContainersMetadata, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &ContainersMetadata{}
+	if err := json.Unmarshal(content, metadata); err != nil {
+		return nil, fmt.Errorf("%w: invalid metadata file %s", err, path)
+	}
+
+	return metadata, nil
+}
+
+// publishMetadata adds the container to the metadata file, if it's configured
+func (c *DockerContainer) publishMetadata(ctx context.Context) error {
+	path := c.provider.config.MetadataFile
+	if path == "" {
+		return nil
+	}
+
+	metadata, err := c.metadataEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	return updateMetadataFile(path, func(m *ContainersMetadata) {
+		m.remove(c.ID)
+		m.Containers = append(m.Containers, metadata)
+	})
+}
+
+// unpublishMetadata removes the container from the metadata file, if it's configured
+func (c *DockerContainer) unpublishMetadata() error {
+	path := c.provider.config.MetadataFile
+	if path == "" {
+		return nil
+	}
+
+	return updateMetadataFile(path, func(m *ContainersMetadata) {
+		m.remove(c.ID)
+	})
+}
+
+func (c *DockerContainer) metadataEntry(ctx context.Context) (ContainerMetadata, error) {
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return ContainerMetadata{}, err
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return ContainerMetadata{}, err
+	}
+
+	ports := map[string]string{}
+	for port, bindings := range inspect.NetworkSettings.Ports {
+		if len(bindings) > 0 {
+			ports[string(port)] = bindings[0].HostPort
+		}
+	}
+
+	return ContainerMetadata{
+		ID:        c.ID,
+		Name:      inspect.Name,
+		Image:     c.Image,
+		SessionID: c.SessionID(),
+		Host:      host,
+		Ports:     ports,
+		Metadata:  c.metadata,
+		StartedAt: time.Now(),
+	}, nil
+}
+
+func (m *ContainersMetadata) remove(id string) {
+	containers := m.Containers[:0]
+	for _, c := range m.Containers {
+		if c.ID != id {
+			containers = append(containers, c)
+		}
+	}
+	m.Containers = containers
+}
+
+// updateMetadataFile applies the update to the content of the metadata file, replacing the file atomically,
+// so the tools reading it never see a partial content
+func updateMetadataFile(path string, update func(*ContainersMetadata)) error {
+	metadataFileMtx.Lock()
+	defer metadataFileMtx.Unlock()
+
+	metadata, err := ReadMetadataFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		metadata, err = &ContainersMetadata{}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	update(metadata)
+
+	sort.Slice(metadata.Containers, func(i, j int) bool {
+		return metadata.Containers[i].StartedAt.Before(metadata.Containers[j].StartedAt)
+	})
+
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(path, content)
+}
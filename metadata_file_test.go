@@ -0,0 +1,94 @@
+package testcontainers
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateMetadataFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testcontainers", "metadata.json")
+
+	now := time.Now()
+	first := ContainerMetadata{ID: "first", Image: "mysql:8.0.33", Ports: map[string]string{"3306/tcp": "49153"}, StartedAt: now}
+	second := ContainerMetadata{ID: "second", Image: "redis:7", Ports: map[string]string{"6379/tcp": "49154"}, StartedAt: now.Add(time.Second)}
+
+	// the containers are sorted by start time
+	for _, c := range []ContainerMetadata{second, first} {
+		c := c
+		require.NoError(t, updateMetadataFile(path, func(m *ContainersMetadata) {
+			m.Containers = append(m.Containers, c)
+		}))
+	}
+
+	metadata, err := ReadMetadataFile(path)
+	require.NoError(t, err)
+	require.Len(t, metadata.Containers, 2)
+	assert.Equal(t, "first", metadata.Containers[0].ID)
+	assert.Equal(t, "second", metadata.Containers[1].ID)
+	assert.Equal(t, "49153", metadata.Containers[0].Ports["3306/tcp"])
+
+	require.NoError(t, updateMetadataFile(path, func(m *ContainersMetadata) {
+		m.remove("first")
+	}))
+
+	metadata, err = ReadMetadataFile(path)
+	require.NoError(t, err)
+	require.Len(t, metadata.Containers, 1)
+	assert.Equal(t, "second", metadata.Containers[0].ID)
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, matches, "the temporary files must be removed")
+}
+
+func TestMetadataFile(t *testing.T) {
+	// reset the configuration singleton, so the metadata file is read again
+	resetConfig := func() {
+		tcConfigOnce = new(sync.Once)
+	}
+	resetConfig()
+	t.Cleanup(resetConfig)
+
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	t.Setenv("TESTCONTAINERS_METADATA_FILE", path)
+
+	ctx := context.Background()
+
+	// metadataFile {
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+			Metadata: map[string]string{
+				"url": "http://localhost",
+			},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+
+	metadata, err := ReadMetadataFile(path)
+	require.NoError(t, err)
+	require.Len(t, metadata.Containers, 1)
+
+	port, err := nginxC.MappedPort(ctx, "80/tcp")
+	require.NoError(t, err)
+
+	assert.Equal(t, nginxC.GetContainerID(), metadata.Containers[0].ID)
+	assert.Equal(t, port.Port(), metadata.Containers[0].Ports["80/tcp"])
+	assert.Equal(t, "http://localhost", metadata.Containers[0].Metadata["url"])
+
+	require.NoError(t, nginxC.Terminate(ctx))
+
+	metadata, err = ReadMetadataFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, metadata.Containers)
+}
@@ -0,0 +1,171 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	exec "github.com/testcontainers/testcontainers-go/exec"
+
+	mock "github.com/stretchr/testify/mock"
+
+	testcontainers "github.com/testcontainers/testcontainers-go"
+
+	nat "github.com/docker/go-connections/nat"
+)
+
+// ContainerHandle is an autogenerated mock type for the ContainerHandle type
+type ContainerHandle struct {
+	mock.Mock
+}
+
+// Exec provides a mock function with given fields: ctx, cmd, options
+func (_m *ContainerHandle) Exec(ctx context.Context, cmd []string, options ...exec.ProcessOption) (int, io.Reader, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, cmd)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 int
+	var r1 io.Reader
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, ...exec.ProcessOption) (int, io.Reader, error)); ok {
+		return rf(ctx, cmd, options...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, ...exec.ProcessOption) int); ok {
+		r0 = rf(ctx, cmd, options...)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, ...exec.ProcessOption) io.Reader); ok {
+		r1 = rf(ctx, cmd, options...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Reader)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, ...exec.ProcessOption) error); ok {
+		r2 = rf(ctx, cmd, options...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Host provides a mock function with given fields: _a0
+func (_m *ContainerHandle) Host(_a0 context.Context) (string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logs provides a mock function with given fields: _a0
+func (_m *ContainerHandle) Logs(_a0 context.Context) (io.ReadCloser, error) {
+	ret := _m.Called(_a0)
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (io.ReadCloser, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) io.ReadCloser); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MappedPort provides a mock function with given fields: _a0, _a1
+func (_m *ContainerHandle) MappedPort(_a0 context.Context, _a1 nat.Port) (nat.Port, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 nat.Port
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, nat.Port) (nat.Port, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, nat.Port) nat.Port); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(nat.Port)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, nat.Port) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Terminate provides a mock function with given fields: ctx, opts
+func (_m *ContainerHandle) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...testcontainers.TerminateOption) error); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewContainerHandle interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewContainerHandle creates a new instance of ContainerHandle. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewContainerHandle(t mockConstructorTestingTNewContainerHandle) *ContainerHandle {
+	mock := &ContainerHandle{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
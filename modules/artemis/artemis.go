@@ -0,0 +1,174 @@
+package artemis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/apache/activemq-artemis:2.30.0-alpine"
+	// }
+
+	// brokerPort is the port of the acceptor of the broker, accepting all the protocols, e.g. AMQP, OpenWire, STOMP and MQTT
+	brokerPort = "61616/tcp"
+	// consolePort is the port of the web console, and of the Jolokia API
+	consolePort = "8161/tcp"
+
+	defaultUser     = "artemis"
+	defaultPassword = "artemis"
+
+	// defaultExtraArgs are the arguments of the image, binding the web console to all the interfaces of the container
+	defaultExtraArgs = "--http-host 0.0.0.0 --relax-jolokia"
+)
+
+// ArtemisContainer represents the Artemis container type used in the module
+type ArtemisContainer struct {
+	testcontainers.Container
+	user     string
+	password string
+}
+
+// Option is a function that configures the Artemis module, e.g. the credentials of the broker.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	user      string
+	password  string
+	anonymous bool
+	extraArgs string
+}
+
+// WithCredentials sets the credentials of the user of the broker and of the web console.
+// By default, both the user and the password are "artemis".
+func WithCredentials(user, password string) Option {
+	return func(o *options) {
+		o.user = user
+		o.password = password
+	}
+}
+
+// WithAnonymousLogin allows the clients to connect to the broker without credentials.
+func WithAnonymousLogin() Option {
+	return func(o *options) {
+		o.anonymous = true
+	}
+}
+
+// WithExtraArgs sets the arguments passed to the "artemis create" command of the image, creating the broker,
+// e.g. "--no-autocreate". They replace the default arguments "--http-host 0.0.0.0 --relax-jolokia",
+// which must be kept to reach the web console from the host.
+func WithExtraArgs(args string) Option {
+	return func(o *options) {
+		o.extraArgs = args
+	}
+}
+
+// StartContainer creates an instance of the Artemis container type, running an ActiveMQ Artemis broker.
+// It waits until the broker is live and its acceptor is listening, so the clients can connect
+// with any of the supported protocols, e.g. AMQP, OpenWire, STOMP or MQTT.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ArtemisContainer, error) {
+	settings := options{
+		user:      defaultUser,
+		password:  defaultPassword,
+		extraArgs: defaultExtraArgs,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image: defaultImage,
+		Env: map[string]string{
+			"ARTEMIS_USER":     settings.user,
+			"ARTEMIS_PASSWORD": settings.password,
+			"ANONYMOUS_LOGIN":  fmt.Sprint(settings.anonymous),
+			"EXTRA_ARGS":       settings.extraArgs,
+		},
+		ExposedPorts: []string{brokerPort, consolePort},
+		// the acceptor is bound before the broker is live, which rejects the connections until then
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Server is now live"),
+			wait.ForListeningPort(brokerPort),
+		),
+		Metadata: map[string]string{
+			"username": settings.user,
+			"password": settings.password,
+		},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &ArtemisContainer{
+		Container: container,
+		user:      settings.user,
+		password:  settings.password,
+	}, err
+}
+
+// User returns the user of the broker
+func (c *ArtemisContainer) User() string {
+	return c.user
+}
+
+// Password returns the password of the user of the broker
+func (c *ArtemisContainer) Password() string {
+	return c.password
+}
+
+// BrokerEndpoint returns the address of the acceptor of the broker, e.g. localhost:49153, accepting all the protocols,
+// e.g. AMQP, OpenWire, STOMP and MQTT
+func (c *ArtemisContainer) BrokerEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, brokerPort)
+	if err != nil {
+		return "", err
+	}
+
+	return host + ":" + mappedPort.Port(), nil
+}
+
+// ConsoleURL returns the URL of the web console of the broker, e.g. http://localhost:49154,
+// which also serves the Jolokia API under /console/jolokia
+func (c *ArtemisContainer) ConsoleURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, consolePort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
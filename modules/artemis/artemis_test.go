@@ -0,0 +1,103 @@
+package artemis
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-stomp/stomp/v3"
+)
+
+func TestArtemis(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithCredentials("test", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// brokerEndpoint {
+	endpoint, err := container.BrokerEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := stomp.Dial("tcp", endpoint, stomp.ConnOpt.Login(container.User(), container.Password()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Disconnect()
+	// }
+
+	sub, err := conn.Subscribe("test", stomp.AckAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := conn.Send("test", "text/plain", []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := <-sub.C
+	if msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+
+	if string(msg.Body) != "hello" {
+		t.Fatalf("expected the message hello, got %s", msg.Body)
+	}
+
+	consoleURL, err := container.ConsoleURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(consoleURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestArtemisWithAnonymousLogin(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartContainer(ctx, WithAnonymousLogin())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	endpoint, err := container.BrokerEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := stomp.Dial("tcp", endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := conn.Disconnect(); err != nil {
+		t.Fatal(err)
+	}
+}
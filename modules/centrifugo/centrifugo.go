@@ -0,0 +1,211 @@
+package centrifugo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "centrifugo/centrifugo:v5.0.4"
+	// }
+
+	defaultAPIKey             = "api-key"
+	defaultTokenHMACSecretKey = "token-hmac-secret-key"
+
+	httpPort = "8000/tcp"
+
+	configContainerPath = "/centrifugo/config.json"
+)
+
+// CentrifugoContainer represents the Centrifugo container type used in the module
+type CentrifugoContainer struct {
+	testcontainers.Container
+	apiKey             string
+	tokenHMACSecretKey string
+}
+
+// Option is a function that configures the Centrifugo module, e.g. the API key or the configuration file.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	apiKey             string
+	tokenHMACSecretKey string
+	configFile         string
+}
+
+// WithAPIKey sets the key used to authorize the requests to the server API of Centrifugo
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithTokenHMACSecretKey sets the secret key used to verify the HMAC SHA-256 signature
+// of the connection tokens of the clients
+func WithTokenHMACSecretKey(secret string) Option {
+	return func(o *options) {
+		o.tokenHMACSecretKey = secret
+	}
+}
+
+// WithConfigFile copies the given Centrifugo configuration file, in JSON format, into the container,
+// and starts Centrifugo with it. The API key and the token HMAC secret key of the module
+// take precedence over the ones defined in the file, as they are passed as environment variables.
+func WithConfigFile(configFile string) Option {
+	return func(o *options) {
+		o.configFile = configFile
+	}
+}
+
+// StartContainer creates an instance of the Centrifugo container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithEnv,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CentrifugoContainer, error) {
+	settings := options{
+		apiKey:             defaultAPIKey,
+		tokenHMACSecretKey: defaultTokenHMACSecretKey,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Cmd:          []string{"centrifugo"},
+		ExposedPorts: []string{httpPort},
+		Env: map[string]string{
+			"CENTRIFUGO_API_KEY":               settings.apiKey,
+			"CENTRIFUGO_TOKEN_HMAC_SECRET_KEY": settings.tokenHMACSecretKey,
+			"CENTRIFUGO_ALLOWED_ORIGINS":       "*",
+			"CENTRIFUGO_HEALTH":                "true",
+		},
+		WaitingFor: wait.ForHTTP("/health").WithPort(httpPort),
+	}
+
+	if settings.configFile != "" {
+		configFile, err := filepath.Abs(settings.configFile)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      configFile,
+			ContainerFilePath: configContainerPath,
+			FileMode:          0o644,
+		})
+		req.Cmd = []string{"centrifugo", "--config", configContainerPath}
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &CentrifugoContainer{
+		Container:          container,
+		apiKey:             settings.apiKey,
+		tokenHMACSecretKey: settings.tokenHMACSecretKey,
+	}, err
+}
+
+// APIKey returns the key used to authorize the requests to the server API, sent in the X-API-Key header
+func (c *CentrifugoContainer) APIKey() string {
+	return c.apiKey
+}
+
+// TokenHMACSecretKey returns the secret key used to sign the connection tokens of the clients
+func (c *CentrifugoContainer) TokenHMACSecretKey() string {
+	return c.tokenHMACSecretKey
+}
+
+// HttpEndpoint returns the HTTP endpoint of Centrifugo, with the format http://<host>:<port>
+func (c *CentrifugoContainer) HttpEndpoint(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, "http", "")
+}
+
+// APIEndpoint returns the endpoint of the server API of Centrifugo, with the format http://<host>:<port>/api
+func (c *CentrifugoContainer) APIEndpoint(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, "http", "/api")
+}
+
+// WebsocketEndpoint returns the endpoint used by the clients to connect to Centrifugo using the websocket transport,
+// with the format ws://<host>:<port>/connection/websocket
+func (c *CentrifugoContainer) WebsocketEndpoint(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, "ws", "/connection/websocket")
+}
+
+// ConnectionToken returns a JWT, signed with the token HMAC secret key of the container, which authenticates
+// a client as the given user when connecting to Centrifugo. The token expires after the given TTL; a TTL of zero
+// creates a token that never expires.
+func (c *CentrifugoContainer) ConnectionToken(user string, ttl time.Duration) (string, error) {
+	claims := map[string]interface{}{
+		"sub": user,
+	}
+	if ttl > 0 {
+		claims["exp"] = time.Now().Add(ttl).Unix()
+	}
+
+	return signToken(claims, c.tokenHMACSecretKey)
+}
+
+func (c *CentrifugoContainer) endpoint(ctx context.Context, scheme string, path string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, nat.Port(httpPort))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://%s:%s%s", scheme, host, mappedPort.Port(), path), nil
+}
+
+// signToken creates a JWT with the given claims, signed using the HS256 algorithm
+func signToken(claims map[string]interface{}, secret string) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,188 @@
+package centrifugo
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCentrifugo(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithAPIKey("my-api-key"),
+		WithTokenHMACSecretKey("my-secret"),
+		WithConfigFile("testdata/config.json"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectWebsocket {
+	endpoint, err := container.WebsocketEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := container.ConnectionToken("anne", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// connect with the token of the user, and subscribe to the news channel
+	sendCommand(t, conn, map[string]interface{}{"id": 1, "connect": map[string]string{"token": token}})
+	sendCommand(t, conn, map[string]interface{}{"id": 2, "subscribe": map[string]string{"channel": "news"}})
+	// }
+
+	waitForReply(t, conn, func(r reply) bool { return r.ID == 2 })
+
+	// publishMessage {
+	apiEndpoint, err := container.APIEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"channel": "news",
+		"data":    map[string]string{"text": "hello"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiEndpoint+"/publish", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", container.APIKey())
+	// }
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	pub := waitForReply(t, conn, func(r reply) bool { return r.Push != nil && r.Push.Channel == "news" })
+	if pub.Push.Pub.Data["text"] != "hello" {
+		t.Fatalf("expected the published message, got %v", pub.Push.Pub.Data)
+	}
+}
+
+func TestConnectionToken(t *testing.T) {
+	container := &CentrifugoContainer{tokenHMACSecretKey: "secret"}
+
+	token, err := container.ConnectionToken("anne", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT with three parts, got %s", token)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		t.Fatal("the signature of the token is not valid")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var claims struct {
+		Sub string `json:"sub"`
+		Exp int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.Sub != "anne" {
+		t.Fatalf("expected the subject to be anne, got %s", claims.Sub)
+	}
+	if claims.Exp <= time.Now().Unix() {
+		t.Fatalf("expected the token to expire in the future, got %d", claims.Exp)
+	}
+}
+
+func sendCommand(t *testing.T, conn *websocket.Conn, command map[string]interface{}) {
+	if err := conn.WriteJSON(command); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// reply represents the replies, and the asynchronous pushes, sent by Centrifugo using the JSON protocol
+type reply struct {
+	ID    int `json:"id"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Push *struct {
+		Channel string `json:"channel"`
+		Pub     struct {
+			Data map[string]string `json:"data"`
+		} `json:"pub"`
+	} `json:"push"`
+}
+
+// waitForReply reads the replies sent by Centrifugo until one of them matches the given function.
+// Centrifugo could send several replies in the same message, delimited by new lines.
+func waitForReply(t *testing.T, conn *websocket.Conn, matches func(reply) bool) reply {
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(message))
+		for decoder.More() {
+			var r reply
+			if err := decoder.Decode(&r); err != nil {
+				t.Fatal(err)
+			}
+
+			if r.Error != nil {
+				t.Fatalf("command %d failed: %d %s", r.ID, r.Error.Code, r.Error.Message)
+			}
+
+			if matches(r) {
+				return r
+			}
+		}
+	}
+}
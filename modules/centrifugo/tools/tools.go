@@ -0,0 +1,11 @@
+//go:build tools
+// +build tools
+
+// This package contains the tool dependencies of the Centrifugo module.
+
+package tools
+
+import (
+	// Register gotestsum for pinning version
+	_ "gotest.tools/gotestsum"
+)
@@ -0,0 +1,191 @@
+package chrony
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	ntpPort = "123/udp"
+
+	// offsetFile is read by libfaketime on every call to the clock, so the offset can be changed at runtime
+	offsetFile = "/etc/faketimerc"
+
+	// dockerfile {
+	dockerfile = `FROM alpine:3.18
+RUN apk add --no-cache chrony libfaketime
+COPY chrony.conf /etc/chrony/chrony.conf
+ENV LD_PRELOAD=/usr/lib/faketime/libfaketime.so.1 \
+    FAKETIME_TIMESTAMP_FILE=` + offsetFile + ` \
+    FAKETIME_NO_CACHE=1 \
+    FAKETIME_DONT_FAKE_MONOTONIC=1
+ENTRYPOINT ["chronyd", "-d", "-x", "-f", "/etc/chrony/chrony.conf"]
+`
+	// }
+
+	// chronyConf serves the clock of the container, shifted by libfaketime, without synchronizing it
+	chronyConf = `local stratum 1
+allow all
+cmdport 0
+`
+)
+
+// ChronyContainer represents the Chrony container type used in the module
+type ChronyContainer struct {
+	testcontainers.Container
+	offset time.Duration
+}
+
+// Option is a function that configures the Chrony module, e.g. the offset of the served time.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	offset time.Duration
+}
+
+// WithOffset sets the offset of the time served by the NTP server, relative to the clock of the Docker host.
+// A negative offset serves a time in the past. By default, there is no offset.
+func WithOffset(offset time.Duration) Option {
+	return func(o *options) {
+		o.offset = offset
+	}
+}
+
+// StartContainer creates an instance of the Chrony container type, an NTP server serving the time of the Docker host
+// shifted by an offset. The image is built from Alpine with chrony and libfaketime, which shifts the clock of chronyd.
+// It accepts the options of the module, and the generic options of the testcontainers package,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ChronyContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	buildContext, err := buildContextArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	req := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			ContextArchive: buildContext,
+		},
+		ExposedPorts: []string{ntpPort},
+		WaitingFor:   wait.ForLog("chronyd version"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	chronyContainer := &ChronyContainer{Container: container}
+
+	// the offset is copied before chronyd starts, so it never serves the time of the host
+	if err := chronyContainer.copyOffset(ctx, settings.offset); err != nil {
+		return chronyContainer, err
+	}
+
+	if err := chronyContainer.Start(ctx); err != nil {
+		return chronyContainer, err
+	}
+
+	return chronyContainer, nil
+}
+
+// buildContextArchive returns the build context of the image, with the Dockerfile and the configuration of chrony
+func buildContextArchive() (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+	tw := tar.NewWriter(buffer)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "Dockerfile", content: dockerfile},
+		{name: "chrony.conf", content: chronyConf},
+	}
+
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name: f.name,
+			Mode: 0o644,
+			Size: int64(len(f.content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer, nil
+}
+
+// formatOffset returns the offset in the format of libfaketime, in seconds with a sign, e.g. +3600.000
+func formatOffset(offset time.Duration) string {
+	return fmt.Sprintf("%+.3f", offset.Seconds())
+}
+
+func (c *ChronyContainer) copyOffset(ctx context.Context, offset time.Duration) error {
+	if err := c.CopyToContainer(ctx, []byte(formatOffset(offset)), offsetFile, 0o644); err != nil {
+		return fmt.Errorf("%w: could not copy the time offset", err)
+	}
+
+	c.offset = offset
+	return nil
+}
+
+// Offset returns the offset of the time served by the NTP server
+func (c *ChronyContainer) Offset() time.Duration {
+	return c.offset
+}
+
+// SetOffset changes the offset of the time served by the NTP server, without restarting it,
+// e.g. to simulate a clock jumping while the components are synchronized
+func (c *ChronyContainer) SetOffset(ctx context.Context, offset time.Duration) error {
+	return c.copyOffset(ctx, offset)
+}
+
+// Address returns the UDP address of the NTP server, with the format host:port
+func (c *ChronyContainer) Address(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, ntpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
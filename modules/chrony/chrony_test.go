@@ -0,0 +1,110 @@
+package chrony
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch, 1900, and the Unix epoch, 1970
+const ntpEpochOffset = 2208988800
+
+// queryTime returns the transmit time of the response of the NTP server, using a SNTP request
+func queryTime(t *testing.T, address string) time.Time {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	// leap indicator 0, version 4, mode 3 (client)
+	request := make([]byte, 48)
+	request[0] = 0x23
+
+	var response []byte
+	// the first packets could be lost while the port is mapped
+	for i := 0; i < 5 && response == nil; i++ {
+		if _, err := conn.Write(request); err != nil {
+			t.Fatal(err)
+		}
+
+		buffer := make([]byte, 48)
+		if _, err := conn.Read(buffer); err == nil {
+			response = buffer
+		}
+	}
+	if response == nil {
+		t.Fatal("no response from the NTP server")
+	}
+
+	seconds := binary.BigEndian.Uint32(response[40:44])
+	fraction := binary.BigEndian.Uint32(response[44:48])
+	nanos := (int64(fraction) * 1e9) >> 32
+
+	return time.Unix(int64(seconds)-ntpEpochOffset, nanos)
+}
+
+func TestChrony(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithOffset(-10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertOffset := func(t *testing.T, want time.Duration) {
+		got := time.Until(queryTime(t, address))
+		if got < want-5*time.Second || got > want+5*time.Second {
+			t.Fatalf("expected an offset of %s, got %s", want, got)
+		}
+	}
+
+	assertOffset(t, -10*time.Minute)
+
+	// setOffset {
+	if err := container.SetOffset(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	assertOffset(t, time.Hour)
+}
+
+func TestFormatOffset(t *testing.T) {
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{offset: 0, want: "+0.000"},
+		{offset: time.Hour, want: "+3600.000"},
+		{offset: -1500 * time.Millisecond, want: "-1.500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatOffset(tt.offset); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
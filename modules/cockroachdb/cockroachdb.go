@@ -0,0 +1,350 @@
+package cockroachdb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/cockroachdb/cockroach:v23.1.11"
+	// }
+
+	sqlPort   = "26257/tcp"
+	adminPort = "8080/tcp"
+
+	rootUser        = "root"
+	defaultDatabase = "defaultdb"
+
+	cockroach = "/cockroach/cockroach"
+	// certsDir and caKey are the directory of the certificates generated in TLS mode, and the key of their CA
+	certsDir = "/cockroach/certs"
+	caKey    = "/cockroach/ca.key"
+)
+
+// ErrPasswordWithoutTLS is returned when a password is set without the TLS mode, as CockroachDB doesn't accept
+// the passwords of the users in insecure mode
+var ErrPasswordWithoutTLS = errors.New("a password can only be set in TLS mode, enabled with the WithTLS option")
+
+// CockroachDBContainer represents the CockroachDB container type used in the module
+type CockroachDBContainer struct {
+	testcontainers.Container
+	user     string
+	password string
+	database string
+	tls      bool
+}
+
+// Option is a function that configures the CockroachDB module, e.g. the database created when it starts.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	user     string
+	password string
+	database string
+	tls      bool
+}
+
+// WithDatabase creates the given database when the container starts. By default, the database is "defaultdb".
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
+
+// WithUser creates the given user when the container starts, with the admin role. By default, the user is "root".
+func WithUser(user string) Option {
+	return func(o *options) {
+		o.user = user
+	}
+}
+
+// WithPassword sets the password of the user, which is only supported in TLS mode.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithTLS starts the node in secure mode, with certificates generated when the container starts:
+// a CA, the certificate of the node for localhost, and the client certificates of root and of the user.
+// The TLSConfig method returns the configuration of the clients trusting the CA, and authenticated by their certificate.
+func WithTLS() Option {
+	return func(o *options) {
+		o.tls = true
+	}
+}
+
+// StartContainer creates an instance of the CockroachDB container type, running a single node in insecure mode,
+// or in secure mode with the WithTLS option. It waits until the node is ready, then creates the database and the user.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CockroachDBContainer, error) {
+	settings := options{
+		user:     rootUser,
+		database: defaultDatabase,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.password != "" && !settings.tls {
+		return nil, ErrPasswordWithoutTLS
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{sqlPort, adminPort},
+		Cmd:          []string{"start-single-node", "--insecure"},
+		WaitingFor:   wait.ForHTTP("/health?ready=1").WithPort(adminPort),
+		Metadata: map[string]string{
+			"username": settings.user,
+			"password": settings.password,
+			"database": settings.database,
+		},
+	}
+
+	if settings.tls {
+		req.Entrypoint = []string{"/bin/bash", "-c", generateCertsScript(settings.user)}
+		req.Cmd = nil
+		req.WaitingFor = wait.ForHTTP("/health?ready=1").WithPort(adminPort).WithTLS(true).WithAllowInsecure(true)
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	c := &CockroachDBContainer{
+		Container: container,
+		user:      settings.user,
+		password:  settings.password,
+		database:  settings.database,
+		tls:       settings.tls,
+	}
+	if err != nil {
+		return c, err
+	}
+
+	if stmts := initStatements(settings); len(stmts) > 0 {
+		if err := c.execSQL(ctx, strings.Join(stmts, "; ")); err != nil {
+			return c, fmt.Errorf("%w: could not create the database %s and the user %s", err, settings.database, settings.user)
+		}
+	}
+
+	return c, nil
+}
+
+// generateCertsScript returns the script generating the certificates of the node and of the clients, root and the given user,
+// before starting the node in secure mode
+func generateCertsScript(user string) string {
+	script := []string{
+		"set -e",
+		cockroach + " cert create-ca --certs-dir=" + certsDir + " --ca-key=" + caKey,
+		cockroach + " cert create-node localhost 127.0.0.1 $(hostname) --certs-dir=" + certsDir + " --ca-key=" + caKey,
+		cockroach + " cert create-client " + rootUser + " --certs-dir=" + certsDir + " --ca-key=" + caKey,
+	}
+
+	if user != rootUser {
+		script = append(script, cockroach+" cert create-client '"+user+"' --certs-dir="+certsDir+" --ca-key="+caKey)
+	}
+
+	return strings.Join(append(script, "exec "+cockroach+" start-single-node --certs-dir="+certsDir), "\n")
+}
+
+// initStatements returns the SQL statements creating the database and the user of the options
+func initStatements(o options) []string {
+	var stmts []string
+
+	if o.database != defaultDatabase {
+		stmts = append(stmts, "CREATE DATABASE IF NOT EXISTS "+quoteIdentifier(o.database))
+	}
+
+	if o.user != rootUser {
+		stmt := "CREATE USER IF NOT EXISTS " + quoteIdentifier(o.user)
+		if o.password != "" {
+			stmt += " WITH PASSWORD " + quoteLiteral(o.password)
+		}
+
+		stmts = append(stmts, stmt, "GRANT admin TO "+quoteIdentifier(o.user))
+	} else if o.password != "" {
+		stmts = append(stmts, "ALTER USER "+rootUser+" WITH PASSWORD "+quoteLiteral(o.password))
+	}
+
+	return stmts
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// execSQL executes the given statements with the SQL client of the image, connected as root
+func (c *CockroachDBContainer) execSQL(ctx context.Context, stmts string) error {
+	cmd := []string{cockroach, "sql", "--insecure", "-e", stmts}
+	if c.tls {
+		cmd = []string{cockroach, "sql", "--certs-dir=" + certsDir, "-e", stmts}
+	}
+
+	code, output, err := c.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		out, _ := io.ReadAll(output)
+		return fmt.Errorf("exit code %d: %s", code, out)
+	}
+
+	return nil
+}
+
+// User returns the user of the database
+func (c *CockroachDBContainer) User() string {
+	return c.user
+}
+
+// Password returns the password of the user, empty if none
+func (c *CockroachDBContainer) Password() string {
+	return c.password
+}
+
+// Database returns the database created when the container started
+func (c *CockroachDBContainer) Database() string {
+	return c.database
+}
+
+// ConnectionString returns the connection string of the database, compatible with pgx and the other PostgreSQL drivers,
+// with the format postgres://<user>:<password>@<host>:<port>/<database>?sslmode=disable. In TLS mode the sslmode is require,
+// i.e. without verifying the certificate of the node: the configuration returned by TLSConfig must be set on the parsed
+// connection string to verify it, or to authenticate with the client certificate.
+func (c *CockroachDBContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, sqlPort)
+	if err != nil {
+		return "", err
+	}
+
+	sslMode := "disable"
+	if c.tls {
+		sslMode = "require"
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.User(c.user),
+		Host:     host + ":" + mappedPort.Port(),
+		Path:     c.database,
+		RawQuery: "sslmode=" + sslMode,
+	}
+	if c.password != "" {
+		u.User = url.UserPassword(c.user, c.password)
+	}
+
+	return u.String(), nil
+}
+
+// TLSConfig returns the configuration of the TLS connections to the node, in TLS mode: it trusts the generated CA,
+// verifies the certificate of the node for localhost whatever the host of the container, and presents
+// the client certificate of the user.
+func (c *CockroachDBContainer) TLSConfig(ctx context.Context) (*tls.Config, error) {
+	if !c.tls {
+		return nil, errors.New("the TLS mode is not enabled, use the WithTLS option")
+	}
+
+	caCert, err := c.readFile(ctx, certsDir+"/ca.crt")
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("invalid CA certificate")
+	}
+
+	clientCert, err := c.readFile(ctx, certsDir+"/client."+c.user+".crt")
+	if err != nil {
+		return nil, err
+	}
+
+	clientKey, err := c.readFile(ctx, certsDir+"/client."+c.user+".key")
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		RootCAs:      certPool,
+		Certificates: []tls.Certificate{cert},
+		ServerName:   "localhost",
+	}, nil
+}
+
+func (c *CockroachDBContainer) readFile(ctx context.Context, path string) ([]byte, error) {
+	reader, err := c.CopyFileFromContainer(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read %s", err, path)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// AdminURL returns the URL of the admin UI of the node, with the format http://<host>:<port>, or https in TLS mode,
+// which also serves the HTTP API of CockroachDB, e.g. /health
+func (c *CockroachDBContainer) AdminURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, adminPort)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := "http"
+	if c.tls {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s:%s", scheme, host, mappedPort.Port()), nil
+}
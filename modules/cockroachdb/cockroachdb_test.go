@@ -0,0 +1,179 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+func TestCockroachDB(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithDatabase("projects"), WithUser("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("pgx", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// }
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE task (id INT PRIMARY KEY, description STRING NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO task VALUES (1, 'write the tests')"); err != nil {
+		t.Fatal(err)
+	}
+
+	var description string
+	if err := db.QueryRowContext(ctx, "SELECT description FROM projects.task WHERE id = 1").Scan(&description); err != nil {
+		t.Fatal(err)
+	}
+
+	if description != "write the tests" {
+		t.Fatalf("expected the inserted task, got %s", description)
+	}
+
+	adminURL, err := container.AdminURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(adminURL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestCockroachDBWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	// withTLS {
+	container, err := StartContainer(ctx, WithTLS(), WithUser("test"), WithPassword("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the password authentication only needs the encryption of the connection, required by the connection string
+	db, err := sql.Open("pgx", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// tlsConfig {
+	config, err := pgx.ParseConfig(connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.TLSConfig, err = container.TLSConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verified := stdlib.OpenDB(*config)
+	defer verified.Close()
+	// }
+
+	if err := verified.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCockroachDBWithPasswordWithoutTLS(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithPassword("secret"))
+	if !errors.Is(err, ErrPasswordWithoutTLS) {
+		t.Fatalf("expected %v, got %v", ErrPasswordWithoutTLS, err)
+	}
+}
+
+func TestInitStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		want     []string
+	}{
+		{
+			name:     "defaults",
+			settings: options{user: rootUser, database: defaultDatabase},
+		},
+		{
+			name:     "database and user",
+			settings: options{user: "test", database: "projects"},
+			want: []string{
+				`CREATE DATABASE IF NOT EXISTS "projects"`,
+				`CREATE USER IF NOT EXISTS "test"`,
+				`GRANT admin TO "test"`,
+			},
+		},
+		{
+			name:     "user with password",
+			settings: options{user: "test", password: "it's secret", database: defaultDatabase},
+			want: []string{
+				`CREATE USER IF NOT EXISTS "test" WITH PASSWORD 'it''s secret'`,
+				`GRANT admin TO "test"`,
+			},
+		},
+		{
+			name:     "root with password",
+			settings: options{user: rootUser, password: "secret", database: defaultDatabase},
+			want:     []string{`ALTER USER root WITH PASSWORD 'secret'`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := initStatements(tt.settings); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
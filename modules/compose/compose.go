@@ -13,9 +13,9 @@ import (
 	"github.com/docker/cli/cli/flags"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/compose"
-	"github.com/google/uuid"
 
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -102,7 +102,7 @@ func NewDockerCompose(filePaths ...string) (*dockerCompose, error) {
 
 func NewDockerComposeWith(opts ...ComposeStackOption) (*dockerCompose, error) {
 	composeOptions := composeStackOptions{
-		Identifier: uuid.New().String(),
+		Identifier: testcontainerssession.NewUUID().String(),
 		Logger:     testcontainers.Logger,
 	}
 
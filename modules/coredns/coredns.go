@@ -0,0 +1,231 @@
+package coredns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "coredns/coredns:1.10.1"
+	// }
+
+	udpPort = "53/udp"
+	tcpPort = "53/tcp"
+
+	corefilePath = "/etc/coredns/Corefile"
+	zonesPath    = "/etc/coredns/zones"
+
+	// forwardBlock resolves the names outside the zones of the module with the resolvers of the container
+	forwardBlock = `.:53 {
+    forward . /etc/resolv.conf
+    log
+    errors
+}
+`
+)
+
+// CoreDNSContainer represents the CoreDNS container type used in the module
+type CoreDNSContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the CoreDNS module, e.g. the zones served by the container.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type zone struct {
+	origin  string
+	content string
+}
+
+type options struct {
+	corefile string
+	zones    []zone
+}
+
+// WithCorefile replaces the Corefile generated by the module with the given content.
+// The zones added with WithZone are still copied to /etc/coredns/zones, so the Corefile can serve them.
+func WithCorefile(corefile string) Option {
+	return func(o *options) {
+		o.corefile = corefile
+	}
+}
+
+// WithZone adds a zone served by the container, with the content of a zone file in the RFC 1035 format.
+// The zone file is copied to /etc/coredns/zones/db.<origin>.
+func WithZone(origin string, content string) Option {
+	return func(o *options) {
+		o.zones = append(o.zones, zone{origin: strings.TrimSuffix(origin, "."), content: content})
+	}
+}
+
+// StartContainer creates an instance of the CoreDNS container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CoreDNSContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{udpPort, tcpPort},
+		Cmd:          []string{"-conf", corefilePath},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("CoreDNS-"),
+			wait.ForListeningPort(tcpPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	// the files are copied before the container is started, so they are read when CoreDNS starts
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsContainer := &CoreDNSContainer{Container: container}
+
+	if err := dnsContainer.CopyToContainer(ctx, []byte(settings.corefileContent()), corefilePath, 0o644); err != nil {
+		return dnsContainer, fmt.Errorf("%w: could not copy the Corefile", err)
+	}
+
+	for _, z := range settings.zones {
+		if err := dnsContainer.CopyToContainer(ctx, []byte(z.content), zoneFilePath(z.origin), 0o644); err != nil {
+			return dnsContainer, fmt.Errorf("%w: could not copy the zone %s", err, z.origin)
+		}
+	}
+
+	if err := dnsContainer.Start(ctx); err != nil {
+		return dnsContainer, err
+	}
+
+	return dnsContainer, nil
+}
+
+func zoneFilePath(origin string) string {
+	return zonesPath + "/db." + origin
+}
+
+// corefileContent returns the Corefile set with WithCorefile, or a Corefile serving the zones of the module,
+// and forwarding the other names to the resolvers of the container
+func (o options) corefileContent() string {
+	if o.corefile != "" {
+		return o.corefile
+	}
+
+	corefile := &strings.Builder{}
+	for _, z := range o.zones {
+		fmt.Fprintf(corefile, "%s:53 {\n    file %s\n    log\n    errors\n}\n", z.origin, zoneFilePath(z.origin))
+	}
+	corefile.WriteString(forwardBlock)
+
+	return corefile.String()
+}
+
+// UDPPort returns the UDP port of the DNS server mapped on the host
+func (c *CoreDNSContainer) UDPPort(ctx context.Context) (nat.Port, error) {
+	return c.MappedPort(ctx, udpPort)
+}
+
+// TCPPort returns the TCP port of the DNS server mapped on the host
+func (c *CoreDNSContainer) TCPPort(ctx context.Context) (nat.Port, error) {
+	return c.MappedPort(ctx, tcpPort)
+}
+
+// Address returns the address of the DNS server for the given network, "udp" or "tcp", with the format host:port,
+// e.g. to be used by the Dial function of a net.Resolver
+func (c *CoreDNSContainer) Address(ctx context.Context, network string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, nat.Port("53/"+network))
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// Resolver returns a resolver sending the queries of the Go code running on the host to the DNS server
+func (c *CoreDNSContainer) Resolver(ctx context.Context) (*net.Resolver, error) {
+	address, err := c.Address(ctx, "udp")
+	if err != nil {
+		return nil, err
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			// the resolver switches to TCP for the truncated responses
+			if strings.HasPrefix(network, "tcp") {
+				tcpAddress, err := c.Address(ctx, "tcp")
+				if err != nil {
+					return nil, err
+				}
+				return dialer.DialContext(ctx, network, tcpAddress)
+			}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}, nil
+}
+
+// WithResolver returns an option pointing the DNS of another container at the DNS server, using its IP address.
+// Both containers must share a network, e.g. the default bridge network. The DNS server is added to the host config
+// after calling the HostConfigModifier of the request, if any.
+func (c *CoreDNSContainer) WithResolver(ctx context.Context) (testcontainers.CustomizeRequestOption, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip == "" {
+		return nil, fmt.Errorf("the DNS server has no IP address in a single network")
+	}
+
+	return func(req *testcontainers.GenericContainerRequest) {
+		modifier := req.HostConfigModifier
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			if modifier != nil {
+				modifier(hostConfig)
+			} else {
+				// the default modifier is only used by the requests without one, so its fields are set here
+				hostConfig.AutoRemove = req.AutoRemove
+				hostConfig.CapAdd = req.CapAdd
+				hostConfig.CapDrop = req.CapDrop
+				hostConfig.Binds = req.Binds
+				hostConfig.ExtraHosts = req.ExtraHosts
+				hostConfig.NetworkMode = req.NetworkMode
+				hostConfig.Resources = req.Resources
+			}
+			hostConfig.DNS = append(hostConfig.DNS, ip)
+		}
+	}, nil
+}
@@ -0,0 +1,131 @@
+package coredns
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const exampleZone = `$ORIGIN example.test.
+@   3600 IN SOA ns.example.test. admin.example.test. 1 7200 3600 1209600 3600
+www 3600 IN A   10.1.2.3
+api 3600 IN A   10.1.2.4
+`
+
+func TestCoreDNS(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithZone("example.test", exampleZone))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// resolver {
+	resolver, err := container.Resolver(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addrs, err := resolver.LookupHost(ctx, "www.example.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if len(addrs) != 1 || addrs[0] != "10.1.2.3" {
+		t.Fatalf("expected 10.1.2.3, got %v", addrs)
+	}
+
+	t.Run("other containers", func(t *testing.T) {
+		// withResolver {
+		withResolver, err := container.WithResolver(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "alpine:3.17",
+				Cmd:        []string{"nslookup", "api.example.test"},
+				WaitingFor: wait.ForExit(),
+			},
+			Started: true,
+		}
+		withResolver.Customize(&req)
+
+		client, err := testcontainers.GenericContainer(ctx, req)
+		// }
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := client.Terminate(ctx); err != nil {
+				t.Fatalf("failed to terminate container: %s", err)
+			}
+		})
+
+		logs, err := client.Logs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer logs.Close()
+
+		output, err := io.ReadAll(logs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(string(output), "10.1.2.4") {
+			t.Fatalf("expected the address of api.example.test, got %s", output)
+		}
+	})
+}
+
+func TestCorefileContent(t *testing.T) {
+	settings := options{
+		zones: []zone{{origin: "example.test"}, {origin: "internal"}},
+	}
+
+	want := `example.test:53 {
+    file /etc/coredns/zones/db.example.test
+    log
+    errors
+}
+internal:53 {
+    file /etc/coredns/zones/db.internal
+    log
+    errors
+}
+` + forwardBlock
+
+	if got := settings.corefileContent(); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	settings.corefile = ".:53 {\n    whoami\n}\n"
+	if got := settings.corefileContent(); got != settings.corefile {
+		t.Fatalf("expected the custom Corefile, got %s", got)
+	}
+}
+
+func TestWithZone(t *testing.T) {
+	settings := options{}
+	WithZone("example.test.", exampleZone)(&settings)
+
+	if settings.zones[0].origin != "example.test" {
+		t.Fatalf("expected the origin without the trailing dot, got %s", settings.zones[0].origin)
+	}
+}
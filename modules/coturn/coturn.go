@@ -0,0 +1,217 @@
+package coturn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "coturn/coturn:4.6.2"
+	// }
+
+	listeningPort = 3478
+	udpPort       = "3478/udp"
+	tcpPort       = "3478/tcp"
+
+	defaultRealm      = "testcontainers.org"
+	defaultUsername   = "test"
+	defaultPassword   = "test"
+	defaultExternalIP = "127.0.0.1"
+	defaultMinPort    = 49160
+	defaultMaxPort    = 49169
+)
+
+// ErrInvalidRelayPortRange is returned when the relay port range is empty or out of the range of the ports
+var ErrInvalidRelayPortRange = errors.New("invalid relay port range")
+
+// CoturnContainer represents the Coturn container type used in the module
+type CoturnContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// Option is a function that configures the Coturn module, e.g. the realm and the credentials.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	realm      string
+	username   string
+	password   string
+	externalIP string
+	minPort    int
+	maxPort    int
+}
+
+// WithRealm sets the realm of the long-term credentials. By default, the realm is "testcontainers.org".
+func WithRealm(realm string) Option {
+	return func(o *options) {
+		o.realm = realm
+	}
+}
+
+// WithCredentials sets the user of the long-term credentials, and its password. By default, both are "test".
+func WithCredentials(username string, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithRelayPortRange sets the range of the UDP ports allocated for the relayed addresses, both included.
+// The ports are published with the same port on the host, so the relayed addresses are reachable.
+// By default, the range is 49160-49169.
+func WithRelayPortRange(minPort int, maxPort int) Option {
+	return func(o *options) {
+		o.minPort = minPort
+		o.maxPort = maxPort
+	}
+}
+
+// WithExternalIP sets the IP address of the relayed addresses, which must reach the Docker host from the clients.
+// By default, the IP address is 127.0.0.1, for the clients running on the same host as the Docker daemon.
+func WithExternalIP(ip string) Option {
+	return func(o *options) {
+		o.externalIP = ip
+	}
+}
+
+// StartContainer creates an instance of the Coturn container type, a TURN and STUN server using long-term credentials
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CoturnContainer, error) {
+	settings := options{
+		realm:      defaultRealm,
+		username:   defaultUsername,
+		password:   defaultPassword,
+		externalIP: defaultExternalIP,
+		minPort:    defaultMinPort,
+		maxPort:    defaultMaxPort,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.minPort <= 0 || settings.maxPort > 65535 || settings.minPort > settings.maxPort {
+		return nil, fmt.Errorf("%w: %d-%d", ErrInvalidRelayPortRange, settings.minPort, settings.maxPort)
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image: defaultImage,
+		ExposedPorts: []string{
+			udpPort,
+			tcpPort,
+			// the relayed addresses are advertised with the ports of the container, so they're published on the same ports of the host
+			fmt.Sprintf("%d-%d:%d-%d/udp", settings.minPort, settings.maxPort, settings.minPort, settings.maxPort),
+		},
+		Cmd:        settings.cmd(),
+		WaitingFor: wait.ForListeningPort(tcpPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &CoturnContainer{Container: container, settings: settings}, err
+}
+
+func (o options) cmd() []string {
+	return []string{
+		"-n",
+		"--log-file=stdout",
+		"--no-cli",
+		"--no-tls",
+		"--no-dtls",
+		"--fingerprint",
+		"--lt-cred-mech",
+		"--listening-port=" + strconv.Itoa(listeningPort),
+		"--realm=" + o.realm,
+		"--user=" + o.username + ":" + o.password,
+		"--external-ip=" + o.externalIP,
+		"--min-port=" + strconv.Itoa(o.minPort),
+		"--max-port=" + strconv.Itoa(o.maxPort),
+	}
+}
+
+// Realm returns the realm of the long-term credentials
+func (c *CoturnContainer) Realm() string {
+	return c.settings.realm
+}
+
+// Username returns the user of the long-term credentials
+func (c *CoturnContainer) Username() string {
+	return c.settings.username
+}
+
+// Password returns the password of the user
+func (c *CoturnContainer) Password() string {
+	return c.settings.password
+}
+
+// RelayPortRange returns the range of the UDP ports allocated for the relayed addresses, both included
+func (c *CoturnContainer) RelayPortRange() (int, int) {
+	return c.settings.minPort, c.settings.maxPort
+}
+
+// Address returns the address of the server for the given network, "udp" or "tcp", with the format host:port
+func (c *CoturnContainer) Address(ctx context.Context, network string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, tcpPort)
+	if network == "udp" {
+		port, err = c.MappedPort(ctx, udpPort)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// STUNURL returns the URL of the STUN server, e.g. stun:localhost:49153, for the ICE servers of a WebRTC peer
+func (c *CoturnContainer) STUNURL(ctx context.Context) (string, error) {
+	address, err := c.Address(ctx, "udp")
+	if err != nil {
+		return "", err
+	}
+
+	return "stun:" + address, nil
+}
+
+// TURNURL returns the URL of the TURN server for the given transport, "udp" or "tcp",
+// e.g. turn:localhost:49153?transport=udp, for the ICE servers of a WebRTC peer
+func (c *CoturnContainer) TURNURL(ctx context.Context, transport string) (string, error) {
+	address, err := c.Address(ctx, transport)
+	if err != nil {
+		return "", err
+	}
+
+	return "turn:" + address + "?transport=" + transport, nil
+}
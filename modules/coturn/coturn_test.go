@@ -0,0 +1,124 @@
+package coturn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/pion/logging"
+	"github.com/pion/turn/v2"
+)
+
+func TestCoturn(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithRealm("example.org"),
+		WithCredentials("alice", "secret"),
+		WithRelayPortRange(49170, 49179),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// allocate {
+	address, err := container.Address(ctx, "udp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.ListenPacket("udp4", "0.0.0.0:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client, err := turn.NewClient(&turn.ClientConfig{
+		STUNServerAddr: address,
+		TURNServerAddr: address,
+		Conn:           conn,
+		Username:       container.Username(),
+		Password:       container.Password(),
+		Realm:          container.Realm(),
+		LoggerFactory:  logging.NewDefaultLoggerFactory(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Listen(); err != nil {
+		t.Fatal(err)
+	}
+
+	relayConn, err := client.Allocate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer relayConn.Close()
+	// }
+
+	relayed := relayConn.LocalAddr().(*net.UDPAddr)
+	minPort, maxPort := container.RelayPortRange()
+	if relayed.Port < minPort || relayed.Port > maxPort {
+		t.Fatalf("expected a relay port between %d and %d, got %d", minPort, maxPort, relayed.Port)
+	}
+
+	if !relayed.IP.Equal(net.ParseIP(defaultExternalIP)) {
+		t.Fatalf("expected the external IP %s, got %s", defaultExternalIP, relayed.IP)
+	}
+
+	// the STUN binding returns the address of the client seen by the server
+	if _, err := client.SendBindingRequest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCoturnWithInvalidRelayPortRange(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithRelayPortRange(50000, 49000))
+	if !errors.Is(err, ErrInvalidRelayPortRange) {
+		t.Fatalf("expected %v, got %v", ErrInvalidRelayPortRange, err)
+	}
+}
+
+func TestCmd(t *testing.T) {
+	settings := options{
+		realm:      "example.org",
+		username:   "alice",
+		password:   "secret",
+		externalIP: "10.0.0.1",
+		minPort:    49160,
+		maxPort:    49161,
+	}
+
+	want := []string{
+		"-n",
+		"--log-file=stdout",
+		"--no-cli",
+		"--no-tls",
+		"--no-dtls",
+		"--fingerprint",
+		"--lt-cred-mech",
+		"--listening-port=3478",
+		"--realm=example.org",
+		"--user=alice:secret",
+		"--external-ip=10.0.0.1",
+		"--min-port=49160",
+		"--max-port=49161",
+	}
+
+	if got := settings.cmd(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
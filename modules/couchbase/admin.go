@@ -0,0 +1,175 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// AdminClient manages the cluster of a Couchbase container through the REST API of Couchbase Server,
+// authenticated as the administrator, e.g. to change the settings of the cluster in the middle of a test.
+type AdminClient struct {
+	container *CouchbaseContainer
+}
+
+// BucketStats are the basic statistics of a bucket
+type BucketStats struct {
+	// ItemCount is the number of documents in the bucket
+	ItemCount int64
+	// MemUsed is the memory used by the bucket, in bytes
+	MemUsed int64
+	// DiskUsed is the disk space used by the bucket, in bytes
+	DiskUsed int64
+	// OpsPerSec is the number of operations per second on the bucket
+	OpsPerSec float64
+}
+
+// Admin returns the client managing the cluster of the container.
+func (c *CouchbaseContainer) Admin() *AdminClient {
+	return &AdminClient{container: c}
+}
+
+// Buckets returns the names of the buckets of the cluster.
+func (a *AdminClient) Buckets(ctx context.Context) ([]string, error) {
+	response, err := a.request(ctx, http.MethodGet, "/pools/default/buckets", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, b := range gjson.ParseBytes(response).Array() {
+		names = append(names, b.Get("name").String())
+	}
+
+	return names, nil
+}
+
+// CreateBucket creates the bucket and waits until it's ready to be used by all the services, like the buckets
+// of the WithBucket option, so it can also be reset between the tests with ResetBucketBetweenTests.
+func (a *AdminClient) CreateBucket(ctx context.Context, bucket bucket) error {
+	c := a.container
+	if _, exists := c.bucket(bucket.name); exists {
+		return fmt.Errorf("bucket %s already exists", bucket.name)
+	}
+
+	if err := bucket.validate(c.config.version, c.config.isEnterprise); err != nil {
+		return err
+	}
+
+	if err := c.setupBucket(ctx, bucket); err != nil {
+		return err
+	}
+
+	c.config.buckets = append(c.config.buckets, bucket)
+
+	return nil
+}
+
+// DeleteBucket deletes the bucket and all its documents.
+func (a *AdminClient) DeleteBucket(ctx context.Context, name string) error {
+	if _, err := a.request(ctx, http.MethodDelete, "/pools/default/buckets/"+url.PathEscape(name), nil); err != nil {
+		return err
+	}
+
+	c := a.container
+	for i, b := range c.config.buckets {
+		if b.name == name {
+			c.config.buckets = append(c.config.buckets[:i], c.config.buckets[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// FlushBucket deletes all the documents of the bucket, which must have been created with flush enabled.
+func (a *AdminClient) FlushBucket(ctx context.Context, name string) error {
+	_, err := a.request(ctx, http.MethodPost, "/pools/default/buckets/"+url.PathEscape(name)+"/controller/doFlush", nil)
+
+	return err
+}
+
+// CompactBucket starts the compaction of the bucket, which runs in the background.
+func (a *AdminClient) CompactBucket(ctx context.Context, name string) error {
+	_, err := a.request(ctx, http.MethodPost, "/pools/default/buckets/"+url.PathEscape(name)+"/controller/compactBucket", nil)
+
+	return err
+}
+
+// BucketStats returns the basic statistics of the bucket.
+func (a *AdminClient) BucketStats(ctx context.Context, name string) (BucketStats, error) {
+	response, err := a.request(ctx, http.MethodGet, "/pools/default/buckets/"+url.PathEscape(name), nil)
+	if err != nil {
+		return BucketStats{}, err
+	}
+
+	stats := gjson.GetBytes(response, "basicStats")
+
+	return BucketStats{
+		ItemCount: stats.Get("itemCount").Int(),
+		MemUsed:   stats.Get("memUsed").Int(),
+		DiskUsed:  stats.Get("diskUsed").Int(),
+		OpsPerSec: stats.Get("opsPerSec").Float(),
+	}, nil
+}
+
+// UpsertUser creates or updates a local user with the given roles, e.g. bucket_full_access[testBucket],
+// to test the code with the permissions it runs with in production.
+func (a *AdminClient) UpsertUser(ctx context.Context, username, password string, roles ...string) error {
+	body := map[string]string{
+		"password": password,
+		"roles":    strings.Join(roles, ","),
+	}
+
+	_, err := a.request(ctx, http.MethodPut, "/settings/rbac/users/local/"+url.PathEscape(username), body)
+
+	return err
+}
+
+// DeleteUser deletes a local user.
+func (a *AdminClient) DeleteUser(ctx context.Context, username string) error {
+	_, err := a.request(ctx, http.MethodDelete, "/settings/rbac/users/local/"+url.PathEscape(username), nil)
+
+	return err
+}
+
+// SetAutoCompaction sets the fragmentation of the data, in percent, triggering the compaction of the buckets,
+// from 2 to 100.
+func (a *AdminClient) SetAutoCompaction(ctx context.Context, fragmentationPercent int) error {
+	if fragmentationPercent < 2 || fragmentationPercent > 100 {
+		return fmt.Errorf("the fragmentation triggering the compaction must be between 2 and 100 percent, got %d", fragmentationPercent)
+	}
+
+	body := map[string]string{
+		"databaseFragmentationThreshold[percentage]": strconv.Itoa(fragmentationPercent),
+		"parallelDBAndViewCompaction":                "false",
+	}
+
+	return a.container.postSettings(ctx, "/controller/setAutoCompaction", body)
+}
+
+// UpdateSettings posts the given settings to an endpoint of the REST API of Couchbase Server,
+// e.g. /settings/querySettings, for the settings without a dedicated method.
+func (a *AdminClient) UpdateSettings(ctx context.Context, path string, settings map[string]string) error {
+	return a.container.postSettings(ctx, path, settings)
+}
+
+// request sends a request to the management port, returning the error reported by Couchbase Server
+// if the response is not successful
+func (a *AdminClient) request(ctx context.Context, method, path string, body map[string]string) ([]byte, error) {
+	response, status, err := a.container.doHttpRequestWithStatus(ctx, MGMT_PORT, path, method, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s %s: unexpected status code %d: %s", method, path, status, strings.TrimSpace(string(response)))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,24 @@
+package couchbase
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSetAutoCompactionValidation(t *testing.T) {
+	admin := (&CouchbaseContainer{config: &Config{}}).Admin()
+
+	for _, percent := range []int{0, 1, 101} {
+		if err := admin.SetAutoCompaction(context.Background(), percent); err == nil {
+			t.Errorf("expected the fragmentation of %d percent to be rejected", percent)
+		}
+	}
+}
+
+func TestCreateExistingBucket(t *testing.T) {
+	admin := (&CouchbaseContainer{config: &Config{buckets: []bucket{NewBucket("test")}}}).Admin()
+
+	if err := admin.CreateBucket(context.Background(), NewBucket("test")); err == nil {
+		t.Fatal("expected the existing bucket to be rejected")
+	}
+}
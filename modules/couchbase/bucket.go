@@ -1,11 +1,49 @@
 package couchbase
 
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// storageBackend is the storage engine persisting the data of a bucket.
+type storageBackend string
+
+// storageBackends {
+const (
+	// CouchStore is the storage engine of the buckets by default, suited to the datasets fitting in memory.
+	CouchStore storageBackend = "couchstore"
+
+	// Magma is the storage engine designed for the datasets much larger than the memory, and the only one
+	// supporting the change history of the documents.
+	// Only available in the Enterprise Edition of Couchbase Server 7.1 or later.
+	Magma storageBackend = "magma"
+)
+
+// }
+
+// minimumHistoryRetentionBytes is the minimum size of the change history of a bucket, when it's limited by size
+const minimumHistoryRetentionBytes = 2 * 1024 * 1024 * 1024
+
 type bucket struct {
 	name              string
 	flushEnabled      bool
 	queryPrimaryIndex bool
 	quota             int
 	numReplicas       int
+	storageBackend    storageBackend
+	// historyRetention is the configuration of the change history, only applied to the buckets enabling it
+	historyRetention *historyRetention
+	// rank is the priority of the bucket when the node is warming up or failing over, 0 if not set
+	rank int
+}
+
+// historyRetention is the configuration of the change history of a bucket, available for the magma buckets
+// of Couchbase Server 7.2 or later.
+type historyRetention struct {
+	collectionDefault bool
+	bytes             int64
+	duration          time.Duration
 }
 
 // NewBucket creates a new bucket with the given name, using default values for all other fields.
@@ -52,3 +90,103 @@ func (b bucket) WithPrimaryIndex(primaryIndex bool) bucket {
 	b.queryPrimaryIndex = primaryIndex
 	return b
 }
+
+// WithStorageBackend sets the storage engine of the bucket, CouchStore by default.
+// The Magma engine is only available in the Enterprise Edition of Couchbase Server 7.1 or later,
+// requiring a quota of at least 1024 MB before Couchbase Server 7.6.
+func (b bucket) WithStorageBackend(backend storageBackend) bucket {
+	b.storageBackend = backend
+	return b
+}
+
+// WithHistoryRetention enables the change history of the bucket, retaining the mutations of the documents
+// until the history exceeds the given size in bytes or the given duration, 0 meaning no limit. The size must be 0,
+// or at least 2 GiB. The collections of the bucket retain their history by default.
+// Only available for the Magma buckets of Couchbase Server 7.2 or later.
+func (b bucket) WithHistoryRetention(bytes int64, duration time.Duration) bucket {
+	b.historyRetention = &historyRetention{
+		collectionDefault: true,
+		bytes:             bytes,
+		duration:          duration,
+	}
+	return b
+}
+
+// WithRank sets the rank of the bucket, from 0 to 1000: the buckets with a higher rank are warmed up and failed over first.
+// Only available in Couchbase Server 7.6 or later.
+func (b bucket) WithRank(rank int) bucket {
+	if rank < 0 {
+		rank = 0
+	} else if rank > 1000 {
+		rank = 1000
+	}
+
+	b.rank = rank
+	return b
+}
+
+// validate checks that the settings of the bucket are supported by the edition and the version of Couchbase Server
+func (b bucket) validate(version serverVersion, isEnterprise bool) error {
+	if b.storageBackend == Magma {
+		if !isEnterprise || !version.atLeast(7, 1) {
+			return fmt.Errorf("bucket %s: the magma storage backend is only supported with the Enterprise version 7.1 or later, got %s", b.name, version)
+		}
+
+		if !version.atLeast(7, 6) && b.quota < 1024 {
+			return fmt.Errorf("bucket %s: the magma storage backend requires a quota of at least 1024 MB before version 7.6, got %d MB", b.name, b.quota)
+		}
+	}
+
+	if b.historyRetention != nil {
+		if b.storageBackend != Magma {
+			return fmt.Errorf("bucket %s: the history retention is only supported with the magma storage backend", b.name)
+		}
+
+		if !version.atLeast(7, 2) {
+			return fmt.Errorf("bucket %s: the history retention is only supported with version 7.2 or later, got %s", b.name, version)
+		}
+
+		if b.historyRetention.bytes != 0 && b.historyRetention.bytes < minimumHistoryRetentionBytes {
+			return fmt.Errorf("bucket %s: the history retention size must be 0 or at least %d bytes, got %d", b.name, minimumHistoryRetentionBytes, b.historyRetention.bytes)
+		}
+	}
+
+	if b.rank != 0 && !version.atLeast(7, 6) {
+		return fmt.Errorf("bucket %s: the rank is only supported with version 7.6 or later, got %s", b.name, version)
+	}
+
+	return nil
+}
+
+// form returns the parameters creating the bucket with the /pools/default/buckets endpoint, valid for the given version
+// of Couchbase Server: the storage backend is only sent from version 7.0, as CouchStore is the only engine before it
+func (b bucket) form(version serverVersion) map[string]string {
+	flushEnabled := "0"
+	if b.flushEnabled {
+		flushEnabled = "1"
+	}
+
+	body := map[string]string{
+		"name":          b.name,
+		"bucketType":    "couchbase",
+		"ramQuotaMB":    strconv.Itoa(b.quota),
+		"flushEnabled":  flushEnabled,
+		"replicaNumber": strconv.Itoa(b.numReplicas),
+	}
+
+	if b.storageBackend != "" && version.atLeast(7, 0) {
+		body["storageBackend"] = string(b.storageBackend)
+	}
+
+	if b.historyRetention != nil {
+		body["historyRetentionCollectionDefault"] = strconv.FormatBool(b.historyRetention.collectionDefault)
+		body["historyRetentionBytes"] = strconv.FormatInt(b.historyRetention.bytes, 10)
+		body["historyRetentionSeconds"] = strconv.Itoa(int(b.historyRetention.duration.Seconds()))
+	}
+
+	if b.rank != 0 {
+		body["rank"] = strconv.Itoa(b.rank)
+	}
+
+	return body
+}
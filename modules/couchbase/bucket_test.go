@@ -0,0 +1,162 @@
+package couchbase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	version, err := parseServerVersion("7.2.0-5325-enterprise")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != (serverVersion{major: 7, minor: 2, patch: 0}) {
+		t.Fatalf("expected version 7.2.0, got %s", version)
+	}
+
+	if _, err := parseServerVersion("unknown"); err == nil {
+		t.Fatal("expected an error for an invalid version")
+	}
+}
+
+func TestImageVersion(t *testing.T) {
+	tests := []struct {
+		image        string
+		version      serverVersion
+		isEnterprise bool
+		known        bool
+	}{
+		{image: "couchbase:6.5.1", version: serverVersion{major: 6, minor: 5, patch: 1}, isEnterprise: true, known: true},
+		{image: "couchbase:community-7.1.1", version: serverVersion{major: 7, minor: 1, patch: 1}, known: true},
+		{image: "couchbase/server:enterprise-7.2.0", version: serverVersion{major: 7, minor: 2, patch: 0}, isEnterprise: true, known: true},
+		{image: "localhost:5000/couchbase:7.6.1", version: serverVersion{major: 7, minor: 6, patch: 1}, isEnterprise: true, known: true},
+		{image: "couchbase:latest"},
+		{image: "couchbase"},
+		{image: "localhost:5000/couchbase"},
+		{image: "couchbase@sha256:0123456789abcdef"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			version, isEnterprise, known := imageVersion(tt.image)
+			if version != tt.version || isEnterprise != tt.isEnterprise || known != tt.known {
+				t.Fatalf("expected %s, enterprise %t, known %t, got %s, enterprise %t, known %t", tt.version, tt.isEnterprise, tt.known, version, isEnterprise, known)
+			}
+		})
+	}
+}
+
+func TestBucketForm(t *testing.T) {
+	b := NewBucket("test").WithStorageBackend(CouchStore).WithFlushEnabled(true)
+
+	form := b.form(serverVersion{major: 7, minor: 2, patch: 0})
+	if form["storageBackend"] != "couchstore" || form["flushEnabled"] != "1" || form["ramQuotaMB"] != "100" {
+		t.Fatalf("expected the storage backend, the flush and the quota of the bucket, got %v", form)
+	}
+
+	form = b.form(serverVersion{major: 6, minor: 6, patch: 0})
+	if _, ok := form["storageBackend"]; ok {
+		t.Fatalf("expected no storage backend before version 7.0, got %v", form)
+	}
+}
+
+func TestBucketValidate(t *testing.T) {
+	v71 := serverVersion{major: 7, minor: 1, patch: 3}
+	v72 := serverVersion{major: 7, minor: 2, patch: 0}
+	v76 := serverVersion{major: 7, minor: 6, patch: 1}
+
+	tests := []struct {
+		name         string
+		bucket       bucket
+		version      serverVersion
+		isEnterprise bool
+		wantErr      bool
+	}{
+		{
+			name:         "couchstore",
+			bucket:       NewBucket("test"),
+			version:      v71,
+			isEnterprise: false,
+		},
+		{
+			name:         "magma",
+			bucket:       NewBucket("test").WithStorageBackend(Magma).WithQuota(1024),
+			version:      v71,
+			isEnterprise: true,
+		},
+		{
+			name:         "magma with community edition",
+			bucket:       NewBucket("test").WithStorageBackend(Magma).WithQuota(1024),
+			version:      v72,
+			isEnterprise: false,
+			wantErr:      true,
+		},
+		{
+			name:         "magma with small quota",
+			bucket:       NewBucket("test").WithStorageBackend(Magma),
+			version:      v72,
+			isEnterprise: true,
+			wantErr:      true,
+		},
+		{
+			name:         "magma with small quota since 7.6",
+			bucket:       NewBucket("test").WithStorageBackend(Magma),
+			version:      v76,
+			isEnterprise: true,
+		},
+		{
+			name:         "history retention",
+			bucket:       NewBucket("test").WithStorageBackend(Magma).WithQuota(1024).WithHistoryRetention(0, time.Hour),
+			version:      v72,
+			isEnterprise: true,
+		},
+		{
+			name:         "history retention before 7.2",
+			bucket:       NewBucket("test").WithStorageBackend(Magma).WithQuota(1024).WithHistoryRetention(0, time.Hour),
+			version:      v71,
+			isEnterprise: true,
+			wantErr:      true,
+		},
+		{
+			name:         "history retention without magma",
+			bucket:       NewBucket("test").WithHistoryRetention(0, time.Hour),
+			version:      v72,
+			isEnterprise: true,
+			wantErr:      true,
+		},
+		{
+			name:         "history retention with small size",
+			bucket:       NewBucket("test").WithStorageBackend(Magma).WithQuota(1024).WithHistoryRetention(1024, 0),
+			version:      v72,
+			isEnterprise: true,
+			wantErr:      true,
+		},
+		{
+			name:         "rank",
+			bucket:       NewBucket("test").WithRank(10),
+			version:      v76,
+			isEnterprise: true,
+		},
+		{
+			name:         "rank before 7.6",
+			bucket:       NewBucket("test").WithRank(10),
+			version:      v72,
+			isEnterprise: true,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bucket.validate(tt.version, tt.isEnterprise)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// CNG_PORT is the port of the data API of the Couchbase cloud native gateway, using gRPC over TLS
+	CNG_PORT = "18098"
+	// CNG_SD_PORT is the port of the service discovery API of the Couchbase cloud native gateway
+	CNG_SD_PORT = "18099"
+
+	// defaultGatewayImage {
+	cloudNativeGatewayImage = "ghcr.io/couchbase/stellar-gateway:v1.1.0"
+	// }
+
+	gatewayCertPath = "/certs/cert.pem"
+	gatewayKeyPath  = "/certs/key.pem"
+)
+
+// ErrCloudNativeGatewayNotEnabled is returned when the cloud native gateway is used but it was not enabled in the container
+var ErrCloudNativeGatewayNotEnabled = errors.New("the cloud native gateway is not enabled, please use the WithCloudNativeGateway option")
+
+type cloudNativeGateway struct {
+	enabled  bool
+	certFile string
+	keyFile  string
+}
+
+// WithCloudNativeGateway starts the Couchbase cloud native gateway alongside the Couchbase container,
+// exposing the couchbase2:// protocol used by Couchbase Capella. By default, the gateway uses a self-signed certificate.
+func WithCloudNativeGateway() Option {
+	return func(c *Config) {
+		c.cloudNativeGateway.enabled = true
+	}
+}
+
+// WithCloudNativeGatewayCertificates starts the Couchbase cloud native gateway, using the given TLS certificate
+// and private key files, in PEM format, instead of a self-signed certificate.
+func WithCloudNativeGatewayCertificates(certFile string, keyFile string) Option {
+	return func(c *Config) {
+		c.cloudNativeGateway.enabled = true
+		c.cloudNativeGateway.certFile = certFile
+		c.cloudNativeGateway.keyFile = keyFile
+	}
+}
+
+// CloudNativeGatewayConnectionString returns the connection string to connect to the cloud native gateway.
+// It returns a string with the format couchbase2://<host>:<port>
+func (c *CouchbaseContainer) CloudNativeGatewayConnectionString(ctx context.Context) (string, error) {
+	if c.gateway == nil {
+		return "", ErrCloudNativeGatewayNotEnabled
+	}
+
+	host, err := c.gateway.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.gateway.MappedPort(ctx, CNG_PORT)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("couchbase2://%s:%d", host, port.Int()), nil
+}
+
+// Terminate terminates the cloud native gateway, if enabled, and the Couchbase container
+func (c *CouchbaseContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	if c.gateway != nil {
+		if err := c.gateway.Terminate(ctx, opts...); err != nil {
+			return fmt.Errorf("%w: could not terminate the cloud native gateway", err)
+		}
+	}
+
+	return c.Container.Terminate(ctx, opts...)
+}
+
+// startCloudNativeGateway starts the cloud native gateway container, connected to the Couchbase container
+// through its internal IP address
+func (c *CouchbaseContainer) startCloudNativeGateway(ctx context.Context) error {
+	ipAddress, err := c.getInternalIPAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	gw := c.config.cloudNativeGateway
+
+	cmd := []string{
+		"--cbs-host=" + ipAddress,
+		"--cbs-user=" + c.config.username,
+		"--cbs-pass=" + c.config.password,
+	}
+
+	var files []testcontainers.ContainerFile
+	if gw.certFile != "" && gw.keyFile != "" {
+		cmd = append(cmd, "--cert="+gatewayCertPath, "--key="+gatewayKeyPath)
+		files = append(files,
+			testcontainers.ContainerFile{HostFilePath: gw.certFile, ContainerFilePath: gatewayCertPath, FileMode: 0o644},
+			testcontainers.ContainerFile{HostFilePath: gw.keyFile, ContainerFilePath: gatewayKeyPath, FileMode: 0o600},
+		)
+	} else {
+		cmd = append(cmd, "--self-sign")
+	}
+
+	waitForGateway := wait.ForListeningPort(CNG_PORT + "/tcp")
+	// the port is also checked inside the container, which needs the execution of commands
+	if capabilities, err := testcontainers.ProviderDefault.Capabilities(ctx); err == nil && !capabilities.Has(testcontainers.CapabilityExec) {
+		waitForGateway = waitForGateway.SkipInternalCheck()
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        cloudNativeGatewayImage,
+		Cmd:          cmd,
+		Files:        files,
+		ExposedPorts: []string{CNG_PORT + "/tcp", CNG_SD_PORT + "/tcp"},
+		WaitingFor:   waitForGateway,
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	// the gateway is terminated with the Couchbase container, even if it failed to start
+	if container != nil {
+		c.gateway = container
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: could not start the cloud native gateway", err)
+	}
+
+	return nil
+}
@@ -9,10 +9,13 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"testing"
+	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/go-connections/nat"
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"github.com/tidwall/gjson"
 )
@@ -45,11 +48,14 @@ type clusterInit func(context.Context) error
 // CouchbaseContainer represents the Couchbase container type used in the module
 type CouchbaseContainer struct {
 	testcontainers.Container
-	config *Config
+	config  *Config
+	gateway testcontainers.Container
 }
 
 // StartContainer creates an instance of the Couchbase container type
-func StartContainer(ctx context.Context, opts ...Option) (*CouchbaseContainer, error) {
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithEnv,
+// which are applied after the options of the module.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CouchbaseContainer, error) {
 	config := &Config{
 		enabledServices: []service{kv, query, search, index},
 		username:        "Administrator",
@@ -58,10 +64,13 @@ func StartContainer(ctx context.Context, opts ...Option) (*CouchbaseContainer, e
 		imageName: "couchbase:6.5.1",
 		// }
 		indexStorageMode: MemoryOptimized,
+		networkMode:      External,
 	}
 
 	for _, opt := range opts {
-		opt(config)
+		if o, ok := opt.(Option); ok {
+			o(config)
+		}
 	}
 
 	req := testcontainers.ContainerRequest{
@@ -69,30 +78,82 @@ func StartContainer(ctx context.Context, opts ...Option) (*CouchbaseContainer, e
 		ExposedPorts: exposePorts(config.enabledServices),
 	}
 
-	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+	genericContainerReq := testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
 		Started:          true,
-	})
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	// the image could be overridden by the generic options, so it's checked once they are applied
+	if err := validateImage(genericContainerReq.Image, config); err != nil {
+		return nil, err
+	}
+
+	if err := validateInitStatements(config); err != nil {
+		return nil, err
+	}
+
+	mode, err := resolveNetworkMode(config.networkMode, testcontainersdocker.InAContainer)
 	if err != nil {
 		return nil, err
 	}
+	config.networkMode = mode
+	// the networks are set by the generic options, e.g. testcontainers.WithNetwork
+	config.networkAlias = networkAlias(genericContainerReq.Networks, genericContainerReq.NetworkAliases)
 
-	couchbaseContainer := CouchbaseContainer{container, config}
+	if config.user != nil {
+		if err := prepareNonRootUser(ctx, *config.user, &genericContainerReq); err != nil {
+			return nil, err
+		}
+	}
 
-	if err = couchbaseContainer.initCluster(ctx); err != nil {
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
 		return nil, err
 	}
 
+	couchbaseContainer := CouchbaseContainer{Container: container, config: config}
+	if err != nil {
+		return &couchbaseContainer, err
+	}
+
+	if err = couchbaseContainer.initCluster(ctx); err != nil {
+		return &couchbaseContainer, err
+	}
+
 	if err = couchbaseContainer.createBuckets(ctx); err != nil {
-		return nil, err
+		return &couchbaseContainer, err
+	}
+
+	if err = couchbaseContainer.executeInitStatements(ctx); err != nil {
+		return &couchbaseContainer, err
+	}
+
+	if config.cloudNativeGateway.enabled {
+		if err = couchbaseContainer.startCloudNativeGateway(ctx); err != nil {
+			return &couchbaseContainer, err
+		}
 	}
 
 	return &couchbaseContainer, nil
 }
 
 // ConnectionString returns the connection string to connect to the Couchbase container instance.
-// It returns a string with the format couchbase://<host>:<port>
+// It returns a string with the format couchbase://<host>:<port>, or couchbase://<network alias> with the Internal network mode,
+// the network alias being the IP address of the container in its network if it has no alias.
 func (c *CouchbaseContainer) ConnectionString(ctx context.Context) (string, error) {
+	if c.config.networkMode == Internal {
+		host, err := c.internalHost(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return "couchbase://" + host, nil
+	}
+
 	host, err := c.Host(ctx)
 	if err != nil {
 		return "", err
@@ -116,10 +177,83 @@ func (c *CouchbaseContainer) Password() string {
 	return c.config.password
 }
 
+// ResetBucketBetweenTests registers a cleanup function in the given test, which flushes the bucket
+// and waits for it to be empty and ready again. It allows to share a container across the tests of a suite,
+// isolating the data of each test. The bucket must have been created with flush enabled.
+func (c *CouchbaseContainer) ResetBucketBetweenTests(t testing.TB, bucketName string) {
+	t.Helper()
+
+	b, ok := c.bucket(bucketName)
+	if !ok {
+		t.Fatalf("bucket %s does not exist in the Couchbase container", bucketName)
+	}
+
+	if !b.flushEnabled {
+		t.Fatalf("bucket %s cannot be reset, since it was not created with flush enabled", bucketName)
+	}
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		if err := c.resetBucket(ctx, b); err != nil {
+			t.Errorf("failed to reset bucket %s: %s", bucketName, err)
+		}
+	})
+}
+
+func (c *CouchbaseContainer) bucket(name string) (bucket, bool) {
+	for _, b := range c.config.buckets {
+		if b.name == name {
+			return b, true
+		}
+	}
+
+	return bucket{}, false
+}
+
+func (c *CouchbaseContainer) resetBucket(ctx context.Context, bucket bucket) error {
+	if err := c.flushBucket(ctx, bucket); err != nil {
+		return err
+	}
+
+	if err := c.waitUntilBucketIsEmpty(ctx, bucket); err != nil {
+		return err
+	}
+
+	return c.waitForAllServicesEnabled(ctx, bucket)
+}
+
+func (c *CouchbaseContainer) flushBucket(ctx context.Context, bucket bucket) error {
+	_, err := c.doHttpRequest(ctx, MGMT_PORT, "/pools/default/buckets/"+bucket.name+"/controller/doFlush", http.MethodPost, nil, true)
+
+	return err
+}
+
+func (c *CouchbaseContainer) waitUntilBucketIsEmpty(ctx context.Context, bucket bucket) error {
+	err := backoff.Retry(func() error {
+		response, err := c.doHttpRequest(ctx, MGMT_PORT, "/pools/default/buckets/"+bucket.name, http.MethodGet, nil, true)
+		if err != nil {
+			return err
+		}
+
+		itemCount := gjson.Get(string(response), "basicStats.itemCount")
+		if !itemCount.Exists() || itemCount.Int() > 0 {
+			return errors.New("bucket is not empty")
+		}
+
+		return nil
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+
+	return err
+}
+
 func (c *CouchbaseContainer) initCluster(ctx context.Context) error {
 	clusterInitFunc := []clusterInit{
 		c.waitUntilNodeIsOnline,
 		c.initializeIsEnterprise,
+		c.validateBuckets,
+		c.validateSettings,
 		c.renameNode,
 		c.initializeServices,
 		c.setMemoryQuotas,
@@ -131,6 +265,14 @@ func (c *CouchbaseContainer) initCluster(ctx context.Context) error {
 		clusterInitFunc = append(clusterInitFunc, c.configureIndexer)
 	}
 
+	if c.config.autoFailover != nil {
+		clusterInitFunc = append(clusterInitFunc, c.configureAutoFailover)
+	}
+
+	if c.config.emailAlerts != nil {
+		clusterInitFunc = append(clusterInitFunc, c.configureEmailAlerts)
+	}
+
 	clusterInitFunc = append(clusterInitFunc, c.waitUntilAllNodesAreHealthy)
 
 	for _, fn := range clusterInitFunc {
@@ -159,12 +301,50 @@ func (c *CouchbaseContainer) initializeIsEnterprise(ctx context.Context) error {
 
 	c.config.isEnterprise = gjson.Get(string(response), "isEnterprise").Bool()
 
-	if !c.config.isEnterprise {
-		if contains(c.config.enabledServices, analytics) {
-			return errors.New("the Analytics Service is only supported with the Enterprise version")
+	c.config.version, err = parseServerVersion(gjson.Get(string(response), "implementationVersion").String())
+	if err != nil {
+		return err
+	}
+
+	if err := validateServices(c.config.enabledServices, c.config.isEnterprise); err != nil {
+		return err
+	}
+
+	return validateServiceQuotas(c.config, c.config.isEnterprise)
+}
+
+// validateImage checks the enabled services, their memory quotas and the settings of the buckets against the edition and the version
+// in the tag of the image, if any, so the invalid configurations are rejected before the container is started.
+// The version detected from the running server is checked again during the initialization of the cluster.
+func validateImage(image string, config *Config) error {
+	version, isEnterprise, ok := imageVersion(image)
+	if !ok {
+		return nil
+	}
+
+	if err := validateServices(config.enabledServices, isEnterprise); err != nil {
+		return fmt.Errorf("image %s: %w", image, err)
+	}
+
+	if err := validateServiceQuotas(config, isEnterprise); err != nil {
+		return fmt.Errorf("image %s: %w", image, err)
+	}
+
+	for _, b := range config.buckets {
+		if err := b.validate(version, isEnterprise); err != nil {
+			return fmt.Errorf("image %s: %w", image, err)
 		}
-		if contains(c.config.enabledServices, eventing) {
-			return errors.New("the Eventing Service is only supported with the Enterprise version")
+	}
+
+	return nil
+}
+
+// validateBuckets checks the settings of the buckets against the detected edition and version of Couchbase Server,
+// before the cluster is set up
+func (c *CouchbaseContainer) validateBuckets(_ context.Context) error {
+	for _, b := range c.config.buckets {
+		if err := b.validate(c.config.version, c.config.isEnterprise); err != nil {
+			return err
 		}
 	}
 
@@ -203,7 +383,7 @@ func (c *CouchbaseContainer) setMemoryQuotas(ctx context.Context) error {
 			continue
 		}
 
-		quota := strconv.Itoa(s.minimumQuotaMb)
+		quota := strconv.Itoa(c.serviceQuota(s))
 		if s.identifier == kv.identifier {
 			body["memoryQuota"] = quota
 		} else {
@@ -216,6 +396,23 @@ func (c *CouchbaseContainer) setMemoryQuotas(ctx context.Context) error {
 	return err
 }
 
+// serviceQuota returns the memory quota of the service set with the WithServiceQuota option, or its minimum quota.
+// The default quota of the data service is large enough to hold the quotas of all the buckets,
+// e.g. the magma buckets requiring 1024 MB.
+func (c *CouchbaseContainer) serviceQuota(s service) int {
+	if quota, ok := c.config.serviceQuotas[quotaService(s.identifier)]; ok {
+		return quota
+	}
+
+	if s.identifier == kv.identifier {
+		if total := bucketsQuota(c.config.buckets); total > kv.minimumQuotaMb {
+			return total
+		}
+	}
+
+	return s.minimumQuotaMb
+}
+
 func (c *CouchbaseContainer) configureAdminUser(ctx context.Context) error {
 	body := map[string]string{
 		"username": c.config.username,
@@ -229,6 +426,17 @@ func (c *CouchbaseContainer) configureAdminUser(ctx context.Context) error {
 }
 
 func (c *CouchbaseContainer) configureExternalPorts(ctx context.Context) error {
+	// the clients inside the network of the container use the addresses of the node, not the mapped ports
+	if c.config.networkMode == Internal {
+		return nil
+	}
+
+	// the alternate addresses were introduced in Couchbase Server 6.5
+	if !c.config.version.atLeast(6, 5) {
+		testcontainers.Logger.Printf("Skipping the alternate addresses, only supported with Couchbase Server 6.5 or later, got %s", c.config.version)
+		return nil
+	}
+
 	host, _ := c.Host(ctx)
 	mgmt, _ := c.MappedPort(ctx, MGMT_PORT)
 	mgmtSSL, _ := c.MappedPort(ctx, MGMT_SSL_PORT)
@@ -314,18 +522,7 @@ func (c *CouchbaseContainer) waitUntilAllNodesAreHealthy(ctx context.Context) er
 		WithStatusCodeMatcher(func(status int) bool {
 			return status == http.StatusOK
 		}).
-		WithResponseMatcher(func(body io.Reader) bool {
-			response, err := io.ReadAll(body)
-			if err != nil {
-				return false
-			}
-			status := gjson.Get(string(response), "nodes.0.status")
-			if status.String() != "healthy" {
-				return false
-			}
-
-			return true
-		}))
+		WithJSONPathMatcher("nodes.0.status", "healthy"))
 
 	if contains(c.config.enabledServices, query) {
 		waitStrategy = append(waitStrategy, wait.ForHTTP("/admin/ping").
@@ -360,38 +557,46 @@ func (c *CouchbaseContainer) waitUntilAllNodesAreHealthy(ctx context.Context) er
 
 func (c *CouchbaseContainer) createBuckets(ctx context.Context) error {
 	for _, bucket := range c.config.buckets {
-		err := c.createBucket(ctx, bucket)
-		if err != nil {
+		if err := c.setupBucket(ctx, bucket); err != nil {
 			return err
 		}
+	}
 
-		err = c.waitForAllServicesEnabled(ctx, bucket)
+	return nil
+}
+
+// setupBucket creates the bucket and waits until it's ready to be used by all the services, creating its primary index if enabled
+func (c *CouchbaseContainer) setupBucket(ctx context.Context, bucket bucket) error {
+	err := c.createBucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	err = c.waitForAllServicesEnabled(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	if contains(c.config.enabledServices, query) {
+		err = c.isQueryKeyspacePresent(ctx, bucket)
 		if err != nil {
 			return err
 		}
+	}
 
-		if contains(c.config.enabledServices, query) {
-			err = c.isQueryKeyspacePresent(ctx, bucket)
-			if err != nil {
-				return err
-			}
+	if bucket.queryPrimaryIndex {
+		if !contains(c.config.enabledServices, query) {
+			return fmt.Errorf("primary index creation for bucket %s ignored, since QUERY service is not present", bucket.name)
 		}
 
-		if bucket.queryPrimaryIndex {
-			if !contains(c.config.enabledServices, query) {
-				return fmt.Errorf("primary index creation for bucket %s ignored, since QUERY service is not present", bucket.name)
-			}
-
-			err = c.createPrimaryIndex(ctx, bucket)
-			if err != nil {
-				return err
-			}
-
-			err = c.isPrimaryIndexOnline(ctx, bucket)
-			if err != nil {
-				return err
-			}
+		err = c.createPrimaryIndex(ctx, bucket)
+		if err != nil {
+			return err
+		}
 
+		err = c.isPrimaryIndexOnline(ctx, bucket)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -473,23 +678,19 @@ func (c *CouchbaseContainer) waitForAllServicesEnabled(ctx context.Context, buck
 }
 
 func (c *CouchbaseContainer) createBucket(ctx context.Context, bucket bucket) error {
-	flushEnabled := "0"
-	if bucket.flushEnabled {
-		flushEnabled = "1"
-	}
-	body := map[string]string{
-		"name":          bucket.name,
-		"ramQuotaMB":    strconv.Itoa(bucket.quota),
-		"flushEnabled":  flushEnabled,
-		"replicaNumber": strconv.Itoa(bucket.numReplicas),
-	}
-
-	_, err := c.doHttpRequest(ctx, MGMT_PORT, "/pools/default/buckets", http.MethodPost, body, true)
+	_, err := c.Admin().request(ctx, http.MethodPost, "/pools/default/buckets", bucket.form(c.config.version))
 
 	return err
 }
 
 func (c *CouchbaseContainer) doHttpRequest(ctx context.Context, port, path, method string, body map[string]string, auth bool) ([]byte, error) {
+	response, _, err := c.doHttpRequestWithStatus(ctx, port, path, method, body, auth)
+
+	return response, err
+}
+
+// doHttpRequestWithStatus sends the request like doHttpRequest, also returning the status code of the response
+func (c *CouchbaseContainer) doHttpRequestWithStatus(ctx context.Context, port, path, method string, body map[string]string, auth bool) ([]byte, int, error) {
 	form := url.Values{}
 	for k, v := range body {
 		form.Set(k, v)
@@ -497,12 +698,12 @@ func (c *CouchbaseContainer) doHttpRequest(ctx context.Context, port, path, meth
 
 	url, err := c.getUrl(ctx, port, path)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	request, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(form.Encode()))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
@@ -513,11 +714,16 @@ func (c *CouchbaseContainer) doHttpRequest(ctx context.Context, port, path, meth
 
 	response, err := http.DefaultClient.Do(request)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer response.Body.Close()
 
-	return io.ReadAll(response.Body)
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return b, response.StatusCode, nil
 }
 
 func (c *CouchbaseContainer) getUrl(ctx context.Context, port, path string) (string, error) {
@@ -2,10 +2,15 @@ package couchbase_test
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/couchbase/gocb/v2"
+	"github.com/testcontainers/testcontainers-go"
 	tccouchbase "github.com/testcontainers/testcontainers-go/modules/couchbase"
 )
 
@@ -67,6 +72,228 @@ func TestCouchbaseWithEnterpriseContainer(t *testing.T) {
 	testBucketUsage(t, cluster.Bucket(bucketName))
 }
 
+func TestResetBucketBetweenTests(t *testing.T) {
+	ctx := context.Background()
+
+	// resetBucket {
+	bucketName := "testBucket"
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithBucket(tccouchbase.NewBucket(bucketName).WithFlushEnabled(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	cluster, err := connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+
+	// the subtests share the container, but each one starts with an empty bucket
+	for _, name := range []string{"first", "second"} {
+		t.Run(name, func(t *testing.T) {
+			container.ResetBucketBetweenTests(t, bucketName)
+
+			collection := cluster.Bucket(bucketName).DefaultCollection()
+
+			_, err := collection.Get("foo", nil)
+			if err == nil {
+				t.Fatal("expected the bucket to be empty")
+			}
+
+			testBucketUsage(t, cluster.Bucket(bucketName))
+		})
+	}
+}
+
+func TestStopGracefullyAndStartAgain(t *testing.T) {
+	ctx := context.Background()
+
+	bucketName := "testBucket"
+	container, err := tccouchbase.StartContainer(ctx, tccouchbase.WithImageName(communityEdition), tccouchbase.WithBucket(tccouchbase.NewBucket(bucketName)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	cluster, err := connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+
+	testBucketUsage(t, cluster.Bucket(bucketName))
+
+	// stopGracefully {
+	if err := container.StopGracefully(ctx); err != nil {
+		t.Fatalf("failed to stop the container gracefully: %s", err)
+	}
+
+	if err := container.StartAgain(ctx); err != nil {
+		t.Fatalf("failed to start the container again: %s", err)
+	}
+	// }
+
+	// the mapped ports could have changed, so a new connection is needed
+	cluster, err = connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+
+	bucket := cluster.Bucket(bucketName)
+	if err := bucket.WaitUntilReady(30*time.Second, nil); err != nil {
+		t.Fatalf("could not connect bucket: %s", err)
+	}
+
+	result, err := bucket.DefaultCollection().Get("foo", nil)
+	if err != nil {
+		t.Fatalf("expected the data to be persisted across restarts: %s", err)
+	}
+
+	var resultData map[string]string
+	if err := result.Content(&resultData); err != nil {
+		t.Fatalf("could not asign content: %s", err)
+	}
+
+	if resultData["key"] != "value" {
+		t.Errorf("Expected value to be [%s], got %s", "value", resultData["key"])
+	}
+}
+
+func TestCouchbaseWithCloudNativeGateway(t *testing.T) {
+	ctx := context.Background()
+
+	// withCloudNativeGateway {
+	bucketName := "testBucket"
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(enterpriseEdition),
+		tccouchbase.WithBucket(tccouchbase.NewBucket(bucketName)),
+		tccouchbase.WithCloudNativeGateway())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the gateway and the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.CloudNativeGatewayConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(connectionString, "couchbase2://") {
+		t.Fatalf("Expected connection string to use the couchbase2 protocol, got %s", connectionString)
+	}
+
+	// the gateway uses a self-signed certificate
+	conn, err := tls.Dial("tcp", strings.TrimPrefix(connectionString, "couchbase2://"), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("could not connect to the cloud native gateway: %s", err)
+	}
+	defer conn.Close()
+}
+
+func TestCouchbaseWithMagmaBucket(t *testing.T) {
+	ctx := context.Background()
+
+	// withMagmaBucket {
+	bucketName := "testBucket"
+	bucket := tccouchbase.NewBucket(bucketName).
+		WithStorageBackend(tccouchbase.Magma).
+		WithQuota(1024).
+		WithHistoryRetention(0, 24*time.Hour)
+
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName("couchbase:enterprise-7.2.0"),
+		tccouchbase.WithBucket(bucket))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	cluster, err := connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+
+	testBucketUsage(t, cluster.Bucket(bucketName))
+}
+
+func TestCouchbaseWithNonRootUser(t *testing.T) {
+	ctx := context.Background()
+
+	// withNonRootUser {
+	bucketName := "testBucket"
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithNonRootUser(1000, 1000),
+		tccouchbase.WithBucket(tccouchbase.NewBucket(bucketName)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	code, _, err := container.Exec(ctx, []string{"sh", "-c", "test \"$(id -u)\" = 1000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 0 {
+		t.Fatalf("expected the container to run as the non-root user, exit code %d", code)
+	}
+
+	cluster, err := connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+
+	testBucketUsage(t, cluster.Bucket(bucketName))
+}
+
+func TestMagmaBucketWithCommunityContainer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithBucket(tccouchbase.NewBucket("testBucket").WithStorageBackend(tccouchbase.Magma).WithQuota(1024)))
+
+	if err == nil {
+		t.Errorf("Expected error to be [%v] , got nil", err)
+	}
+}
+
 func TestAnalyticsServiceWithCommunityContainer(t *testing.T) {
 	ctx := context.Background()
 
@@ -126,6 +353,402 @@ func testBucketUsage(t *testing.T, bucket *gocb.Bucket) {
 	}
 }
 
+func TestCouchbaseWithAutoFailover(t *testing.T) {
+	ctx := context.Background()
+
+	// withAutoFailover {
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithAutoFailover(30*time.Second, 1),
+		tccouchbase.WithEmailAlerts(
+			tccouchbase.NewEmailAlerts("couchbase@example.com", "ops@example.com").WithServer("smtp.example.com", 1025),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	var autoFailover struct {
+		Enabled  bool `json:"enabled"`
+		Timeout  int  `json:"timeout"`
+		MaxCount int  `json:"maxCount"`
+	}
+	getSettings(ctx, t, container, "/settings/autoFailover", &autoFailover)
+
+	if !autoFailover.Enabled || autoFailover.Timeout != 30 || autoFailover.MaxCount != 1 {
+		t.Fatalf("expected the auto-failover to be enabled after 30s for 1 node, got %+v", autoFailover)
+	}
+
+	var alerts struct {
+		Enabled    bool     `json:"enabled"`
+		Recipients []string `json:"recipients"`
+	}
+	getSettings(ctx, t, container, "/settings/alerts", &alerts)
+
+	if !alerts.Enabled || len(alerts.Recipients) != 1 || alerts.Recipients[0] != "ops@example.com" {
+		t.Fatalf("expected the email alerts to be sent to ops@example.com, got %+v", alerts)
+	}
+}
+
+func TestCouchbaseWithServiceQuotas(t *testing.T) {
+	ctx := context.Background()
+
+	// withServiceQuota {
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithServiceQuota(tccouchbase.DataService, 512),
+		tccouchbase.WithServiceQuota(tccouchbase.SearchService, 512),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	var pool struct {
+		MemoryQuota      int `json:"memoryQuota"`
+		FtsMemoryQuota   int `json:"ftsMemoryQuota"`
+		IndexMemoryQuota int `json:"indexMemoryQuota"`
+	}
+	getSettings(ctx, t, container, "/pools/default", &pool)
+
+	if pool.MemoryQuota != 512 || pool.FtsMemoryQuota != 512 || pool.IndexMemoryQuota != 256 {
+		t.Fatalf("expected the data and search quotas to be 512 MB and the index quota 256 MB, got %+v", pool)
+	}
+}
+
+func TestServiceQuotaWithCommunityContainer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithAnalyticsService(),
+		tccouchbase.WithServiceQuota(tccouchbase.AnalyticsService, 1024),
+	)
+	if err == nil {
+		t.Fatal("expected the analytics quota to be rejected with the community edition")
+	}
+}
+
+func TestCouchbaseWithInternalNetworkMode(t *testing.T) {
+	ctx := context.Background()
+
+	networkName := "couchbase-internal-network"
+	network, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{Name: networkName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := network.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	// withInternalNetworkMode {
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithNetworkMode(tccouchbase.Internal),
+		testcontainers.WithNetwork(networkName, "couchbase"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connectionString != "couchbase://couchbase" {
+		t.Fatalf("expected the connection string to use the network alias, got %s", connectionString)
+	}
+
+	var pool struct {
+		Nodes []struct {
+			AlternateAddresses map[string]interface{} `json:"alternateAddresses"`
+		} `json:"nodes"`
+	}
+	getSettings(ctx, t, container, "/pools/default", &pool)
+
+	if len(pool.Nodes) != 1 || len(pool.Nodes[0].AlternateAddresses) != 0 {
+		t.Fatalf("expected no alternate addresses, got %+v", pool.Nodes)
+	}
+}
+
+func TestCouchbaseWithInitStatements(t *testing.T) {
+	ctx := context.Background()
+
+	bucketName := "testBucket"
+	// withInitStatements {
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithBucket(tccouchbase.NewBucket(bucketName)),
+		tccouchbase.WithInitStatements(
+			"CREATE INDEX idx_name ON `testBucket`(name)",
+			"INSERT INTO `testBucket` (KEY, VALUE) VALUES (\"user::1\", {\"name\": \"alice\"})",
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	cluster, err := connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+
+	bucket := cluster.Bucket(bucketName)
+	if err := bucket.WaitUntilReady(5*time.Second, nil); err != nil {
+		t.Fatalf("could not connect bucket: %s", err)
+	}
+
+	result, err := bucket.DefaultCollection().Get("user::1", nil)
+	if err != nil {
+		t.Fatalf("could not get the seed data: %s", err)
+	}
+
+	var user map[string]string
+	if err := result.Content(&user); err != nil {
+		t.Fatalf("could not asign content: %s", err)
+	}
+
+	if user["name"] != "alice" {
+		t.Errorf("Expected name to be [%s], got %s", "alice", user["name"])
+	}
+}
+
+func TestInitStatementWithSyntaxError(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := tccouchbase.StartContainer(ctx,
+		tccouchbase.WithImageName(communityEdition),
+		tccouchbase.WithInitStatements("SELEC 1"),
+	)
+	if container != nil {
+		t.Cleanup(func() {
+			if err := container.Terminate(ctx); err != nil {
+				t.Fatalf("failed to terminate container: %s", err)
+			}
+		})
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Fatalf("expected a syntax error, got %v", err)
+	}
+}
+
+func TestCouchbaseAdminClient(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := tccouchbase.StartContainer(ctx, tccouchbase.WithImageName(communityEdition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// adminClient {
+	admin := container.Admin()
+
+	err = admin.CreateBucket(ctx, tccouchbase.NewBucket("orders").WithFlushEnabled(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = admin.UpsertUser(ctx, "app", "app-password", "bucket_full_access[orders]")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = admin.SetAutoCompaction(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	buckets, err := admin.Buckets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 1 || buckets[0] != "orders" {
+		t.Fatalf("expected the orders bucket, got %v", buckets)
+	}
+
+	cluster, err := connectCluster(ctx, container)
+	if err != nil {
+		t.Fatalf("could not connect couchbase: %s", err)
+	}
+	testBucketUsage(t, cluster.Bucket("orders"))
+
+	if err := admin.FlushBucket(ctx, "orders"); err != nil {
+		t.Fatal(err)
+	}
+
+	var autoCompaction struct {
+		Settings struct {
+			DatabaseFragmentationThreshold struct {
+				Percentage int `json:"percentage"`
+			} `json:"databaseFragmentationThreshold"`
+		} `json:"autoCompactionSettings"`
+	}
+	getSettings(ctx, t, container, "/settings/autoCompaction", &autoCompaction)
+
+	if autoCompaction.Settings.DatabaseFragmentationThreshold.Percentage != 10 {
+		t.Fatalf("expected the compaction to be triggered at 10%% of fragmentation, got %+v", autoCompaction)
+	}
+
+	if err := admin.DeleteUser(ctx, "app"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := admin.DeleteBucket(ctx, "orders"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetupXDCR(t *testing.T) {
+	ctx := context.Background()
+
+	bucketName := "testBucket"
+	startContainer := func() *tccouchbase.CouchbaseContainer {
+		container, err := tccouchbase.StartContainer(ctx, tccouchbase.WithImageName(communityEdition), tccouchbase.WithBucket(tccouchbase.NewBucket(bucketName)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Clean up the container after the test is complete
+		t.Cleanup(func() {
+			if err := container.Terminate(ctx); err != nil {
+				t.Fatalf("failed to terminate container: %s", err)
+			}
+		})
+
+		return container
+	}
+
+	source := startContainer()
+	target := startContainer()
+
+	// setupXDCR {
+	err := tccouchbase.SetupXDCR(ctx, source, target, bucketName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	sourceCluster, err := connectCluster(ctx, source)
+	if err != nil {
+		t.Fatalf("could not connect the source cluster: %s", err)
+	}
+
+	targetCluster, err := connectCluster(ctx, target)
+	if err != nil {
+		t.Fatalf("could not connect the target cluster: %s", err)
+	}
+
+	sourceBucket := sourceCluster.Bucket(bucketName)
+	if err := sourceBucket.WaitUntilReady(5*time.Second, nil); err != nil {
+		t.Fatalf("could not connect the source bucket: %s", err)
+	}
+
+	if _, err := sourceBucket.DefaultCollection().Upsert("foo", map[string]string{"key": "value"}, nil); err != nil {
+		t.Fatalf("could not upsert data: %s", err)
+	}
+
+	targetBucket := targetCluster.Bucket(bucketName)
+	if err := targetBucket.WaitUntilReady(5*time.Second, nil); err != nil {
+		t.Fatalf("could not connect the target bucket: %s", err)
+	}
+
+	var resultData map[string]string
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		result, err := targetBucket.DefaultCollection().Get("foo", nil)
+		if err == nil {
+			if err := result.Content(&resultData); err != nil {
+				t.Fatalf("could not asign content: %s", err)
+			}
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("the document was not replicated: %s", err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	if resultData["key"] != "value" {
+		t.Errorf("Expected value to be [%s], got %s", "value", resultData["key"])
+	}
+}
+
+// getSettings decodes the settings of the cluster returned by the given endpoint of the REST API of Couchbase Server
+func getSettings(ctx context.Context, t *testing.T, container *tccouchbase.CouchbaseContainer, path string, settings interface{}) {
+	t.Helper()
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := container.MappedPort(ctx, tccouchbase.MGMT_PORT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+":"+port.Port()+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(container.Username(), container.Password())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(settings); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // connectToCluster {
 func connectCluster(ctx context.Context, container *tccouchbase.CouchbaseContainer) (*gocb.Cluster, error) {
 	connectionString, err := container.ConnectionString(ctx)
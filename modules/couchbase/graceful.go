@@ -0,0 +1,88 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/tidwall/gjson"
+)
+
+// gracefulStopTimeout is the time given to Couchbase Server to shut down before the container is killed
+const gracefulStopTimeout = 2 * time.Minute
+
+// StopGracefully stops the Couchbase container keeping the data of the buckets intact: it waits until the disk write
+// queues of the buckets are empty, so all the mutations are persisted, and then stops the container, giving Couchbase
+// Server enough time to shut down cleanly. The container can be started again with StartAgain.
+func (c *CouchbaseContainer) StopGracefully(ctx context.Context) error {
+	for _, b := range c.config.buckets {
+		if err := c.waitUntilDiskWriteQueueIsEmpty(ctx, b); err != nil {
+			return fmt.Errorf("%w: the data of bucket %s could not be persisted", err, b.name)
+		}
+	}
+
+	timeout := gracefulStopTimeout
+	return c.Stop(ctx, &timeout)
+}
+
+// StartAgain starts a Couchbase container stopped with StopGracefully, waiting until the node and the buckets are ready
+// again. The external ports of the node are configured again, as the mapped ports could change when it's restarted.
+func (c *CouchbaseContainer) StartAgain(ctx context.Context) error {
+	if err := c.Start(ctx); err != nil {
+		return err
+	}
+
+	restartFuncs := []clusterInit{
+		c.waitUntilNodeIsOnline,
+		c.configureExternalPorts,
+		c.waitUntilAllNodesAreHealthy,
+	}
+
+	for _, fn := range restartFuncs {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range c.config.buckets {
+		if err := c.waitForAllServicesEnabled(ctx, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *CouchbaseContainer) waitUntilDiskWriteQueueIsEmpty(ctx context.Context, bucket bucket) error {
+	err := backoff.Retry(func() error {
+		response, err := c.doHttpRequest(ctx, MGMT_PORT, "/pools/default/buckets/"+bucket.name+"/stats", http.MethodGet, nil, true)
+		if err != nil {
+			return err
+		}
+
+		if diskWriteQueue(response) > 0 {
+			return errors.New("disk write queue is not empty")
+		}
+
+		return nil
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+
+	return err
+}
+
+// diskWriteQueue returns the number of items waiting to be written to disk, from the latest samples of the stats
+// of a bucket, which is the sum of the items in the queue and the items being flushed
+func diskWriteQueue(stats []byte) int64 {
+	var queue int64
+	for _, stat := range []string{"op.samples.ep_queue_size", "op.samples.ep_flusher_todo"} {
+		samples := gjson.GetBytes(stats, stat).Array()
+		if len(samples) > 0 {
+			queue += samples[len(samples)-1].Int()
+		}
+	}
+
+	return queue
+}
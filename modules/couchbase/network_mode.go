@@ -0,0 +1,67 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+)
+
+// The network mode defines where the clients of the tests connect to the cluster from.
+type networkMode string
+
+// networkModes {
+const (
+	// External is for the clients running on the host of the tests, outside the Docker network of the container.
+	// The cluster advertises the mapped ports of the container as alternate addresses, so the SDKs use them.
+	// This is the default value for the testcontainers couchbase implementation.
+	External networkMode = "external"
+
+	// Internal is for the clients running inside the Docker network of the container, e.g. in a Docker-in-Docker CI.
+	// The alternate addresses are not configured, as the SDKs would bootstrap with the mapped ports, unreachable
+	// from the network, and the connection string uses the network alias of the container.
+	Internal networkMode = "internal"
+
+	// Auto uses the Internal network mode when the tests are running inside a container, and External otherwise.
+	Auto networkMode = "auto"
+)
+
+// }
+
+// WithNetworkMode sets where the clients of the tests connect to the cluster from.
+func WithNetworkMode(mode networkMode) Option {
+	return func(c *Config) {
+		c.networkMode = mode
+	}
+}
+
+// resolveNetworkMode returns the network mode of the cluster, resolving the Auto network mode
+func resolveNetworkMode(mode networkMode, inAContainer func() bool) (networkMode, error) {
+	switch mode {
+	case External, Internal:
+		return mode, nil
+	case Auto:
+		if inAContainer() {
+			return Internal, nil
+		}
+		return External, nil
+	default:
+		return "", fmt.Errorf("invalid network mode %q, it must be one of %s, %s or %s", mode, External, Internal, Auto)
+	}
+}
+
+// internalHost returns the host of the container in its Docker network: its first network alias, or its IP address
+func (c *CouchbaseContainer) internalHost(ctx context.Context) (string, error) {
+	if c.config.networkAlias != "" {
+		return c.config.networkAlias, nil
+	}
+
+	return c.getInternalIPAddress(ctx)
+}
+
+// networkAlias returns the first alias of the container in its first network, if any
+func networkAlias(networks []string, aliases map[string][]string) string {
+	if len(networks) == 0 || len(aliases[networks[0]]) == 0 {
+		return ""
+	}
+
+	return aliases[networks[0]][0]
+}
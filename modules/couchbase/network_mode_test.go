@@ -0,0 +1,47 @@
+package couchbase
+
+import "testing"
+
+func TestResolveNetworkMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         networkMode
+		inAContainer bool
+		want         networkMode
+		wantErr      bool
+	}{
+		{name: "external", mode: External, inAContainer: true, want: External},
+		{name: "internal", mode: Internal, want: Internal},
+		{name: "auto on the host", mode: Auto, want: External},
+		{name: "auto in a container", mode: Auto, inAContainer: true, want: Internal},
+		{name: "invalid", mode: "bridge", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveNetworkMode(tt.mode, func() bool { return tt.inAContainer })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveNetworkMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveNetworkMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkAlias(t *testing.T) {
+	aliases := map[string][]string{"ci": {"couchbase", "db"}}
+
+	if got := networkAlias([]string{"ci", "other"}, aliases); got != "couchbase" {
+		t.Errorf("networkAlias() = %q, want couchbase", got)
+	}
+
+	if got := networkAlias([]string{"other", "ci"}, aliases); got != "" {
+		t.Errorf("networkAlias() = %q, want no alias", got)
+	}
+
+	if got := networkAlias(nil, aliases); got != "" {
+		t.Errorf("networkAlias() = %q, want no alias", got)
+	}
+}
@@ -1,8 +1,15 @@
 package couchbase
 
+import "github.com/testcontainers/testcontainers-go"
+
 // Option is a function that configures the Couchbase container.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
 type Option func(*Config)
 
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the Couchbase container.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
 // Config is the configuration for the Couchbase container, that will be stored in the container itself.
 type Config struct {
 	enabledServices  []service
@@ -12,6 +19,24 @@ type Config struct {
 	buckets          []bucket
 	imageName        string
 	indexStorageMode indexStorageMode
+	// user runs Couchbase Server instead of root, if set
+	user *containerUser
+	// version is the version of Couchbase Server, detected when the cluster is initialized
+	version serverVersion
+	// cloudNativeGateway is the configuration of the cloud native gateway started alongside the container
+	cloudNativeGateway cloudNativeGateway
+	// autoFailover is the auto-failover policy of the cluster, the default policy of Couchbase Server if nil
+	autoFailover *autoFailover
+	// emailAlerts is the configuration of the email alerts of the cluster, disabled if nil
+	emailAlerts *emailAlerts
+	// networkMode defines where the clients connect to the cluster from, resolved when the container is started
+	networkMode networkMode
+	// networkAlias is the first alias of the container in its first network, if any
+	networkAlias string
+	// serviceQuotas are the memory quotas of the services in megabytes, the minimum quotas of the services if not set
+	serviceQuotas map[quotaService]int
+	// initStatements are the N1QL statements executed once the buckets are created
+	initStatements []string
 }
 
 // WithEnterpriseService enables the eventing service in the container.
@@ -52,6 +77,18 @@ func WithImageName(imageName string) Option {
 	}
 }
 
+// WithServiceQuota sets the memory quota of a service in megabytes, e.g. to run memory-heavy search or analytics tests.
+// The quota can't be lower than the minimum quota of the service, 256 MB, and the service must be enabled.
+// The quota of the data service must hold the quotas of all the buckets.
+func WithServiceQuota(service quotaService, quotaMb int) Option {
+	return func(c *Config) {
+		if c.serviceQuotas == nil {
+			c.serviceQuotas = map[quotaService]int{}
+		}
+		c.serviceQuotas[service] = quotaMb
+	}
+}
+
 // WithIndexStorageMode sets the storage mode to be used in the cluster.
 func WithIndexStorageMode(indexStorageMode indexStorageMode) Option {
 	return func(c *Config) {
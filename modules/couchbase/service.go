@@ -1,5 +1,10 @@
 package couchbase
 
+import (
+	"errors"
+	"fmt"
+)
+
 type service struct {
 	identifier     string
 	minimumQuotaMb int
@@ -46,3 +51,96 @@ var (
 		ports:          []string{EVENTING_PORT, EVENTING_SSL_PORT},
 	}
 )
+
+// validateServices checks that the enabled services are supported by the edition of Couchbase Server,
+// suggesting how to fix the configuration otherwise
+func validateServices(services []service, isEnterprise bool) error {
+	if isEnterprise {
+		return nil
+	}
+
+	if contains(services, analytics) {
+		return errors.New("the Analytics Service is only supported with the Enterprise version: use an Enterprise image, e.g. couchbase:enterprise-7.2.0, or remove the WithAnalyticsService option")
+	}
+
+	if contains(services, eventing) {
+		return errors.New("the Eventing Service is only supported with the Enterprise version: use an Enterprise image, e.g. couchbase:enterprise-7.2.0, or remove the WithEventingService option")
+	}
+
+	return nil
+}
+
+// The quota service is a service of Couchbase Server with a memory quota, configured with the WithServiceQuota option.
+type quotaService string
+
+// quotaServices {
+const (
+	// DataService is the key-value service, its quota holding the quotas of all the buckets.
+	DataService quotaService = "kv"
+	// SearchService is the full-text search service.
+	SearchService quotaService = "fts"
+	// IndexService is the global secondary indexes service.
+	IndexService quotaService = "index"
+	// AnalyticsService is the analytics service, enabled with the WithAnalyticsService option.
+	AnalyticsService quotaService = "cbas"
+	// EventingService is the eventing service, enabled with the WithEventingService option.
+	EventingService quotaService = "eventing"
+)
+
+// }
+
+// quotaServices maps the quota services to the services of Couchbase Server, and the options enabling them
+var quotaServices = map[quotaService]struct {
+	service service
+	option  string
+}{
+	DataService:      {service: kv},
+	SearchService:    {service: search},
+	IndexService:     {service: index},
+	AnalyticsService: {service: analytics, option: "WithAnalyticsService"},
+	EventingService:  {service: eventing, option: "WithEventingService"},
+}
+
+// validateServiceQuotas checks that the memory quotas are set for enabled services, supported by the edition
+// of Couchbase Server, and are not lower than the minimum quotas of the services. The quota of the data service
+// must also hold the quotas of all the buckets.
+func validateServiceQuotas(config *Config, isEnterprise bool) error {
+	for name, quota := range config.serviceQuotas {
+		qs, ok := quotaServices[name]
+		if !ok {
+			return fmt.Errorf("invalid service %q, the memory quota can only be set for the %s, %s, %s, %s and %s services",
+				name, DataService, SearchService, IndexService, AnalyticsService, EventingService)
+		}
+
+		// the services enabled with an option are only supported by the Enterprise Edition
+		if qs.option != "" && !isEnterprise {
+			return fmt.Errorf("the memory quota of the %s service is only supported with the Enterprise version: use an Enterprise image, e.g. couchbase:enterprise-7.2.0", name)
+		}
+
+		if !contains(config.enabledServices, qs.service) {
+			return fmt.Errorf("the memory quota of the %s service is set, but the service is not enabled: add the %s option", name, qs.option)
+		}
+
+		if quota < qs.service.minimumQuotaMb {
+			return fmt.Errorf("the memory quota of the %s service must be at least %d MB, got %d MB", name, qs.service.minimumQuotaMb, quota)
+		}
+	}
+
+	if quota, ok := config.serviceQuotas[DataService]; ok {
+		if buckets := bucketsQuota(config.buckets); quota < buckets {
+			return fmt.Errorf("the memory quota of the %s service must hold the quotas of the buckets, %d MB, got %d MB", DataService, buckets, quota)
+		}
+	}
+
+	return nil
+}
+
+// bucketsQuota returns the sum of the quotas of the buckets, in megabytes
+func bucketsQuota(buckets []bucket) int {
+	total := 0
+	for _, b := range buckets {
+		total += b.quota
+	}
+
+	return total
+}
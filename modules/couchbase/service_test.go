@@ -0,0 +1,118 @@
+package couchbase
+
+import "testing"
+
+func TestValidateImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "community edition",
+			image:  "couchbase:community-7.1.1",
+			config: Config{enabledServices: []service{kv, query, search, index}},
+		},
+		{
+			name:    "analytics with community edition",
+			image:   "couchbase:community-7.1.1",
+			config:  Config{enabledServices: []service{kv, analytics}},
+			wantErr: true,
+		},
+		{
+			name:    "eventing with community edition",
+			image:   "couchbase:community-7.1.1",
+			config:  Config{enabledServices: []service{kv, eventing}},
+			wantErr: true,
+		},
+		{
+			name:   "eventing with enterprise edition",
+			image:  "couchbase:enterprise-7.1.3",
+			config: Config{enabledServices: []service{kv, eventing}},
+		},
+		{
+			name:    "magma bucket before 7.1",
+			image:   "couchbase:6.5.1",
+			config:  Config{enabledServices: []service{kv}, buckets: []bucket{NewBucket("test").WithStorageBackend(Magma).WithQuota(1024)}},
+			wantErr: true,
+		},
+		{
+			name:   "service quotas",
+			image:  "couchbase:community-7.1.1",
+			config: Config{enabledServices: []service{kv, search}, serviceQuotas: map[quotaService]int{DataService: 512, SearchService: 1024}},
+		},
+		{
+			name:    "quota below the minimum",
+			image:   "couchbase:community-7.1.1",
+			config:  Config{enabledServices: []service{kv, search}, serviceQuotas: map[quotaService]int{SearchService: 128}},
+			wantErr: true,
+		},
+		{
+			name:    "quota of a disabled service",
+			image:   "couchbase:community-7.1.1",
+			config:  Config{enabledServices: []service{kv}, serviceQuotas: map[quotaService]int{SearchService: 512}},
+			wantErr: true,
+		},
+		{
+			name:    "analytics quota with community edition",
+			image:   "couchbase:community-7.1.1",
+			config:  Config{enabledServices: []service{kv}, serviceQuotas: map[quotaService]int{AnalyticsService: 1024}},
+			wantErr: true,
+		},
+		{
+			name:   "analytics quota with enterprise edition",
+			image:  "couchbase:enterprise-7.1.3",
+			config: Config{enabledServices: []service{kv, analytics}, serviceQuotas: map[quotaService]int{AnalyticsService: 1024}},
+		},
+		{
+			name:    "data quota smaller than the buckets",
+			image:   "couchbase:enterprise-7.1.3",
+			config:  Config{enabledServices: []service{kv}, buckets: []bucket{NewBucket("test").WithStorageBackend(Magma).WithQuota(1024)}, serviceQuotas: map[quotaService]int{DataService: 512}},
+			wantErr: true,
+		},
+		{
+			name:    "service without quota",
+			image:   "couchbase:enterprise-7.1.3",
+			config:  Config{enabledServices: []service{kv, query}, serviceQuotas: map[quotaService]int{"n1ql": 512}},
+			wantErr: true,
+		},
+		{
+			name:   "unknown version",
+			image:  "couchbase:latest",
+			config: Config{enabledServices: []service{kv, analytics}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateImage(tt.image, &tt.config)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+		})
+	}
+}
+
+func TestServiceQuota(t *testing.T) {
+	c := &CouchbaseContainer{config: &Config{
+		buckets:       []bucket{NewBucket("a").WithQuota(512), NewBucket("b").WithQuota(512)},
+		serviceQuotas: map[quotaService]int{SearchService: 1024},
+	}}
+
+	if quota := c.serviceQuota(kv); quota != 1024 {
+		t.Errorf("expected the data quota to hold the buckets, got %d MB", quota)
+	}
+
+	if quota := c.serviceQuota(search); quota != 1024 {
+		t.Errorf("expected the search quota of the option, got %d MB", quota)
+	}
+
+	if quota := c.serviceQuota(index); quota != index.minimumQuotaMb {
+		t.Errorf("expected the minimum index quota, got %d MB", quota)
+	}
+}
@@ -0,0 +1,231 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// autoFailover is the auto-failover policy of the cluster
+type autoFailover struct {
+	enabled bool
+	// timeout is the time a node must be unresponsive before it's failed over
+	timeout time.Duration
+	// maxCount is the number of nodes failed over before the auto-failover is disabled, until it's reset
+	maxCount int
+}
+
+// alert is an event of the cluster triggering an email alert
+type alert string
+
+// alerts {
+const (
+	// AlertAutoFailoverNode is raised when a node is failed over automatically
+	AlertAutoFailoverNode alert = "auto_failover_node"
+	// AlertAutoFailoverMaximumReached is raised when the maximum number of nodes failed over automatically is reached
+	AlertAutoFailoverMaximumReached alert = "auto_failover_maximum_reached"
+	// AlertAutoFailoverOtherNodesDown is raised when a node can't be failed over, because other nodes are down
+	AlertAutoFailoverOtherNodesDown alert = "auto_failover_other_nodes_down"
+	// AlertAutoFailoverClusterTooSmall is raised when a node can't be failed over, because the cluster is too small
+	AlertAutoFailoverClusterTooSmall alert = "auto_failover_cluster_too_small"
+	// AlertAutoFailoverDisabled is raised when a node can't be failed over, because the auto-failover is disabled
+	AlertAutoFailoverDisabled alert = "auto_failover_disabled"
+	// AlertDisk is raised when the disk usage of a node is too high
+	AlertDisk alert = "disk"
+)
+
+// }
+
+// emailAlerts is the configuration of the email alerts of the cluster
+type emailAlerts struct {
+	sender     string
+	recipients []string
+	host       string
+	port       int
+	encrypt    bool
+	username   string
+	password   string
+	// alerts are the events sending an email, all the auto-failover events if empty
+	alerts []alert
+}
+
+// NewEmailAlerts creates the configuration of the email alerts sent by the cluster from the given sender
+// to the given recipients, using an SMTP server on localhost:25 and all the auto-failover alerts by default.
+func NewEmailAlerts(sender string, recipients ...string) emailAlerts {
+	return emailAlerts{
+		sender:     sender,
+		recipients: recipients,
+		host:       "localhost",
+		port:       25,
+		alerts: []alert{
+			AlertAutoFailoverNode,
+			AlertAutoFailoverMaximumReached,
+			AlertAutoFailoverOtherNodesDown,
+			AlertAutoFailoverClusterTooSmall,
+			AlertAutoFailoverDisabled,
+		},
+	}
+}
+
+// WithServer sets the SMTP server sending the emails, e.g. a MailHog container on the same network as Couchbase.
+func (a emailAlerts) WithServer(host string, port int) emailAlerts {
+	a.host = host
+	a.port = port
+	return a
+}
+
+// WithCredentials sets the credentials of the SMTP server.
+func (a emailAlerts) WithCredentials(username, password string) emailAlerts {
+	a.username = username
+	a.password = password
+	return a
+}
+
+// WithEncryption sets whether the connections to the SMTP server are encrypted.
+func (a emailAlerts) WithEncryption(encrypt bool) emailAlerts {
+	a.encrypt = encrypt
+	return a
+}
+
+// WithAlerts sets the events sending an email, replacing the auto-failover alerts.
+func (a emailAlerts) WithAlerts(alerts ...alert) emailAlerts {
+	a.alerts = alerts
+	return a
+}
+
+// WithAutoFailover enables the auto-failover of the cluster: a node unresponsive for the given timeout is failed over,
+// until the given number of nodes are failed over. The timeout must be between 5 seconds and 1 hour,
+// and more than one node can only be failed over by the Enterprise Edition of Couchbase Server.
+func WithAutoFailover(timeout time.Duration, maxCount int) Option {
+	return func(c *Config) {
+		c.autoFailover = &autoFailover{
+			enabled:  true,
+			timeout:  timeout,
+			maxCount: maxCount,
+		}
+	}
+}
+
+// WithoutAutoFailover disables the auto-failover of the cluster, which is enabled by Couchbase Server by default.
+func WithoutAutoFailover() Option {
+	return func(c *Config) {
+		c.autoFailover = &autoFailover{enabled: false}
+	}
+}
+
+// WithEmailAlerts enables the email alerts of the cluster, e.g. when a node is failed over.
+func WithEmailAlerts(alerts emailAlerts) Option {
+	return func(c *Config) {
+		c.emailAlerts = &alerts
+	}
+}
+
+// validate checks that the auto-failover policy is supported by the edition of Couchbase Server
+func (a autoFailover) validate(isEnterprise bool) error {
+	if !a.enabled {
+		return nil
+	}
+
+	if a.timeout < 5*time.Second || a.timeout > time.Hour {
+		return fmt.Errorf("the auto-failover timeout must be between 5s and 1h, got %s", a.timeout)
+	}
+
+	if a.maxCount < 1 {
+		return fmt.Errorf("the auto-failover maximum count must be at least 1, got %d", a.maxCount)
+	}
+
+	if a.maxCount > 1 && !isEnterprise {
+		return fmt.Errorf("failing over more than one node is only supported with the Enterprise version, got a maximum count of %d", a.maxCount)
+	}
+
+	return nil
+}
+
+// form returns the parameters of the /settings/autoFailover endpoint
+func (a autoFailover) form() map[string]string {
+	if !a.enabled {
+		return map[string]string{"enabled": "false"}
+	}
+
+	return map[string]string{
+		"enabled":  "true",
+		"timeout":  strconv.Itoa(int(a.timeout.Seconds())),
+		"maxCount": strconv.Itoa(a.maxCount),
+	}
+}
+
+// validate checks that the email alerts can be sent
+func (a emailAlerts) validate() error {
+	if a.sender == "" {
+		return fmt.Errorf("the email alerts require a sender")
+	}
+
+	if len(a.recipients) == 0 {
+		return fmt.Errorf("the email alerts require at least one recipient")
+	}
+
+	return nil
+}
+
+// form returns the parameters of the /settings/alerts endpoint
+func (a emailAlerts) form() map[string]string {
+	alerts := make([]string, len(a.alerts))
+	for i, alert := range a.alerts {
+		alerts[i] = string(alert)
+	}
+
+	return map[string]string{
+		"enabled":      "true",
+		"sender":       a.sender,
+		"recipients":   strings.Join(a.recipients, ","),
+		"emailHost":    a.host,
+		"emailPort":    strconv.Itoa(a.port),
+		"emailEncrypt": strconv.FormatBool(a.encrypt),
+		"emailUser":    a.username,
+		"emailPass":    a.password,
+		"alerts":       strings.Join(alerts, ","),
+	}
+}
+
+// validateSettings checks the settings of the cluster against the detected edition of Couchbase Server,
+// before the cluster is set up
+func (c *CouchbaseContainer) validateSettings(_ context.Context) error {
+	if c.config.autoFailover != nil {
+		if err := c.config.autoFailover.validate(c.config.isEnterprise); err != nil {
+			return err
+		}
+	}
+
+	if c.config.emailAlerts != nil {
+		if err := c.config.emailAlerts.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *CouchbaseContainer) configureAutoFailover(ctx context.Context) error {
+	return c.postSettings(ctx, "/settings/autoFailover", c.config.autoFailover.form())
+}
+
+func (c *CouchbaseContainer) configureEmailAlerts(ctx context.Context) error {
+	return c.postSettings(ctx, "/settings/alerts", c.config.emailAlerts.form())
+}
+
+// postSettings posts the given settings of the cluster, returning the error reported by Couchbase Server if they are rejected
+func (c *CouchbaseContainer) postSettings(ctx context.Context, path string, body map[string]string) error {
+	response, status, err := c.doHttpRequestWithStatus(ctx, MGMT_PORT, path, http.MethodPost, body, true)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("could not configure %s: unexpected status code %d: %s", path, status, strings.TrimSpace(string(response)))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package couchbase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAutoFailoverValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		autoFailover autoFailover
+		isEnterprise bool
+		wantErr      bool
+	}{
+		{
+			name:         "disabled",
+			autoFailover: autoFailover{enabled: false},
+		},
+		{
+			name:         "one node",
+			autoFailover: autoFailover{enabled: true, timeout: 30 * time.Second, maxCount: 1},
+		},
+		{
+			name:         "timeout too short",
+			autoFailover: autoFailover{enabled: true, timeout: time.Second, maxCount: 1},
+			wantErr:      true,
+		},
+		{
+			name:         "several nodes with the community edition",
+			autoFailover: autoFailover{enabled: true, timeout: 30 * time.Second, maxCount: 2},
+			wantErr:      true,
+		},
+		{
+			name:         "several nodes with the enterprise edition",
+			autoFailover: autoFailover{enabled: true, timeout: 30 * time.Second, maxCount: 2},
+			isEnterprise: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.autoFailover.validate(tt.isEnterprise)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAutoFailoverForm(t *testing.T) {
+	config := &Config{}
+	WithAutoFailover(10*time.Second, 1)(config)
+
+	want := map[string]string{"enabled": "true", "timeout": "10", "maxCount": "1"}
+	if got := config.autoFailover.form(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	WithoutAutoFailover()(config)
+
+	want = map[string]string{"enabled": "false"}
+	if got := config.autoFailover.form(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEmailAlertsForm(t *testing.T) {
+	alerts := NewEmailAlerts("couchbase@example.com", "ops@example.com", "dev@example.com").
+		WithServer("mailhog", 1025).
+		WithAlerts(AlertAutoFailoverNode, AlertDisk)
+
+	if err := alerts.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"enabled":      "true",
+		"sender":       "couchbase@example.com",
+		"recipients":   "ops@example.com,dev@example.com",
+		"emailHost":    "mailhog",
+		"emailPort":    "1025",
+		"emailEncrypt": "false",
+		"emailUser":    "",
+		"emailPass":    "",
+		"alerts":       "auto_failover_node,disk",
+	}
+	if got := alerts.form(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if err := NewEmailAlerts("couchbase@example.com").validate(); err == nil {
+		t.Fatal("expected an error for the alerts without recipients")
+	}
+}
@@ -0,0 +1,428 @@
+// Package stack starts an opinionated Couchbase Mobile stack: a Couchbase Server container, with a seeded bucket,
+// and a Sync Gateway container serving the bucket over TLS, for the tests of the mobile and edge sync topologies.
+package stack
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	tccouchbase "github.com/testcontainers/testcontainers-go/modules/couchbase"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// PUBLIC_PORT is the port of the public REST API of Sync Gateway, used by Couchbase Lite to replicate the documents
+	PUBLIC_PORT = "4984"
+	// ADMIN_PORT is the port of the admin REST API of Sync Gateway
+	ADMIN_PORT = "4985"
+
+	// defaultImages {
+	defaultCouchbaseImage   = "couchbase:enterprise-7.1.3"
+	defaultSyncGatewayImage = "couchbase/sync-gateway:3.1.0-enterprise"
+	// }
+
+	configPath = "/etc/sync_gateway/config.json"
+	certPath   = "/etc/sync_gateway/cert.pem"
+	keyPath    = "/etc/sync_gateway/key.pem"
+)
+
+// Stack is a Couchbase Server container and a Sync Gateway container serving one of its buckets as a database
+type Stack struct {
+	// Couchbase is the Couchbase Server container, storing the documents of the database
+	Couchbase *tccouchbase.CouchbaseContainer
+	// SyncGateway is the Sync Gateway container, serving its APIs over TLS
+	SyncGateway testcontainers.Container
+
+	database  string
+	tlsConfig *tls.Config
+	// certDir is the temporary directory of the generated certificate and of the configuration of Sync Gateway
+	certDir string
+}
+
+// Option is a function that configures the stack
+type Option func(*options)
+
+type options struct {
+	couchbaseImage   string
+	syncGatewayImage string
+	bucket           string
+	documents        map[string]interface{}
+	users            map[string]string
+	couchbaseOptions []testcontainers.ContainerCustomizer
+}
+
+// WithImages sets the images of Couchbase Server, which must be 7.0 or later, and of Sync Gateway, which must be 3.0 or later
+func WithImages(couchbaseImage, syncGatewayImage string) Option {
+	return func(o *options) {
+		o.couchbaseImage = couchbaseImage
+		o.syncGatewayImage = syncGatewayImage
+	}
+}
+
+// WithBucket sets the name of the bucket created in Couchbase Server, which is also the name of the Sync Gateway database.
+// By default, the bucket is named "demo".
+func WithBucket(name string) Option {
+	return func(o *options) {
+		o.bucket = name
+	}
+}
+
+// WithDocuments seeds the database with the given documents, by their IDs, once Sync Gateway is serving it.
+// It can be called multiple times.
+func WithDocuments(documents map[string]interface{}) Option {
+	return func(o *options) {
+		for id, doc := range documents {
+			o.documents[id] = doc
+		}
+	}
+}
+
+// WithUser creates a Sync Gateway user, with access to all the channels of the database, e.g. for the replicators of Couchbase Lite.
+// It can be called multiple times.
+func WithUser(username, password string) Option {
+	return func(o *options) {
+		o.users[username] = password
+	}
+}
+
+// WithCouchbaseOptions passes the given options to the Couchbase Server container, e.g. its credentials,
+// which are also used by Sync Gateway
+func WithCouchbaseOptions(opts ...testcontainers.ContainerCustomizer) Option {
+	return func(o *options) {
+		o.couchbaseOptions = append(o.couchbaseOptions, opts...)
+	}
+}
+
+// StartStack starts a Couchbase Server container with a bucket, and a Sync Gateway container serving the bucket as a database
+// over TLS, with a certificate generated for the stack. It returns once the database is online, seeded with the documents
+// and the users of the options.
+func StartStack(ctx context.Context, opts ...Option) (_ *Stack, err error) {
+	settings := options{
+		couchbaseImage:   defaultCouchbaseImage,
+		syncGatewayImage: defaultSyncGatewayImage,
+		bucket:           "demo",
+		documents:        map[string]interface{}{},
+		users:            map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	// the documents are checked before starting the containers
+	docs, err := bulkDocs(settings.documents)
+	if err != nil {
+		return nil, err
+	}
+
+	certDir, err := os.MkdirTemp("", "couchbase-stack-")
+	if err != nil {
+		return nil, err
+	}
+
+	stack := &Stack{database: settings.bucket, certDir: certDir}
+	defer func() {
+		// the containers already started are removed if the stack can't start
+		if err != nil {
+			_ = stack.Terminate(context.Background())
+		}
+	}()
+
+	stack.tlsConfig, err = generateCertificate(certDir)
+	if err != nil {
+		return nil, err
+	}
+
+	couchbaseOptions := append([]testcontainers.ContainerCustomizer{
+		tccouchbase.WithImageName(settings.couchbaseImage),
+		tccouchbase.WithBucket(tccouchbase.NewBucket(settings.bucket)),
+	}, settings.couchbaseOptions...)
+
+	stack.Couchbase, err = tccouchbase.StartContainer(ctx, couchbaseOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not start Couchbase Server", err)
+	}
+
+	if err = stack.startSyncGateway(ctx, settings.syncGatewayImage); err != nil {
+		return nil, err
+	}
+
+	if err = stack.createDatabase(ctx, settings.bucket); err != nil {
+		return nil, err
+	}
+
+	for username, password := range settings.users {
+		if err = stack.createUser(ctx, username, password); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(docs) > 0 {
+		if err = stack.seedDocuments(ctx, docs); err != nil {
+			return nil, err
+		}
+	}
+
+	return stack, nil
+}
+
+// startSyncGateway starts the Sync Gateway container, connected to the Couchbase Server container
+// through its internal IP address
+func (s *Stack) startSyncGateway(ctx context.Context, image string) error {
+	ipAddress, err := s.Couchbase.ContainerIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	config := map[string]interface{}{
+		"bootstrap": map[string]interface{}{
+			"server":   "couchbase://" + ipAddress,
+			"username": s.Couchbase.Username(),
+			"password": s.Couchbase.Password(),
+		},
+		"api": map[string]interface{}{
+			"public_interface": ":" + PUBLIC_PORT,
+			"admin_interface":  ":" + ADMIN_PORT,
+			"https": map[string]string{
+				"tls_cert_path": certPath,
+				"tls_key_path":  keyPath,
+			},
+		},
+		"logging": map[string]interface{}{
+			"console": map[string]string{"log_level": "info"},
+		},
+	}
+
+	configFile := filepath.Join(s.certDir, "config.json")
+	content, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(configFile, content, 0o600); err != nil {
+		return err
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        image,
+		Cmd:          []string{configPath},
+		ExposedPorts: []string{PUBLIC_PORT + "/tcp", ADMIN_PORT + "/tcp"},
+		Files: []testcontainers.ContainerFile{
+			{HostFilePath: configFile, ContainerFilePath: configPath, FileMode: 0o644},
+			{HostFilePath: filepath.Join(s.certDir, "cert.pem"), ContainerFilePath: certPath, FileMode: 0o644},
+			{HostFilePath: filepath.Join(s.certDir, "key.pem"), ContainerFilePath: keyPath, FileMode: 0o644},
+		},
+		// the certificate may not be valid for the address used by the wait strategy
+		WaitingFor: wait.ForHTTP("/").WithPort(PUBLIC_PORT + "/tcp").WithTLS(true).WithAllowInsecure(true),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: could not start Sync Gateway", err)
+	}
+
+	s.SyncGateway = container
+
+	return nil
+}
+
+// createDatabase creates the database of the bucket, waiting until it's online
+func (s *Stack) createDatabase(ctx context.Context, bucket string) error {
+	body := map[string]interface{}{
+		"bucket": bucket,
+		// the cluster has a single node
+		"num_index_replicas": 0,
+	}
+
+	if _, err := s.adminRequest(ctx, http.MethodPut, "/"+s.database+"/", body, http.StatusCreated); err != nil {
+		return fmt.Errorf("%w: could not create the database %s", err, s.database)
+	}
+
+	return backoff.Retry(func() error {
+		content, err := s.adminRequest(ctx, http.MethodGet, "/"+s.database+"/", nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+
+		var info struct {
+			State string `json:"state"`
+		}
+		if err := json.Unmarshal(content, &info); err != nil {
+			return backoff.Permanent(err)
+		}
+
+		if info.State != "Online" {
+			return fmt.Errorf("the database %s is %s", s.database, info.State)
+		}
+
+		return nil
+	}, backoff.WithContext(backoff.NewConstantBackOff(time.Second), ctx))
+}
+
+func (s *Stack) createUser(ctx context.Context, username, password string) error {
+	body := map[string]interface{}{
+		"password":       password,
+		"admin_channels": []string{"*"},
+	}
+
+	if _, err := s.adminRequest(ctx, http.MethodPut, "/"+s.database+"/_user/"+username, body, http.StatusCreated); err != nil {
+		return fmt.Errorf("%w: could not create the user %s", err, username)
+	}
+
+	return nil
+}
+
+// bulkDocs returns the documents in the format of the bulk API of Sync Gateway, with their IDs in the _id field
+func bulkDocs(documents map[string]interface{}) ([]map[string]interface{}, error) {
+	docs := make([]map[string]interface{}, 0, len(documents))
+	for id, doc := range documents {
+		content, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not encode the document %s", err, id)
+		}
+
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(content, &fields); err != nil {
+			return nil, fmt.Errorf("%w: the document %s must be a JSON object", err, id)
+		}
+
+		fields["_id"] = id
+		docs = append(docs, fields)
+	}
+
+	return docs, nil
+}
+
+func (s *Stack) seedDocuments(ctx context.Context, docs []map[string]interface{}) error {
+	body := map[string]interface{}{"docs": docs}
+	if _, err := s.adminRequest(ctx, http.MethodPost, "/"+s.database+"/_bulk_docs", body, http.StatusCreated); err != nil {
+		return fmt.Errorf("%w: could not seed the documents", err)
+	}
+
+	return nil
+}
+
+// adminRequest sends a request to the admin API of Sync Gateway, authenticated with the credentials of Couchbase Server,
+// returning the body of the response if it has the expected status code
+func (s *Stack) adminRequest(ctx context.Context, method, path string, body interface{}, expectedStatus int) ([]byte, error) {
+	adminURL, err := s.AdminURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, adminURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.Couchbase.Username(), s.Couchbase.Password())
+
+	resp, err := s.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("unexpected status code %d for %s %s: %s", resp.StatusCode, method, path, content)
+	}
+
+	return content, nil
+}
+
+// Database returns the name of the Sync Gateway database, which is also the name of the bucket
+func (s *Stack) Database() string {
+	return s.database
+}
+
+// PublicURL returns the URL of the public REST API of Sync Gateway, e.g. https://localhost:49153
+func (s *Stack) PublicURL(ctx context.Context) (string, error) {
+	return s.url(ctx, "https", PUBLIC_PORT)
+}
+
+// AdminURL returns the URL of the admin REST API of Sync Gateway, e.g. https://localhost:49154
+func (s *Stack) AdminURL(ctx context.Context) (string, error) {
+	return s.url(ctx, "https", ADMIN_PORT)
+}
+
+// ReplicationURL returns the URL of the database for the replicators of Couchbase Lite, e.g. wss://localhost:49153/demo
+func (s *Stack) ReplicationURL(ctx context.Context) (string, error) {
+	u, err := s.url(ctx, "wss", PUBLIC_PORT)
+	if err != nil {
+		return "", err
+	}
+
+	return u + "/" + s.database, nil
+}
+
+func (s *Stack) url(ctx context.Context, scheme, port string) (string, error) {
+	host, err := s.SyncGateway.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := s.SyncGateway.MappedPort(ctx, nat.Port(port+"/tcp"))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://%s:%d", scheme, host, mappedPort.Int()), nil
+}
+
+// TLSConfig returns a TLS configuration trusting the certificate generated for Sync Gateway
+func (s *Stack) TLSConfig() *tls.Config {
+	return s.tlsConfig.Clone()
+}
+
+// HTTPClient returns an HTTP client trusting the certificate generated for Sync Gateway
+func (s *Stack) HTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: s.TLSConfig()},
+	}
+}
+
+// Terminate terminates the Sync Gateway container and the Couchbase Server container, and removes the generated certificate
+func (s *Stack) Terminate(ctx context.Context) error {
+	if s.SyncGateway != nil {
+		if err := s.SyncGateway.Terminate(ctx); err != nil {
+			return fmt.Errorf("%w: could not terminate Sync Gateway", err)
+		}
+	}
+
+	if s.Couchbase != nil {
+		if err := s.Couchbase.Terminate(ctx); err != nil {
+			return fmt.Errorf("%w: could not terminate Couchbase Server", err)
+		}
+	}
+
+	return os.RemoveAll(s.certDir)
+}
@@ -0,0 +1,88 @@
+package stack_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go/modules/couchbase/stack"
+)
+
+func TestStack(t *testing.T) {
+	ctx := context.Background()
+
+	// startStack {
+	s, err := stack.StartStack(ctx,
+		stack.WithBucket("inventory"),
+		stack.WithUser("mobile", "mobile-password"),
+		stack.WithDocuments(map[string]interface{}{
+			"item::1": map[string]interface{}{"name": "bike", "stock": 3},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the containers after the test is complete
+	t.Cleanup(func() {
+		if err := s.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate the stack: %s", err)
+		}
+	})
+
+	replicationURL, err := s.ReplicationURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(replicationURL, "wss://") || !strings.HasSuffix(replicationURL, "/inventory") {
+		t.Fatalf("unexpected replication URL %s", replicationURL)
+	}
+
+	// readDocument {
+	publicURL, err := s.PublicURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, publicURL+"/"+s.Database()+"/item::1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("mobile", "mobile-password")
+
+	// the client trusts the certificate generated for the stack
+	resp, err := s.HTTPClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	// }
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var doc struct {
+		Name  string `json:"name"`
+		Stock int    `json:"stock"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Name != "bike" || doc.Stock != 3 {
+		t.Fatalf("expected the seeded document, got %+v", doc)
+	}
+}
+
+func TestStackWithInvalidDocument(t *testing.T) {
+	// the documents of Sync Gateway must be JSON objects
+	_, err := stack.StartStack(context.Background(), stack.WithDocuments(map[string]interface{}{"invalid": "not an object"}))
+	if err == nil {
+		t.Fatal("expected an error seeding a document which is not a JSON object")
+	}
+}
@@ -0,0 +1,86 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/tidwall/gjson"
+)
+
+// WithInitStatements adds N1QL statements executed in order once the buckets and their primary indexes are created,
+// e.g. to create the scopes, collections and indexes, seed the data or define the functions used by the tests.
+// The statements are retried until the query service accepts them, e.g. while a new keyspace is not known yet,
+// except the statements with a syntax or semantic error, which fail the start of the container.
+func WithInitStatements(statements ...string) Option {
+	return func(c *Config) {
+		c.initStatements = append(c.initStatements, statements...)
+	}
+}
+
+// validateInitStatements checks that the query service is enabled to execute the init statements
+func validateInitStatements(config *Config) error {
+	if len(config.initStatements) > 0 && !contains(config.enabledServices, query) {
+		return errors.New("the init statements can't be executed, since the query service is not present")
+	}
+
+	return nil
+}
+
+// executeInitStatements executes the init statements in order, retrying each statement until it succeeds
+func (c *CouchbaseContainer) executeInitStatements(ctx context.Context) error {
+	for _, statement := range c.config.initStatements {
+		body := map[string]string{
+			"statement": statement,
+		}
+
+		err := backoff.Retry(func() error {
+			response, err := c.doHttpRequest(ctx, QUERY_PORT, "/query/service", http.MethodPost, body, true)
+			if err != nil {
+				return err
+			}
+
+			return queryError(response)
+		}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+		if err != nil {
+			return fmt.Errorf("%w: init statement %q failed", err, statement)
+		}
+	}
+
+	return nil
+}
+
+// queryError returns the errors reported in the response of the query service, nil if the statement succeeded.
+// The syntax and semantic errors, in the 3000 range of the error codes, are returned as permanent errors,
+// as retrying the statement won't fix them.
+func queryError(response []byte) error {
+	result := gjson.ParseBytes(response)
+	if result.Get("status").String() == "success" {
+		return nil
+	}
+
+	errs := result.Get("errors").Array()
+	if len(errs) == 0 {
+		return fmt.Errorf("unexpected query response: %s", strings.TrimSpace(string(response)))
+	}
+
+	messages := make([]string, len(errs))
+	permanent := false
+	for i, e := range errs {
+		code := e.Get("code").Int()
+		messages[i] = fmt.Sprintf("%d: %s", code, e.Get("msg").String())
+		if code >= 3000 && code < 4000 {
+			permanent = true
+		}
+	}
+
+	err := errors.New(strings.Join(messages, ", "))
+	if permanent {
+		return backoff.Permanent(err)
+	}
+
+	return err
+}
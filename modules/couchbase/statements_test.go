@@ -0,0 +1,64 @@
+package couchbase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+func TestQueryError(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		wantErr   bool
+		permanent bool
+	}{
+		{
+			name:     "success",
+			response: `{"status": "success", "results": []}`,
+		},
+		{
+			name:     "unknown keyspace",
+			response: `{"status": "fatal", "errors": [{"code": 12003, "msg": "Keyspace not found in CB datastore: default:testBucket"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:      "syntax error",
+			response:  `{"status": "fatal", "errors": [{"code": 3000, "msg": "syntax error - line 1, column 1, near 'SELEC', at: SELEC"}]}`,
+			wantErr:   true,
+			permanent: true,
+		},
+		{
+			name:     "unexpected response",
+			response: `Service Unavailable`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := queryError([]byte(tt.response))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("queryError() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var permanent *backoff.PermanentError
+			if isPermanent := errors.As(err, &permanent); isPermanent != tt.permanent {
+				t.Fatalf("expected the error to be permanent: %t, got %v", tt.permanent, err)
+			}
+		})
+	}
+}
+
+func TestValidateInitStatements(t *testing.T) {
+	config := &Config{enabledServices: []service{kv, query}, initStatements: []string{"SELECT 1"}}
+	if err := validateInitStatements(config); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	config.enabledServices = []service{kv}
+	if err := validateInitStatements(config); err == nil {
+		t.Fatal("expected an error without the query service")
+	}
+}
@@ -0,0 +1,113 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// dataPath is the directory of the data of Couchbase Server, which must be writable by the user running it
+const dataPath = "/opt/couchbase/var"
+
+// ErrRootUser is returned when the non-root user of the container is root
+var ErrRootUser = errors.New("the non-root user of the Couchbase container can't be root")
+
+// containerUser is the user running Couchbase Server in the container, instead of root
+type containerUser struct {
+	uid int
+	gid int
+}
+
+func (u containerUser) String() string {
+	return strconv.Itoa(u.uid) + ":" + strconv.Itoa(u.gid)
+}
+
+// WithNonRootUser runs Couchbase Server with the given user and group IDs, instead of root, as required by the hardened
+// Docker hosts. The data directory of Couchbase Server is stored in a volume, and the volumes mounted in the container
+// are owned by the user before it starts, changing their owner from a short-lived init container.
+func WithNonRootUser(uid, gid int) Option {
+	return func(c *Config) {
+		c.user = &containerUser{uid: uid, gid: gid}
+	}
+}
+
+// prepareNonRootUser configures the request to run the container with the non-root user, mounting a volume
+// in the data directory if there isn't one, and changing the owner of the volumes to the user
+func prepareNonRootUser(ctx context.Context, user containerUser, req *testcontainers.GenericContainerRequest) error {
+	if user.uid <= 0 || user.gid < 0 {
+		return fmt.Errorf("%w: got %s", ErrRootUser, user)
+	}
+
+	req.User = user.String()
+
+	if !hasMount(req.Mounts, dataPath) {
+		volume := "couchbase-data-" + testcontainerssession.NewUUID().String()
+		req.Mounts = append(req.Mounts, testcontainers.VolumeMount(volume, dataPath))
+	}
+
+	return chownVolumes(ctx, req.Image, user, req.Mounts)
+}
+
+// chownVolumes changes the owner of the volumes to the given user, from an init container running as root,
+// which mounts the volumes as the Couchbase container will do, and exits once they are owned by the user
+func chownVolumes(ctx context.Context, image string, user containerUser, mounts testcontainers.ContainerMounts) error {
+	volumes := testcontainers.ContainerMounts{}
+	targets := []string{}
+	for _, m := range mounts {
+		if m.Source.Type() != testcontainers.MountTypeVolume {
+			continue
+		}
+
+		volumes = append(volumes, m)
+		targets = append(targets, m.Target.Target())
+	}
+
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	initContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      image,
+			User:       "0:0",
+			Entrypoint: append([]string{"chown", "-R", user.String()}, targets...),
+			Mounts:     volumes,
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	})
+	if initContainer != nil {
+		defer func() {
+			_ = initContainer.Terminate(ctx)
+		}()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: could not change the owner of the volumes", err)
+	}
+
+	state, err := initContainer.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state.ExitCode != 0 {
+		return fmt.Errorf("could not change the owner of the volumes to %s, exit code %d", user, state.ExitCode)
+	}
+
+	return nil
+}
+
+func hasMount(mounts testcontainers.ContainerMounts, target string) bool {
+	for _, m := range mounts {
+		if m.Target.Target() == target {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,30 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestPrepareNonRootUserWithRoot(t *testing.T) {
+	req := testcontainers.GenericContainerRequest{}
+
+	err := prepareNonRootUser(context.Background(), containerUser{uid: 0, gid: 0}, &req)
+	if !errors.Is(err, ErrRootUser) {
+		t.Fatalf("expected %v, got %v", ErrRootUser, err)
+	}
+}
+
+func TestHasMount(t *testing.T) {
+	mounts := testcontainers.Mounts(testcontainers.VolumeMount("data", dataPath))
+
+	if !hasMount(mounts, dataPath) {
+		t.Fatalf("expected a mount in %s", dataPath)
+	}
+
+	if hasMount(mounts, "/tmp") {
+		t.Fatal("expected no mount in /tmp")
+	}
+}
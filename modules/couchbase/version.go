@@ -0,0 +1,76 @@
+package couchbase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// serverVersion is the version of Couchbase Server running in the container, detected during the initialization
+// of the cluster, so the settings only supported by the newer versions can be validated before being applied
+type serverVersion struct {
+	major int
+	minor int
+	patch int
+}
+
+// parseServerVersion parses the implementation version reported by Couchbase Server, e.g. "7.2.0-5325-enterprise"
+func parseServerVersion(implementationVersion string) (serverVersion, error) {
+	release, _, _ := strings.Cut(implementationVersion, "-")
+
+	parts := strings.Split(release, ".")
+	if len(parts) != 3 {
+		return serverVersion{}, fmt.Errorf("invalid Couchbase Server version %q", implementationVersion)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return serverVersion{}, fmt.Errorf("%w: invalid Couchbase Server version %q", err, implementationVersion)
+		}
+
+		numbers[i] = n
+	}
+
+	return serverVersion{major: numbers[0], minor: numbers[1], patch: numbers[2]}, nil
+}
+
+// atLeast returns true if the version is equal or greater than the given major and minor version
+func (v serverVersion) atLeast(major, minor int) bool {
+	if v.major != major {
+		return v.major > major
+	}
+
+	return v.minor >= minor
+}
+
+func (v serverVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// imageVersion returns the version and the edition of Couchbase Server from the tag of the given image, e.g. couchbase:7.2.0,
+// couchbase:community-7.1.1 or couchbase/server:enterprise-7.2.0, the official images without an edition being the Enterprise Edition.
+// It returns false if the tag doesn't carry a version, e.g. latest or a digest, so the checks are deferred until
+// the version is detected from the running server.
+func imageVersion(image string) (serverVersion, bool, bool) {
+	if strings.Contains(image, "@") {
+		return serverVersion{}, false, false
+	}
+
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return serverVersion{}, false, false
+	}
+	tag := image[i+1:]
+
+	isEnterprise := !strings.HasPrefix(tag, "community-")
+	tag = strings.TrimPrefix(strings.TrimPrefix(tag, "community-"), "enterprise-")
+
+	version, err := parseServerVersion(tag)
+	if err != nil {
+		return serverVersion{}, false, false
+	}
+
+	return version, isEnterprise, true
+}
@@ -0,0 +1,136 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/tidwall/gjson"
+)
+
+// SetupXDCR sets up the cross datacenter replication of a bucket from the source container to the target container,
+// e.g. to test the code reading the replicated documents. It creates a reference to the target cluster in the source
+// cluster, if it doesn't exist yet, and a continuous replication of the bucket to the bucket with the same name in the
+// target cluster, and waits until the replication is running. The bucket must exist in both clusters.
+//
+// The source container reaches the target container with its address in their Docker network,
+// so both containers must be in the same network, e.g. the default bridge network.
+func SetupXDCR(ctx context.Context, source, target *CouchbaseContainer, bucket string) error {
+	remoteCluster, err := source.createRemoteClusterReference(ctx, target)
+	if err != nil {
+		return fmt.Errorf("%w: could not create the reference to the target cluster", err)
+	}
+
+	id, err := source.createReplication(ctx, remoteCluster, bucket)
+	if err != nil {
+		return fmt.Errorf("%w: could not create the replication of bucket %s", err, bucket)
+	}
+
+	if err := source.waitUntilReplicationIsRunning(ctx, id); err != nil {
+		return fmt.Errorf("%w: the replication of bucket %s is not running", err, bucket)
+	}
+
+	return nil
+}
+
+// createRemoteClusterReference creates the reference to the target cluster, named after the target container,
+// returning its name. An existing reference is reused, so several buckets can be replicated to the same cluster.
+func (c *CouchbaseContainer) createRemoteClusterReference(ctx context.Context, target *CouchbaseContainer) (string, error) {
+	name := "testcontainers-" + target.GetContainerID()
+
+	response, status, err := c.doHttpRequestWithStatus(ctx, MGMT_PORT, "/pools/default/remoteClusters", http.MethodGet, nil, true)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d: %s", status, strings.TrimSpace(string(response)))
+	}
+
+	for _, reference := range gjson.ParseBytes(response).Array() {
+		if reference.Get("name").String() == name && !reference.Get("deleted").Bool() {
+			return name, nil
+		}
+	}
+
+	host, err := target.internalHost(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body := map[string]string{
+		"name":     name,
+		"hostname": host + ":" + MGMT_PORT,
+		"username": target.config.username,
+		"password": target.config.password,
+	}
+
+	response, status, err = c.doHttpRequestWithStatus(ctx, MGMT_PORT, "/pools/default/remoteClusters", http.MethodPost, body, true)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d: %s", status, strings.TrimSpace(string(response)))
+	}
+
+	return name, nil
+}
+
+// createReplication creates a continuous replication of the bucket to the bucket with the same name
+// in the remote cluster, returning the identifier of the replication
+func (c *CouchbaseContainer) createReplication(ctx context.Context, remoteCluster string, bucket string) (string, error) {
+	body := map[string]string{
+		"fromBucket":      bucket,
+		"toCluster":       remoteCluster,
+		"toBucket":        bucket,
+		"replicationType": "continuous",
+	}
+
+	response, status, err := c.doHttpRequestWithStatus(ctx, MGMT_PORT, "/controller/createReplication", http.MethodPost, body, true)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d: %s", status, strings.TrimSpace(string(response)))
+	}
+
+	id := gjson.GetBytes(response, "id").String()
+	if id == "" {
+		return "", fmt.Errorf("no replication identifier in the response: %s", strings.TrimSpace(string(response)))
+	}
+
+	return id, nil
+}
+
+// waitUntilReplicationIsRunning waits until the XDCR task of the replication is running
+func (c *CouchbaseContainer) waitUntilReplicationIsRunning(ctx context.Context, id string) error {
+	return backoff.Retry(func() error {
+		response, err := c.doHttpRequest(ctx, MGMT_PORT, "/pools/default/tasks", http.MethodGet, nil, true)
+		if err != nil {
+			return err
+		}
+
+		status, ok := replicationStatus(response, id)
+		if !ok {
+			return errors.New("replication task not found")
+		}
+		if status != "running" {
+			return fmt.Errorf("replication status is %s", status)
+		}
+
+		return nil
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+}
+
+// replicationStatus returns the status of the XDCR task of the replication in the tasks of the cluster, if it's found
+func replicationStatus(tasks []byte, id string) (string, bool) {
+	for _, task := range gjson.ParseBytes(tasks).Array() {
+		if task.Get("type").String() == "xdcr" && task.Get("id").String() == id {
+			return task.Get("status").String(), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,20 @@
+package couchbase
+
+import "testing"
+
+func TestReplicationStatus(t *testing.T) {
+	tasks := []byte(`[
+		{"type": "rebalance", "status": "notRunning"},
+		{"type": "xdcr", "id": "a1b2/other/other", "status": "notRunning"},
+		{"type": "xdcr", "id": "a1b2/testBucket/testBucket", "status": "running"}
+	]`)
+
+	status, ok := replicationStatus(tasks, "a1b2/testBucket/testBucket")
+	if !ok || status != "running" {
+		t.Errorf("expected the replication to be running, got %q, found %t", status, ok)
+	}
+
+	if _, ok := replicationStatus(tasks, "c3d4/testBucket/testBucket"); ok {
+		t.Error("expected the replication not to be found")
+	}
+}
@@ -0,0 +1,276 @@
+package cups
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	ippPort = "631/tcp"
+
+	defaultPrinter = "testcontainers"
+
+	spoolPath = "/var/spool/cups"
+
+	// dockerfile {
+	dockerfile = `FROM alpine:3.18
+RUN apk add --no-cache cups cups-client && echo "FileDevice Yes" >> /etc/cups/cups-files.conf
+COPY cupsd.conf /etc/cups/cupsd.conf
+COPY start.sh /usr/local/bin/start.sh
+ENTRYPOINT ["sh", "/usr/local/bin/start.sh"]
+`
+	// }
+
+	// cupsdConf accepts the jobs of any client without authentication, and preserves the jobs and their documents
+	cupsdConf = `LogLevel warn
+Listen 0.0.0.0:631
+Listen /run/cups/cups.sock
+ServerAlias *
+DefaultEncryption Never
+WebInterface No
+PreserveJobHistory Yes
+PreserveJobFiles Yes
+MaxJobs 0
+<Location />
+  Order allow,deny
+  Allow all
+</Location>
+<Policy default>
+  <Limit All>
+    Order allow,deny
+    Allow all
+  </Limit>
+</Policy>
+`
+
+	// startScript creates the printer once the scheduler is running, discarding the output of its device,
+	// as the documents of the jobs are preserved in the spool directory
+	startScript = `#!/bin/sh
+cupsd -f &
+until lpstat -r | grep -q "is running"; do sleep 0.1; done
+lpadmin -p "$PRINTER" -E -v file:///dev/null
+echo "printer $PRINTER is ready"
+wait
+`
+)
+
+// ErrInvalidPrinter is returned when the name of the printer contains characters not allowed by CUPS
+var ErrInvalidPrinter = errors.New("the name of the printer can't be empty, nor contain spaces, slashes or the # character")
+
+// CUPSContainer represents the CUPS container type used in the module
+type CUPSContainer struct {
+	testcontainers.Container
+	printer string
+}
+
+// Job is a print job captured by the printer of the container
+type Job struct {
+	ID   int
+	User string
+	Size int // in bytes, rounded up to kilobytes by CUPS
+}
+
+// Option is a function that configures the CUPS module, e.g. the name of the printer.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	printer string
+}
+
+// WithPrinter sets the name of the printer created when the container starts. By default, the name is "testcontainers".
+func WithPrinter(printer string) Option {
+	return func(o *options) {
+		o.printer = printer
+	}
+}
+
+// StartContainer creates an instance of the CUPS container type, a print server with a single printer capturing its jobs,
+// so the printing integrations can be tested without physical printers. The image is built from Alpine with CUPS.
+// It accepts the options of the module, and the generic options of the testcontainers package,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*CUPSContainer, error) {
+	settings := options{
+		printer: defaultPrinter,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.printer == "" || strings.ContainsAny(settings.printer, " \t/#") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPrinter, settings.printer)
+	}
+
+	buildContext, err := buildContextArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	req := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			ContextArchive: buildContext,
+		},
+		Env: map[string]string{
+			"PRINTER": settings.printer,
+		},
+		ExposedPorts: []string{ippPort},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("printer "+settings.printer+" is ready"),
+			wait.ForListeningPort(ippPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &CUPSContainer{Container: container, printer: settings.printer}, err
+}
+
+// buildContextArchive returns the build context of the image, with the Dockerfile, the configuration of CUPS and the start script
+func buildContextArchive() (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+	tw := tar.NewWriter(buffer)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "Dockerfile", content: dockerfile},
+		{name: "cupsd.conf", content: cupsdConf},
+		{name: "start.sh", content: startScript},
+	}
+
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name: f.name,
+			Mode: 0o644,
+			Size: int64(len(f.content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer, nil
+}
+
+// Printer returns the name of the printer of the container
+func (c *CUPSContainer) Printer() string {
+	return c.printer
+}
+
+// IPPPort returns the IPP port of the print server mapped on the host
+func (c *CUPSContainer) IPPPort(ctx context.Context) (int, error) {
+	port, err := c.MappedPort(ctx, ippPort)
+	if err != nil {
+		return 0, err
+	}
+
+	return port.Int(), nil
+}
+
+// PrinterURI returns the IPP URI of the printer, e.g. ipp://localhost:49153/printers/testcontainers
+func (c *CUPSContainer) PrinterURI(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, ippPort, "ipp")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/printers/" + c.printer, nil
+}
+
+// Jobs returns the jobs captured by the printer, completed or not, ordered by ID
+func (c *CUPSContainer) Jobs(ctx context.Context) ([]Job, error) {
+	code, reader, err := c.Exec(ctx, []string{"lpstat", "-W", "all", "-o", c.printer}, tcexec.Multiplexed())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != 0 {
+		return nil, fmt.Errorf("could not list the jobs, exit code %d: %s", code, output)
+	}
+
+	return parseJobs(c.printer, output)
+}
+
+// parseJobs parses the output of lpstat, whose lines start with the ID of the job, the user and the size,
+// e.g. "testcontainers-1 root 1024 Mon 17 Apr 2023 10:21:32 AM UTC"
+func parseJobs(printer string, output []byte) ([]Job, error) {
+	jobs := []Job{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], printer+"-") {
+			continue
+		}
+
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[0], printer+"-"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid ID of the job %s", err, fields[0])
+		}
+
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid size of the job %d", err, id)
+		}
+
+		jobs = append(jobs, Job{ID: id, User: fields[1], Size: size})
+	}
+
+	return jobs, scanner.Err()
+}
+
+// JobDocument returns the content of the first document of the given job, as sent by the client
+func (c *CUPSContainer) JobDocument(ctx context.Context, id int) ([]byte, error) {
+	path := fmt.Sprintf("%s/d%05d-001", spoolPath, id)
+
+	reader, err := c.CopyFileFromContainer(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read the document of the job %d", err, id)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
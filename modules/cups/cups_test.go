@@ -0,0 +1,142 @@
+package cups
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// printJob sends the document to the printer with an IPP Print-Job request, as a printing integration would do
+func printJob(ctx context.Context, printerURI string, document []byte) error {
+	body := &bytes.Buffer{}
+	// IPP 2.0, Print-Job operation, request ID 1
+	body.Write([]byte{0x02, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x01})
+	// operation attributes
+	body.WriteByte(0x01)
+	writeAttribute := func(tag byte, name string, value string) {
+		body.WriteByte(tag)
+		_ = binary.Write(body, binary.BigEndian, uint16(len(name)))
+		body.WriteString(name)
+		_ = binary.Write(body, binary.BigEndian, uint16(len(value)))
+		body.WriteString(value)
+	}
+	writeAttribute(0x47, "attributes-charset", "utf-8")
+	writeAttribute(0x48, "attributes-natural-language", "en")
+	writeAttribute(0x45, "printer-uri", printerURI)
+	writeAttribute(0x42, "requesting-user-name", "alice")
+	writeAttribute(0x49, "document-format", "application/octet-stream")
+	// end of the attributes, followed by the document
+	body.WriteByte(0x03)
+	body.Write(document)
+
+	httpURL := strings.Replace(printerURI, "ipp://", "http://", 1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, httpURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/ipp")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	status := make([]byte, 4)
+	if _, err := resp.Body.Read(status); err != nil {
+		return err
+	}
+
+	// the status codes lower than 0x0100 are successful
+	if code := binary.BigEndian.Uint16(status[2:4]); code >= 0x0100 {
+		return fmt.Errorf("unexpected IPP status 0x%04x", code)
+	}
+
+	return nil
+}
+
+func TestCUPS(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithPrinter("office"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// printerURI {
+	printerURI, err := container.PrinterURI(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if err := printJob(ctx, printerURI, []byte("hello printer")); err != nil {
+		t.Fatal(err)
+	}
+
+	// capturedJobs {
+	jobs, err := container.Jobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+
+	document, err := container.JobDocument(ctx, jobs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if jobs[0].User != "alice" {
+		t.Fatalf("expected the job of alice, got %s", jobs[0].User)
+	}
+
+	if string(document) != "hello printer" {
+		t.Fatalf("expected the printed document, got %s", document)
+	}
+}
+
+func TestCUPSWithInvalidPrinter(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithPrinter("my printer"))
+	if !errors.Is(err, ErrInvalidPrinter) {
+		t.Fatalf("expected %v, got %v", ErrInvalidPrinter, err)
+	}
+}
+
+func TestParseJobs(t *testing.T) {
+	output := `office-1                alice          1024   Mon 17 Apr 2023 10:21:32 AM UTC
+office-12               root           3072   Mon 17 Apr 2023 10:22:10 AM UTC
+`
+
+	jobs, err := parseJobs("office", []byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Job{
+		{ID: 1, User: "alice", Size: 1024},
+		{ID: 12, User: "root", Size: 3072},
+	}
+
+	if !reflect.DeepEqual(jobs, want) {
+		t.Fatalf("expected %v, got %v", want, jobs)
+	}
+}
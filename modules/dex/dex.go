@@ -0,0 +1,207 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "ghcr.io/dexidp/dex:v2.37.0"
+	// }
+
+	httpPort = "5556/tcp"
+
+	// issuerPath is the path of the issuer, under which all the endpoints of Dex are served
+	issuerPath = "/dex"
+
+	configFile = "/etc/dex/testcontainers.yaml"
+)
+
+// DexContainer represents the Dex container type used in the module
+type DexContainer struct {
+	testcontainers.Container
+	settings options
+}
+
+// Option is a function that configures the Dex module, e.g. its static clients.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	clients   []staticClient
+	passwords []staticPassword
+}
+
+type staticClient struct {
+	ID           string   `json:"id"`
+	Secret       string   `json:"secret"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirectURIs,omitempty"`
+}
+
+type staticPassword struct {
+	Email    string `json:"email"`
+	password string
+	Hash     string `json:"hash"`
+	Username string `json:"username"`
+	UserID   string `json:"userID"`
+}
+
+// WithStaticClient registers an OAuth2 client with the given ID and secret, allowed to redirect the users
+// to the given URIs after their login, e.g. the callback of the application under test. It can be called multiple times.
+func WithStaticClient(id string, secret string, redirectURIs ...string) Option {
+	return func(o *options) {
+		o.clients = append(o.clients, staticClient{ID: id, Secret: secret, Name: id, RedirectURIs: redirectURIs})
+	}
+}
+
+// WithStaticPassword registers a user logging in with the given email and password, in the local password database of Dex.
+// The tokens of the user contain the email and the username as claims. It can be called multiple times.
+func WithStaticPassword(email string, password string, username string) Option {
+	return func(o *options) {
+		o.passwords = append(o.passwords, staticPassword{Email: email, password: password, Username: username, UserID: username})
+	}
+}
+
+// StartContainer creates an instance of the Dex container type, an OpenID Connect provider with the static clients
+// and passwords of the options, storing its state in memory. The issuer is the URL of Dex on the host, which
+// is only known once the port is mapped, so the configuration is rendered after the container is started.
+// It waits until the discovery document of the issuer is served.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*DexContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{httpPort},
+		// the issuer needs the mapped port, which is only known after the container is started,
+		// so the container waits for the configuration, copied once the port is mapped
+		Entrypoint: []string{"sh"},
+		Cmd:        []string{"-c", "while [ ! -f " + configFile + " ]; do sleep 0.1; done; exec dex serve " + configFile},
+		WaitingFor: wait.ForHTTP(issuerPath + "/.well-known/openid-configuration").WithPort(httpPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	// the container is not ready until the configuration is copied, so the wait strategy is run afterwards
+	waitingFor := genericContainerReq.WaitingFor
+	genericContainerReq.WaitingFor = nil
+	genericContainerReq.Started = false
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	dexContainer := &DexContainer{Container: container, settings: settings}
+
+	if err := dexContainer.start(ctx, waitingFor); err != nil {
+		return dexContainer, err
+	}
+
+	return dexContainer, nil
+}
+
+// start starts the container, and copies the configuration with the issuer on the mapped port,
+// then it waits until Dex is ready
+func (c *DexContainer) start(ctx context.Context, waitingFor wait.Strategy) error {
+	if err := c.Start(ctx); err != nil {
+		return err
+	}
+
+	issuerURL, err := c.IssuerURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	config, err := renderConfig(issuerURL, c.settings)
+	if err != nil {
+		return fmt.Errorf("%w: could not render the configuration", err)
+	}
+
+	if err := c.CopyToContainer(ctx, config, configFile, 0o644); err != nil {
+		return fmt.Errorf("%w: could not copy the configuration", err)
+	}
+
+	if waitingFor == nil {
+		return nil
+	}
+
+	return waitingFor.WaitUntilReady(ctx, c)
+}
+
+// renderConfig returns the configuration of Dex, in JSON format which is valid YAML: the given issuer, served on all
+// the interfaces of the container, the memory storage, and the static clients and passwords of the options.
+// The password grant is enabled, so the tests can get tokens without the login form.
+func renderConfig(issuerURL string, o options) ([]byte, error) {
+	passwords := make([]staticPassword, 0, len(o.passwords))
+	for _, p := range o.passwords {
+		hash, err := bcrypt.GenerateFromPassword([]byte(p.password), bcrypt.MinCost)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Hash = string(hash)
+		passwords = append(passwords, p)
+	}
+
+	clients := o.clients
+	if clients == nil {
+		clients = []staticClient{}
+	}
+
+	config := map[string]interface{}{
+		"issuer":  issuerURL,
+		"storage": map[string]interface{}{"type": "memory"},
+		"web":     map[string]interface{}{"http": "0.0.0.0:5556"},
+		"oauth2": map[string]interface{}{
+			"skipApprovalScreen": true,
+			"passwordConnector":  "local",
+		},
+		"enablePasswordDB": true,
+		"staticClients":    clients,
+		"staticPasswords":  passwords,
+	}
+
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// IssuerURL returns the URL of the issuer, e.g. http://localhost:49153/dex, which is also the base URL of
+// the endpoints of Dex, and must be used by the clients to discover them and to verify the tokens
+func (c *DexContainer) IssuerURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s%s", host, mappedPort.Port(), issuerPath), nil
+}
@@ -0,0 +1,132 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDex(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithStaticClient("app", "secret", "http://localhost:8080/callback"),
+		WithStaticPassword("admin@example.com", "password", "admin"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// issuerURL {
+	issuerURL, err := container.IssuerURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	resp, err := http.Get(issuerURL + "/.well-known/openid-configuration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var discovery struct {
+		Issuer        string `json:"issuer"`
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		t.Fatal(err)
+	}
+
+	if discovery.Issuer != issuerURL {
+		t.Fatalf("expected the issuer %s, got %s", issuerURL, discovery.Issuer)
+	}
+
+	form := url.Values{
+		"grant_type": {"password"},
+		"username":   {"admin@example.com"},
+		"password":   {"password"},
+		"scope":      {"openid email"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("app", "secret")
+
+	tokenResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tokenResp.Body.Close()
+
+	if tokenResp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, tokenResp.StatusCode)
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(tokenResp.Body).Decode(&token); err != nil {
+		t.Fatal(err)
+	}
+
+	if token.IDToken == "" {
+		t.Fatal("expected an ID token for the static password")
+	}
+}
+
+func TestRenderConfig(t *testing.T) {
+	settings := options{}
+	WithStaticClient("app", "secret", "http://localhost:8080/callback")(&settings)
+	WithStaticPassword("admin@example.com", "password", "admin")(&settings)
+
+	content, err := renderConfig("http://localhost:5556/dex", settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var config struct {
+		Issuer          string           `json:"issuer"`
+		StaticClients   []staticClient   `json:"staticClients"`
+		StaticPasswords []staticPassword `json:"staticPasswords"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Issuer != "http://localhost:5556/dex" {
+		t.Fatalf("expected the given issuer, got %s", config.Issuer)
+	}
+
+	if len(config.StaticClients) != 1 || config.StaticClients[0].RedirectURIs[0] != "http://localhost:8080/callback" {
+		t.Fatalf("expected the static client, got %+v", config.StaticClients)
+	}
+
+	if len(config.StaticPasswords) != 1 || config.StaticPasswords[0].UserID != "admin" {
+		t.Fatalf("expected the static password, got %+v", config.StaticPasswords)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(config.StaticPasswords[0].Hash), []byte("password")); err != nil {
+		t.Fatalf("expected the bcrypt hash of the password: %s", err)
+	}
+
+	if strings.Contains(string(content), `"password"`) {
+		t.Fatal("expected the password to be hashed in the configuration")
+	}
+}
@@ -0,0 +1,198 @@
+package dind
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker:23.0-dind"
+	// }
+
+	// daemonPort is the TLS port of the nested Docker daemon
+	daemonPort = "2376/tcp"
+
+	// certsDir is the directory of the certificates generated by the container when it starts
+	certsDir = "/certs"
+	// clientCertsDir is the directory of the TLS client certificates of the nested Docker daemon
+	clientCertsDir = certsDir + "/client"
+)
+
+// clientCerts are the files of the TLS client certificates, named as expected by the Docker clients
+var clientCerts = []string{"ca.pem", "cert.pem", "key.pem"}
+
+// DindContainer represents the Docker-in-Docker container type used in the module
+type DindContainer struct {
+	testcontainers.Container
+	// certPath is the directory of the TLS client certificates, copied from the container
+	certPath string
+}
+
+// StartContainer creates an instance of the Docker-in-Docker container type, running a nested Docker daemon,
+// so the tools driving Docker can be tested against a daemon of their own. The container runs privileged,
+// and the nested daemon is exposed with the TLS certificates generated when the container starts.
+// It accepts the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*DindContainer, error) {
+	host, err := daemonHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{daemonPort},
+		Privileged:   true,
+		Env: map[string]string{
+			"DOCKER_TLS_CERTDIR": certsDir,
+			// the certificate of the nested daemon must be valid for the host running the tests
+			"DOCKER_TLS_SAN": subjectAltName(host),
+		},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("API listen on [::]:2376"),
+			wait.ForListeningPort(daemonPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	dindContainer := &DindContainer{Container: container}
+	if err != nil {
+		return dindContainer, err
+	}
+
+	if dindContainer.certPath, err = copyClientCerts(ctx, container); err != nil {
+		return dindContainer, err
+	}
+
+	return dindContainer, nil
+}
+
+// DockerHost returns the address of the nested Docker daemon, with its port mapped on the host,
+// e.g. tcp://localhost:49153
+func (c *DindContainer) DockerHost(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, daemonPort)
+	if err != nil {
+		return "", err
+	}
+
+	return "tcp://" + net.JoinHostPort(host, port.Port()), nil
+}
+
+// CertPath returns the directory of the TLS client certificates of the nested Docker daemon, ca.pem, cert.pem and key.pem,
+// as expected in the DOCKER_CERT_PATH environment variable of the Docker clients. It's removed when the container is terminated.
+func (c *DindContainer) CertPath() string {
+	return c.certPath
+}
+
+// ProviderOption returns the option of the Docker provider targeting the nested Docker daemon
+func (c *DindContainer) ProviderOption(ctx context.Context) (testcontainers.DockerProviderOption, error) {
+	host, err := c.DockerHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return testcontainers.WithDockerHost(host, c.certPath), nil
+}
+
+// Provider returns a Docker provider creating the containers in the nested Docker daemon. The ports of these containers
+// are published in the Docker-in-Docker container, not on the host. The provider must be closed once it's not used anymore.
+func (c *DindContainer) Provider(ctx context.Context, opts ...testcontainers.DockerProviderOption) (*testcontainers.DockerProvider, error) {
+	opt, err := c.ProviderOption(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return testcontainers.NewDockerProvider(append(opts, opt)...)
+}
+
+// Terminate terminates the container, and all the containers of the nested Docker daemon,
+// removing the TLS client certificates copied from the container
+func (c *DindContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	if c.certPath != "" {
+		defer os.RemoveAll(c.certPath)
+	}
+
+	return c.Container.Terminate(ctx, opts...)
+}
+
+// copyClientCerts copies the TLS client certificates generated by the container to a temporary directory,
+// returning its path
+func copyClientCerts(ctx context.Context, container testcontainers.Container) (string, error) {
+	dir, err := os.MkdirTemp("", "testcontainers-dind-")
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range clientCerts {
+		if err := copyFile(ctx, container, clientCertsDir+"/"+name, filepath.Join(dir, name)); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", fmt.Errorf("%w: could not copy the TLS client certificate %s", err, name)
+		}
+	}
+
+	return dir, nil
+}
+
+func copyFile(ctx context.Context, container testcontainers.Container, containerPath string, hostPath string) error {
+	reader, err := container.CopyFileFromContainer(ctx, containerPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	f, err := os.OpenFile(hostPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, reader)
+	return err
+}
+
+// daemonHost returns the host of the Docker daemon, where the ports of the container are published
+func daemonHost(ctx context.Context) (string, error) {
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return "", err
+	}
+	defer provider.Close()
+
+	return provider.DaemonHost(ctx)
+}
+
+// subjectAltName returns the subject alternative name of the certificate of the nested daemon for the given host,
+// an IP address or a DNS name
+func subjectAltName(host string) string {
+	if net.ParseIP(host) != nil {
+		return "IP:" + host
+	}
+
+	return "DNS:" + host
+}
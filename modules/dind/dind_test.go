@@ -0,0 +1,80 @@
+package dind
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDind(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	for _, name := range clientCerts {
+		if _, err := os.Stat(filepath.Join(container.CertPath(), name)); err != nil {
+			t.Fatalf("expected the TLS client certificate %s: %s", name, err)
+		}
+	}
+
+	// nestedProvider {
+	provider, err := container.Provider(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	nested, err := provider.RunContainer(ctx, testcontainers.ContainerRequest{
+		Image:      "alpine:3.17",
+		Cmd:        []string{"echo", "hello from the nested daemon"},
+		WaitingFor: wait.ForLog("hello from the nested daemon"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	info, err := provider.Client().Info(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Containers != 1 {
+		t.Fatalf("expected the container to be created in the nested daemon, got %d containers", info.Containers)
+	}
+
+	if err := nested.Terminate(ctx); err != nil {
+		t.Fatalf("failed to terminate the nested container: %s", err)
+	}
+}
+
+func TestSubjectAltName(t *testing.T) {
+	tests := map[string]string{
+		"localhost":      "DNS:localhost",
+		"docker.local":   "DNS:docker.local",
+		"192.168.99.100": "IP:192.168.99.100",
+		"::1":            "IP:::1",
+	}
+
+	for host, want := range tests {
+		if got := subjectAltName(host); got != want {
+			t.Errorf("subjectAltName(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
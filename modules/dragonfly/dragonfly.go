@@ -0,0 +1,134 @@
+package dragonfly
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/docker/go-units"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/rediscompat"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.dragonflydb.io/dragonflydb/dragonfly:v1.3.0"
+	// }
+)
+
+// DragonflyContainer represents the Dragonfly container type used in the module
+type DragonflyContainer struct {
+	testcontainers.Container
+	password string
+}
+
+// Option is a function that configures the Dragonfly module, e.g. the number of proactor threads.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	proactorThreads int
+	maxMemory       string
+	cacheMode       bool
+	password        string
+}
+
+// WithProactorThreads sets the number of threads used by Dragonfly to handle the connections and the data shards,
+// using the --proactor_threads flag. By default, Dragonfly uses as many threads as CPUs are available to the container.
+func WithProactorThreads(threads int) Option {
+	return func(o *options) {
+		o.proactorThreads = threads
+	}
+}
+
+// WithMaxMemory sets the limit of memory used by Dragonfly, e.g. "1gb", using the --maxmemory flag
+func WithMaxMemory(maxMemory string) Option {
+	return func(o *options) {
+		o.maxMemory = maxMemory
+	}
+}
+
+// WithCacheMode enables the cache mode of Dragonfly, which evicts the least recently used keys
+// when the memory limit is reached, using the --cache_mode flag
+func WithCacheMode() Option {
+	return func(o *options) {
+		o.cacheMode = true
+	}
+}
+
+// WithPassword sets the password required to authenticate the clients, using the --requirepass flag
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// StartContainer creates an instance of the Dragonfly container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*DragonflyContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Cmd:          settings.flags(),
+		ExposedPorts: []string{rediscompat.Port},
+		// Dragonfly locks the memory used by its io_uring based engine
+		Ulimits: []*units.Ulimit{
+			{Name: "memlock", Soft: -1, Hard: -1},
+		},
+		WaitingFor: wait.ForListeningPort(rediscompat.Port),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &DragonflyContainer{Container: container, password: settings.password}, err
+}
+
+// ConnectionString returns the Redis compatible connection string of Dragonfly,
+// with the format redis://[:password@]<host>:<port>, which can be parsed by the Redis clients
+func (c *DragonflyContainer) ConnectionString(ctx context.Context) (string, error) {
+	return rediscompat.ConnectionString(ctx, c, rediscompat.Settings{Password: c.password})
+}
+
+// flags returns the command line flags of Dragonfly for the options of the module
+func (o options) flags() []string {
+	var flags []string
+
+	if o.proactorThreads > 0 {
+		flags = append(flags, "--proactor_threads", strconv.Itoa(o.proactorThreads))
+	}
+	if o.maxMemory != "" {
+		flags = append(flags, "--maxmemory", o.maxMemory)
+	}
+	if o.cacheMode {
+		flags = append(flags, "--cache_mode=true")
+	}
+	if o.password != "" {
+		flags = append(flags, "--requirepass", o.password)
+	}
+
+	return flags
+}
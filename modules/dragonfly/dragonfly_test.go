@@ -0,0 +1,91 @@
+package dragonfly
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestDragonfly(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithProactorThreads(2),
+		WithCacheMode(),
+		WithPassword("secret"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := redis.ParseURL(connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := redis.NewClient(options)
+	defer client.Close()
+	// }
+
+	if err := client.Set(ctx, "greeting", "hello", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := client.Get(ctx, "greeting").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "hello" {
+		t.Fatalf("expected hello, got %s", value)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		want     []string
+	}{
+		{
+			name:     "default flags",
+			settings: options{},
+			want:     nil,
+		},
+		{
+			name: "all the flags",
+			settings: options{
+				proactorThreads: 4,
+				maxMemory:       "1gb",
+				cacheMode:       true,
+				password:        "secret",
+			},
+			want: []string{"--proactor_threads", "4", "--maxmemory", "1gb", "--cache_mode=true", "--requirepass", "secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.flags(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package elasticsearch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.elastic.co/elasticsearch/elasticsearch:8.9.0"
+	// }
+
+	httpPort = "9200/tcp"
+
+	defaultUsername = "elastic"
+	defaultPassword = "changeme"
+
+	// caCertPath is the path of the CA certificate generated by the 8.x images, signing the certificate of the HTTP layer
+	caCertPath = "/usr/share/elasticsearch/config/certs/http_ca.crt"
+)
+
+// ErrInvalidCACert is returned when the CA certificate of the container can't be parsed
+var ErrInvalidCACert = errors.New("invalid CA certificate")
+
+// ElasticsearchContainer represents the Elasticsearch container type used in the module
+type ElasticsearchContainer struct {
+	testcontainers.Container
+	password string
+	caCert   []byte
+}
+
+// Option is a function that configures the Elasticsearch module, e.g. the password of the elastic user.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	password string
+}
+
+// WithPassword sets the password of the elastic superuser. By default, the password is "changeme".
+// It's only used by the images with the security enabled, which is the default since 8.0.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// StartContainer creates an instance of the Elasticsearch container type, running a single-node cluster.
+// The security is enabled by default since 8.0, so the container of those images serves HTTPS,
+// with a certificate signed by a CA generated when it starts, and requires the password of the elastic user.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ElasticsearchContainer, error) {
+	settings := options{
+		password: defaultPassword,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{httpPort},
+		Env: map[string]string{
+			"discovery.type": "single-node",
+			// the disk watermarks would make the indices read-only on the machines with little free space
+			"cluster.routing.allocation.disk.threshold_enabled": "false",
+			"ELASTIC_PASSWORD": settings.password,
+			"ES_JAVA_OPTS":     "-Xms1g -Xmx1g",
+		},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	// the image could be replaced by the options, so the wait strategy depends on the final one
+	secured := isSecuredByDefault(genericContainerReq.Image)
+	if genericContainerReq.WaitingFor == nil {
+		genericContainerReq.WaitingFor = wait.ForHTTP("/").
+			WithPort(httpPort).
+			// the CA is not known until the container is started
+			WithTLS(secured, &tls.Config{InsecureSkipVerify: true}).
+			WithBasicAuth(defaultUsername, settings.password)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	esContainer := &ElasticsearchContainer{Container: container, password: settings.password}
+	if err != nil {
+		return esContainer, err
+	}
+
+	if secured {
+		if esContainer.caCert, err = esContainer.readCACert(ctx); err != nil {
+			return esContainer, err
+		}
+	}
+
+	return esContainer, nil
+}
+
+// isSecuredByDefault returns true if the image enables the security by default, which is the case from 8.0.
+// The images whose version can't be parsed, e.g. latest, are considered secured.
+func isSecuredByDefault(image string) bool {
+	i := strings.LastIndex(image, ":")
+	// the colon of a registry port is not the separator of the tag
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return true
+	}
+	tag := image[i+1:]
+
+	major, err := strconv.Atoi(strings.SplitN(tag, ".", 2)[0])
+	if err != nil {
+		return true
+	}
+
+	return major >= 8
+}
+
+func (c *ElasticsearchContainer) readCACert(ctx context.Context) ([]byte, error) {
+	reader, err := c.CopyFileFromContainer(ctx, caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read the CA certificate", err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// Username returns the superuser of the cluster, elastic
+func (c *ElasticsearchContainer) Username() string {
+	return defaultUsername
+}
+
+// Password returns the password of the elastic user
+func (c *ElasticsearchContainer) Password() string {
+	return c.password
+}
+
+// CACert returns the CA certificate, in the PEM format, signing the certificate of the HTTP layer,
+// or nil if the security is disabled, e.g. for the 7.x images
+func (c *ElasticsearchContainer) CACert() []byte {
+	return c.caCert
+}
+
+// Address returns the address of the HTTP layer of the cluster, e.g. https://localhost:49153,
+// with the https scheme if the security is enabled
+func (c *ElasticsearchContainer) Address(ctx context.Context) (string, error) {
+	scheme := "http"
+	if c.caCert != nil {
+		scheme = "https"
+	}
+
+	return c.PortEndpoint(ctx, httpPort, scheme)
+}
+
+// TLSConfig returns a TLS configuration trusting the CA certificate of the container,
+// to be used by the transport of the HTTP clients. It returns nil if the security is disabled.
+func (c *ElasticsearchContainer) TLSConfig() (*tls.Config, error) {
+	if c.caCert == nil {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(c.caCert) {
+		return nil, ErrInvalidCACert
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
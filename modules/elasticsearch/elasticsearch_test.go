@@ -0,0 +1,142 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type clusterInfo struct {
+	ClusterName string `json:"cluster_name"`
+	Version     struct {
+		Number string `json:"number"`
+	} `json:"version"`
+}
+
+func TestElasticsearch(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithPassword("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// httpClient {
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig, err := container.TLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(container.Username(), container.Password())
+	// }
+
+	if !strings.HasPrefix(address, "https://") {
+		t.Fatalf("expected an https address, got %s", address)
+	}
+
+	info := getClusterInfo(t, client, req)
+	if !strings.HasPrefix(info.Version.Number, "8.") {
+		t.Fatalf("expected a 8.x version, got %s", info.Version.Number)
+	}
+}
+
+func TestElasticsearch7(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartContainer(ctx, testcontainers.WithImage("docker.elastic.co/elasticsearch/elasticsearch:7.17.10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if container.CACert() != nil {
+		t.Fatal("expected no CA certificate without security")
+	}
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := getClusterInfo(t, http.DefaultClient, req)
+	if !strings.HasPrefix(info.Version.Number, "7.") {
+		t.Fatalf("expected a 7.x version, got %s", info.Version.Number)
+	}
+}
+
+func getClusterInfo(t *testing.T, client *http.Client, req *http.Request) clusterInfo {
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var info clusterInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		t.Fatal(err)
+	}
+
+	return info
+}
+
+func TestIsSecuredByDefault(t *testing.T) {
+	tests := []struct {
+		image string
+		want  bool
+	}{
+		{image: "docker.elastic.co/elasticsearch/elasticsearch:8.9.0", want: true},
+		{image: "docker.elastic.co/elasticsearch/elasticsearch:7.17.10", want: false},
+		{image: "elasticsearch:latest", want: true},
+		{image: "localhost:5000/elasticsearch", want: true},
+		{image: "localhost:5000/elasticsearch:7.10.1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := isSecuredByDefault(tt.image); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package flink
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "flink:1.17.1"
+	// }
+
+	restPort = "8081/tcp"
+
+	// jobManagerAlias is the hostname of the job manager in the network of the cluster
+	jobManagerAlias = "jobmanager"
+
+	// pollInterval is the interval between the queries of the state of a job while waiting for it
+	pollInterval = 500 * time.Millisecond
+)
+
+// FlinkContainer represents a Flink session cluster: the job manager container, serving the REST API,
+// and the task manager containers running the jobs, attached to a network created for the cluster
+type FlinkContainer struct {
+	// Container is the job manager container
+	testcontainers.Container
+	// TaskManagers are the task manager containers, registered in the job manager
+	TaskManagers []testcontainers.Container
+
+	network     testcontainers.Network
+	networkName string
+}
+
+// Option is a function that configures the Flink module, e.g. the number of task managers.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	taskManagers int
+	taskSlots    int
+	properties   map[string]string
+}
+
+// WithTaskManagers sets the number of task manager containers of the cluster, 1 by default
+func WithTaskManagers(count int) Option {
+	return func(o *options) {
+		o.taskManagers = count
+	}
+}
+
+// WithTaskSlots sets the number of task slots of each task manager, 2 by default, which bounds the parallelism of the jobs
+func WithTaskSlots(slots int) Option {
+	return func(o *options) {
+		o.taskSlots = slots
+	}
+}
+
+// WithProperty sets a property of the configuration of Flink, e.g. "parallelism.default", in the job manager
+// and the task managers. It can be called multiple times.
+func WithProperty(key string, value string) Option {
+	return func(o *options) {
+		o.properties[key] = value
+	}
+}
+
+// StartContainer starts a Flink session cluster: a job manager and the task managers, attached to a network created for the cluster.
+// It returns once all the task managers are registered in the job manager, so the jobs can be submitted.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied to all the containers of the cluster after their default configuration.
+// The containers already started are removed if the cluster can't start.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (_ *FlinkContainer, err error) {
+	settings := options{
+		taskManagers: 1,
+		taskSlots:    2,
+		properties:   map[string]string{},
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.taskManagers < 1 {
+		return nil, fmt.Errorf("the cluster needs at least one task manager, got %d", settings.taskManagers)
+	}
+
+	networkName := "flink-" + uuid.NewString()
+	network, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{Name: networkName, CheckDuplicate: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not create the network of the cluster", err)
+	}
+
+	cluster := &FlinkContainer{network: network, networkName: networkName}
+	defer func() {
+		if err != nil {
+			_ = cluster.Terminate(context.Background())
+		}
+	}()
+
+	properties := settings.flinkProperties()
+
+	jobManagerReq := testcontainers.ContainerRequest{
+		Image:          defaultImage,
+		Cmd:            []string{"jobmanager"},
+		Env:            map[string]string{"FLINK_PROPERTIES": properties},
+		ExposedPorts:   []string{restPort},
+		Networks:       []string{networkName},
+		NetworkAliases: map[string][]string{networkName: {jobManagerAlias}},
+		WaitingFor:     wait.ForHTTP("/overview").WithPort(restPort),
+	}
+
+	cluster.Container, err = startContainer(ctx, jobManagerReq, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not start the job manager", err)
+	}
+
+	for i := 0; i < settings.taskManagers; i++ {
+		taskManagerReq := testcontainers.ContainerRequest{
+			Image:    defaultImage,
+			Cmd:      []string{"taskmanager"},
+			Env:      map[string]string{"FLINK_PROPERTIES": properties},
+			Networks: []string{networkName},
+		}
+
+		taskManager, err := startContainer(ctx, taskManagerReq, opts)
+		if taskManager != nil {
+			cluster.TaskManagers = append(cluster.TaskManagers, taskManager)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not start the task manager %d", err, i+1)
+		}
+	}
+
+	registered := wait.ForHTTP("/overview").
+		WithPort(restPort).
+		WithJSONPathMatcher("taskmanagers", strconv.Itoa(settings.taskManagers))
+	if err = registered.WaitUntilReady(ctx, cluster.Container); err != nil {
+		return nil, fmt.Errorf("%w: the task managers did not register in the job manager", err)
+	}
+
+	return cluster, nil
+}
+
+// startContainer starts a container of the cluster, applying the generic options
+func startContainer(ctx context.Context, req testcontainers.ContainerRequest, opts []testcontainers.ContainerCustomizer) (testcontainers.Container, error) {
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	return testcontainers.GenericContainer(ctx, genericContainerReq)
+}
+
+// flinkProperties returns the configuration of Flink for the options, in the format of the FLINK_PROPERTIES
+// environment variable of the image, which appends it to the configuration file
+func (o options) flinkProperties() string {
+	properties := map[string]string{
+		"jobmanager.rpc.address":        jobManagerAlias,
+		"taskmanager.numberOfTaskSlots": strconv.Itoa(o.taskSlots),
+	}
+	for k, v := range o.properties {
+		properties[k] = v
+	}
+
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	// the properties are sorted, so the containers of the same options are identical
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+": "+properties[k])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RESTURL returns the URL of the REST API of the job manager, with the format http://<host>:<port>,
+// which also serves the web UI of Flink
+func (c *FlinkContainer) RESTURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, restPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// NetworkName returns the name of the network of the cluster, e.g. to attach the containers of the sources
+// and the sinks of the jobs with testcontainers.WithNetwork. The job manager is reached at the jobmanager hostname.
+func (c *FlinkContainer) NetworkName() string {
+	return c.networkName
+}
+
+// Terminate terminates the task managers, the job manager, and removes the network of the cluster
+func (c *FlinkContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	for _, taskManager := range c.TaskManagers {
+		if err := taskManager.Terminate(ctx, opts...); err != nil {
+			return fmt.Errorf("%w: could not terminate a task manager", err)
+		}
+	}
+	c.TaskManagers = nil
+
+	if c.Container != nil {
+		if err := c.Container.Terminate(ctx, opts...); err != nil {
+			return fmt.Errorf("%w: could not terminate the job manager", err)
+		}
+		c.Container = nil
+	}
+
+	if c.network != nil {
+		if err := c.network.Remove(ctx); err != nil {
+			return fmt.Errorf("%w: could not remove the network of the cluster", err)
+		}
+		c.network = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package flink
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFlink(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithTaskManagers(2), WithTaskSlots(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the containers and the network after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate the cluster: %s", err)
+		}
+	})
+
+	if len(container.TaskManagers) != 2 {
+		t.Fatalf("expected 2 task managers, got %d", len(container.TaskManagers))
+	}
+
+	// the examples of the image are used as the jobs of the tests
+	jarPath := copyExampleJar(t, container, "/opt/flink/examples/batch/WordCount.jar")
+
+	// submitJar {
+	job, err := container.SubmitJar(ctx, jarPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	if err := job.Wait(waitCtx); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	state, err := job.State(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if state != JobFinished {
+		t.Fatalf("expected the job to be finished, got %s", state)
+	}
+
+	// the job fails with an unknown input file
+	job, err = container.SubmitJar(ctx, jarPath, "--input", "/missing.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitCtx, cancel = context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	if err := job.Wait(waitCtx); !errors.Is(err, ErrJobFailed) {
+		t.Fatalf("expected the job to fail, got %v", err)
+	}
+}
+
+// copyExampleJar copies a JAR file of the job manager to a temporary directory of the test
+func copyExampleJar(t *testing.T, container *FlinkContainer, containerPath string) string {
+	t.Helper()
+
+	reader, err := container.CopyFileFromContainer(context.Background(), containerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	jarPath := filepath.Join(t.TempDir(), filepath.Base(containerPath))
+	jar, err := os.Create(jarPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer jar.Close()
+
+	if _, err := io.Copy(jar, reader); err != nil {
+		t.Fatal(err)
+	}
+
+	return jarPath
+}
+
+func TestFlinkProperties(t *testing.T) {
+	settings := options{
+		taskSlots:  4,
+		properties: map[string]string{"parallelism.default": "2"},
+	}
+
+	want := "jobmanager.rpc.address: jobmanager\nparallelism.default: 2\ntaskmanager.numberOfTaskSlots: 4"
+	if got := settings.flinkProperties(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestStartContainerWithoutTaskManagers(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithTaskManagers(0))
+	if err == nil {
+		t.Fatal("expected an error without task managers")
+	}
+}
@@ -0,0 +1,240 @@
+package flink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// JobState is the state of a Flink job, as reported by the REST API
+type JobState string
+
+const (
+	JobCreated     JobState = "CREATED"
+	JobRunning     JobState = "RUNNING"
+	JobFailing     JobState = "FAILING"
+	JobFailed      JobState = "FAILED"
+	JobCancelling  JobState = "CANCELLING"
+	JobCanceled    JobState = "CANCELED"
+	JobFinished    JobState = "FINISHED"
+	JobRestarting  JobState = "RESTARTING"
+	JobSuspended   JobState = "SUSPENDED"
+	JobReconciling JobState = "RECONCILING"
+)
+
+// ErrJobFailed is returned when waiting for a job which failed or was canceled
+var ErrJobFailed = errors.New("the job did not finish")
+
+// terminal returns true if the job can't change its state anymore
+func (s JobState) terminal() bool {
+	return s == JobFinished || s == JobFailed || s == JobCanceled
+}
+
+// Job is a job submitted to the cluster
+type Job struct {
+	// ID is the ID of the job in Flink
+	ID string
+
+	cluster *FlinkContainer
+}
+
+// SubmitJar uploads the JAR file of a job to the job manager and runs it, with the given program arguments,
+// returning once the job is submitted. The entry class is read from the manifest of the JAR file.
+// The Wait method of the job waits for the completion of the batch jobs, and the WaitForState method
+// waits for the streaming jobs to run.
+func (c *FlinkContainer) SubmitJar(ctx context.Context, jarPath string, args ...string) (*Job, error) {
+	jarID, err := c.uploadJar(ctx, jarPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not upload the JAR file %s", err, jarPath)
+	}
+
+	if args == nil {
+		args = []string{}
+	}
+
+	var response struct {
+		JobID string `json:"jobid"`
+	}
+	if err := c.doRequest(ctx, http.MethodPost, "/jars/"+jarID+"/run", map[string]interface{}{"programArgsList": args}, &response); err != nil {
+		return nil, fmt.Errorf("%w: could not run the JAR file %s", err, jarPath)
+	}
+
+	return &Job{ID: response.JobID, cluster: c}, nil
+}
+
+// uploadJar uploads the JAR file to the job manager, returning its ID in the job manager
+func (c *FlinkContainer) uploadJar(ctx context.Context, jarPath string) (string, error) {
+	jar, err := os.Open(jarPath)
+	if err != nil {
+		return "", err
+	}
+	defer jar.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("jarfile", filepath.Base(jarPath))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(part, jar); err != nil {
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	restURL, err := c.RESTURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, restURL+"/jars/upload", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var response struct {
+		Filename string `json:"filename"`
+	}
+	if err := do(req, &response); err != nil {
+		return "", err
+	}
+
+	// the ID of the JAR file is the name of the file stored by the job manager
+	return path.Base(response.Filename), nil
+}
+
+// State returns the current state of the job
+func (j *Job) State(ctx context.Context) (JobState, error) {
+	var response struct {
+		State JobState `json:"state"`
+	}
+	if err := j.cluster.doRequest(ctx, http.MethodGet, "/jobs/"+j.ID, nil, &response); err != nil {
+		return "", fmt.Errorf("%w: could not get the state of the job %s", err, j.ID)
+	}
+
+	return response.State, nil
+}
+
+// Wait waits until the job completes, returning an error matching ErrJobFailed, with the root exception of the job,
+// if it failed or was canceled. The job is polled until the context is done, so the context must have a deadline,
+// e.g. with context.WithTimeout.
+func (j *Job) Wait(ctx context.Context) error {
+	return j.WaitForState(ctx, JobFinished)
+}
+
+// WaitForState waits until the job reaches the given state, e.g. JobRunning for a streaming job, returning an error matching
+// ErrJobFailed if the job completes in another state. The job is polled until the context is done.
+func (j *Job) WaitForState(ctx context.Context, state JobState) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var current JobState
+	for {
+		var err error
+		current, err = j.State(ctx)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		if current == state {
+			return nil
+		}
+
+		if current.terminal() {
+			return fmt.Errorf("%w: the job %s is %s: %s", ErrJobFailed, j.ID, current, j.rootException(ctx))
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: the job %s is %s, waiting for %s", ctx.Err(), j.ID, current, state)
+		case <-ticker.C:
+		}
+	}
+}
+
+// rootException returns the root exception of the job, to explain why it failed
+func (j *Job) rootException(ctx context.Context) string {
+	var response struct {
+		RootException string `json:"root-exception"`
+	}
+	if err := j.cluster.doRequest(ctx, http.MethodGet, "/jobs/"+j.ID+"/exceptions", nil, &response); err != nil {
+		return "unknown root exception: " + err.Error()
+	}
+
+	if response.RootException == "" {
+		return "no root exception"
+	}
+
+	return response.RootException
+}
+
+// Cancel cancels the job, e.g. a streaming job once the test checked its output
+func (j *Job) Cancel(ctx context.Context) error {
+	if err := j.cluster.doRequest(ctx, http.MethodPatch, "/jobs/"+j.ID+"?mode=cancel", nil, nil); err != nil {
+		return fmt.Errorf("%w: could not cancel the job %s", err, j.ID)
+	}
+
+	return nil
+}
+
+// doRequest sends the given request, in JSON format if the body is not nil, to the REST API of the job manager,
+// decoding its JSON response into the given value, if not nil
+func (c *FlinkContainer) doRequest(ctx context.Context, method string, path string, body interface{}, value interface{}) error {
+	restURL, err := c.RESTURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, restURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return do(req, value)
+}
+
+// do sends the request, returning the errors reported by Flink if it's rejected
+func do(req *http.Request, value interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(value)
+}
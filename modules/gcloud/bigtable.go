@@ -0,0 +1,20 @@
+package gcloud
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// StartBigTableContainer creates an instance of the BigTable emulator of the Cloud SDK.
+// The URI of the container is the value of the BIGTABLE_EMULATOR_HOST environment variable read by the clients.
+func StartBigTableContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GCloudContainer, error) {
+	return startEmulator(ctx, emulator{
+		image:   defaultImage,
+		port:    "9000/tcp",
+		hostEnv: "BIGTABLE_EMULATOR_HOST",
+		cmd:     gcloudEmulatorCmd("bigtable", "9000/tcp"),
+		waitFor: wait.ForLog("running"),
+	}, opts...)
+}
@@ -0,0 +1,20 @@
+package gcloud
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// StartFirestoreContainer creates an instance of the Firestore emulator of the Cloud SDK.
+// The URI of the container is the value of the FIRESTORE_EMULATOR_HOST environment variable read by the clients.
+func StartFirestoreContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GCloudContainer, error) {
+	return startEmulator(ctx, emulator{
+		image:   defaultImage,
+		port:    "8080/tcp",
+		hostEnv: "FIRESTORE_EMULATOR_HOST",
+		cmd:     gcloudEmulatorCmd("firestore", "8080/tcp"),
+		waitFor: wait.ForLog("running"),
+	}, opts...)
+}
@@ -0,0 +1,146 @@
+package gcloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "gcr.io/google.com/cloudsdktool/cloud-sdk:367.0.0-emulators"
+	// }
+
+	// DefaultProjectID is the project ID used by the emulators, unless changed by the WithProjectID option
+	DefaultProjectID = "test-project"
+)
+
+// GCloudContainer represents the container of a Google Cloud emulator, used in the module
+type GCloudContainer struct {
+	testcontainers.Container
+	// URI is the endpoint of the emulator, with the format <host>:<port>, as expected by the clients
+	URI string
+	// ProjectID is the project of the Google Cloud resources created in the emulator
+	ProjectID string
+	// emulatorHostEnv is the environment variable read by the clients of the Google Cloud libraries to connect to the emulator
+	emulatorHostEnv string
+}
+
+// Environment returns the environment variables configuring the clients of the Google Cloud libraries
+// to connect to the emulator, e.g. PUBSUB_EMULATOR_HOST, and to use its project, e.g. with t.Setenv in the tests.
+func (c *GCloudContainer) Environment() map[string]string {
+	return map[string]string{
+		c.emulatorHostEnv:      c.URI,
+		"GOOGLE_CLOUD_PROJECT": c.ProjectID,
+	}
+}
+
+// Option is a function that configures the emulators of the module, e.g. the project ID.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	projectID string
+}
+
+// WithProjectID sets the project ID of the Google Cloud resources created in the emulator. Defaults to DefaultProjectID.
+func WithProjectID(projectID string) Option {
+	return func(o *options) {
+		o.projectID = projectID
+	}
+}
+
+// emulator describes how to run one of the emulators of the module
+type emulator struct {
+	image string
+	port  nat.Port
+	// hostEnv is the environment variable read by the clients to connect to the emulator
+	hostEnv string
+	// cmd returns the command of the emulator for the given project ID, or nil to use the command of the image
+	cmd     func(projectID string) []string
+	waitFor wait.Strategy
+}
+
+// gcloudEmulatorCmd returns the command starting one of the emulators of the Cloud SDK, listening on all the interfaces
+func gcloudEmulatorCmd(name string, port nat.Port) func(string) []string {
+	return func(projectID string) []string {
+		return []string{
+			"/bin/sh",
+			"-c",
+			fmt.Sprintf("gcloud beta emulators %s start --project %s --host-port 0.0.0.0:%s", name, projectID, port.Port()),
+		}
+	}
+}
+
+// startEmulator creates an instance of the container of the given emulator, configured with the options of the module,
+// and the generic options of the testcontainers package, e.g. testcontainers.WithImage.
+func startEmulator(ctx context.Context, e emulator, opts ...testcontainers.ContainerCustomizer) (*GCloudContainer, error) {
+	settings := options{
+		projectID: DefaultProjectID,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if strings.TrimSpace(settings.projectID) == "" {
+		return nil, fmt.Errorf("the project ID cannot be empty")
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        e.image,
+		ExposedPorts: []string{string(e.port)},
+		WaitingFor:   e.waitFor,
+	}
+
+	if e.cmd != nil {
+		req.Cmd = e.cmd(settings.projectID)
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	c := &GCloudContainer{
+		Container:       container,
+		ProjectID:       settings.projectID,
+		emulatorHostEnv: e.hostEnv,
+	}
+	if err != nil {
+		return c, err
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return c, err
+	}
+
+	mappedPort, err := container.MappedPort(ctx, e.port)
+	if err != nil {
+		return c, err
+	}
+
+	c.URI = fmt.Sprintf("%s:%s", host, mappedPort.Port())
+	return c, nil
+}
@@ -0,0 +1,317 @@
+package gcloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/spanner"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"google.golang.org/api/option"
+	"google.golang.org/api/option/internaloption"
+	databasepb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
+	instancepb "google.golang.org/genproto/googleapis/spanner/admin/instance/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// setEnvironment configures the clients of the Google Cloud libraries to connect to the emulator, for the duration of the test
+func setEnvironment(t *testing.T, container *GCloudContainer) {
+	// setEnvironment {
+	for key, value := range container.Environment() {
+		t.Setenv(key, value)
+	}
+	// }
+}
+
+func terminate(t *testing.T, container testcontainers.Container) {
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+}
+
+func TestPubsub(t *testing.T) {
+	ctx := context.Background()
+
+	// startPubsub {
+	container, err := StartPubsubContainer(ctx, WithProjectID("my-project-id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+	terminate(t, container)
+
+	setEnvironment(t, container)
+
+	client, err := pubsub.NewClient(ctx, container.ProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	topic, err := client.CreateTopic(ctx, "greetings")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription, err := client.CreateSubscription(ctx, "subscription", pubsub.SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := topic.Publish(ctx, &pubsub.Message{Data: []byte("Hello World")})
+	if _, err := result.Get(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var data []byte
+	cctx, cancel := context.WithCancel(ctx)
+	err = subscription.Receive(cctx, func(ctx context.Context, m *pubsub.Message) {
+		data = m.Data
+		m.Ack()
+		cancel()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "Hello World" {
+		t.Fatalf("expected value %s, got %s", "Hello World", data)
+	}
+}
+
+func TestFirestore(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartFirestoreContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terminate(t, container)
+
+	setEnvironment(t, container)
+
+	client, err := firestore.NewClient(ctx, container.ProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	type person struct {
+		Firstname string `firestore:"firstname"`
+		Lastname  string `firestore:"lastname"`
+	}
+
+	docRef := client.Collection("users").Doc("alovelace")
+
+	data := person{Firstname: "Ada", Lastname: "Lovelace"}
+	if _, err := docRef.Create(ctx, data); err != nil {
+		t.Fatal(err)
+	}
+
+	docsnap, err := docRef.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var saved person
+	if err := docsnap.DataTo(&saved); err != nil {
+		t.Fatal(err)
+	}
+
+	if saved != data {
+		t.Fatalf("expected value %v, got %v", data, saved)
+	}
+}
+
+func TestBigTable(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartBigTableContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terminate(t, container)
+
+	setEnvironment(t, container)
+
+	const (
+		instanceID = "test-instance"
+		tableName  = "test-table"
+	)
+
+	adminClient, err := bigtable.NewAdminClient(ctx, container.ProjectID, instanceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.Close()
+
+	if err := adminClient.CreateTable(ctx, tableName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := adminClient.CreateColumnFamily(ctx, tableName, "name"); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := bigtable.NewClient(ctx, container.ProjectID, instanceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	tbl := client.Open(tableName)
+
+	mut := bigtable.NewMutation()
+	mut.Set("name", "firstName", bigtable.Now(), []byte("Gopher"))
+	if err := tbl.Apply(ctx, "1", mut); err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := tbl.ReadRow(ctx, "1", bigtable.RowFilter(bigtable.FamilyFilter("name")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name := string(row["name"][0].Value); name != "Gopher" {
+		t.Fatalf("expected row key to be 'Gopher', got '%s'", name)
+	}
+}
+
+func TestSpanner(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartSpannerContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terminate(t, container)
+
+	const (
+		instanceID   = "test-instance"
+		databaseName = "test-db"
+	)
+
+	// spannerOptions {
+	options := []option.ClientOption{
+		option.WithEndpoint(container.URI),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		option.WithoutAuthentication(),
+		internaloption.SkipDialSettingsValidation(),
+	}
+	// }
+
+	instanceAdmin, err := instance.NewInstanceAdminClient(ctx, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instanceAdmin.Close()
+
+	instanceOp, err := instanceAdmin.CreateInstance(ctx, &instancepb.CreateInstanceRequest{
+		Parent:     fmt.Sprintf("projects/%s", container.ProjectID),
+		InstanceId: instanceID,
+		Instance: &instancepb.Instance{
+			DisplayName: instanceID,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := instanceOp.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	databaseAdmin, err := database.NewDatabaseAdminClient(ctx, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer databaseAdmin.Close()
+
+	databaseOp, err := databaseAdmin.CreateDatabase(ctx, &databasepb.CreateDatabaseRequest{
+		Parent:          fmt.Sprintf("projects/%s/instances/%s", container.ProjectID, instanceID),
+		CreateStatement: fmt.Sprintf("CREATE DATABASE `%s`", databaseName),
+		ExtraStatements: []string{
+			"CREATE TABLE Languages (Language STRING(MAX), Mascot STRING(MAX)) PRIMARY KEY (Language)",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := databaseOp.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	db := fmt.Sprintf("projects/%s/instances/%s/databases/%s", container.ProjectID, instanceID, databaseName)
+	client, err := spanner.NewClient(ctx, db, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, err = client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Languages", []string{"language", "mascot"}, []interface{}{"Go", "Gopher"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := client.Single().ReadRow(ctx, "Languages", spanner.Key{"Go"}, []string{"mascot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mascot string
+	if err := row.ColumnByName("Mascot", &mascot); err != nil {
+		t.Fatal(err)
+	}
+
+	if mascot != "Gopher" {
+		t.Fatalf("expected value %s, got %s", "Gopher", mascot)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	container := &GCloudContainer{
+		URI:             "localhost:49153",
+		ProjectID:       "my-project-id",
+		emulatorHostEnv: "PUBSUB_EMULATOR_HOST",
+	}
+
+	env := container.Environment()
+
+	if env["PUBSUB_EMULATOR_HOST"] != "localhost:49153" {
+		t.Fatalf("expected the emulator host to be localhost:49153, got %s", env["PUBSUB_EMULATOR_HOST"])
+	}
+
+	if env["GOOGLE_CLOUD_PROJECT"] != "my-project-id" {
+		t.Fatalf("expected the project to be my-project-id, got %s", env["GOOGLE_CLOUD_PROJECT"])
+	}
+}
+
+func TestEmptyProjectID(t *testing.T) {
+	// the project ID is validated before the container is created
+	_, err := StartPubsubContainer(context.Background(), WithProjectID(" "))
+	if err == nil {
+		t.Fatal("expected an error for an empty project ID")
+	}
+}
+
+func TestGCloudEmulatorCmd(t *testing.T) {
+	cmd := gcloudEmulatorCmd("pubsub", "8085/tcp")("my-project-id")
+
+	expected := "gcloud beta emulators pubsub start --project my-project-id --host-port 0.0.0.0:8085"
+	if len(cmd) != 3 || cmd[2] != expected {
+		t.Fatalf("expected %s, got %v", expected, cmd)
+	}
+}
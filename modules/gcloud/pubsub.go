@@ -0,0 +1,20 @@
+package gcloud
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// StartPubsubContainer creates an instance of the Pub/Sub emulator of the Cloud SDK.
+// The URI of the container is the value of the PUBSUB_EMULATOR_HOST environment variable read by the clients.
+func StartPubsubContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GCloudContainer, error) {
+	return startEmulator(ctx, emulator{
+		image:   defaultImage,
+		port:    "8085/tcp",
+		hostEnv: "PUBSUB_EMULATOR_HOST",
+		cmd:     gcloudEmulatorCmd("pubsub", "8085/tcp"),
+		waitFor: wait.ForLog("started"),
+	}, opts...)
+}
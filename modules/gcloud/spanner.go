@@ -0,0 +1,26 @@
+package gcloud
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// spannerImage {
+const spannerImage = "gcr.io/cloud-spanner-emulator/emulator:1.4.0"
+
+// }
+
+// StartSpannerContainer creates an instance of the Spanner emulator, which is distributed in its own image.
+// The URI of the container is the value of the SPANNER_EMULATOR_HOST environment variable read by the clients,
+// and the endpoint of its gRPC API. The emulator accepts the requests of any project, so the project ID
+// of the container is only the project the tests are expected to use.
+func StartSpannerContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GCloudContainer, error) {
+	return startEmulator(ctx, emulator{
+		image:   spannerImage,
+		port:    "9010/tcp",
+		hostEnv: "SPANNER_EMULATOR_HOST",
+		waitFor: wait.ForLog("Cloud Spanner emulator running"),
+	}, opts...)
+}
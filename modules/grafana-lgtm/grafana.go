@@ -0,0 +1,272 @@
+package grafanalgtm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "grafana/otel-lgtm:0.6.0"
+	// }
+
+	grafanaPort    = "3000/tcp"
+	lokiPort       = "3100/tcp"
+	tempoPort      = "3200/tcp"
+	otlpGRPCPort   = "4317/tcp"
+	otlpHTTPPort   = "4318/tcp"
+	prometheusPort = "9090/tcp"
+
+	readyLog = "The OpenTelemetry collector and the Grafana LGTM stack are up and running"
+)
+
+// GrafanaLGTMContainer represents the Grafana LGTM container type used in the module, running Loki for the logs,
+// Tempo for the traces and Prometheus for the metrics, behind an OpenTelemetry collector and Grafana
+type GrafanaLGTMContainer struct {
+	testcontainers.Container
+}
+
+// LogEntry is a line of logs stored by Loki, with the labels of its stream
+type LogEntry struct {
+	Labels    map[string]string
+	Timestamp time.Time
+	Line      string
+}
+
+// StartContainer creates an instance of the Grafana LGTM container type. It's ready once all the components
+// of the stack are, Loki and Tempo answering their readiness endpoints.
+// It accepts the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*GrafanaLGTMContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{grafanaPort, lokiPort, tempoPort, otlpGRPCPort, otlpHTTPPort, prometheusPort},
+		WaitingFor: wait.ForAll(
+			wait.ForLog(readyLog),
+			wait.ForHTTP("/ready").WithPort(lokiPort),
+			wait.ForHTTP("/ready").WithPort(tempoPort),
+		).WithDeadline(3 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &GrafanaLGTMContainer{Container: container}, err
+}
+
+// endpoint returns the HTTP URL of the given port, with the format http://<host>:<port>
+func (c *GrafanaLGTMContainer) endpoint(ctx context.Context, port nat.Port) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// GrafanaURL returns the URL of Grafana, whose data sources are Loki, Tempo and Prometheus, with the anonymous access enabled
+func (c *GrafanaLGTMContainer) GrafanaURL(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, grafanaPort)
+}
+
+// LokiURL returns the URL of the HTTP API of Loki, e.g. to push the logs to /loki/api/v1/push
+func (c *GrafanaLGTMContainer) LokiURL(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, lokiPort)
+}
+
+// TempoURL returns the URL of the HTTP API of Tempo, e.g. to query the traces at /api/traces/<trace ID>
+func (c *GrafanaLGTMContainer) TempoURL(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, tempoPort)
+}
+
+// PrometheusURL returns the URL of the HTTP API of Prometheus
+func (c *GrafanaLGTMContainer) PrometheusURL(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, prometheusPort)
+}
+
+// OTLPHTTPEndpoint returns the URL of the OTLP/HTTP receiver of the OpenTelemetry collector, to export the logs,
+// the traces and the metrics of the code under test
+func (c *GrafanaLGTMContainer) OTLPHTTPEndpoint(ctx context.Context) (string, error) {
+	return c.endpoint(ctx, otlpHTTPPort)
+}
+
+// OTLPGRPCEndpoint returns the address of the OTLP/gRPC receiver of the OpenTelemetry collector, with the format <host>:<port>,
+// as expected by the gRPC exporters of OpenTelemetry
+func (c *GrafanaLGTMContainer) OTLPGRPCEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, otlpGRPCPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, mappedPort.Port()), nil
+}
+
+// PushLogs pushes the lines to Loki, in a stream with the given labels, timestamped with the current time
+func (c *GrafanaLGTMContainer) PushLogs(ctx context.Context, labels map[string]string, lines ...string) error {
+	values := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		values = append(values, []string{strconv.FormatInt(time.Now().UnixNano(), 10), line})
+	}
+
+	body := map[string]interface{}{
+		"streams": []map[string]interface{}{
+			{"stream": labels, "values": values},
+		},
+	}
+
+	lokiURL, err := c.LokiURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := doRequest(ctx, http.MethodPost, lokiURL+"/loki/api/v1/push", body, nil); err != nil {
+		return fmt.Errorf("%w: could not push the logs to Loki", err)
+	}
+
+	return nil
+}
+
+// QueryLogs returns the lines of logs of the last hour matching the LogQL query, e.g. {service="checkout"} |= "error",
+// the newest first. The logs are queryable shortly after they're pushed, so the tests should poll until they're found.
+func (c *GrafanaLGTMContainer) QueryLogs(ctx context.Context, query string) ([]LogEntry, error) {
+	lokiURL, err := c.LokiURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(time.Now().Add(-time.Hour).UnixNano(), 10))
+
+	var response struct {
+		Data struct {
+			Result []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][]string        `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+
+	if err := doRequest(ctx, http.MethodGet, lokiURL+"/loki/api/v1/query_range?"+params.Encode(), nil, &response); err != nil {
+		return nil, fmt.Errorf("%w: could not query the logs of Loki", err)
+	}
+
+	var entries []LogEntry
+	for _, stream := range response.Data.Result {
+		for _, value := range stream.Values {
+			if len(value) != 2 {
+				continue
+			}
+
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid timestamp of a log line", err)
+			}
+
+			entries = append(entries, LogEntry{Labels: stream.Stream, Timestamp: time.Unix(0, ns), Line: value[1]})
+		}
+	}
+
+	return entries, nil
+}
+
+// HasTrace returns true if Tempo stores the trace with the given hex-encoded ID. The traces are queryable shortly after
+// they're exported, so the tests should poll until they're found.
+func (c *GrafanaLGTMContainer) HasTrace(ctx context.Context, traceID string) (bool, error) {
+	tempoURL, err := c.TempoURL(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tempoURL+"/api/traces/"+traceID, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		msg, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+}
+
+// doRequest sends the given request, in JSON format if the body is not nil, decoding the JSON response into
+// the given value, if not nil
+func doRequest(ctx context.Context, method string, url string, body interface{}, value interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(value)
+}
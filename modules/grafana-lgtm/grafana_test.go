@@ -0,0 +1,91 @@
+package grafanalgtm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	traceID = "5b8efff798038103d269b633813fc60c"
+	spanID  = "eee19b7ec3c1b174"
+)
+
+func TestGrafanaLGTM(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		// pushAndQueryLogs {
+		err := container.PushLogs(ctx, map[string]string{"service": "checkout"}, "order 42 paid")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var entries []LogEntry
+		for start := time.Now(); len(entries) == 0 && time.Since(start) < 30*time.Second; time.Sleep(500 * time.Millisecond) {
+			entries, err = container.QueryLogs(ctx, `{service="checkout"} |= "paid"`)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		// }
+
+		if len(entries) != 1 || entries[0].Line != "order 42 paid" || entries[0].Labels["service"] != "checkout" {
+			t.Fatalf("expected the pushed line, got %v", entries)
+		}
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		// exportTrace {
+		endpoint, err := container.OTLPHTTPEndpoint(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// }
+
+		now := time.Now()
+		trace := fmt.Sprintf(`{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},`+
+			`"scopeSpans":[{"spans":[{"traceId":%q,"spanId":%q,"name":"POST /orders","kind":2,"startTimeUnixNano":%q,"endTimeUnixNano":%q}]}]}]}`,
+			traceID, spanID, strconv.FormatInt(now.Add(-time.Second).UnixNano(), 10), strconv.FormatInt(now.UnixNano(), 10))
+
+		resp, err := http.Post(endpoint+"/v1/traces", "application/json", bytes.NewBufferString(trace))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		found := false
+		for start := time.Now(); !found && time.Since(start) < 30*time.Second; time.Sleep(500 * time.Millisecond) {
+			found, err = container.HasTrace(ctx, traceID)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if !found {
+			t.Fatalf("expected the trace %s to be stored by Tempo", traceID)
+		}
+	})
+}
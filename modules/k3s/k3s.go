@@ -0,0 +1,202 @@
+package k3s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/rancher/k3s:v1.27.1-k3s1"
+	// }
+
+	apiServerPort = "6443/tcp"
+
+	kubeConfigPath = "/etc/rancher/k3s/k3s.yaml"
+	// kubeConfigServer is the address of the API server in the kubeconfig generated by k3s
+	kubeConfigServer = "https://127.0.0.1:6443"
+	// manifestsPath is the directory of the manifests deployed by k3s when it starts
+	manifestsPath = "/var/lib/rancher/k3s/server/manifests"
+	// imagesArchivePath is the path of the archive of the images loaded into the node
+	imagesArchivePath = "/tmp/testcontainers-images.tar"
+)
+
+// K3sContainer represents the K3s container type used in the module
+type K3sContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the K3s module, e.g. the manifests deployed when the node starts.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	manifests []string
+}
+
+// WithManifest deploys the manifest file, e.g. the custom resource definitions of an operator, when the node starts.
+// It can be called multiple times.
+func WithManifest(manifestPath string) Option {
+	return func(o *options) {
+		o.manifests = append(o.manifests, manifestPath)
+	}
+}
+
+// StartContainer creates an instance of the K3s container type, a single node Kubernetes cluster without the Traefik
+// ingress controller, so operators and controllers can be tested without a real cluster. The container runs privileged.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*K3sContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	host, err := daemonHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]testcontainers.ContainerFile, 0, len(settings.manifests))
+	for _, manifest := range settings.manifests {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      manifest,
+			ContainerFilePath: manifestsPath + "/" + filepath.Base(manifest),
+			FileMode:          0o644,
+		})
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{apiServerPort},
+		Privileged:   true,
+		// the API server certificate must be valid for the host running the tests
+		Cmd: []string{"server", "--disable=traefik", "--tls-san=" + host},
+		Tmpfs: map[string]string{
+			"/run":     "",
+			"/var/run": "",
+		},
+		// k3s manages the cgroups of its pods, which requires the cgroup namespace of the host with cgroups v2
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.CgroupnsMode = "host"
+		},
+		Files:      files,
+		WaitingFor: wait.ForLog("Node controller sync successful"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &K3sContainer{Container: container}, err
+}
+
+// daemonHost returns the host of the Docker daemon, where the ports of the container are published
+func daemonHost(ctx context.Context) (string, error) {
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return "", err
+	}
+	defer provider.Close()
+
+	return provider.DaemonHost(ctx)
+}
+
+// GetKubeConfig returns the kubeconfig of the cluster, for the administrator of the cluster,
+// with the address of the API server rewritten to its port mapped on the host
+func (c *K3sContainer) GetKubeConfig(ctx context.Context) ([]byte, error) {
+	reader, err := c.CopyFileFromContainer(ctx, kubeConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read the kubeconfig", err)
+	}
+	defer reader.Close()
+
+	kubeConfig, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := c.APIServerURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strings.ReplaceAll(string(kubeConfig), kubeConfigServer, server)), nil
+}
+
+// APIServerURL returns the URL of the API server, e.g. https://localhost:49153
+func (c *K3sContainer) APIServerURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, apiServerPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://%s:%s", host, port.Port()), nil
+}
+
+// LoadImages side-loads the given images, available in the Docker daemon, into the container runtime of the node,
+// so the pods can use the images built locally, with the IfNotPresent or Never pull policies
+func (c *K3sContainer) LoadImages(ctx context.Context, images ...string) error {
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+
+	reader, err := provider.Client().ImageSave(ctx, images)
+	if err != nil {
+		return fmt.Errorf("%w: could not save the images %v", err, images)
+	}
+	defer reader.Close()
+
+	archive, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("%w: could not save the images %v", err, images)
+	}
+
+	if err := c.CopyToContainer(ctx, archive, imagesArchivePath, 0o644); err != nil {
+		return fmt.Errorf("%w: could not copy the images to the node", err)
+	}
+
+	code, output, err := c.Exec(ctx, []string{"sh", "-c", "ctr -n k8s.io images import " + imagesArchivePath + " && rm " + imagesArchivePath}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		out, _ := io.ReadAll(output)
+		return fmt.Errorf("could not import the images %v, exit code %d: %s", images, code, out)
+	}
+
+	return nil
+}
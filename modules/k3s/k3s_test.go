@@ -0,0 +1,119 @@
+package k3s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestK3s(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// getKubeConfig {
+	kubeConfig, err := container.GetKubeConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nodes.Items) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(nodes.Items))
+	}
+
+	t.Run("LoadImages", func(t *testing.T) {
+		// the image must be available in the Docker daemon, so a container is started to pull it
+		nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "nginx:alpine",
+				WaitingFor: wait.ForLog("start worker processes"),
+			},
+			Started: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := nginx.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		// loadImages {
+		if err := container.LoadImages(ctx, "nginx:alpine"); err != nil {
+			t.Fatal(err)
+		}
+		// }
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "nginx",
+						Image: "nginx:alpine",
+						// the pod fails if the image was not loaded into the node
+						ImagePullPolicy: corev1.PullNever,
+					},
+				},
+			},
+		}
+
+		if _, err := clientset.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+
+		deadline := time.Now().Add(2 * time.Minute)
+		for {
+			state, err := clientset.CoreV1().Pods("default").Get(ctx, "nginx", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if state.Status.Phase == corev1.PodRunning {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				t.Fatalf("the pod is not running, got the phase %s", state.Status.Phase)
+			}
+
+			time.Sleep(time.Second)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "confluentinc/confluent-local:7.5.0"
+	// }
+
+	// publicPort is the port of the listener advertised with the host and the mapped port, used from the host
+	publicPort = "9093/tcp"
+	// brokerPort is the port of the listener advertised with the hostname of the container, used from the Docker network
+	brokerPort = "9092"
+	// controllerPort is the port of the KRaft controller
+	controllerPort = "9094"
+
+	starterScript = "/usr/sbin/testcontainers_start.sh"
+
+	starterScriptContent = `#!/bin/bash
+source /etc/confluent/docker/bash-config
+export KAFKA_ADVERTISED_LISTENERS=%s,%s
+echo Starting Kafka KRaft mode
+sed -i '/KAFKA_ZOOKEEPER_CONNECT/d' /etc/confluent/docker/configure
+echo 'kafka-storage format --ignore-formatted -t "%s" -c /etc/kafka/kafka.properties' >> /etc/confluent/docker/configure
+echo '' > /etc/confluent/docker/ensure
+/etc/confluent/docker/configure
+/etc/confluent/docker/launch`
+)
+
+// ErrInvalidClusterID is returned when the cluster ID is not a base64 encoded UUID, as required by kafka-storage
+var ErrInvalidClusterID = errors.New("the cluster ID must be a base64 encoded UUID, e.g. the output of kafka-storage random-uuid")
+
+// KafkaContainer represents the Kafka container type used in the module
+type KafkaContainer struct {
+	testcontainers.Container
+	clusterID string
+}
+
+// Option is a function that configures the Kafka module, e.g. the ID of the KRaft cluster.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	clusterID string
+}
+
+// WithClusterID sets the ID of the KRaft cluster, used to format the storage of the broker.
+// It must be a base64 encoded UUID. By default, a random ID is generated by the container.
+func WithClusterID(clusterID string) Option {
+	return func(o *options) {
+		o.clusterID = clusterID
+	}
+}
+
+// StartContainer creates an instance of the Kafka container type, running a single-node KRaft cluster,
+// where the broker is also the controller, so no Zookeeper is needed.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KafkaContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if err := validateClusterID(settings.clusterID); err != nil {
+		return nil, err
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{publicPort},
+		Env: map[string]string{
+			"KAFKA_LISTENERS":                                "PLAINTEXT://0.0.0.0:9093,BROKER://0.0.0.0:" + brokerPort + ",CONTROLLER://0.0.0.0:" + controllerPort,
+			"KAFKA_REST_BOOTSTRAP_SERVERS":                   "PLAINTEXT://0.0.0.0:9093,BROKER://0.0.0.0:" + brokerPort + ",CONTROLLER://0.0.0.0:" + controllerPort,
+			"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP":           "BROKER:PLAINTEXT,PLAINTEXT:PLAINTEXT,CONTROLLER:PLAINTEXT",
+			"KAFKA_INTER_BROKER_LISTENER_NAME":               "BROKER",
+			"KAFKA_BROKER_ID":                                "1",
+			"KAFKA_NODE_ID":                                  "1",
+			"KAFKA_PROCESS_ROLES":                            "broker,controller",
+			"KAFKA_CONTROLLER_LISTENER_NAMES":                "CONTROLLER",
+			"KAFKA_CONTROLLER_QUORUM_VOTERS":                 "1@localhost:" + controllerPort,
+			"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR":         "1",
+			"KAFKA_OFFSETS_TOPIC_NUM_PARTITIONS":             "1",
+			"KAFKA_TRANSACTION_STATE_LOG_MIN_ISR":            "1",
+			"KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR": "1",
+			"KAFKA_LOG_FLUSH_INTERVAL_MESSAGES":              "9223372036854775807",
+			"KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS":         "0",
+		},
+		// the advertised listeners need the mapped port, which is only known after the container is started,
+		// so the container waits for the starter script, copied once the port is mapped
+		Entrypoint: []string{"sh"},
+		Cmd:        []string{"-c", "while [ ! -f " + starterScript + " ]; do sleep 0.1; done; bash " + starterScript},
+		WaitingFor: wait.ForLog("Kafka Server started"),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	// the container is not ready until the starter script is copied, so the wait strategy is run afterwards
+	waitingFor := genericContainerReq.WaitingFor
+	genericContainerReq.WaitingFor = nil
+	genericContainerReq.Started = false
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaContainer := &KafkaContainer{Container: container, clusterID: settings.clusterID}
+
+	if err := kafkaContainer.start(ctx, waitingFor); err != nil {
+		return kafkaContainer, err
+	}
+
+	return kafkaContainer, nil
+}
+
+// start starts the container, and copies the starter script advertising the listeners with the mapped port,
+// then it waits until the broker is ready
+func (c *KafkaContainer) start(ctx context.Context, waitingFor wait.Strategy) error {
+	if err := c.Start(ctx); err != nil {
+		return err
+	}
+
+	brokers, err := c.Brokers(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the hostname of the container is its short ID, which is also an alias in the Docker networks
+	hostname := c.GetContainerID()[:12]
+
+	clusterID := c.clusterID
+	if clusterID == "" {
+		clusterID = "$(kafka-storage random-uuid)"
+	}
+
+	script := fmt.Sprintf(starterScriptContent, "PLAINTEXT://"+brokers[0], "BROKER://"+hostname+":"+brokerPort, clusterID)
+
+	if err := c.CopyToContainer(ctx, []byte(script), starterScript, 0o755); err != nil {
+		return fmt.Errorf("%w: could not copy the starter script", err)
+	}
+
+	if waitingFor == nil {
+		return nil
+	}
+
+	return waitingFor.WaitUntilReady(ctx, c)
+}
+
+// Brokers returns the bootstrap addresses of the broker, with the host and the mapped port, e.g. localhost:49153,
+// to be used by the clients running on the host
+func (c *KafkaContainer) Brokers(ctx context.Context) ([]string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := c.MappedPort(ctx, publicPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{fmt.Sprintf("%s:%s", host, port.Port())}, nil
+}
+
+// validateClusterID returns an error if the cluster ID, when set, is not a base64 encoded UUID
+func validateClusterID(clusterID string) error {
+	if clusterID == "" {
+		return nil
+	}
+
+	uuid, err := base64.RawURLEncoding.DecodeString(clusterID)
+	if err != nil || len(uuid) != 16 {
+		return fmt.Errorf("%w: %s", ErrInvalidClusterID, clusterID)
+	}
+
+	return nil
+}
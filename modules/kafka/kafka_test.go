@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+func TestKafka(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithClusterID("MkU3OEVBNTcwNTJENDM2Qk"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// brokers {
+	brokers, err := container.Brokers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writer := &kafkago.Writer{
+		Addr:                   kafkago.TCP(brokers...),
+		Topic:                  "greetings",
+		AllowAutoTopicCreation: true,
+	}
+	defer writer.Close()
+	// }
+
+	// the topic is created by the first message, failing until the leader is elected
+	var writeErr error
+	for i := 0; i < 10; i++ {
+		writeErr = writer.WriteMessages(ctx, kafkago.Message{Key: []byte("key"), Value: []byte("hello")})
+		if writeErr == nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if writeErr != nil {
+		t.Fatal(writeErr)
+	}
+
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers: brokers,
+		Topic:   "greetings",
+	})
+	defer reader.Close()
+
+	readCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message, err := reader.ReadMessage(readCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(message.Value) != "hello" {
+		t.Fatalf("expected hello, got %s", message.Value)
+	}
+}
+
+func TestKafkaWithInvalidClusterID(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithClusterID("kraftCluster"))
+	if !errors.Is(err, ErrInvalidClusterID) {
+		t.Fatalf("expected %v, got %v", ErrInvalidClusterID, err)
+	}
+}
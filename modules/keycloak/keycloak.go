@@ -0,0 +1,188 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "quay.io/keycloak/keycloak:21.1"
+	// }
+
+	httpPort = "8080/tcp"
+
+	// importPath is the directory of the realms imported by Keycloak when it starts
+	importPath = "/opt/keycloak/data/import"
+
+	// MasterRealm is the realm of the administrators of Keycloak
+	MasterRealm = "master"
+)
+
+// KeycloakContainer represents the Keycloak container type used in the module
+type KeycloakContainer struct {
+	testcontainers.Container
+	adminUsername string
+	adminPassword string
+}
+
+// Option is a function that configures the Keycloak module, e.g. the realms imported when it starts.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	adminUsername string
+	adminPassword string
+	realmFiles    []string
+}
+
+// WithAdminCredentials sets the username and the password of the administrator of the master realm,
+// admin and admin by default
+func WithAdminCredentials(username, password string) Option {
+	return func(o *options) {
+		o.adminUsername = username
+		o.adminPassword = password
+	}
+}
+
+// WithRealmImportFile imports the realm exported in the given JSON file when Keycloak starts, e.g. with its clients and users.
+// It can be called multiple times to import several realms.
+func WithRealmImportFile(realmFile string) Option {
+	return func(o *options) {
+		o.realmFiles = append(o.realmFiles, realmFile)
+	}
+}
+
+// StartContainer creates an instance of the Keycloak container type, running Keycloak in development mode,
+// with an in-memory database. It waits until the endpoints of the master realm and of the imported realms are served,
+// so the OIDC flows can be tested right away.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KeycloakContainer, error) {
+	settings := options{
+		adminUsername: "admin",
+		adminPassword: "admin",
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	realms := []string{MasterRealm}
+	files := make([]testcontainers.ContainerFile, 0, len(settings.realmFiles))
+	for _, realmFile := range settings.realmFiles {
+		realm, err := realmName(realmFile)
+		if err != nil {
+			return nil, err
+		}
+
+		realms = append(realms, realm)
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      realmFile,
+			ContainerFilePath: importPath + "/" + filepath.Base(realmFile),
+			FileMode:          0o644,
+		})
+	}
+
+	cmd := []string{"start-dev"}
+	if len(files) > 0 {
+		cmd = append(cmd, "--import-realm")
+	}
+
+	strategies := make([]wait.Strategy, 0, len(realms))
+	for _, realm := range realms {
+		strategies = append(strategies, wait.ForHTTP("/realms/"+realm).WithPort(httpPort))
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{httpPort},
+		Env: map[string]string{
+			"KEYCLOAK_ADMIN":          settings.adminUsername,
+			"KEYCLOAK_ADMIN_PASSWORD": settings.adminPassword,
+		},
+		Cmd:        cmd,
+		Files:      files,
+		WaitingFor: wait.ForAll(strategies...).WithDeadline(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &KeycloakContainer{
+		Container:     container,
+		adminUsername: settings.adminUsername,
+		adminPassword: settings.adminPassword,
+	}, err
+}
+
+// realmName returns the name of the realm exported in the given file, which must be served before the container is ready
+func realmName(realmFile string) (string, error) {
+	content, err := os.ReadFile(realmFile)
+	if err != nil {
+		return "", fmt.Errorf("%w: could not read the realm file", err)
+	}
+
+	var realm struct {
+		Realm string `json:"realm"`
+	}
+	if err := json.Unmarshal(content, &realm); err != nil {
+		return "", fmt.Errorf("%w: could not parse the realm file %s", err, realmFile)
+	}
+
+	if realm.Realm == "" {
+		return "", fmt.Errorf("the realm file %s does not define the name of the realm", realmFile)
+	}
+
+	return realm.Realm, nil
+}
+
+// AuthServerURL returns the base URL of Keycloak, e.g. http://localhost:49153, the issuer of a realm
+// being AuthServerURL + "/realms/" + realm
+func (c *KeycloakContainer) AuthServerURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
+}
+
+// AdminUsername returns the username of the administrator of the master realm
+func (c *KeycloakContainer) AdminUsername() string {
+	return c.adminUsername
+}
+
+// AdminPassword returns the password of the administrator of the master realm
+func (c *KeycloakContainer) AdminPassword() string {
+	return c.adminPassword
+}
@@ -0,0 +1,108 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeycloak(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithRealmImportFile(filepath.Join("testdata", "test-realm.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// authServerURL {
+	authServerURL, err := container.AuthServerURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenURL := authServerURL + "/realms/test/protocol/openid-connect/token"
+	// }
+
+	t.Run("password grant of the imported realm", func(t *testing.T) {
+		token := requestToken(t, tokenURL, url.Values{
+			"grant_type": {"password"},
+			"client_id":  {"test-client"},
+			"username":   {"alice"},
+			"password":   {"alice-password"},
+		})
+
+		if token == "" {
+			t.Fatal("expected an access token")
+		}
+	})
+
+	t.Run("admin credentials", func(t *testing.T) {
+		token := requestToken(t, authServerURL+"/realms/master/protocol/openid-connect/token", url.Values{
+			"grant_type": {"password"},
+			"client_id":  {"admin-cli"},
+			"username":   {container.AdminUsername()},
+			"password":   {container.AdminPassword()},
+		})
+
+		if token == "" {
+			t.Fatal("expected an access token")
+		}
+	})
+}
+
+func TestStartContainerWithInvalidRealmFile(t *testing.T) {
+	realmFile := filepath.Join(t.TempDir(), "realm.json")
+	if err := os.WriteFile(realmFile, []byte(`{"enabled": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := StartContainer(context.Background(), WithRealmImportFile(realmFile))
+	if err == nil {
+		t.Fatal("expected an error with a realm file without the name of the realm")
+	}
+}
+
+func TestRealmName(t *testing.T) {
+	realm, err := realmName(filepath.Join("testdata", "test-realm.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if realm != "test" {
+		t.Fatalf("expected the realm test, got %s", realm)
+	}
+}
+
+func requestToken(t *testing.T, tokenURL string, form url.Values) string {
+	resp, err := http.PostForm(tokenURL, form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		t.Fatal(err)
+	}
+
+	return token.AccessToken
+}
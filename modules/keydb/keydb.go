@@ -0,0 +1,153 @@
+package keydb
+
+import (
+	"context"
+	"crypto/tls"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/rediscompat"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "eqalpha/keydb:x86_64_v6.3.2"
+	// }
+
+	configContainerPath = "/etc/keydb/keydb.conf"
+)
+
+// KeyDBContainer represents the KeyDB container type used in the module
+type KeyDBContainer struct {
+	testcontainers.Container
+	settings  rediscompat.Settings
+	tlsConfig *tls.Config
+}
+
+var _ rediscompat.Container = (*KeyDBContainer)(nil)
+
+// Option is a function that configures the KeyDB module, e.g. the number of server threads.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	serverThreads        int
+	serverThreadAffinity bool
+	activeReplica        bool
+	compat               rediscompat.Settings
+}
+
+// WithServerThreads sets the number of threads used by KeyDB to handle the connections,
+// using the --server-threads flag. By default, KeyDB uses two threads.
+func WithServerThreads(threads int) Option {
+	return func(o *options) {
+		o.serverThreads = threads
+	}
+}
+
+// WithServerThreadAffinity pins each server thread to a CPU, using the --server-thread-affinity flag
+func WithServerThreadAffinity() Option {
+	return func(o *options) {
+		o.serverThreadAffinity = true
+	}
+}
+
+// WithActiveReplica enables the active replication of KeyDB, which allows the replicas to accept writes,
+// using the --active-replica flag
+func WithActiveReplica() Option {
+	return func(o *options) {
+		o.activeReplica = true
+	}
+}
+
+// WithPassword sets the password required to authenticate the clients, using the --requirepass flag
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.compat.Password = password
+	}
+}
+
+// WithTLS serves the Redis protocol over TLS only, with the given PEM encoded certificate and private key files,
+// using the --tls-port flag. The CA certificate file verifies the certificate of the server, which is used instead if empty,
+// e.g. for a self-signed certificate. The certificate must be valid for the host of the Docker daemon, e.g. localhost.
+func WithTLS(certFile, keyFile, caCertFile string) Option {
+	return func(o *options) {
+		o.compat.TLS = &rediscompat.TLS{CertFile: certFile, KeyFile: keyFile, CACertFile: caCertFile}
+	}
+}
+
+// StartContainer creates an instance of the KeyDB container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*KeyDBContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	tlsConfig, err := settings.compat.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Cmd:          append([]string{"keydb-server", configContainerPath}, settings.flags()...),
+		Files:        settings.compat.Files(),
+		ExposedPorts: []string{rediscompat.Port},
+		WaitingFor:   rediscompat.WaitStrategy(),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &KeyDBContainer{Container: container, settings: settings.compat, tlsConfig: tlsConfig}, err
+}
+
+// ConnectionString returns the Redis compatible connection string of KeyDB,
+// with the format redis://[:password@]<host>:<port>, or rediss:// over TLS, which can be parsed by the Redis clients
+func (c *KeyDBContainer) ConnectionString(ctx context.Context) (string, error) {
+	return rediscompat.ConnectionString(ctx, c, c.settings)
+}
+
+// TLSConfig returns the TLS configuration of the clients, trusting the certificate of KeyDB, nil if TLS is not enabled
+func (c *KeyDBContainer) TLSConfig() *tls.Config {
+	return c.tlsConfig
+}
+
+// flags returns the command line flags of KeyDB for the options of the module,
+// which take precedence over the configuration file of the image
+func (o options) flags() []string {
+	var flags []string
+
+	if o.serverThreads > 0 {
+		flags = append(flags, "--server-threads", strconv.Itoa(o.serverThreads))
+	}
+	if o.serverThreadAffinity {
+		flags = append(flags, "--server-thread-affinity", "true")
+	}
+	if o.activeReplica {
+		flags = append(flags, "--active-replica", "yes")
+	}
+	flags = append(flags, o.compat.Flags()...)
+
+	return flags
+}
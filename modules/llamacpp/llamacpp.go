@@ -0,0 +1,209 @@
+package llamacpp
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "ghcr.io/ggml-org/llama.cpp:server-b4719"
+	// }
+
+	serverPort = "8080/tcp"
+
+	modelsContainerDir = "/models"
+)
+
+// ErrMissingModel is returned when the container is started without a model file or a Hugging Face model
+var ErrMissingModel = errors.New("a model file, or a Hugging Face model, is required to start the inference server")
+
+// LlamaCppContainer represents the llama.cpp server container type used in the module
+type LlamaCppContainer struct {
+	testcontainers.Container
+	apiKey string
+}
+
+// Option is a function that configures the llama.cpp module, e.g. the model served by the server.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	modelFile   string
+	hfRepo      string
+	hfFile      string
+	apiKey      string
+	alias       string
+	contextSize int
+}
+
+// WithModelFile serves the given GGUF model file of the host, whose directory is mounted read-only into the container,
+// so the big models are not copied
+func WithModelFile(path string) Option {
+	return func(o *options) {
+		o.modelFile = path
+	}
+}
+
+// WithHuggingFaceModel serves the given GGUF file of the Hugging Face repository, e.g. the file "tinyllamas/stories260K.gguf"
+// of the "ggml-org/models" repository, which is downloaded by the server when it starts
+func WithHuggingFaceModel(repo, file string) Option {
+	return func(o *options) {
+		o.hfRepo = repo
+		o.hfFile = file
+	}
+}
+
+// WithAPIKey sets the API key required by the server in the Authorization header of the requests.
+// By default, a random API key is generated.
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithModelAlias sets the name of the model in the OpenAI API, e.g. in the model field of the requests and in /v1/models.
+// By default, it's the path of the model.
+func WithModelAlias(alias string) Option {
+	return func(o *options) {
+		o.alias = alias
+	}
+}
+
+// WithContextSize sets the size of the prompt context of the model, in tokens, which bounds the memory used by the server.
+// By default, it's the context size of the model.
+func WithContextSize(size int) Option {
+	return func(o *options) {
+		o.contextSize = size
+	}
+}
+
+// StartContainer creates an instance of the llama.cpp server container type, an OpenAI API compatible inference server.
+// It returns once the model is loaded.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*LlamaCppContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.modelFile == "" && settings.hfRepo == "" {
+		return nil, ErrMissingModel
+	}
+
+	if settings.apiKey == "" {
+		apiKey, err := randomAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		settings.apiKey = apiKey
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{serverPort},
+		// the model is loaded, or downloaded, before the server is healthy
+		WaitingFor: wait.ForHTTP("/health").WithPort(serverPort).WithStartupTimeout(5 * time.Minute),
+	}
+
+	if settings.modelFile != "" {
+		modelFile, err := filepath.Abs(settings.modelFile)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Mounts = testcontainers.Mounts(testcontainers.ContainerMount{
+			Source:   testcontainers.GenericBindMountSource{HostPath: filepath.Dir(modelFile)},
+			Target:   modelsContainerDir,
+			ReadOnly: true,
+		})
+		settings.modelFile = modelsContainerDir + "/" + filepath.Base(modelFile)
+	}
+
+	req.Cmd = settings.args()
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &LlamaCppContainer{Container: container, apiKey: settings.apiKey}, err
+}
+
+// args returns the arguments of the server for the options, the model file being the path in the container
+func (o options) args() []string {
+	args := []string{"--host", "0.0.0.0", "--port", "8080", "--api-key", o.apiKey}
+
+	if o.modelFile != "" {
+		args = append(args, "--model", o.modelFile)
+	} else {
+		args = append(args, "--hf-repo", o.hfRepo, "--hf-file", o.hfFile)
+	}
+
+	if o.alias != "" {
+		args = append(args, "--alias", o.alias)
+	}
+	if o.contextSize > 0 {
+		args = append(args, "--ctx-size", strconv.Itoa(o.contextSize))
+	}
+
+	return args
+}
+
+// randomAPIKey returns a random API key, seeded by the session seed if it's set
+func randomAPIKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(testcontainerssession.Rand(), b); err != nil {
+		return "", err
+	}
+
+	return "sk-" + hex.EncodeToString(b), nil
+}
+
+// BaseURL returns the base URL of the OpenAI API of the server, with the format http://<host>:<port>/v1,
+// to be set as the base URL of the OpenAI clients
+func (c *LlamaCppContainer) BaseURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, serverPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s/v1", host, mappedPort.Port()), nil
+}
+
+// APIKey returns the API key required by the server, to be set as the API key of the OpenAI clients
+func (c *LlamaCppContainer) APIKey() string {
+	return c.apiKey
+}
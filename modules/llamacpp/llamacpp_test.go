@@ -0,0 +1,144 @@
+package llamacpp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLlamaCpp(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithHuggingFaceModel("ggml-org/models", "tinyllamas/stories260K.gguf"),
+		WithModelAlias("stories"),
+		WithContextSize(256),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// completion {
+	baseURL, err := container.BaseURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model":       "stories",
+		"prompt":      "Once upon a time",
+		"max_tokens":  16,
+		"temperature": 0,
+		"seed":        42,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/completions", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+container.APIKey())
+	// }
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var completion struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Text string `json:"text"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(completion.Choices) != 1 || strings.TrimSpace(completion.Choices[0].Text) == "" {
+		t.Fatalf("expected a completion, got %+v", completion)
+	}
+
+	// the requests without the API key are rejected
+	resp, err = http.Get(baseURL + "/models")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status code %d without the API key, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestLlamaCppWithoutModel(t *testing.T) {
+	_, err := StartContainer(context.Background())
+	if !errors.Is(err, ErrMissingModel) {
+		t.Fatalf("expected %s, got %v", ErrMissingModel, err)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		want     []string
+	}{
+		{
+			name:     "model file",
+			settings: options{modelFile: "/models/model.gguf", apiKey: "secret"},
+			want:     []string{"--host", "0.0.0.0", "--port", "8080", "--api-key", "secret", "--model", "/models/model.gguf"},
+		},
+		{
+			name:     "Hugging Face model",
+			settings: options{hfRepo: "ggml-org/models", hfFile: "tinyllamas/stories260K.gguf", apiKey: "secret", alias: "stories", contextSize: 256},
+			want: []string{
+				"--host", "0.0.0.0", "--port", "8080", "--api-key", "secret",
+				"--hf-repo", "ggml-org/models", "--hf-file", "tinyllamas/stories260K.gguf",
+				"--alias", "stories",
+				"--ctx-size", "256",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.args(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRandomAPIKey(t *testing.T) {
+	apiKey, err := randomAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(apiKey, "sk-") || len(apiKey) != 35 {
+		t.Fatalf("expected a random API key, got %s", apiKey)
+	}
+}
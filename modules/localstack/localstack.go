@@ -42,7 +42,8 @@ func isLegacyMode(image string) bool {
 
 // StartContainer creates an instance of the LocalStack container type, being possible to pass a custom request and options:
 // - overrideReq: a function that can be used to override the default container request, usually used to set the image version, environment variables for localstack, etc.
-func StartContainer(ctx context.Context, overrideReq OverrideContainerRequestOption) (*LocalStackContainer, error) {
+// - opts: the generic options of the testcontainers package, e.g. testcontainers.WithNetwork, which are applied after the overridden request.
+func StartContainer(ctx context.Context, overrideReq OverrideContainerRequestOption, opts ...testcontainers.ContainerCustomizer) (*LocalStackContainer, error) {
 	// defaultContainerRequest {
 	req := testcontainers.ContainerRequest{
 		Image:        fmt.Sprintf("localstack/localstack:%s", defaultVersion),
@@ -63,6 +64,17 @@ func StartContainer(ctx context.Context, overrideReq OverrideContainerRequestOpt
 		localStackReq.ContainerRequest = merged
 	}
 
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: localStackReq.ContainerRequest,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	localStackReq.ContainerRequest = genericContainerReq.ContainerRequest
+
 	if isLegacyMode(localStackReq.Image) {
 		return nil, fmt.Errorf("version=%s. Testcontainers for Go does not support running LocalStack in legacy mode. Please use a version >= 0.11.0", localStackReq.Image)
 	}
@@ -71,20 +83,19 @@ func StartContainer(ctx context.Context, overrideReq OverrideContainerRequestOpt
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("Setting %s to %s (%s)\n", hostnameExternalEnvVar, req.Env[hostnameExternalEnvVar], hostnameExternalReason)
+	testcontainers.Logger.Printf("Setting %s to %s (%s)", hostnameExternalEnvVar, req.Env[hostnameExternalEnvVar], hostnameExternalReason)
 
-	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: localStackReq.ContainerRequest,
-		Started:          true,
-	})
-	if err != nil {
+	genericContainerReq.ContainerRequest = localStackReq.ContainerRequest
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
 		return nil, err
 	}
 
 	c := &LocalStackContainer{
 		Container: container,
 	}
-	return c, nil
+	return c, err
 }
 
 func configureDockerHost(req *LocalStackContainerRequest) (reason string, err error) {
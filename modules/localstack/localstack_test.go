@@ -165,3 +165,30 @@ func TestStartWithNetwork(t *testing.T) {
 	require.Equal(t, 1, len(networks))
 	require.Equal(t, "localstack-network", networks[0])
 }
+
+func TestStartWithGenericOptions(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{
+			Name: "localstack-generic-network",
+		},
+	})
+	require.Nil(t, err)
+	assert.NotNil(t, nw)
+
+	// withGenericOptions {
+	container, err := StartContainer(
+		ctx,
+		NoopOverrideContainerRequest,
+		testcontainers.WithNetwork("localstack-generic-network", "localstack"),
+		testcontainers.WithEnv(map[string]string{"SERVICES": "s3"}),
+	)
+	// }
+	require.Nil(t, err)
+	assert.NotNil(t, container)
+
+	aliases, err := container.NetworkAliases(ctx)
+	require.Nil(t, err)
+	require.Contains(t, aliases["localstack-generic-network"], "localstack")
+}
@@ -1,8 +1,6 @@
 package localstack
 
 import (
-	"fmt"
-
 	"github.com/imdario/mergo"
 	"github.com/testcontainers/testcontainers-go"
 )
@@ -31,7 +29,7 @@ var NoopOverrideContainerRequest = func(req testcontainers.ContainerRequest) tes
 func OverrideContainerRequest(r testcontainers.ContainerRequest) func(req testcontainers.ContainerRequest) testcontainers.ContainerRequest {
 	return func(req testcontainers.ContainerRequest) testcontainers.ContainerRequest {
 		if err := mergo.Merge(&req, r, mergo.WithOverride); err != nil {
-			fmt.Printf("error merging container request %v. Keeping the default one: %v", err, req)
+			testcontainers.Logger.Printf("error merging container request %v. Keeping the default one: %v", err, req)
 			return req
 		}
 
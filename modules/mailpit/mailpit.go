@@ -0,0 +1,219 @@
+package mailpit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "axllent/mailpit:v1.5.5"
+	// }
+
+	smtpPort = "1025/tcp"
+	httpPort = "8025/tcp"
+
+	// pollInterval is the interval between the queries of the messages while waiting for a message
+	pollInterval = 100 * time.Millisecond
+)
+
+// MailpitContainer represents the Mailpit container type used in the module
+type MailpitContainer struct {
+	testcontainers.Container
+}
+
+// Address is an email address of a message, with the name of its owner, if any
+type Address struct {
+	Name    string `json:"Name"`
+	Address string `json:"Address"`
+}
+
+// Message is the summary of a message received by Mailpit
+type Message struct {
+	// ID is the ID of the message in Mailpit
+	ID string `json:"ID"`
+	// MessageID is the Message-ID header of the message
+	MessageID   string    `json:"MessageID"`
+	From        *Address  `json:"From"`
+	To          []Address `json:"To"`
+	Cc          []Address `json:"Cc"`
+	Bcc         []Address `json:"Bcc"`
+	Subject     string    `json:"Subject"`
+	Created     time.Time `json:"Created"`
+	Size        int       `json:"Size"`
+	Attachments int       `json:"Attachments"`
+}
+
+// MessageMatcher returns true if the message is the expected one
+type MessageMatcher func(Message) bool
+
+// StartContainer creates an instance of the Mailpit container type.
+// It accepts the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MailpitContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{smtpPort, httpPort},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(smtpPort),
+			wait.ForHTTP("/api/v1/messages").WithPort(httpPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &MailpitContainer{Container: container}, err
+}
+
+// SMTPAddress returns the address of the SMTP server, with the format <host>:<port>, to be used by the code sending the emails.
+// The server accepts any sender and recipient, without authentication.
+func (c *MailpitContainer) SMTPAddress(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, smtpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, mappedPort.Port()), nil
+}
+
+// BaseURL returns the base URL of the web UI and the HTTP API of Mailpit, with the format http://<host>:<port>
+func (c *MailpitContainer) BaseURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// Messages returns the messages received by Mailpit, the newest first
+func (c *MailpitContainer) Messages(ctx context.Context) ([]Message, error) {
+	var response struct {
+		Messages []Message `json:"messages"`
+	}
+
+	if err := c.doRequest(ctx, http.MethodGet, "/api/v1/messages", &response); err != nil {
+		return nil, fmt.Errorf("%w: could not list the messages", err)
+	}
+
+	return response.Messages, nil
+}
+
+// DeleteAll deletes all the messages received by Mailpit, e.g. between the tests sharing the container
+func (c *MailpitContainer) DeleteAll(ctx context.Context) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/api/v1/messages", nil); err != nil {
+		return fmt.Errorf("%w: could not delete the messages", err)
+	}
+
+	return nil
+}
+
+// WaitForMessage waits until a message matching the matcher is received, and returns it. As the emails are often
+// sent asynchronously by the code under test, the messages are polled until the context is done,
+// so the context must have a deadline, e.g. with context.WithTimeout.
+func (c *MailpitContainer) WaitForMessage(ctx context.Context, matcher MessageMatcher) (*Message, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		messages, err := c.Messages(ctx)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+
+		for _, m := range messages {
+			if matcher(m) {
+				m := m
+				return &m, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: no message matched after receiving %d messages", ctx.Err(), len(messages))
+		case <-ticker.C:
+		}
+	}
+}
+
+// WithSubject returns a matcher of the messages with the given subject
+func WithSubject(subject string) MessageMatcher {
+	return func(m Message) bool {
+		return m.Subject == subject
+	}
+}
+
+// WithRecipient returns a matcher of the messages sent to the given address, in the To, Cc or Bcc recipients
+func WithRecipient(address string) MessageMatcher {
+	return func(m Message) bool {
+		for _, recipients := range [][]Address{m.To, m.Cc, m.Bcc} {
+			for _, r := range recipients {
+				if r.Address == address {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
+
+// doRequest sends the given request to the HTTP API of Mailpit, decoding its JSON response into the given value, if not nil
+func (c *MailpitContainer) doRequest(ctx context.Context, method string, path string, value interface{}) error {
+	baseURL, err := c.BaseURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(value)
+}
@@ -0,0 +1,103 @@
+package mailpit
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+	"time"
+)
+
+func TestMailpit(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// sendEmail {
+	smtpAddress, err := container.SMTPAddress(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := "To: bob@example.com\r\nSubject: Welcome\r\n\r\nHello Bob!\r\n"
+	err = smtp.SendMail(smtpAddress, nil, "alice@example.com", []string{"bob@example.com"}, []byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// waitForMessage {
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	message, err := container.WaitForMessage(waitCtx, WithSubject("Welcome"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if message.From == nil || message.From.Address != "alice@example.com" {
+		t.Fatalf("expected the message to be sent by alice@example.com, got %v", message.From)
+	}
+
+	if !WithRecipient("bob@example.com")(*message) {
+		t.Fatalf("expected the message to be sent to bob@example.com, got %v", message.To)
+	}
+
+	messages, err := container.Messages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	if err := container.DeleteAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err = container.Messages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 0 {
+		t.Fatalf("expected no message after deleting them, got %d", len(messages))
+	}
+
+	waitCtx, cancel = context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	if _, err := container.WaitForMessage(waitCtx, WithSubject("Welcome")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to time out, got %v", err)
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	m := Message{
+		Subject: "Welcome",
+		To:      []Address{{Name: "Bob", Address: "bob@example.com"}},
+		Bcc:     []Address{{Address: "audit@example.com"}},
+	}
+
+	if !WithSubject("Welcome")(m) || WithSubject("Goodbye")(m) {
+		t.Fatal("expected the subject matcher to match the subject only")
+	}
+
+	if !WithRecipient("bob@example.com")(m) || !WithRecipient("audit@example.com")(m) || WithRecipient("alice@example.com")(m) {
+		t.Fatal("expected the recipient matcher to match the recipients only")
+	}
+}
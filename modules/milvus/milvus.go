@@ -0,0 +1,116 @@
+package milvus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/milvusdb/milvus:v2.3.9"
+	// }
+
+	// grpcPort is the port of the gRPC API of Milvus, used by the SDKs
+	grpcPort = "19530/tcp"
+	// metricsPort is the port of the metrics and of the health endpoint of Milvus
+	metricsPort = "9091/tcp"
+
+	embedEtcdConfigPath = "/milvus/configs/embedEtcd.yaml"
+
+	// embedEtcdConfig is the configuration of the etcd server embedded in Milvus, listening on all the interfaces
+	embedEtcdConfig = `listen-client-urls: http://0.0.0.0:2379
+advertise-client-urls: http://0.0.0.0:2379
+`
+)
+
+// MilvusContainer represents the Milvus container type used in the module
+type MilvusContainer struct {
+	testcontainers.Container
+}
+
+// StartContainer creates an instance of the Milvus container type, running Milvus in standalone mode.
+// The metadata are stored by the etcd server embedded in Milvus, and the data on the local storage
+// of the container instead of MinIO, so no other container is needed.
+// It accepts the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MilvusContainer, error) {
+	configFile, err := writeEmbedEtcdConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not write the configuration of the embedded etcd", err)
+	}
+	defer os.Remove(configFile)
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{grpcPort, metricsPort},
+		Cmd:          []string{"milvus", "run", "standalone"},
+		Env: map[string]string{
+			"ETCD_USE_EMBED":     "true",
+			"ETCD_DATA_DIR":      "/var/lib/milvus/etcd",
+			"ETCD_CONFIG_PATH":   embedEtcdConfigPath,
+			"COMMON_STORAGETYPE": "local",
+		},
+		Files: []testcontainers.ContainerFile{
+			{
+				HostFilePath:      configFile,
+				ContainerFilePath: embedEtcdConfigPath,
+				FileMode:          0o644,
+			},
+		},
+		// the health endpoint answers once all the components of the standalone mode are ready
+		WaitingFor: wait.ForHTTP("/healthz").
+			WithPort(metricsPort).
+			WithStartupTimeout(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &MilvusContainer{Container: container}, err
+}
+
+// writeEmbedEtcdConfig writes the configuration of the embedded etcd to a temporary file, returning its path
+func writeEmbedEtcdConfig() (string, error) {
+	f, err := os.CreateTemp("", "milvus-embed-etcd-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(embedEtcdConfig); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// ConnectionString returns the address of the gRPC API of Milvus, e.g. localhost:49153, used by the SDKs
+func (c *MilvusContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, grpcPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, mappedPort.Port()), nil
+}
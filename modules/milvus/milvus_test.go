@@ -0,0 +1,116 @@
+package milvus
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+func TestMilvus(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	milvusClient, err := client.NewClient(ctx, client.Config{
+		Address: connectionString,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer milvusClient.Close()
+	// }
+
+	schema := entity.NewSchema().
+		WithName("books").
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("embedding").WithDataType(entity.FieldTypeFloatVector).WithDim(2))
+
+	if err := milvusClient.CreateCollection(ctx, schema, entity.DefaultShardNumber); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = milvusClient.Insert(ctx, "books", "",
+		entity.NewColumnInt64("id", []int64{1, 2, 3}),
+		entity.NewColumnFloatVector("embedding", 2, [][]float32{{0, 1}, {1, 0}, {1, 1}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := entity.NewIndexFlat(entity.L2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := milvusClient.CreateIndex(ctx, "books", "embedding", index, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := milvusClient.LoadCollection(ctx, "books", false); err != nil {
+		t.Fatal(err)
+	}
+
+	searchParam, err := entity.NewIndexFlatSearchParam()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := milvusClient.Search(ctx, "books", nil, "", []string{"id"},
+		[]entity.Vector{entity.FloatVector([]float32{0.9, 0.1})}, "embedding", entity.L2, 1, searchParam,
+		client.WithSearchQueryConsistencyLevel(entity.ClStrong))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || results[0].ResultCount != 1 {
+		t.Fatalf("expected one result, got %v", results)
+	}
+
+	// the nearest vector of (0.9, 0.1) is (1, 0)
+	id, err := results[0].IDs.GetAsInt64(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 2 {
+		t.Fatalf("expected the book 2, got %d", id)
+	}
+}
+
+func TestWriteEmbedEtcdConfig(t *testing.T) {
+	path, err := writeEmbedEtcdConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != embedEtcdConfig {
+		t.Fatalf("expected %q, got %q", embedEtcdConfig, content)
+	}
+}
@@ -0,0 +1,118 @@
+package mockoon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "mockoon/cli:6.2.0"
+	// }
+
+	mockPort = "3000/tcp"
+
+	dataContainerDir = "/data"
+)
+
+// ErrMissingData is returned when the container is started without an OpenAPI specification or a Mockoon environment file
+var ErrMissingData = errors.New("an OpenAPI specification file, or a Mockoon environment file, is required to start the mock server")
+
+// MockoonContainer represents the Mockoon container type used in the module
+type MockoonContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the Mockoon module, e.g. the OpenAPI specification to be mocked.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	dataFile string
+}
+
+// WithOpenAPISpec sets the OpenAPI specification file, in JSON or YAML format, to be served as a mock API.
+// Mockoon answers the requests to the paths of the specification with the examples, or the schemas,
+// of their responses. A Mockoon environment file, in JSON format, is accepted too.
+func WithOpenAPISpec(specFile string) Option {
+	return func(o *options) {
+		o.dataFile = specFile
+	}
+}
+
+// StartContainer creates an instance of the Mockoon container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MockoonContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.dataFile == "" {
+		return nil, ErrMissingData
+	}
+
+	dataFile, err := filepath.Abs(settings.dataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// the extension of the file is kept, so Mockoon is able to parse it
+	dataContainerPath := dataContainerDir + "/" + filepath.Base(dataFile)
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Entrypoint:   []string{"mockoon-cli", "start"},
+		Cmd:          []string{"--data", dataContainerPath, "--port", "3000"},
+		ExposedPorts: []string{mockPort},
+		Files: []testcontainers.ContainerFile{
+			{HostFilePath: dataFile, ContainerFilePath: dataContainerPath, FileMode: 0o644},
+		},
+		WaitingFor: wait.ForListeningPort(mockPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &MockoonContainer{Container: container}, err
+}
+
+// BaseURL returns the base URL of the mock API, with the format http://<host>:<port>.
+// The paths of the OpenAPI specification are served under it.
+func (c *MockoonContainer) BaseURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, mockPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
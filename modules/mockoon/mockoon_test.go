@@ -0,0 +1,63 @@
+package mockoon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMockoon(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithOpenAPISpec("testdata/petstore.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// callMockAPI {
+	baseURL, err := container.BaseURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(baseURL + "/pets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	// }
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var pets []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pets); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pets) != 2 || pets[0].Name != "Rex" {
+		t.Fatalf("expected the example of the specification, got %v", pets)
+	}
+}
+
+func TestMockoonWithoutData(t *testing.T) {
+	_, err := StartContainer(context.Background())
+	if !errors.Is(err, ErrMissingData) {
+		t.Fatalf("expected %v, got %v", ErrMissingData, err)
+	}
+}
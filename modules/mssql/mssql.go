@@ -0,0 +1,248 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "mcr.microsoft.com/mssql/server:2022-CU2-ubuntu-20.04"
+	// }
+
+	mssqlPort = "1433/tcp"
+
+	saUser          = "sa"
+	defaultPassword = "Strong@Passw0rd"
+
+	scriptsContainerPath = "/tmp/testcontainers-scripts"
+
+	// sqlcmd is the command of the sqlcmd client of the image, connected to the server as sa. The recent images
+	// only ship the mssql-tools18 package, whose client encrypts the connections, thus must trust the certificate of the server
+	sqlcmd = `if [ -x /opt/mssql-tools18/bin/sqlcmd ]; then set -- /opt/mssql-tools18/bin/sqlcmd -C; else set -- /opt/mssql-tools/bin/sqlcmd; fi; ` +
+		`"$@" -S localhost -U sa -P "$MSSQL_SA_PASSWORD" -b`
+)
+
+var (
+	// ErrEULANotAccepted is returned when the container is started without accepting the EULA of SQL Server,
+	// which the image requires to start
+	ErrEULANotAccepted = errors.New("the EULA of SQL Server must be accepted with the WithAcceptEULA option")
+
+	// ErrInvalidPassword is returned when the password of the sa user doesn't follow the password policy of SQL Server,
+	// which would make the server exit when it starts
+	ErrInvalidPassword = errors.New("invalid password for the sa user")
+)
+
+// MSSQLContainer represents the MSSQL container type used in the module
+type MSSQLContainer struct {
+	testcontainers.Container
+	password string
+}
+
+// Option is a function that configures the MSSQL module, e.g. the password of the sa user.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	acceptEULA bool
+	password   string
+	scripts    []string
+}
+
+// WithAcceptEULA accepts the End-User Licensing Agreement of SQL Server, which is required to start the container.
+// See https://go.microsoft.com/fwlink/?linkid=857698 for the terms of the EULA.
+func WithAcceptEULA() Option {
+	return func(o *options) {
+		o.acceptEULA = true
+	}
+}
+
+// WithPassword sets the password of the sa user. By default, the password is "Strong@Passw0rd".
+// It must follow the password policy of SQL Server: at least 8 characters, from at least three of the four categories
+// of characters, i.e. the uppercase letters, the lowercase letters, the digits and the symbols.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithInitSQL executes the given SQL scripts, in order, with sqlcmd once the server is started,
+// e.g. to create the databases and the tables of the tests. It can be called multiple times.
+func WithInitSQL(scripts ...string) Option {
+	return func(o *options) {
+		o.scripts = append(o.scripts, scripts...)
+	}
+}
+
+// StartContainer creates an instance of the MSSQL container type, running SQL Server as the sa user.
+// The EULA of SQL Server must be accepted with the WithAcceptEULA option, and the password must follow
+// the password policy of SQL Server, otherwise an error is returned before the container is created.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MSSQLContainer, error) {
+	settings := options{
+		password: defaultPassword,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if !settings.acceptEULA {
+		return nil, ErrEULANotAccepted
+	}
+
+	if err := validatePassword(settings.password); err != nil {
+		return nil, err
+	}
+
+	files := make([]testcontainers.ContainerFile, 0, len(settings.scripts))
+	for i, script := range settings.scripts {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      script,
+			ContainerFilePath: scriptPath(i, script),
+			FileMode:          0o644,
+		})
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image: defaultImage,
+		Env: map[string]string{
+			"ACCEPT_EULA":       "Y",
+			"MSSQL_SA_PASSWORD": settings.password,
+		},
+		ExposedPorts: []string{mssqlPort},
+		Files:        files,
+		// the server listens on its port before the recovery of the system databases is complete
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(mssqlPort),
+			wait.ForLog("Recovery is complete."),
+		),
+		Metadata: map[string]string{
+			"username": saUser,
+			"password": settings.password,
+		},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	c := &MSSQLContainer{Container: container, password: settings.password}
+	if err != nil {
+		return c, err
+	}
+
+	for i, script := range settings.scripts {
+		if err := c.execSQL(ctx, "-i", scriptPath(i, script)); err != nil {
+			return c, fmt.Errorf("%w: could not execute the script %s", err, script)
+		}
+	}
+
+	return c, nil
+}
+
+// validatePassword checks that the password follows the password policy of SQL Server: from 8 to 128 characters,
+// from at least three of the four categories of characters
+func validatePassword(password string) error {
+	if n := utf8.RuneCountInString(password); n < 8 || n > 128 {
+		return fmt.Errorf("%w: it must have from 8 to 128 characters, got %d", ErrInvalidPassword, n)
+	}
+
+	var upper, lower, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	if categories := upper + lower + digit + symbol; categories < 3 {
+		return fmt.Errorf("%w: it must contain characters from at least three of the categories uppercase letters, lowercase letters, digits and symbols, got %d", ErrInvalidPassword, categories)
+	}
+
+	return nil
+}
+
+// scriptPath returns the path of the script in the container, prefixed by its index to keep the names unique
+func scriptPath(i int, script string) string {
+	return scriptsContainerPath + "/" + strconv.Itoa(i) + "-" + filepath.Base(script)
+}
+
+// execSQL executes the sqlcmd client of the image, connected as sa, with the given arguments
+func (c *MSSQLContainer) execSQL(ctx context.Context, args ...string) error {
+	code, output, err := c.Exec(ctx, []string{"sh", "-c", sqlcmd + " " + strings.Join(args, " ")}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		out, _ := io.ReadAll(output)
+		return fmt.Errorf("exit code %d: %s", code, out)
+	}
+
+	return nil
+}
+
+// Password returns the password of the sa user
+func (c *MSSQLContainer) Password() string {
+	return c.password
+}
+
+// ConnectionString returns the connection string of the server for the go-mssqldb driver, connected as sa,
+// with the format sqlserver://sa:<password>@<host>:<port>. The given arguments are appended as parameters
+// of the URL, e.g. "database=test" or "encrypt=disable".
+func (c *MSSQLContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, mssqlPort)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(saUser, c.password),
+		Host:     host + ":" + mappedPort.Port(),
+		RawQuery: strings.Join(args, "&"),
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,92 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	// Import go-mssqldb into the scope of this package (required)
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+func TestMSSQL(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithAcceptEULA(),
+		WithPassword("SuperStrong@Passw0rd"),
+		WithInitSQL(filepath.Join("testdata", "schema.sql")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx, "database=test", "encrypt=disable")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlserver", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// }
+
+	var name string
+	if err := db.QueryRowContext(ctx, "SELECT name FROM profile WHERE id = 1").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "profile 1" {
+		t.Fatalf("expected profile 1, got %s", name)
+	}
+}
+
+func TestMSSQLWithoutEULA(t *testing.T) {
+	_, err := StartContainer(context.Background())
+	if !errors.Is(err, ErrEULANotAccepted) {
+		t.Fatalf("expected %v, got %v", ErrEULANotAccepted, err)
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		valid    bool
+	}{
+		{name: "default", password: defaultPassword, valid: true},
+		{name: "three categories", password: "passw0rd!", valid: true},
+		{name: "too short", password: "P@ssw0r", valid: false},
+		{name: "too long", password: "P@ssw0rd" + strings.Repeat("a", 121), valid: false},
+		{name: "two categories", password: "password123", valid: false},
+		{name: "one category", password: "passwordpassword", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePassword(tt.password)
+			if tt.valid && err != nil {
+				t.Fatalf("expected a valid password, got %v", err)
+			}
+
+			if !tt.valid && !errors.Is(err, ErrInvalidPassword) {
+				t.Fatalf("expected %v, got %v", ErrInvalidPassword, err)
+			}
+		})
+	}
+}
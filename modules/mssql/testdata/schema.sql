@@ -0,0 +1,9 @@
+CREATE DATABASE test;
+GO
+
+USE test;
+GO
+
+CREATE TABLE profile (id INT PRIMARY KEY, name NVARCHAR(255) NOT NULL);
+INSERT INTO profile (id, name) VALUES (1, 'profile 1');
+GO
@@ -0,0 +1,235 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "mysql:8.0.33"
+	// }
+
+	mysqlPort = "3306/tcp"
+
+	rootUser        = "root"
+	defaultUser     = "test"
+	defaultPassword = "test"
+	defaultDatabase = "test"
+
+	configContainerPath  = "/etc/mysql/conf.d/my.cnf"
+	scriptsContainerPath = "/docker-entrypoint-initdb.d"
+)
+
+// ErrEmptyPassword is returned when the container is started with an empty password for a user other than root,
+// which is not supported by the image
+var ErrEmptyPassword = errors.New("an empty password is only supported for the root user")
+
+// MySQLContainer represents the MySQL container type used in the module.
+// It also supports MariaDB, whose images accept the same configuration.
+type MySQLContainer struct {
+	testcontainers.Container
+	username string
+	password string
+	database string
+}
+
+// Option is a function that configures the MySQL module, e.g. the credentials of the user.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	username   string
+	password   string
+	database   string
+	configFile string
+	scripts    []string
+}
+
+// WithUsername sets the user created when the container starts, with all the privileges on the database.
+// When the user is root, only the password of root is set. By default, the user is "test".
+func WithUsername(username string) Option {
+	return func(o *options) {
+		o.username = username
+	}
+}
+
+// WithPassword sets the password of the user, which is also the password of root. By default, the password is "test".
+// An empty password is only supported for the root user.
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithDatabase sets the database created when the container starts. By default, the database is "test".
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
+
+// WithConfigFile sets a custom my.cnf configuration file, which is copied to /etc/mysql/conf.d,
+// so it's applied on top of the default configuration of the image
+func WithConfigFile(configFile string) Option {
+	return func(o *options) {
+		o.configFile = configFile
+	}
+}
+
+// WithScripts sets the SQL or shell scripts executed, in alphabetical order, when the database is initialized.
+// They are copied to /docker-entrypoint-initdb.d, and executed against the database of the module.
+func WithScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.scripts = append(o.scripts, scripts...)
+	}
+}
+
+// StartContainer creates an instance of the MySQL container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container. A MariaDB image can be used with testcontainers.WithImage.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*MySQLContainer, error) {
+	settings := options{
+		username: defaultUser,
+		password: defaultPassword,
+		database: defaultDatabase,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	env, err := settings.env()
+	if err != nil {
+		return nil, err
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Env:          env,
+		ExposedPorts: []string{mysqlPort},
+		// the image starts a temporary server, listening on port 0, to initialize the database
+		WaitingFor: wait.ForAll(
+			wait.ForLog("port: 3306"),
+			wait.ForListeningPort(mysqlPort),
+		),
+		Metadata: map[string]string{
+			"username": settings.username,
+			"password": settings.password,
+			"database": settings.database,
+		},
+	}
+
+	if settings.configFile != "" {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      settings.configFile,
+			ContainerFilePath: configContainerPath,
+			FileMode:          0o644,
+		})
+	}
+
+	for _, script := range settings.scripts {
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      script,
+			ContainerFilePath: scriptsContainerPath + "/" + filepath.Base(script),
+			FileMode:          0o755,
+		})
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &MySQLContainer{
+		Container: container,
+		username:  settings.username,
+		password:  settings.password,
+		database:  settings.database,
+	}, err
+}
+
+// Username returns the user of the database
+func (c *MySQLContainer) Username() string {
+	return c.username
+}
+
+// Password returns the password of the user
+func (c *MySQLContainer) Password() string {
+	return c.password
+}
+
+// Database returns the database created when the container started
+func (c *MySQLContainer) Database() string {
+	return c.database
+}
+
+// ConnectionString returns the connection string of the database, as a DSN of the go-sql-driver/mysql driver,
+// with the format <user>:<password>@tcp(<host>:<port>)/<database>. The given arguments are appended as parameters
+// of the DSN, e.g. "parseTime=true" or "tls=skip-verify".
+func (c *MySQLContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, mysqlPort)
+	if err != nil {
+		return "", err
+	}
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", c.username, c.password, host, mappedPort.Port(), c.database)
+	if len(args) > 0 {
+		dsn += "?" + strings.Join(args, "&")
+	}
+
+	return dsn, nil
+}
+
+// env returns the environment variables of the image for the options of the module
+func (o options) env() (map[string]string, error) {
+	env := map[string]string{
+		"MYSQL_DATABASE": o.database,
+	}
+
+	if strings.EqualFold(o.username, rootUser) {
+		if o.password == "" {
+			env["MYSQL_ALLOW_EMPTY_PASSWORD"] = "yes"
+		} else {
+			env["MYSQL_ROOT_PASSWORD"] = o.password
+		}
+
+		return env, nil
+	}
+
+	if o.password == "" {
+		return nil, ErrEmptyPassword
+	}
+
+	env["MYSQL_USER"] = o.username
+	env["MYSQL_PASSWORD"] = o.password
+	env["MYSQL_ROOT_PASSWORD"] = o.password
+
+	return env, nil
+}
@@ -0,0 +1,163 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	// Import mysql into the scope of this package (required)
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestMySQL(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithDatabase("foo"),
+		WithUsername("user"),
+		WithPassword("secret"),
+		WithConfigFile(filepath.Join("testdata", "my.cnf")),
+		WithScripts(filepath.Join("testdata", "schema.sql")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx, "parseTime=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("mysql", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// }
+
+	var name string
+	if err := db.QueryRowContext(ctx, "SELECT name FROM profile WHERE id = 1").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "profile 1" {
+		t.Fatalf("expected profile 1, got %s", name)
+	}
+
+	var variable string
+	var maxConnections int
+	if err := db.QueryRowContext(ctx, "SHOW VARIABLES LIKE 'max_connections'").Scan(&variable, &maxConnections); err != nil {
+		t.Fatal(err)
+	}
+
+	if maxConnections != 42 {
+		t.Fatalf("expected the max_connections of the config file, got %d", maxConnections)
+	}
+}
+
+func TestMariaDB(t *testing.T) {
+	ctx := context.Background()
+
+	// withMariaDB {
+	container, err := StartContainer(ctx,
+		testcontainers.WithImage("mariadb:10.11"),
+		WithUsername("root"),
+		WithPassword(""),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("mysql", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMySQLWithEmptyPassword(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithPassword(""))
+	if !errors.Is(err, ErrEmptyPassword) {
+		t.Fatalf("expected %v, got %v", ErrEmptyPassword, err)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		want     map[string]string
+	}{
+		{
+			name:     "user",
+			settings: options{username: "user", password: "secret", database: "foo"},
+			want: map[string]string{
+				"MYSQL_DATABASE":      "foo",
+				"MYSQL_USER":          "user",
+				"MYSQL_PASSWORD":      "secret",
+				"MYSQL_ROOT_PASSWORD": "secret",
+			},
+		},
+		{
+			name:     "root",
+			settings: options{username: "root", password: "secret", database: "foo"},
+			want: map[string]string{
+				"MYSQL_DATABASE":      "foo",
+				"MYSQL_ROOT_PASSWORD": "secret",
+			},
+		},
+		{
+			name:     "root without password",
+			settings: options{username: "root", database: "foo"},
+			want: map[string]string{
+				"MYSQL_DATABASE":             "foo",
+				"MYSQL_ALLOW_EMPTY_PASSWORD": "yes",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.settings.env()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/ollama/ollama:0.1.25"
+	// }
+
+	// apiPort is the port of the HTTP API of Ollama, including the OpenAI compatible API
+	apiPort = "11434/tcp"
+
+	// pullStallTimeout is the time without progress after which the pull of a model is considered stalled
+	pullStallTimeout = 2 * time.Minute
+)
+
+// OllamaContainer represents the Ollama container type used in the module
+type OllamaContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the Ollama module, e.g. the GPU passthrough.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	gpu bool
+}
+
+// WithGPU passes all the GPUs of the host through to the container, to run the inference on them.
+// The Docker daemon must be configured with the NVIDIA Container Toolkit.
+func WithGPU() Option {
+	return func(o *options) {
+		o.gpu = true
+	}
+}
+
+// StartContainer creates an instance of the Ollama container type, without any model.
+// The models are pulled afterwards with the PullModel method.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*OllamaContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{apiPort},
+		WaitingFor:   wait.ForHTTP("/").WithPort(apiPort),
+	}
+
+	if settings.gpu {
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			hostConfig.DeviceRequests = []container.DeviceRequest{
+				{Count: -1, Capabilities: [][]string{{"gpu"}}},
+			}
+		}
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &OllamaContainer{Container: container}, err
+}
+
+// ConnectionString returns the URL of the HTTP API of Ollama, e.g. http://localhost:49153.
+// The OpenAI compatible API is served under the /v1 path of this URL, which is the base URL of the OpenAI clients.
+func (c *OllamaContainer) ConnectionString(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, apiPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// pullProgress is a message of the stream of the progress of a pull
+type pullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// PullModel pulls the given model, e.g. "llama2" or "tinyllama:1.1b", from the Ollama library, and waits until it's ready.
+// The pull can take a long time for the large models, so there is no global timeout besides the one of the context:
+// the pull fails if Ollama reports no progress for two minutes.
+func (c *OllamaContainer) PullModel(ctx context.Context, name string) error {
+	url, err := c.ConnectionString(ctx)
+	if err != nil {
+		return err
+	}
+
+	return pullModel(ctx, url, name, pullStallTimeout)
+}
+
+func pullModel(ctx context.Context, url string, name string, stallTimeout time.Duration) error {
+	body, err := json.Marshal(map[string]interface{}{"name": name, "stream": true})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the request is canceled if no progress is received before the timer expires
+	stalled := time.AfterFunc(stallTimeout, cancel)
+	defer stalled.Stop()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/api/pull", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return stallError(err, stalled, name)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("could not pull the model %s: unexpected status code %d: %s", name, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		stalled.Reset(stallTimeout)
+
+		var progress pullProgress
+		if err := json.Unmarshal(scanner.Bytes(), &progress); err != nil {
+			return fmt.Errorf("%w: could not decode the progress of the pull of the model %s", err, name)
+		}
+
+		if progress.Error != "" {
+			return fmt.Errorf("could not pull the model %s: %s", name, progress.Error)
+		}
+
+		if progress.Status == "success" {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return stallError(err, stalled, name)
+	}
+
+	return fmt.Errorf("could not pull the model %s: the pull ended without success", name)
+}
+
+// stallError wraps the error of a pull, explaining that the pull was canceled if it stalled
+func stallError(err error, stalled *time.Timer, name string) error {
+	// the timer can't be stopped once it fired, which is when the pull stalled
+	if !stalled.Stop() {
+		return fmt.Errorf("%w: the pull of the model %s stalled", err, name)
+	}
+
+	return fmt.Errorf("%w: could not pull the model %s", err, name)
+}
@@ -0,0 +1,150 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOllama(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// pullModel {
+	if err := container.PullModel(ctx, "qwen:0.5b"); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the base URL of the OpenAI clients
+	baseURL := connectionString + "/v1"
+	// }
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model": "qwen:0.5b",
+		"messages": []map[string]string{
+			{"role": "user", "content": "Say hello"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Post(baseURL+"/chat/completions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(completion.Choices) == 0 || completion.Choices[0].Message.Content == "" {
+		t.Fatalf("expected a completion, got %+v", completion)
+	}
+}
+
+func TestPullModel(t *testing.T) {
+	// progressServer streams the given progress messages of a pull, then waits for the given duration
+	progressServer := func(messages []string, wait time.Duration) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, message := range messages {
+				fmt.Fprintln(w, message)
+				w.(http.Flusher).Flush()
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-r.Context().Done():
+			}
+		}))
+	}
+
+	tests := []struct {
+		name     string
+		messages []string
+		wait     time.Duration
+		wantErr  string
+	}{
+		{
+			name: "success",
+			messages: []string{
+				`{"status":"pulling manifest"}`,
+				`{"status":"downloading","digest":"sha256:fad2a06e4cc7","total":394989760,"completed":4096}`,
+				`{"status":"success"}`,
+			},
+		},
+		{
+			name:     "unknown model",
+			messages: []string{`{"status":"pulling manifest"}`, `{"error":"pull model manifest: file does not exist"}`},
+			wantErr:  "file does not exist",
+		},
+		{
+			name:     "stalled pull",
+			messages: []string{`{"status":"pulling manifest"}`},
+			wait:     5 * time.Second,
+			wantErr:  "stalled",
+		},
+		{
+			name:     "interrupted pull",
+			messages: []string{`{"status":"pulling manifest"}`},
+			wantErr:  "ended without success",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := progressServer(tt.messages, tt.wait)
+			defer server.Close()
+
+			err := pullModel(context.Background(), server.URL, "qwen:0.5b", 500*time.Millisecond)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package openfga
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func TestOpenFGA(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithStore("documents", "testdata/model.fga"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if container.StoreID() == "" {
+		t.Fatal("expected the store to be created")
+	}
+	if container.AuthorizationModelID() == "" {
+		t.Fatal("expected the authorization model to be created")
+	}
+
+	// checkRelationship {
+	endpoint, err := container.HttpEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storeURL := fmt.Sprintf("%s/stores/%s", endpoint, container.StoreID())
+
+	tuple := map[string]string{"user": "user:anne", "relation": "owner", "object": "document:roadmap"}
+
+	// write a relationship tuple
+	postJSON(t, storeURL+"/write", map[string]interface{}{
+		"writes":                 map[string]interface{}{"tuple_keys": []interface{}{tuple}},
+		"authorization_model_id": container.AuthorizationModelID(),
+	}, nil)
+
+	// check if anne can view the document, as she is its owner
+	var check struct {
+		Allowed bool `json:"allowed"`
+	}
+	postJSON(t, storeURL+"/check", map[string]interface{}{
+		"tuple_key":              map[string]string{"user": "user:anne", "relation": "viewer", "object": "document:roadmap"},
+		"authorization_model_id": container.AuthorizationModelID(),
+	}, &check)
+	// }
+
+	if !check.Allowed {
+		t.Fatal("expected anne to be allowed to view the document")
+	}
+}
+
+func TestOpenFGAWithPostgresDatastore(t *testing.T) {
+	ctx := context.Background()
+
+	// withPostgresDatastore {
+	pgContainer, err := postgres.StartContainer(ctx,
+		postgres.WithDatabase("openfga"),
+		postgres.WithUsername("openfga"),
+		postgres.WithPassword("password"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := pgContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// the datastore must be reachable from the OpenFGA container
+	pgIP, err := pgContainer.ContainerIP(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container, err := StartContainer(ctx,
+		WithPostgresDatastore(fmt.Sprintf("postgres://openfga:password@%s:5432/openfga?sslmode=disable", pgIP)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	endpoint, err := container.HttpEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var store struct {
+		ID string `json:"id"`
+	}
+	postJSON(t, endpoint+"/stores", map[string]string{"name": "documents"}, &store)
+
+	if store.ID == "" {
+		t.Fatal("expected the store to be created in the Postgres datastore")
+	}
+}
+
+func TestParseStoreCreateOutput(t *testing.T) {
+	output := []byte(`{
+  "store": {
+    "created_at": "2023-04-12T10:00:00.000000Z",
+    "id": "01GXSA8YR785C4FYS3C0RTG7B1",
+    "name": "documents",
+    "updated_at": "2023-04-12T10:00:00.000000Z"
+  },
+  "model": {
+    "authorization_model_id": "01GXSA8YR785C4FYS3C0RTG7B2"
+  }
+}`)
+
+	storeID, modelID, err := parseStoreCreateOutput(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if storeID != "01GXSA8YR785C4FYS3C0RTG7B1" {
+		t.Fatalf("expected store ID to be 01GXSA8YR785C4FYS3C0RTG7B1, got %s", storeID)
+	}
+	if modelID != "01GXSA8YR785C4FYS3C0RTG7B2" {
+		t.Fatalf("expected model ID to be 01GXSA8YR785C4FYS3C0RTG7B2, got %s", modelID)
+	}
+
+	if _, _, err := parseStoreCreateOutput([]byte("Error: connection refused")); err == nil {
+		t.Fatal("expected an error for an unexpected output")
+	}
+}
+
+func postJSON(t *testing.T, url string, body interface{}, result interface{}) {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		t.Fatalf("unexpected status code %d for %s", resp.StatusCode, url)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
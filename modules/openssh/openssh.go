@@ -0,0 +1,234 @@
+package openssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "linuxserver/openssh-server:version-9.3_p2-r0"
+	// }
+
+	sshPort = "2222/tcp"
+
+	defaultUser = "test"
+
+	hostKeysPath = "/config/ssh_host_keys"
+)
+
+// ErrNoCredentials is returned when the container is started without a password nor an authorized key,
+// so no client could log in
+var ErrNoCredentials = errors.New("a password or an authorized key is required to log in")
+
+// OpenSSHContainer represents the OpenSSH container type used in the module
+type OpenSSHContainer struct {
+	testcontainers.Container
+	user     string
+	password string
+}
+
+// Option is a function that configures the OpenSSH module, e.g. the user and its authorized keys.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	user           string
+	password       string
+	authorizedKeys []string
+	sudo           bool
+}
+
+// WithUser sets the user created when the container starts. By default, the user is "test".
+func WithUser(user string) Option {
+	return func(o *options) {
+		o.user = user
+	}
+}
+
+// WithPassword sets the password of the user, enabling the password authentication,
+// which is disabled by default
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithAuthorizedKeys adds public keys, in the authorized_keys format, to the authorized_keys file of the user
+func WithAuthorizedKeys(keys ...string) Option {
+	return func(o *options) {
+		o.authorizedKeys = append(o.authorizedKeys, keys...)
+	}
+}
+
+// WithPublicKeys adds public keys to the authorized_keys file of the user, e.g. the ones of the signers used by the tests
+func WithPublicKeys(keys ...ssh.PublicKey) Option {
+	return func(o *options) {
+		for _, key := range keys {
+			o.authorizedKeys = append(o.authorizedKeys, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))))
+		}
+	}
+}
+
+// WithSudo allows the user to run commands with sudo, without password
+func WithSudo() Option {
+	return func(o *options) {
+		o.sudo = true
+	}
+}
+
+// StartContainer creates an instance of the OpenSSH container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*OpenSSHContainer, error) {
+	settings := options{
+		user: defaultUser,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.password == "" && len(settings.authorizedKeys) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Env:          settings.env(),
+		ExposedPorts: []string{sshPort},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("[ls.io-init] done."),
+			wait.ForListeningPort(sshPort),
+		),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &OpenSSHContainer{Container: container, user: settings.user, password: settings.password}, err
+}
+
+func (o options) env() map[string]string {
+	env := map[string]string{
+		"USER_NAME":       o.user,
+		"PASSWORD_ACCESS": "false",
+		"SUDO_ACCESS":     "false",
+	}
+
+	if o.password != "" {
+		env["USER_PASSWORD"] = o.password
+		env["PASSWORD_ACCESS"] = "true"
+	}
+
+	if len(o.authorizedKeys) > 0 {
+		env["PUBLIC_KEY"] = strings.Join(o.authorizedKeys, "\n")
+	}
+
+	if o.sudo {
+		env["SUDO_ACCESS"] = "true"
+	}
+
+	return env
+}
+
+// User returns the user created in the container
+func (c *OpenSSHContainer) User() string {
+	return c.user
+}
+
+// Password returns the password of the user, empty if the password authentication is disabled
+func (c *OpenSSHContainer) Password() string {
+	return c.password
+}
+
+// Port returns the port of the SSH server mapped on the host
+func (c *OpenSSHContainer) Port(ctx context.Context) (int, error) {
+	port, err := c.MappedPort(ctx, sshPort)
+	if err != nil {
+		return 0, err
+	}
+
+	return port.Int(), nil
+}
+
+// Address returns the address of the SSH server, with the format host:port, to be used by ssh.Dial
+func (c *OpenSSHContainer) Address(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, sshPort)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// HostKey returns the public host key of the given type, e.g. "ed25519", "ecdsa" or "rsa", generated by the container,
+// so the clients can verify the server instead of ignoring its key
+func (c *OpenSSHContainer) HostKey(ctx context.Context, keyType string) (ssh.PublicKey, error) {
+	path := fmt.Sprintf("%s/ssh_host_%s_key.pub", hostKeysPath, keyType)
+
+	reader, err := c.CopyFileFromContainer(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read the host key %s", err, path)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid host key %s", err, path)
+	}
+
+	return key, nil
+}
+
+// ClientConfig returns the configuration of a client logging in as the user with the given authentication methods,
+// verifying the ed25519 host key of the container
+func (c *OpenSSHContainer) ClientConfig(ctx context.Context, auth ...ssh.AuthMethod) (*ssh.ClientConfig, error) {
+	hostKey, err := c.HostKey(ctx, "ed25519")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:              c.user,
+		Auth:              auth,
+		HostKeyCallback:   ssh.FixedHostKey(hostKey),
+		HostKeyAlgorithms: []string{hostKey.Type()},
+	}, nil
+}
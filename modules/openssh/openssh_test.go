@@ -0,0 +1,155 @@
+package openssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestOpenSSH(t *testing.T) {
+	ctx := context.Background()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithUser("deploy"),
+		WithPublicKeys(signer.PublicKey()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connect {
+	config, err := container.ClientConfig(ctx, ssh.PublicKeys(signer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := ssh.Dial("tcp", address, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	// }
+
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	output, err := session.Output("whoami")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSpace(string(output)) != "deploy" {
+		t.Fatalf("expected deploy, got %s", output)
+	}
+}
+
+func TestOpenSSHWithPassword(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartContainer(ctx, WithPassword("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	config, err := container.ClientConfig(ctx, ssh.Password(container.Password()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := ssh.Dial("tcp", address, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+}
+
+func TestOpenSSHWithoutCredentials(t *testing.T) {
+	_, err := StartContainer(context.Background())
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("expected %v, got %v", ErrNoCredentials, err)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		want     map[string]string
+	}{
+		{
+			name:     "authorized keys",
+			settings: options{user: "deploy", authorizedKeys: []string{"ssh-ed25519 AAAA first", "ssh-ed25519 AAAA second"}},
+			want: map[string]string{
+				"USER_NAME":       "deploy",
+				"PASSWORD_ACCESS": "false",
+				"SUDO_ACCESS":     "false",
+				"PUBLIC_KEY":      "ssh-ed25519 AAAA first\nssh-ed25519 AAAA second",
+			},
+		},
+		{
+			name:     "password with sudo",
+			settings: options{user: "deploy", password: "secret", sudo: true},
+			want: map[string]string{
+				"USER_NAME":       "deploy",
+				"USER_PASSWORD":   "secret",
+				"PASSWORD_ACCESS": "true",
+				"SUDO_ACCESS":     "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.settings.env()
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
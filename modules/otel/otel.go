@@ -0,0 +1,123 @@
+package otel
+
+import (
+	"context"
+
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// instrumentationName is the name of the tracer and of the meter of the instrumentation
+const instrumentationName = "github.com/testcontainers/testcontainers-go"
+
+const (
+	// ContainersStartedMetric counts the containers started successfully
+	ContainersStartedMetric = "testcontainers.containers.started"
+	// ContainersFailedMetric counts the containers failing to be created or started
+	ContainersFailedMetric = "testcontainers.containers.failed"
+)
+
+// The attributes of the spans and of the measurements
+const (
+	ImageKey       = attribute.Key("testcontainers.image")
+	ContainerIDKey = attribute.Key("testcontainers.container.id")
+	StrategyKey    = attribute.Key("testcontainers.wait.strategy")
+	OperationKey   = attribute.Key("testcontainers.operation")
+)
+
+var _ testcontainers.Instrumentation = (*Instrumentation)(nil)
+
+// Instrumentation reports the operations of the lifecycle of the containers as OpenTelemetry spans,
+// named after the operation, e.g. testcontainers.container.start, and counts the containers started and failed
+type Instrumentation struct {
+	tracer  trace.Tracer
+	started metric.Int64Counter
+	failed  metric.Int64Counter
+}
+
+// Options are the options of the OpenTelemetry instrumentation
+type Options struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option is an option of the OpenTelemetry instrumentation
+type Option func(*Options)
+
+// WithTracerProvider sets the provider of the tracer of the spans, the global one by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *Options) {
+		o.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the provider of the meter of the counters, the global one by default
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(o *Options) {
+		o.meterProvider = provider
+	}
+}
+
+// New creates the OpenTelemetry instrumentation, to be set with testcontainers.SetInstrumentation
+func New(opts ...Option) (*Instrumentation, error) {
+	options := Options{
+		tracerProvider: otelapi.GetTracerProvider(),
+		meterProvider:  global.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	meter := options.meterProvider.Meter(instrumentationName)
+
+	started, err := meter.NewInt64Counter(ContainersStartedMetric, metric.WithDescription("The number of containers started"))
+	if err != nil {
+		return nil, err
+	}
+
+	failed, err := meter.NewInt64Counter(ContainersFailedMetric, metric.WithDescription("The number of containers failing to be created or started"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		tracer:  options.tracerProvider.Tracer(instrumentationName),
+		started: started,
+		failed:  failed,
+	}, nil
+}
+
+// StartOperation starts the span of the operation, ended with its error
+func (i *Instrumentation) StartOperation(ctx context.Context, op testcontainers.Operation) (context.Context, func(err error)) {
+	attrs := []attribute.KeyValue{ImageKey.String(op.Image)}
+	if op.ContainerID != "" {
+		attrs = append(attrs, ContainerIDKey.String(op.ContainerID))
+	}
+	if op.Strategy != "" {
+		attrs = append(attrs, StrategyKey.String(op.Strategy))
+	}
+
+	ctx, span := i.tracer.Start(ctx, "testcontainers."+op.Name, trace.WithAttributes(attrs...))
+
+	return ctx, func(err error) {
+		defer span.End()
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		switch {
+		case err != nil && (op.Name == testcontainers.OperationContainerCreate || op.Name == testcontainers.OperationContainerStart):
+			i.failed.Add(ctx, 1, ImageKey.String(op.Image), OperationKey.String(op.Name))
+		case err == nil && op.Name == testcontainers.OperationContainerStart:
+			i.started.Add(ctx, 1, ImageKey.String(op.Image))
+		}
+	}
+}
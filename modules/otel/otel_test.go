@@ -0,0 +1,184 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/metrictest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// recordedSpan is a span recording its attributes, errors and status
+type recordedSpan struct {
+	trace.Span
+	name   string
+	attrs  []attribute.KeyValue
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recordedSpan) End(_ ...trace.SpanEndOption) {
+	s.ended = true
+}
+
+// recordingTracer is a tracer provider recording the spans of its tracers
+type recordingTracer struct {
+	trace.TracerProvider
+	mx    sync.Mutex
+	spans []*recordedSpan
+}
+
+func newRecordingTracer() *recordingTracer {
+	return &recordingTracer{TracerProvider: trace.NewNoopTracerProvider()}
+}
+
+func (r *recordingTracer) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
+	return r
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordedSpan{
+		Span:  trace.SpanFromContext(ctx),
+		name:  name,
+		attrs: config.Attributes(),
+	}
+	r.spans = append(r.spans, span)
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func (r *recordingTracer) spanNames() []string {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	names := make([]string, 0, len(r.spans))
+	for _, span := range r.spans {
+		names = append(names, span.name)
+	}
+
+	return names
+}
+
+func TestInstrumentation(t *testing.T) {
+	ctx := context.Background()
+
+	tracer := newRecordingTracer()
+	meter := metrictest.NewMeterProvider()
+
+	instrumentation, err := New(WithTracerProvider(tracer), WithMeterProvider(meter))
+	require.NoError(t, err)
+
+	t.Run("Started container", func(t *testing.T) {
+		_, end := instrumentation.StartOperation(ctx, testcontainers.Operation{
+			Name:        testcontainers.OperationContainerStart,
+			Image:       "nginx:alpine",
+			ContainerID: "3c1a9f0e2b7d",
+		})
+		end(nil)
+
+		span := tracer.spans[len(tracer.spans)-1]
+		assert.Equal(t, "testcontainers.container.start", span.name)
+		assert.Equal(t, []attribute.KeyValue{ImageKey.String("nginx:alpine"), ContainerIDKey.String("3c1a9f0e2b7d")}, span.attrs)
+		assert.Equal(t, codes.Unset, span.status)
+		assert.True(t, span.ended)
+
+		measured := metrictest.AsStructs(meter.MeasurementBatches)
+		require.Len(t, measured, 1)
+		assert.Equal(t, ContainersStartedMetric, measured[0].Name)
+		assert.Equal(t, int64(1), measured[0].Number.AsInt64())
+		assert.Equal(t, attribute.StringValue("nginx:alpine"), measured[0].Labels[ImageKey])
+	})
+
+	t.Run("Failed wait strategy", func(t *testing.T) {
+		meter.MeasurementBatches = nil
+		failure := errors.New("context deadline exceeded")
+
+		_, end := instrumentation.StartOperation(ctx, testcontainers.Operation{
+			Name:     testcontainers.OperationWait,
+			Image:    "nginx:alpine",
+			Strategy: "*wait.LogStrategy",
+		})
+		end(failure)
+
+		span := tracer.spans[len(tracer.spans)-1]
+		assert.Equal(t, "testcontainers.container.wait", span.name)
+		assert.Contains(t, span.attrs, StrategyKey.String("*wait.LogStrategy"))
+		assert.Equal(t, []error{failure}, span.errs)
+		assert.Equal(t, codes.Error, span.status)
+
+		// the failure is counted by the operation starting the container
+		assert.Empty(t, meter.MeasurementBatches)
+	})
+
+	t.Run("Failed creation", func(t *testing.T) {
+		meter.MeasurementBatches = nil
+
+		_, end := instrumentation.StartOperation(ctx, testcontainers.Operation{
+			Name:  testcontainers.OperationContainerCreate,
+			Image: "nginx:unknown",
+		})
+		end(errors.New("No such image: nginx:unknown"))
+
+		measured := metrictest.AsStructs(meter.MeasurementBatches)
+		require.Len(t, measured, 1)
+		assert.Equal(t, ContainersFailedMetric, measured[0].Name)
+		assert.Equal(t, attribute.StringValue(testcontainers.OperationContainerCreate), measured[0].Labels[OperationKey])
+	})
+}
+
+func TestInstrumentedContainer(t *testing.T) {
+	ctx := context.Background()
+	tracer := newRecordingTracer()
+
+	// setInstrumentation {
+	instrumentation, err := New(WithTracerProvider(tracer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcontainers.SetInstrumentation(instrumentation)
+	defer testcontainers.SetInstrumentation(nil)
+	// }
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	names := tracer.spanNames()
+	assert.Contains(t, names, "testcontainers.container.create")
+	assert.Contains(t, names, "testcontainers.container.start")
+	assert.Contains(t, names, "testcontainers.container.wait")
+}
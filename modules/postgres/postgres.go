@@ -50,18 +50,24 @@ func (c *PostgresContainer) ConnectionString(ctx context.Context, args ...string
 	return connStr, nil
 }
 
-// PostgresContainerOption is a function that configures the postgres container, affecting the container request
+// PostgresContainerOption is a function that configures the postgres container, affecting the container request.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
 type PostgresContainerOption func(req *testcontainers.ContainerRequest)
 
+// Customize applies the option to the container request of the postgres container
+func (o PostgresContainerOption) Customize(req *testcontainers.GenericContainerRequest) {
+	o(&req.ContainerRequest)
+}
+
 // WithWaitStrategy sets the wait strategy for the postgres container
-func WithWaitStrategy(strategies ...wait.Strategy) func(req *testcontainers.ContainerRequest) {
+func WithWaitStrategy(strategies ...wait.Strategy) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		req.WaitingFor = wait.ForAll(strategies...).WithDeadline(1 * time.Minute)
 	}
 }
 
 // WithImage sets the image to be used for the postgres container
-func WithImage(image string) func(req *testcontainers.ContainerRequest) {
+func WithImage(image string) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		if image == "" {
 			image = defaultPostgresImage
@@ -74,7 +80,7 @@ func WithImage(image string) func(req *testcontainers.ContainerRequest) {
 // WithConfigFile sets the config file to be used for the postgres container
 // It will also set the "config_file" parameter to the path of the config file
 // as a command line argument to the container
-func WithConfigFile(cfg string) func(req *testcontainers.ContainerRequest) {
+func WithConfigFile(cfg string) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		cfgFile := testcontainers.ContainerFile{
 			HostFilePath:      cfg,
@@ -91,14 +97,14 @@ func WithConfigFile(cfg string) func(req *testcontainers.ContainerRequest) {
 // WithDatabase sets the initial database to be created when the container starts
 // It can be used to define a different name for the default database that is created when the image is first started.
 // If it is not specified, then the value of WithUser will be used.
-func WithDatabase(dbName string) func(req *testcontainers.ContainerRequest) {
+func WithDatabase(dbName string) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		req.Env["POSTGRES_DB"] = dbName
 	}
 }
 
 // WithInitScripts sets the init scripts to be run when the container starts
-func WithInitScripts(scripts ...string) func(req *testcontainers.ContainerRequest) {
+func WithInitScripts(scripts ...string) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		initScripts := []testcontainers.ContainerFile{}
 		for _, script := range scripts {
@@ -116,7 +122,7 @@ func WithInitScripts(scripts ...string) func(req *testcontainers.ContainerReques
 // WithPassword sets the initial password of the user to be created when the container starts
 // It is required for you to use the PostgreSQL image. It must not be empty or undefined.
 // This environment variable sets the superuser password for PostgreSQL.
-func WithPassword(password string) func(req *testcontainers.ContainerRequest) {
+func WithPassword(password string) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		req.Env["POSTGRES_PASSWORD"] = password
 	}
@@ -126,7 +132,7 @@ func WithPassword(password string) func(req *testcontainers.ContainerRequest) {
 // It is used in conjunction with WithPassword to set a user and its password.
 // It will create the specified user with superuser power and a database with the same name.
 // If it is not specified, then the default user of postgres will be used.
-func WithUsername(user string) func(req *testcontainers.ContainerRequest) {
+func WithUsername(user string) PostgresContainerOption {
 	return func(req *testcontainers.ContainerRequest) {
 		if user == "" {
 			user = defaultUser
@@ -136,8 +142,10 @@ func WithUsername(user string) func(req *testcontainers.ContainerRequest) {
 	}
 }
 
-// StartContainer creates an instance of the postgres container type
-func StartContainer(ctx context.Context, opts ...PostgresContainerOption) (*PostgresContainer, error) {
+// StartContainer creates an instance of the postgres container type.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithNetwork,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*PostgresContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image: defaultPostgresImage,
 		Env: map[string]string{
@@ -149,21 +157,23 @@ func StartContainer(ctx context.Context, opts ...PostgresContainerOption) (*Post
 		Cmd:          []string{"postgres", "-c", "fsync=off"},
 	}
 
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
 	for _, opt := range opts {
-		opt(&req)
+		opt.Customize(&genericContainerReq)
 	}
 
-	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: req,
-		Started:          true,
-	})
-	if err != nil {
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
 		return nil, err
 	}
 
-	user := req.Env["POSTGRES_USER"]
-	password := req.Env["POSTGRES_PASSWORD"]
-	dbName := req.Env["POSTGRES_DB"]
+	user := genericContainerReq.Env["POSTGRES_USER"]
+	password := genericContainerReq.Env["POSTGRES_PASSWORD"]
+	dbName := genericContainerReq.Env["POSTGRES_DB"]
 
-	return &PostgresContainer{Container: container, dbName: dbName, password: password, user: user}, nil
+	return &PostgresContainer{Container: container, dbName: dbName, password: password, user: user}, err
 }
@@ -136,7 +136,10 @@ func TestContainerWithWaitForSQL(t *testing.T) {
 			WithWaitStrategy(wait.ForSQL(nat.Port(port), "postgres", dbURL).WithStartupTimeout(time.Second*5).WithQuery("SELECT 'a' from b")),
 		)
 		require.Error(t, err)
-		require.Nil(t, container)
+
+		// the container which failed to start is returned, so it can be terminated
+		require.NotNil(t, container)
+		require.NoError(t, container.Terminate(ctx))
 	})
 }
 
@@ -0,0 +1,57 @@
+package presidio
+
+import (
+	"context"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// AnalyzerContainer represents the container of the Presidio analyzer, which detects the PII entities in a text
+type AnalyzerContainer struct {
+	*presidioContainer
+}
+
+// AnalyzeRequest is the request of the analyzer, describing the text to analyze
+type AnalyzeRequest struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+	// Entities restricts the analysis to the given entity types, e.g. PHONE_NUMBER, all the supported types if empty
+	Entities []string `json:"entities,omitempty"`
+	// ScoreThreshold is the minimal confidence score of the returned entities
+	ScoreThreshold float64 `json:"score_threshold,omitempty"`
+}
+
+// RecognizerResult is a PII entity detected by the analyzer, and the position of its value in the text
+type RecognizerResult struct {
+	EntityType string  `json:"entity_type"`
+	Start      int     `json:"start"`
+	End        int     `json:"end"`
+	Score      float64 `json:"score"`
+}
+
+// StartAnalyzerContainer creates an instance of the Presidio analyzer container type.
+// The analyzer loads its NLP models when it starts, so it waits up to two minutes for the service to be healthy.
+func StartAnalyzerContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*AnalyzerContainer, error) {
+	container, err := startService(ctx, defaultAnalyzerImage, 2*time.Minute, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnalyzerContainer{presidioContainer: container}, nil
+}
+
+// Analyze returns the PII entities detected by the analyzer in the text of the request.
+// The language defaults to English if empty.
+func (c *AnalyzerContainer) Analyze(ctx context.Context, request AnalyzeRequest) ([]RecognizerResult, error) {
+	if request.Language == "" {
+		request.Language = "en"
+	}
+
+	var results []RecognizerResult
+	if err := c.post(ctx, "/analyze", request, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
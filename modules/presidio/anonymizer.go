@@ -0,0 +1,79 @@
+package presidio
+
+import (
+	"context"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// DefaultOperator is the key of the operator applied to the entity types without a dedicated operator
+const DefaultOperator = "DEFAULT"
+
+// AnonymizerContainer represents the container of the Presidio anonymizer, which masks the PII entities
+// detected by the analyzer in a text
+type AnonymizerContainer struct {
+	*presidioContainer
+}
+
+// Operator describes how the anonymizer transforms the values of an entity type, e.g. replace, redact, mask or hash.
+type Operator struct {
+	Type string `json:"type"`
+	// NewValue is the value of the replace operator
+	NewValue string `json:"new_value,omitempty"`
+	// MaskingChar, CharsToMask and FromEnd configure the mask operator
+	MaskingChar string `json:"masking_char,omitempty"`
+	CharsToMask int    `json:"chars_to_mask,omitempty"`
+	FromEnd     bool   `json:"from_end,omitempty"`
+	// HashType is the algorithm of the hash operator, e.g. sha256
+	HashType string `json:"hash_type,omitempty"`
+}
+
+// AnonymizeRequest is the request of the anonymizer, with the text and its entities returned by the analyzer
+type AnonymizeRequest struct {
+	Text string `json:"text"`
+	// Operators are the operators by entity type. The DefaultOperator key applies to the other types,
+	// which are replaced by their type, e.g. <PHONE_NUMBER>, if not set.
+	Operators       map[string]Operator `json:"anonymizers,omitempty"`
+	AnalyzerResults []RecognizerResult  `json:"analyzer_results"`
+}
+
+// AnonymizeResponse is the response of the anonymizer, with the anonymized text
+type AnonymizeResponse struct {
+	Text  string           `json:"text"`
+	Items []AnonymizedItem `json:"items"`
+}
+
+// AnonymizedItem is an entity transformed by the anonymizer, and the position of its new value in the anonymized text
+type AnonymizedItem struct {
+	Operator   string `json:"operator"`
+	EntityType string `json:"entity_type"`
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	Text       string `json:"text"`
+}
+
+// StartAnonymizerContainer creates an instance of the Presidio anonymizer container type
+func StartAnonymizerContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*AnonymizerContainer, error) {
+	container, err := startService(ctx, defaultAnonymizerImage, time.Minute, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnonymizerContainer{presidioContainer: container}, nil
+}
+
+// Anonymize transforms the entities of the text of the request with their operators
+func (c *AnonymizerContainer) Anonymize(ctx context.Context, request AnonymizeRequest) (*AnonymizeResponse, error) {
+	if request.AnalyzerResults == nil {
+		// the anonymizer rejects the requests without results
+		request.AnalyzerResults = []RecognizerResult{}
+	}
+
+	var response AnonymizeResponse
+	if err := c.post(ctx, "/anonymize", request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,109 @@
+package presidio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImages {
+	defaultAnalyzerImage   = "mcr.microsoft.com/presidio-analyzer:2.2.33"
+	defaultAnonymizerImage = "mcr.microsoft.com/presidio-anonymizer:2.2.33"
+	// }
+
+	// httpPort is the port of the HTTP API of the Presidio services
+	httpPort = "3000/tcp"
+)
+
+// presidioContainer is the container of one of the Presidio services, exposing its HTTP API
+type presidioContainer struct {
+	testcontainers.Container
+}
+
+// URL returns the URL of the HTTP API of the service, e.g. http://localhost:49153
+func (c *presidioContainer) URL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// post sends the given request, in JSON format, to the given path of the HTTP API of the service,
+// and decodes the JSON response into the given value
+func (c *presidioContainer) post(ctx context.Context, path string, request interface{}, response interface{}) error {
+	url, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	return postJSON(ctx, url+path, request, response)
+}
+
+func postJSON(ctx context.Context, url string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// the services describe the invalid requests in the body of the response
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// startService creates an instance of the container of a Presidio service, with the given image, which is ready
+// once its health endpoint answers. It accepts the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func startService(ctx context.Context, image string, startupTimeout time.Duration, opts ...testcontainers.ContainerCustomizer) (*presidioContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        image,
+		ExposedPorts: []string{httpPort},
+		WaitingFor:   wait.ForHTTP("/health").WithPort(httpPort).WithStartupTimeout(startupTimeout),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &presidioContainer{Container: container}, err
+}
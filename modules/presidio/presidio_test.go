@@ -0,0 +1,123 @@
+package presidio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPresidio(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainers {
+	analyzer, err := StartAnalyzerContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anonymizer, err := StartAnonymizerContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the containers after the test is complete
+	t.Cleanup(func() {
+		if err := analyzer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+		if err := anonymizer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	text := "My phone number is 212-555-5555"
+
+	// analyzeAndAnonymize {
+	results, err := analyzer.Analyze(ctx, AnalyzeRequest{
+		Text:     text,
+		Entities: []string{"PHONE_NUMBER"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := anonymizer.Anonymize(ctx, AnonymizeRequest{
+		Text: text,
+		Operators: map[string]Operator{
+			"PHONE_NUMBER": {Type: "mask", MaskingChar: "*", CharsToMask: 4, FromEnd: true},
+		},
+		AnalyzerResults: results,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if len(results) != 1 || results[0].EntityType != "PHONE_NUMBER" {
+		t.Fatalf("expected a phone number to be detected, got %v", results)
+	}
+
+	if expected := "My phone number is 212-555-****"; response.Text != expected {
+		t.Fatalf("expected %s, got %s", expected, response.Text)
+	}
+}
+
+func TestPostJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request AnalyzeRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if request.Language != "en" {
+			http.Error(w, "No matching recognizers were found to serve the request.", http.StatusInternalServerError)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode([]RecognizerResult{{EntityType: "PERSON", Start: 0, End: 4, Score: 0.85}})
+	}))
+	defer server.Close()
+
+	t.Run("decodes the response", func(t *testing.T) {
+		var results []RecognizerResult
+		if err := postJSON(context.Background(), server.URL, AnalyzeRequest{Text: "John", Language: "en"}, &results); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(results) != 1 || results[0].EntityType != "PERSON" {
+			t.Fatalf("expected a person, got %v", results)
+		}
+	})
+
+	t.Run("returns the message of the errors", func(t *testing.T) {
+		var results []RecognizerResult
+		err := postJSON(context.Background(), server.URL, AnalyzeRequest{Text: "John", Language: "xx"}, &results)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if !strings.Contains(err.Error(), "No matching recognizers") {
+			t.Fatalf("expected the message of the service in the error, got %s", err)
+		}
+	})
+}
+
+func TestAnonymizeRequestJSON(t *testing.T) {
+	body, err := json.Marshal(AnonymizeRequest{
+		Text:      "John",
+		Operators: map[string]Operator{DefaultOperator: {Type: "replace", NewValue: "ANONYMIZED"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"text":"John","anonymizers":{"DEFAULT":{"type":"replace","new_value":"ANONYMIZED"}},"analyzer_results":null}`
+	if string(body) != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
@@ -0,0 +1,245 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/prom/prometheus:v2.47.2"
+	// }
+
+	httpPort = "9090/tcp"
+
+	configContainerPath = "/etc/prometheus/prometheus.yml"
+	// rulesContainerDir is the directory of the rule files in the container
+	rulesContainerDir = "/etc/prometheus/rules"
+
+	defaultScrapeInterval = 5 * time.Second
+)
+
+// ErrConflictingConfig is returned when the container is started with both a configuration file and scrape targets,
+// which are only used to generate the configuration
+var ErrConflictingConfig = errors.New("the scrape targets can't be combined with a configuration file, which must define them")
+
+// PrometheusContainer represents the Prometheus container type used in the module
+type PrometheusContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the Prometheus module, e.g. the targets it scrapes.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	configFile     string
+	targets        []string
+	scrapeInterval time.Duration
+	ruleFiles      []string
+}
+
+// WithConfigFile sets the configuration file of Prometheus, replacing the configuration generated by the module.
+// The rule files of the WithRuleFiles option are copied to /etc/prometheus/rules, where the configuration must reference them.
+func WithConfigFile(configFile string) Option {
+	return func(o *options) {
+		o.configFile = configFile
+	}
+}
+
+// WithScrapeTarget adds the given target, with the format <host>:<port>, to the targets scraped by Prometheus,
+// regenerating its configuration. The host is resolved inside the container, e.g. the name of a container
+// on the same network, or testcontainers.HostInternal for the ports exposed with testcontainers.ExposeHostPorts.
+// It can be called multiple times.
+func WithScrapeTarget(target string) Option {
+	return func(o *options) {
+		o.targets = append(o.targets, target)
+	}
+}
+
+// WithScrapeInterval sets the interval between the scrapes of the targets of the generated configuration, 5 seconds by default.
+func WithScrapeInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.scrapeInterval = interval
+	}
+}
+
+// WithRuleFiles copies the given recording and alerting rule files to the container, so they are evaluated
+// by Prometheus, e.g. to test the alerts fired for the metrics of an exporter. It can be called multiple times.
+func WithRuleFiles(ruleFiles ...string) Option {
+	return func(o *options) {
+		o.ruleFiles = append(o.ruleFiles, ruleFiles...)
+	}
+}
+
+// StartContainer creates an instance of the Prometheus container type, scraping the targets of the options, and
+// accepting the samples pushed with the remote write protocol, so it's also a sink for the remote write clients.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*PrometheusContainer, error) {
+	settings := options{
+		scrapeInterval: defaultScrapeInterval,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	configFile := settings.configFile
+	if configFile == "" {
+		generated, err := writeConfig(settings)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not write the configuration of Prometheus", err)
+		}
+		defer os.Remove(generated)
+
+		configFile = generated
+	} else if len(settings.targets) > 0 {
+		return nil, ErrConflictingConfig
+	}
+
+	files := []testcontainers.ContainerFile{
+		{HostFilePath: configFile, ContainerFilePath: configContainerPath, FileMode: 0o644},
+	}
+	for _, ruleFile := range settings.ruleFiles {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      ruleFile,
+			ContainerFilePath: ruleContainerPath(ruleFile),
+			FileMode:          0o644,
+		})
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image: defaultImage,
+		Cmd: []string{
+			"--config.file=" + configContainerPath,
+			"--storage.tsdb.path=/prometheus",
+			"--web.enable-remote-write-receiver",
+			"--web.enable-lifecycle",
+		},
+		ExposedPorts: []string{httpPort},
+		Files:        files,
+		WaitingFor:   wait.ForHTTP("/-/ready").WithPort(httpPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &PrometheusContainer{Container: container}, err
+}
+
+// ruleContainerPath returns the path of the rule file in the container
+func ruleContainerPath(ruleFile string) string {
+	return rulesContainerDir + "/" + filepath.Base(ruleFile)
+}
+
+// config is the configuration of Prometheus generated by the module
+type config struct {
+	Global struct {
+		ScrapeInterval     string `json:"scrape_interval"`
+		EvaluationInterval string `json:"evaluation_interval"`
+	} `json:"global"`
+	RuleFiles     []string       `json:"rule_files,omitempty"`
+	ScrapeConfigs []scrapeConfig `json:"scrape_configs,omitempty"`
+}
+
+type scrapeConfig struct {
+	JobName       string         `json:"job_name"`
+	StaticConfigs []staticConfig `json:"static_configs"`
+}
+
+type staticConfig struct {
+	Targets []string `json:"targets"`
+}
+
+// generateConfig returns the configuration of Prometheus for the options of the module, in JSON format,
+// which is valid YAML. The targets are scraped by the same job, and the rules are evaluated at the scrape interval.
+func generateConfig(o options) ([]byte, error) {
+	cfg := config{}
+	cfg.Global.ScrapeInterval = o.scrapeInterval.String()
+	cfg.Global.EvaluationInterval = o.scrapeInterval.String()
+
+	for _, ruleFile := range o.ruleFiles {
+		cfg.RuleFiles = append(cfg.RuleFiles, ruleContainerPath(ruleFile))
+	}
+
+	if len(o.targets) > 0 {
+		cfg.ScrapeConfigs = []scrapeConfig{
+			{JobName: "testcontainers", StaticConfigs: []staticConfig{{Targets: o.targets}}},
+		}
+	}
+
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
+// writeConfig writes the generated configuration of Prometheus to a temporary file, returning its path
+func writeConfig(o options) (string, error) {
+	content, err := generateConfig(o)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", "prometheus-config-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(content); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// APIEndpoint returns the URL of the HTTP API of Prometheus, with the format http://<host>:<port>,
+// e.g. to query the scraped metrics under /api/v1/query, or the alerts under /api/v1/alerts
+func (c *PrometheusContainer) APIEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// RemoteWriteEndpoint returns the URL receiving the samples pushed with the remote write protocol,
+// with the format http://<host>:<port>/api/v1/write
+func (c *PrometheusContainer) RemoteWriteEndpoint(ctx context.Context) (string, error) {
+	endpoint, err := c.APIEndpoint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/api/v1/write", nil
+}
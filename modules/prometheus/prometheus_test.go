@@ -0,0 +1,180 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPrometheus(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithScrapeTarget("localhost:9090"),
+		WithScrapeInterval(time.Second),
+		WithRuleFiles(filepath.Join("testdata", "alerts.yml")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// apiEndpoint {
+	endpoint, err := container.APIEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	eventually(t, func() bool {
+		var result struct {
+			Data struct {
+				Result []interface{} `json:"result"`
+			} `json:"data"`
+		}
+		getJSON(t, endpoint+"/api/v1/query?query="+url.QueryEscape(`up{job="testcontainers"} == 1`), &result)
+
+		return len(result.Data.Result) == 1
+	})
+
+	eventually(t, func() bool {
+		var result struct {
+			Data struct {
+				Alerts []struct {
+					Labels map[string]string `json:"labels"`
+				} `json:"alerts"`
+			} `json:"data"`
+		}
+		getJSON(t, endpoint+"/api/v1/alerts", &result)
+
+		return len(result.Data.Alerts) == 1 && result.Data.Alerts[0].Labels["alertname"] == "AlwaysFiring"
+	})
+}
+
+func TestPrometheusWithConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := StartContainer(ctx, WithConfigFile(filepath.Join("testdata", "prometheus.yml")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	endpoint, err := container.APIEndpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result struct {
+		Data struct {
+			ActiveTargets []struct {
+				ScrapePool string `json:"scrapePool"`
+			} `json:"activeTargets"`
+		} `json:"data"`
+	}
+	getJSON(t, endpoint+"/api/v1/targets", &result)
+
+	if len(result.Data.ActiveTargets) != 1 || result.Data.ActiveTargets[0].ScrapePool != "prometheus" {
+		t.Fatalf("expected the target of the configuration file, got %+v", result.Data.ActiveTargets)
+	}
+}
+
+// eventually fails the test if the condition is not true after 30 seconds
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(30 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("the condition is not true after 30s")
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// getJSON sends a GET request to the given URL, decoding the JSON response into the given value
+func getJSON(t *testing.T, url string, v interface{}) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrometheusWithConflictingConfig(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithConfigFile("prometheus.yml"), WithScrapeTarget("exporter:9100"))
+	if !errors.Is(err, ErrConflictingConfig) {
+		t.Fatalf("expected %v, got %v", ErrConflictingConfig, err)
+	}
+}
+
+func TestGenerateConfig(t *testing.T) {
+	content, err := generateConfig(options{
+		targets:        []string{"exporter:9100", "app:8080"},
+		scrapeInterval: time.Second,
+		ruleFiles:      []string{filepath.Join("testdata", "alerts.yml")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg config
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Global.ScrapeInterval != "1s" || cfg.Global.EvaluationInterval != "1s" {
+		t.Fatalf("expected the intervals of the options, got %+v", cfg.Global)
+	}
+
+	if len(cfg.RuleFiles) != 1 || cfg.RuleFiles[0] != rulesContainerDir+"/alerts.yml" {
+		t.Fatalf("expected the rule file in the container, got %v", cfg.RuleFiles)
+	}
+
+	if len(cfg.ScrapeConfigs) != 1 || len(cfg.ScrapeConfigs[0].StaticConfigs[0].Targets) != 2 {
+		t.Fatalf("expected one job scraping the targets, got %+v", cfg.ScrapeConfigs)
+	}
+
+	content, err = generateConfig(options{scrapeInterval: defaultScrapeInterval})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg = config{}
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.ScrapeConfigs) != 0 {
+		t.Fatalf("expected no job without targets, got %+v", cfg.ScrapeConfigs)
+	}
+}
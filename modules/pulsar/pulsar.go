@@ -20,14 +20,28 @@ const defaultPulsarCmd = "/pulsar/bin/apply-config-from-env.py /pulsar/conf/stan
 const detaultPulsarCmdWithoutFunctionsWorker = "--no-functions-worker -nss"
 const transactionTopicEndpoint = "/admin/v2/persistent/pulsar/system/transaction_coordinator_assign/partitions"
 
-var defaultWaitStrategies = wait.ForAll(
-	wait.ForHTTP("/admin/v2/clusters").WithPort(defaultPulsarAdminPort).WithResponseMatcher(func(r io.Reader) bool {
-		respBytes, _ := io.ReadAll(r)
-		resp := string(respBytes)
-		return resp == `["standalone"]`
-	}),
-	wait.ForLog("Successfully updated the policies on namespace public/default"),
-)
+// defaultWaitStrategies returns the wait strategies of a new container, extended by the options of the container,
+// so the options enabling a feature of a container don't change the wait strategies of the next ones
+func defaultWaitStrategies() *wait.MultiStrategy {
+	return wait.ForAll(
+		wait.ForHTTP("/admin/v2/clusters").WithPort(defaultPulsarAdminPort).WithResponseMatcher(func(r io.Reader) bool {
+			respBytes, _ := io.ReadAll(r)
+			resp := string(respBytes)
+			return resp == `["standalone"]`
+		}),
+		wait.ForLog("Successfully updated the policies on namespace public/default"),
+	)
+}
+
+// addWaitStrategy adds the strategy to the wait strategies of the request
+func addWaitStrategy(req *ContainerRequest, strategy wait.Strategy) {
+	if strategies, ok := req.WaitingFor.(*wait.MultiStrategy); ok {
+		strategies.Strategies = append(strategies.Strategies, strategy)
+		return
+	}
+
+	req.WaitingFor = wait.ForAll(req.WaitingFor, strategy)
+}
 
 type Container struct {
 	testcontainers.Container
@@ -72,9 +86,14 @@ type ContainerRequest struct {
 	logConsumers []testcontainers.LogConsumer
 }
 
-// ContainerOptions is a function that can be used to configure the Pulsar container
+// ContainerOptions is a function that can be used to configure the Pulsar container.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
 type ContainerOptions func(req *ContainerRequest)
 
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the Pulsar request, which also holds the log consumers.
+func (o ContainerOptions) Customize(req *testcontainers.GenericContainerRequest) {}
+
 // WithConfigModifier allows to override the default container config
 func WithConfigModifier(modifier func(config *container.Config)) ContainerOptions {
 	return func(req *ContainerRequest) {
@@ -85,7 +104,7 @@ func WithConfigModifier(modifier func(config *container.Config)) ContainerOption
 // WithEndpointSettingsModifier allows to override the default endpoint settings
 func WithEndpointSettingsModifier(modifier func(settings map[string]*network.EndpointSettings)) ContainerOptions {
 	return func(req *ContainerRequest) {
-		req.EnpointSettingsModifier = modifier
+		req.EndpointSettingsModifier = modifier
 	}
 }
 
@@ -96,12 +115,7 @@ func WithFunctionsWorker() ContainerOptions {
 		req.Cmd = []string{"/bin/bash", "-c", defaultPulsarCmd}
 
 		// add the waiting strategy for the functions worker
-		defaultWaitStrategies.Strategies = append(
-			defaultWaitStrategies.Strategies,
-			wait.ForLog("Function worker service started"),
-		)
-
-		req.WaitingFor = defaultWaitStrategies
+		addWaitStrategy(req, wait.ForLog("Function worker service started"))
 	}
 }
 
@@ -138,36 +152,25 @@ func WithPulsarImage(image string) ContainerOptions {
 	}
 }
 
+// WithTransactions enables the transaction coordinator, adding a waiting strategy for the transaction topic
 func WithTransactions() ContainerOptions {
 	return func(req *ContainerRequest) {
 		WithPulsarEnv("transactionCoordinatorEnabled", "true")(req)
 
 		// add the waiting strategy for the transaction topic
-		defaultWaitStrategies.Strategies = append(
-			defaultWaitStrategies.Strategies,
-			wait.ForHTTP(transactionTopicEndpoint).WithPort(defaultPulsarAdminPort).WithStatusCodeMatcher(func(statusCode int) bool {
-				return statusCode == 200
-			}),
-		)
-
-		req.WaitingFor = defaultWaitStrategies
+		addWaitStrategy(req, wait.ForHTTP(transactionTopicEndpoint).WithPort(defaultPulsarAdminPort).WithStatusCodeMatcher(func(statusCode int) bool {
+			return statusCode == 200
+		}))
 	}
 }
 
-// StartContainer creates an instance of the Pulsar container type, being possible to pass a custom request and options
-// The created container will use the following defaults:
-// - image: docker.io/apachepulsar/pulsar:2.10.2
-// - exposed ports: 6650/tcp, 8080/tcp
-// - waiting strategy: wait for all the following strategies:
-//		- the Pulsar admin API ("/admin/v2/clusters") to be ready on port 8080/tcp and return the response `["standalone"]`
-// 		- the log message "Successfully updated the policies on namespace public/default"
-// - command: "/bin/bash -c /pulsar/bin/apply-config-from-env.py /pulsar/conf/standalone.conf && bin/pulsar standalone --no-functions-worker -nss"
-func StartContainer(ctx context.Context, opts ...ContainerOptions) (*Container, error) {
+// newContainerRequest returns the default request of a container, with the options of the module applied
+func newContainerRequest(opts ...testcontainers.ContainerCustomizer) ContainerRequest {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultPulsarImage,
 		Env:          map[string]string{},
 		ExposedPorts: []string{defaultPulsarPort, defaultPulsarAdminPort},
-		WaitingFor:   defaultWaitStrategies,
+		WaitingFor:   defaultWaitStrategies(),
 		Cmd:          []string{"/bin/bash", "-c", strings.Join([]string{defaultPulsarCmd, detaultPulsarCmdWithoutFunctionsWorker}, " ")},
 	}
 
@@ -177,14 +180,39 @@ func StartContainer(ctx context.Context, opts ...ContainerOptions) (*Container,
 	}
 
 	for _, opt := range opts {
-		opt(&pulsarRequest)
+		if o, ok := opt.(ContainerOptions); ok {
+			o(&pulsarRequest)
+		}
 	}
 
-	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+	return pulsarRequest
+}
+
+// StartContainer creates an instance of the Pulsar container type, being possible to pass a custom request and options
+// The created container will use the following defaults:
+// - image: docker.io/apachepulsar/pulsar:2.10.2
+// - exposed ports: 6650/tcp, 8080/tcp
+// - waiting strategy: wait for all the following strategies:
+//		- the Pulsar admin API ("/admin/v2/clusters") to be ready on port 8080/tcp and return the response `["standalone"]`
+// 		- the log message "Successfully updated the policies on namespace public/default"
+// - command: "/bin/bash -c /pulsar/bin/apply-config-from-env.py /pulsar/conf/standalone.conf && bin/pulsar standalone --no-functions-worker -nss"
+//
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithNetwork,
+// which are applied after the options of the module.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*Container, error) {
+	pulsarRequest := newContainerRequest(opts...)
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
 		ContainerRequest: pulsarRequest.ContainerRequest,
 		Started:          true,
-	})
-	if err != nil {
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if c == nil {
 		return nil, err
 	}
 
@@ -192,6 +220,9 @@ func StartContainer(ctx context.Context, opts ...ContainerOptions) (*Container,
 		Container:    c,
 		LogConsumers: pulsarRequest.logConsumers,
 	}
+	if err != nil {
+		return pc, err
+	}
 
 	if len(pc.LogConsumers) > 0 {
 		c.StartLogProducer(ctx)
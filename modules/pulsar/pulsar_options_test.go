@@ -0,0 +1,36 @@
+package pulsar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// waitStrategies returns the wait strategies of the request
+func waitStrategies(t *testing.T, req ContainerRequest) []wait.Strategy {
+	t.Helper()
+
+	strategies, ok := req.WaitingFor.(*wait.MultiStrategy)
+	require.True(t, ok, "expected a multi strategy, got %T", req.WaitingFor)
+
+	return strategies.Strategies
+}
+
+func TestOptionsDontShareWaitStrategies(t *testing.T) {
+	transactions := waitStrategies(t, newContainerRequest(WithTransactions()))
+	functionsWorker := waitStrategies(t, newContainerRequest(WithFunctionsWorker()))
+	defaults := waitStrategies(t, newContainerRequest())
+
+	// the default strategies, and the strategy added by the option of each container
+	require.Len(t, transactions, 3)
+	require.Len(t, functionsWorker, 3)
+	require.Len(t, defaults, 2)
+
+	assert.NotContains(t, functionsWorker, transactions[2], "the strategy of the transactions must not be added to the next containers")
+	assert.NotContains(t, defaults, transactions[2])
+	assert.NotContains(t, defaults, functionsWorker[2], "the strategy of the functions worker must not be added to the next containers")
+	assert.Equal(t, wait.ForLog("Function worker service started"), functionsWorker[2])
+}
@@ -44,14 +44,14 @@ func TestPulsar(t *testing.T) {
 
 	tests := []struct {
 		name string
-		opts []testcontainerspulsar.ContainerOptions
+		opts []testcontainers.ContainerCustomizer
 	}{
 		{
 			name: "default",
 		},
 		{
 			name: "with modifiers",
-			opts: []testcontainerspulsar.ContainerOptions{
+			opts: []testcontainers.ContainerCustomizer{
 				// setPulsarImage {
 				testcontainerspulsar.WithPulsarImage("docker.io/apachepulsar/pulsar:2.10.2"),
 				// }
@@ -77,7 +77,7 @@ func TestPulsar(t *testing.T) {
 		},
 		{
 			name: "with functions worker",
-			opts: []testcontainerspulsar.ContainerOptions{
+			opts: []testcontainers.ContainerCustomizer{
 				// withFunctionsWorker {
 				testcontainerspulsar.WithFunctionsWorker(),
 				// }
@@ -85,7 +85,7 @@ func TestPulsar(t *testing.T) {
 		},
 		{
 			name: "with transactions",
-			opts: []testcontainerspulsar.ContainerOptions{
+			opts: []testcontainers.ContainerCustomizer{
 				// withTransactions {
 				testcontainerspulsar.WithTransactions(),
 				// }
@@ -93,7 +93,7 @@ func TestPulsar(t *testing.T) {
 		},
 		{
 			name: "with log consumers",
-			opts: []testcontainerspulsar.ContainerOptions{
+			opts: []testcontainers.ContainerCustomizer{
 				// withLogConsumers {
 				testcontainerspulsar.WithLogConsumers(&testLogConsumer{}),
 				// }
@@ -0,0 +1,363 @@
+package qemu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "tianon/qemu:native"
+	// }
+
+	sshPort = "22/tcp"
+
+	diskContainerPath = "/qemu/disk"
+
+	defaultMemory      = 512
+	defaultCPUs        = 1
+	defaultBootTimeout = 5 * time.Minute
+)
+
+var (
+	// ErrMissingDiskImage is returned when the container is started without a disk image to boot
+	ErrMissingDiskImage = errors.New("a disk image is required to boot the virtual machine")
+
+	// ErrMissingSSHCredentials is returned when a command is executed in the virtual machine without SSH credentials
+	ErrMissingSSHCredentials = errors.New("the SSH credentials of the virtual machine are required to execute commands in it")
+)
+
+// QEMUContainer represents the QEMU container type used in the module.
+// The container runs a QEMU virtual machine, whose forwarded ports are exposed as ports of the container,
+// so the MappedPort method of the container returns the host port of a port of the virtual machine.
+//
+// This module is experimental: its API could change in future versions.
+type QEMUContainer struct {
+	testcontainers.Container
+	sshConfig *ssh.ClientConfig
+}
+
+// Option is a function that configures the QEMU module, e.g. the disk image of the virtual machine.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	diskImage   string
+	diskFormat  string
+	memory      int
+	cpus        int
+	kvm         bool
+	ports       []int
+	sshUser     string
+	sshPassword string
+	bootTimeout time.Duration
+}
+
+// WithDiskImage sets the disk image booted by the virtual machine, and its format, e.g. "qcow2" or "raw".
+// The image is mounted read-only: the changes done by the virtual machine are discarded when it's terminated.
+func WithDiskImage(path string, format string) Option {
+	return func(o *options) {
+		o.diskImage = path
+		o.diskFormat = format
+	}
+}
+
+// WithMemory sets the memory of the virtual machine, in megabytes. By default, 512MB are used.
+func WithMemory(megabytes int) Option {
+	return func(o *options) {
+		o.memory = megabytes
+	}
+}
+
+// WithCPUs sets the number of CPUs of the virtual machine. By default, one CPU is used.
+func WithCPUs(cpus int) Option {
+	return func(o *options) {
+		o.cpus = cpus
+	}
+}
+
+// WithKVM enables the hardware acceleration of the virtual machine, giving the container access to the /dev/kvm device
+// of the Docker host. Without it, the virtual machine is fully emulated, which is much slower.
+func WithKVM() Option {
+	return func(o *options) {
+		o.kvm = true
+	}
+}
+
+// WithForwardedPorts forwards the given TCP ports of the virtual machine to the same ports of the container,
+// which are exposed, so their host ports are returned by the MappedPort method of the container.
+// The SSH port of the virtual machine is always forwarded.
+func WithForwardedPorts(ports ...int) Option {
+	return func(o *options) {
+		o.ports = append(o.ports, ports...)
+	}
+}
+
+// WithSSHCredentials sets the credentials used to execute commands in the virtual machine through SSH,
+// using the Exec method of the container.
+func WithSSHCredentials(user string, password string) Option {
+	return func(o *options) {
+		o.sshUser = user
+		o.sshPassword = password
+	}
+}
+
+// WithBootTimeout sets the time to wait for the SSH server of the virtual machine to be ready. By default, five minutes.
+func WithBootTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.bootTimeout = timeout
+	}
+}
+
+// StartContainer creates an instance of the QEMU container type, booting the virtual machine and waiting for its
+// SSH server to be ready. It accepts the options of the module, and the generic options of the testcontainers package,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*QEMUContainer, error) {
+	settings := options{
+		memory:      defaultMemory,
+		cpus:        defaultCPUs,
+		bootTimeout: defaultBootTimeout,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	if settings.diskImage == "" {
+		return nil, ErrMissingDiskImage
+	}
+
+	diskImage, err := filepath.Abs(settings.diskImage)
+	if err != nil {
+		return nil, err
+	}
+
+	exposedPorts := []string{sshPort}
+	for _, p := range settings.ports {
+		exposedPorts = append(exposedPorts, fmt.Sprintf("%d/tcp", p))
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Entrypoint:   []string{"qemu-system-x86_64"},
+		Cmd:          settings.qemuArgs(),
+		ExposedPorts: exposedPorts,
+		Mounts: testcontainers.ContainerMounts{
+			{Source: testcontainers.GenericBindMountSource{HostPath: diskImage}, Target: diskContainerPath, ReadOnly: true},
+		},
+		WaitingFor: &sshBannerStrategy{timeout: settings.bootTimeout},
+	}
+
+	if settings.kvm {
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			hostConfig.Devices = append(hostConfig.Devices, container.DeviceMapping{
+				PathOnHost:        "/dev/kvm",
+				PathInContainer:   "/dev/kvm",
+				CgroupPermissions: "rwm",
+			})
+		}
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if c == nil {
+		return nil, err
+	}
+
+	qemuC := &QEMUContainer{Container: c}
+
+	if settings.sshUser != "" {
+		qemuC.sshConfig = &ssh.ClientConfig{
+			User: settings.sshUser,
+			Auth: []ssh.AuthMethod{ssh.Password(settings.sshPassword)},
+			// the host key of a disposable virtual machine can't be known in advance
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			Timeout:         30 * time.Second,
+		}
+	}
+
+	return qemuC, err
+}
+
+// SSHEndpoint returns the endpoint of the SSH server of the virtual machine, with the format <host>:<port>
+func (c *QEMUContainer) SSHEndpoint(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, sshPort)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
+
+// Exec executes the given command in the virtual machine through SSH, using the credentials set with the
+// WithSSHCredentials option, and returns its exit code and its combined output. The process options are ignored.
+func (c *QEMUContainer) Exec(ctx context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	if c.sshConfig == nil {
+		return 0, nil, ErrMissingSSHCredentials
+	}
+
+	endpoint, err := c.SSHEndpoint(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	client, err := ssh.Dial("tcp", endpoint, c.sshConfig)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: could not connect to the virtual machine", err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer session.Close()
+
+	// close the session if the context is done before the command finishes
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Close()
+		case <-done:
+		}
+	}()
+
+	output := &bytes.Buffer{}
+	session.Stdout = output
+	session.Stderr = output
+
+	err = session.Run(shellQuote(cmd))
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), output, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return 0, output, nil
+}
+
+// qemuArgs returns the arguments of QEMU for the options of the module. The virtual machine uses the user mode
+// network of QEMU, which forwards the ports of the container to the ports of the virtual machine.
+func (o options) qemuArgs() []string {
+	netdev := "user,id=net0,hostfwd=tcp::22-:22"
+	for _, p := range o.ports {
+		netdev += fmt.Sprintf(",hostfwd=tcp::%d-:%d", p, p)
+	}
+
+	args := []string{
+		"-nographic",
+		"-m", strconv.Itoa(o.memory),
+		"-smp", strconv.Itoa(o.cpus),
+		"-drive", fmt.Sprintf("file=%s,format=%s,if=virtio,snapshot=on", diskContainerPath, o.diskFormat),
+		"-netdev", netdev,
+		"-device", "virtio-net-pci,netdev=net0",
+	}
+
+	if o.kvm {
+		args = append(args, "-enable-kvm", "-cpu", "host")
+	}
+
+	return args
+}
+
+// shellQuote joins the arguments of a command, quoting them to be interpreted by the shell of the SSH server
+func shellQuote(cmd []string) string {
+	quoted := make([]string, 0, len(cmd))
+	for _, arg := range cmd {
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// sshBannerStrategy waits until the SSH server of the virtual machine sends its banner. QEMU listens on the forwarded
+// ports as soon as it starts, so the connection is accepted before the virtual machine has booted.
+type sshBannerStrategy struct {
+	timeout time.Duration
+}
+
+var _ wait.Strategy = (*sshBannerStrategy)(nil)
+
+func (s *sshBannerStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: the SSH server of the virtual machine is not ready", ctx.Err())
+		case <-time.After(time.Second):
+		}
+
+		host, err := target.Host(ctx)
+		if err != nil {
+			continue
+		}
+
+		port, err := target.MappedPort(ctx, sshPort)
+		if err != nil {
+			continue
+		}
+
+		if readBanner(ctx, net.JoinHostPort(host, port.Port())) {
+			return nil
+		}
+	}
+}
+
+// readBanner returns true if the server listening on the given address sends an SSH banner
+func readBanner(ctx context.Context, address string) bool {
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	banner := make([]byte, 4)
+	if _, err := io.ReadFull(conn, banner); err != nil {
+		return false
+	}
+
+	return string(banner) == "SSH-"
+}
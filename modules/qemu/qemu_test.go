@@ -0,0 +1,138 @@
+package qemu
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestQEMU boots the disk image defined by the QEMU_DISK_IMAGE environment variable, e.g. a cloud image of a Linux
+// distribution configured with an SSH user, whose credentials are defined by the QEMU_SSH_USER and QEMU_SSH_PASSWORD
+// environment variables.
+func TestQEMU(t *testing.T) {
+	diskImage := os.Getenv("QEMU_DISK_IMAGE")
+	if diskImage == "" {
+		t.Skip("QEMU_DISK_IMAGE is not set, skipping test")
+	}
+
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithDiskImage(diskImage, "qcow2"),
+		WithMemory(1024),
+		WithCPUs(2),
+		WithSSHCredentials(os.Getenv("QEMU_SSH_USER"), os.Getenv("QEMU_SSH_PASSWORD")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// execInVirtualMachine {
+	code, reader, err := container.Exec(ctx, []string{"uname", "-s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSpace(string(output)) != "Linux" {
+		t.Fatalf("expected Linux, got %s", output)
+	}
+}
+
+func TestQEMUWithoutDiskImage(t *testing.T) {
+	_, err := StartContainer(context.Background())
+	if !errors.Is(err, ErrMissingDiskImage) {
+		t.Fatalf("expected %v, got %v", ErrMissingDiskImage, err)
+	}
+}
+
+func TestQEMUArgs(t *testing.T) {
+	settings := options{
+		diskFormat: "qcow2",
+		memory:     1024,
+		cpus:       2,
+		kvm:        true,
+		ports:      []int{8080, 5432},
+	}
+
+	want := []string{
+		"-nographic",
+		"-m", "1024",
+		"-smp", "2",
+		"-drive", "file=/qemu/disk,format=qcow2,if=virtio,snapshot=on",
+		"-netdev", "user,id=net0,hostfwd=tcp::22-:22,hostfwd=tcp::8080-:8080,hostfwd=tcp::5432-:5432",
+		"-device", "virtio-net-pci,netdev=net0",
+		"-enable-kvm", "-cpu", "host",
+	}
+
+	if got := settings.qemuArgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	got := shellQuote([]string{"sh", "-c", "echo 'hello world'"})
+	want := `'sh' '-c' 'echo '\''hello world'\'''`
+
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestReadBanner(t *testing.T) {
+	tests := []struct {
+		name   string
+		banner string
+		want   bool
+	}{
+		{name: "SSH server", banner: "SSH-2.0-OpenSSH_9.0\r\n", want: true},
+		{name: "other server", banner: "HTTP/1.1 400 Bad Request\r\n", want: false},
+		{name: "no banner", banner: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer listener.Close()
+
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				_, _ = conn.Write([]byte(tt.banner))
+			}()
+
+			if got := readBanner(context.Background(), listener.Addr().String()); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// manifestMediaTypes are the media types of the manifests accepted when resolving the digest of an image
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// PushImage pushes the given image of the Docker daemon, e.g. nginx:alpine, to the registry, returning its reference
+// in the registry, e.g. localhost:49153/library/nginx:alpine. The image is tagged with this reference during the push only.
+func (c *RegistryContainer) PushImage(ctx context.Context, ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid reference %s", err, ref)
+	}
+
+	tagged, ok := reference.TagNameOnly(named).(reference.Tagged)
+	if !ok {
+		return "", fmt.Errorf("the reference %s must be a tag, not a digest", ref)
+	}
+
+	address, err := c.Address(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	target := address + "/" + reference.Path(named) + ":" + tagged.Tag()
+
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return "", err
+	}
+	defer provider.Close()
+
+	cli := provider.Client()
+
+	if err := cli.ImageTag(ctx, ref, target); err != nil {
+		return "", fmt.Errorf("%w: could not tag the image %s", err, ref)
+	}
+	defer func() {
+		_, _ = cli.ImageRemove(ctx, target, types.ImageRemoveOptions{})
+	}()
+
+	auth, err := testcontainers.EncodeRegistryCred(types.AuthConfig{
+		Username:      c.username,
+		Password:      c.password,
+		ServerAddress: address,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	output, err := cli.ImagePush(ctx, target, types.ImagePushOptions{RegistryAuth: auth})
+	if err != nil {
+		return "", fmt.Errorf("%w: could not push the image %s", err, target)
+	}
+	defer output.Close()
+
+	// the errors of the push are reported in its output
+	if err := jsonmessage.DisplayJSONMessagesStream(output, io.Discard, 0, false, nil); err != nil {
+		return "", fmt.Errorf("%w: could not push the image %s", err, target)
+	}
+
+	return target, nil
+}
+
+// DeleteImage deletes the manifest of the given image from the registry, e.g. the reference returned by PushImage,
+// so the image can't be pulled anymore. The reference may omit the address of the registry, e.g. nginx:alpine.
+func (c *RegistryContainer) DeleteImage(ctx context.Context, ref string) error {
+	address, err := c.Address(ctx)
+	if err != nil {
+		return err
+	}
+
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return fmt.Errorf("%w: invalid reference %s", err, ref)
+	}
+
+	// the references without a registry are normalized with the domain of the Docker Hub
+	if domain := reference.Domain(named); domain != address && strings.HasPrefix(ref, domain) {
+		return fmt.Errorf("the image %s is not stored in the registry %s", ref, address)
+	}
+
+	url, err := c.URL(ctx)
+	if err != nil {
+		return err
+	}
+
+	manifestURL := url + "/v2/" + reference.Path(named) + "/manifests/"
+
+	digest := ""
+	if digested, ok := named.(reference.Digested); ok {
+		digest = digested.Digest().String()
+	} else {
+		tagged := reference.TagNameOnly(named).(reference.Tagged)
+
+		digest, err = c.resolveDigest(ctx, manifestURL+tagged.Tag())
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.do(ctx, http.MethodDelete, manifestURL+digest)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("could not delete the image %s, got the status code %d", ref, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// resolveDigest returns the digest of the manifest, which is required to delete it
+func (c *RegistryContainer) resolveDigest(ctx context.Context, manifestURL string) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, manifestURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not resolve the digest of %s, got the status code %d", manifestURL, resp.StatusCode)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("could not resolve the digest of %s, the registry did not return it", manifestURL)
+	}
+
+	return digest, nil
+}
+
+func (c *RegistryContainer) do(ctx context.Context, method string, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not call the registry", err)
+	}
+
+	return resp, nil
+}
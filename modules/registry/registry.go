@@ -0,0 +1,229 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/registry:2.8.2"
+	// }
+
+	registryPort = "5000/tcp"
+
+	htpasswdPath = "/auth/htpasswd"
+	certPath     = "/certs/registry.crt"
+	keyPath      = "/certs/registry.key"
+)
+
+// RegistryContainer represents the Registry container type used in the module
+type RegistryContainer struct {
+	testcontainers.Container
+	username string
+	password string
+	// rootCAs trusts the certificate of the registry, nil if TLS is disabled
+	rootCAs *x509.CertPool
+}
+
+// Option is a function that configures the Registry module, e.g. its users or its certificate.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	users    []user
+	certFile string
+	keyFile  string
+}
+
+type user struct {
+	username string
+	password string
+}
+
+// WithBasicAuth enables the htpasswd authentication of the registry, adding the given user.
+// It can be called multiple times to add several users, the first one is used by the helpers of the container,
+// e.g. PushImage.
+func WithBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.users = append(o.users, user{username: username, password: password})
+	}
+}
+
+// WithTLS serves the registry over HTTPS, with the given PEM encoded certificate and private key files.
+// The certificate must be valid for the host of the Docker daemon, e.g. localhost.
+func WithTLS(certFile, keyFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// StartContainer creates an instance of the Registry container type, a Docker registry storing the images
+// in the container, with the deletion of the images enabled.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*RegistryContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{registryPort},
+		Env: map[string]string{
+			"REGISTRY_STORAGE_DELETE_ENABLED": "true",
+		},
+	}
+
+	registry := &RegistryContainer{}
+
+	if len(settings.users) > 0 {
+		htpasswd, err := writeHtpasswd(settings.users)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(htpasswd)
+
+		req.Env["REGISTRY_AUTH"] = "htpasswd"
+		req.Env["REGISTRY_AUTH_HTPASSWD_REALM"] = "Registry"
+		req.Env["REGISTRY_AUTH_HTPASSWD_PATH"] = htpasswdPath
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			HostFilePath:      htpasswd,
+			ContainerFilePath: htpasswdPath,
+			FileMode:          0o644,
+		})
+
+		registry.username = settings.users[0].username
+		registry.password = settings.users[0].password
+	}
+
+	waitStrategy := wait.ForHTTP("/").WithPort(registryPort)
+
+	if settings.certFile != "" {
+		cert, err := os.ReadFile(settings.certFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not read the certificate of the registry", err)
+		}
+
+		registry.rootCAs = x509.NewCertPool()
+		if !registry.rootCAs.AppendCertsFromPEM(cert) {
+			return nil, fmt.Errorf("the certificate of the registry %s is not PEM encoded", settings.certFile)
+		}
+
+		req.Env["REGISTRY_HTTP_TLS_CERTIFICATE"] = certPath
+		req.Env["REGISTRY_HTTP_TLS_KEY"] = keyPath
+		req.Files = append(req.Files,
+			testcontainers.ContainerFile{HostFilePath: settings.certFile, ContainerFilePath: certPath, FileMode: 0o644},
+			testcontainers.ContainerFile{HostFilePath: settings.keyFile, ContainerFilePath: keyPath, FileMode: 0o644},
+		)
+
+		// the certificate may not be valid for the address used by the wait strategy
+		waitStrategy = waitStrategy.WithTLS(true).WithAllowInsecure(true)
+	}
+
+	req.WaitingFor = waitStrategy
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	registry.Container = container
+	return registry, err
+}
+
+// writeHtpasswd writes the htpasswd file of the users in a temporary file, returning its path.
+// The registry only supports the bcrypt hashes.
+func writeHtpasswd(users []user) (string, error) {
+	lines := make([]string, 0, len(users))
+	for _, u := range users {
+		hash, err := bcrypt.GenerateFromPassword([]byte(u.password), bcrypt.DefaultCost)
+		if err != nil {
+			return "", fmt.Errorf("%w: could not hash the password of the user %s", err, u.username)
+		}
+
+		lines = append(lines, u.username+":"+string(hash))
+	}
+
+	f, err := os.CreateTemp("", "registry-htpasswd-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// Address returns the address of the registry, e.g. localhost:49153, which prefixes the references of its images
+func (c *RegistryContainer) Address(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := c.MappedPort(ctx, registryPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", host, port.Port()), nil
+}
+
+// URL returns the URL of the API of the registry, e.g. https://localhost:49153
+func (c *RegistryContainer) URL(ctx context.Context) (string, error) {
+	address, err := c.Address(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := "http"
+	if c.rootCAs != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + address, nil
+}
+
+// httpClient returns a client of the API of the registry, trusting its certificate
+func (c *RegistryContainer) httpClient() *http.Client {
+	if c.rootCAs == nil {
+		return http.DefaultClient
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: c.rootCAs},
+		},
+	}
+}
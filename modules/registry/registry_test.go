@@ -0,0 +1,269 @@
+package registry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const testImage = "docker.io/library/alpine:3.17"
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	pullImage(t, testImage)
+
+	// pushImage {
+	ref, err := container.PushImage(ctx, testImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	address, err := container.Address(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := address + "/library/alpine:3.17"; ref != expected {
+		t.Fatalf("expected the reference %s, got %s", expected, ref)
+	}
+
+	assertManifestStatus(t, container, "library/alpine", "3.17", http.StatusOK)
+
+	// deleteImage {
+	if err := container.DeleteImage(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	assertManifestStatus(t, container, "library/alpine", "3.17", http.StatusNotFound)
+}
+
+func TestRegistryWithBasicAuth(t *testing.T) {
+	ctx := context.Background()
+
+	// withBasicAuth {
+	container, err := StartContainer(ctx, WithBasicAuth("testuser", "testpassword"))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	url, err := container.URL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(url + "/v2/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the status code %d without credentials, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	pullImage(t, testImage)
+
+	ref, err := container.PushImage(ctx, testImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertManifestStatus(t, container, "library/alpine", "3.17", http.StatusOK)
+
+	if err := container.DeleteImage(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistryWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	certFile, keyFile := generateCertificate(t)
+
+	// withTLS {
+	container, err := StartContainer(ctx, WithTLS(certFile, keyFile))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	url, err := container.URL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(url, "https://") {
+		t.Fatalf("expected an HTTPS URL, got %s", url)
+	}
+
+	pullImage(t, testImage)
+
+	ref, err := container.PushImage(ctx, testImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertManifestStatus(t, container, "library/alpine", "3.17", http.StatusOK)
+
+	if err := container.DeleteImage(ctx, ref); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartContainerWithInvalidCertificate(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "registry.crt")
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := StartContainer(context.Background(), WithTLS(certFile, certFile))
+	if err == nil {
+		t.Fatal("expected an error with a certificate which is not PEM encoded")
+	}
+}
+
+func TestWriteHtpasswd(t *testing.T) {
+	path, err := writeHtpasswd([]user{{username: "testuser", password: "testpassword"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the registry only supports the bcrypt hashes
+	if !strings.HasPrefix(string(content), "testuser:$2a$") {
+		t.Fatalf("expected a bcrypt hash of the password, got %s", content)
+	}
+}
+
+func pullImage(t *testing.T, image string) {
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	output, err := provider.Client().ImagePull(context.Background(), image, types.ImagePullOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	if _, err := io.Copy(io.Discard, output); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertManifestStatus(t *testing.T, container *RegistryContainer, repository, tag string, expected int) {
+	url, err := container.URL(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := container.do(context.Background(), http.MethodHead, url+"/v2/"+repository+"/manifests/"+tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != expected {
+		t.Fatalf("expected the status code %d for %s:%s, got %d", expected, repository, tag, resp.StatusCode)
+	}
+}
+
+// generateCertificate writes a self-signed certificate, valid for localhost, and its private key in the temporary
+// directory of the test
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "registry.crt")
+	keyFile := filepath.Join(dir, "registry.key")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKey}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
@@ -0,0 +1,108 @@
+package selenium
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// ScreenshotFailureHook returns a testcontainers.FailureHook saving a PNG screenshot of each WebDriver session
+// opened in the failed browser to the given directory, named after the container and the session, e.g. to see the page
+// displayed by the browser when its termination failed. It's added to the browser with the WithScreenshotsOnFailure option,
+// and the errors taking the screenshots are logged.
+func ScreenshotFailureHook(dir string) testcontainers.FailureHook {
+	return testcontainers.FailureHookFunc(func(ctx context.Context, c testcontainers.Container, _ error) {
+		endpoint, err := c.PortEndpoint(ctx, webDriverPort, "http")
+		if err != nil {
+			testcontainers.Logger.Printf("could not take the screenshots of the browser %.12s: %s", c.GetContainerID(), err)
+			return
+		}
+
+		paths, err := saveScreenshots(ctx, endpoint, dir, fmt.Sprintf("%.12s", c.GetContainerID()))
+		for _, path := range paths {
+			testcontainers.Logger.Printf("📸 Screenshot of the browser %.12s saved to %s", c.GetContainerID(), path)
+		}
+		if err != nil {
+			testcontainers.Logger.Printf("could not take the screenshots of the browser %.12s: %s", c.GetContainerID(), err)
+		}
+	})
+}
+
+// saveScreenshots saves a screenshot of each session opened in the browser reached at the endpoint to the directory,
+// returning the paths of the saved screenshots
+func saveScreenshots(ctx context.Context, endpoint string, dir string, prefix string) ([]string, error) {
+	var status struct {
+		Nodes []struct {
+			Slots []struct {
+				Session *struct {
+					SessionID string `json:"sessionId"`
+				} `json:"session"`
+			} `json:"slots"`
+		} `json:"nodes"`
+	}
+	if err := webDriverGet(ctx, endpoint+"/status", &status); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%w: could not create the screenshots directory", err)
+	}
+
+	var paths []string
+	for _, node := range status.Nodes {
+		for _, slot := range node.Slots {
+			if slot.Session == nil {
+				continue
+			}
+
+			var screenshot string
+			if err := webDriverGet(ctx, endpoint+"/session/"+slot.Session.SessionID+"/screenshot", &screenshot); err != nil {
+				return paths, err
+			}
+
+			png, err := base64.StdEncoding.DecodeString(screenshot)
+			if err != nil {
+				return paths, fmt.Errorf("%w: could not decode the screenshot of the session %s", err, slot.Session.SessionID)
+			}
+
+			path := filepath.Join(dir, prefix+"-"+slot.Session.SessionID+".png")
+			if err := os.WriteFile(path, png, 0o644); err != nil {
+				return paths, err
+			}
+
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// webDriverGet sends a GET command to the remote WebDriver, decoding the value of its response
+func webDriverGet(ctx context.Context, url string, value interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, url)
+	}
+
+	response := struct {
+		Value interface{} `json:"value"`
+	}{Value: value}
+
+	return json.NewDecoder(resp.Body).Decode(&response)
+}
@@ -0,0 +1,60 @@
+package selenium
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveScreenshots(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	// a standalone browser with an idle slot and a slot running a session
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			fmt.Fprint(w, `{"value": {"ready": false, "nodes": [{"slots": [{"session": null}, {"session": {"sessionId": "f00d"}}]}]}}`)
+		case "/session/f00d/screenshot":
+			fmt.Fprintf(w, `{"value": %q}`, base64.StdEncoding.EncodeToString(png))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "screenshots")
+
+	paths, err := saveScreenshots(context.Background(), server.URL, dir, "0123456789ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := filepath.Join(dir, "0123456789ab-f00d.png")
+	if len(paths) != 1 || paths[0] != expected {
+		t.Fatalf("expected the screenshot %s, got %v", expected, paths)
+	}
+
+	content, err := os.ReadFile(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(png, content) {
+		t.Fatalf("expected the decoded screenshot, got %q", content)
+	}
+}
+
+func TestSaveScreenshotsFailsWithoutWebDriver(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := saveScreenshots(context.Background(), server.URL, t.TempDir(), "0123456789ab"); err == nil {
+		t.Fatal("expected an error without the status of the WebDriver")
+	}
+}
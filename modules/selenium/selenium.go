@@ -0,0 +1,277 @@
+package selenium
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultChromeImage {
+	defaultChromeImage = "selenium/standalone-chrome:112.0"
+	// }
+
+	// defaultFirefoxImage {
+	defaultFirefoxImage = "selenium/standalone-firefox:112.0"
+	// }
+
+	// defaultRecorderImage {
+	defaultRecorderImage = "selenium/video:ffmpeg-4.3.1-20230421"
+	// }
+
+	webDriverPort = "4444/tcp"
+	vncPort       = "5900/tcp"
+	noVNCPort     = "7900/tcp"
+
+	// vncPassword is the password of the VNC server of the Selenium images
+	vncPassword = "secret"
+
+	// defaultShmSize is the size of /dev/shm recommended by Selenium, as the browsers crash with the 64MB of Docker
+	defaultShmSize = 2 * 1024 * 1024 * 1024
+
+	recordingsContainerDir = "/videos"
+
+	// recorderStopTimeout is the time given to the recorder to finalize the video once it's stopped
+	recorderStopTimeout = 10 * time.Second
+)
+
+// Browser is the browser run by the standalone Selenium container
+type Browser string
+
+const (
+	// Chrome runs Google Chrome, which is the default browser
+	Chrome Browser = "chrome"
+	// Firefox runs Mozilla Firefox
+	Firefox Browser = "firefox"
+)
+
+// image returns the default image of the browser
+func (b Browser) image() (string, error) {
+	switch b {
+	case Chrome:
+		return defaultChromeImage, nil
+	case Firefox:
+		return defaultFirefoxImage, nil
+	default:
+		return "", fmt.Errorf("unsupported browser %q, it must be %q or %q", string(b), Chrome, Firefox)
+	}
+}
+
+// SeleniumContainer represents the Selenium standalone browser container type used in the module
+type SeleniumContainer struct {
+	testcontainers.Container
+	// recorder is the container recording the screen of the browser, if the recording is enabled
+	recorder      testcontainers.Container
+	recordingPath string
+}
+
+// Option is a function that configures the Selenium module, e.g. the browser or the recording of the session.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	browser        Browser
+	recordingDir   string
+	screenshotsDir string
+	hostPorts      []int
+}
+
+// WithBrowser sets the browser of the container, Chrome by default.
+// The image of the browser can still be overridden with testcontainers.WithImage.
+func WithBrowser(browser Browser) Option {
+	return func(o *options) {
+		o.browser = browser
+	}
+}
+
+// WithRecording records the screen of the browser in a MP4 video, written to the given directory of the host
+// once the container is terminated. The video is recorded by a sidecar container sharing the network of the browser.
+func WithRecording(outputDir string) Option {
+	return func(o *options) {
+		o.recordingDir = outputDir
+	}
+}
+
+// WithScreenshotsOnFailure saves a screenshot of each session opened in the browser to the given directory of the host,
+// when the browser fails to become ready or to be terminated, using the ScreenshotFailureHook.
+func WithScreenshotsOnFailure(outputDir string) Option {
+	return func(o *options) {
+		o.screenshotsDir = outputDir
+	}
+}
+
+// WithHostPorts makes the given ports of the test host reachable from the browser, at the testcontainers.HostInternal
+// hostname, e.g. http://host.testcontainers.internal:8080 for the application under test started by the test.
+// It uses testcontainers.ExposeHostPorts, whose forwarder is shared by the containers of the test process.
+func WithHostPorts(ports ...int) Option {
+	return func(o *options) {
+		o.hostPorts = append(o.hostPorts, ports...)
+	}
+}
+
+// StartContainer creates an instance of the Selenium standalone browser container type.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithNetwork
+// to reach the application under test started in another container, which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*SeleniumContainer, error) {
+	settings := options{browser: Chrome}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	image, err := settings.browser.image()
+	if err != nil {
+		return nil, err
+	}
+
+	var recordingDir string
+	if settings.recordingDir != "" {
+		recordingDir, err = filepath.Abs(settings.recordingDir)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := os.MkdirAll(recordingDir, 0o755); err != nil {
+			return nil, fmt.Errorf("%w: could not create the recordings directory", err)
+		}
+	}
+
+	if len(settings.hostPorts) > 0 {
+		// the forwarder must run before the browser is created, so the browser resolves HostInternal
+		if _, err := testcontainers.ExposeHostPorts(ctx, settings.hostPorts...); err != nil {
+			return nil, fmt.Errorf("%w: could not expose the host ports to the browser", err)
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        image,
+		ExposedPorts: []string{webDriverPort, vncPort, noVNCPort},
+		ShmSize:      defaultShmSize,
+		WaitingFor: wait.ForHTTP("/status").
+			WithPort(webDriverPort).
+			WithJSONPathMatcher("value.ready", "true").
+			WithStartupTimeout(2 * time.Minute),
+	}
+
+	if settings.screenshotsDir != "" {
+		req.FailureHooks = append(req.FailureHooks, ScreenshotFailureHook(settings.screenshotsDir))
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	browser, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if browser == nil {
+		return nil, err
+	}
+
+	c := &SeleniumContainer{Container: browser}
+	if err != nil || recordingDir == "" {
+		return c, err
+	}
+
+	if err := c.startRecorder(ctx, recordingDir); err != nil {
+		return c, fmt.Errorf("%w: could not start the recorder", err)
+	}
+
+	return c, nil
+}
+
+// startRecorder starts the container recording the screen of the browser, which joins the network namespace
+// of the browser to reach its X server on localhost
+func (c *SeleniumContainer) startRecorder(ctx context.Context, recordingDir string) error {
+	id := c.GetContainerID()
+	fileName := id[:12] + ".mp4"
+
+	recorder, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: defaultRecorderImage,
+			Env: map[string]string{
+				"DISPLAY_CONTAINER_NAME": "localhost",
+				"FILE_NAME":              fileName,
+			},
+			Mounts: testcontainers.Mounts(
+				testcontainers.BindMount(recordingDir, recordingsContainerDir),
+			),
+			HostConfigModifier: func(hostConfig *container.HostConfig) {
+				hostConfig.NetworkMode = container.NetworkMode("container:" + id)
+			},
+		},
+		Started: true,
+	})
+	if recorder != nil {
+		c.recorder = recorder
+	}
+	if err != nil {
+		return err
+	}
+
+	c.recordingPath = filepath.Join(recordingDir, fileName)
+
+	return nil
+}
+
+// Terminate terminates the browser, stopping the recorder first, if any, so the video is finalized
+func (c *SeleniumContainer) Terminate(ctx context.Context, opts ...testcontainers.TerminateOption) error {
+	if c.recorder != nil {
+		if err := c.recorder.Terminate(ctx, testcontainers.WithTerminateStopTimeout(recorderStopTimeout)); err != nil {
+			return fmt.Errorf("%w: could not terminate the recorder", err)
+		}
+		c.recorder = nil
+	}
+
+	return c.Container.Terminate(ctx, opts...)
+}
+
+// RecordingPath returns the path of the video of the browser on the host, empty if the recording is not enabled.
+// The video is only complete once the container is terminated.
+func (c *SeleniumContainer) RecordingPath() string {
+	return c.recordingPath
+}
+
+// WebDriverURL returns the URL of the remote WebDriver, with the format http://<host>:<port>/wd/hub,
+// to be used by the WebDriver clients of the tests
+func (c *SeleniumContainer) WebDriverURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, webDriverPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/wd/hub", nil
+}
+
+// VNCURL returns the URL of the VNC server showing the screen of the browser, with the format vnc://<host>:<port>.
+// The password of the server is "secret".
+func (c *SeleniumContainer) VNCURL(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, vncPort, "vnc")
+}
+
+// NoVNCURL returns the URL of the noVNC web client showing the screen of the browser in a web browser,
+// logging in automatically
+func (c *SeleniumContainer) NoVNCURL(ctx context.Context) (string, error) {
+	endpoint, err := c.PortEndpoint(ctx, noVNCPort, "http")
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint + "/?autoconnect=1&resize=scale&password=" + vncPassword, nil
+}
@@ -0,0 +1,242 @@
+package selenium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// webDriverRequest sends a command to the remote WebDriver, decoding the value of its response
+func webDriverRequest(t *testing.T, method string, url string, body interface{}, value interface{}) {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, url, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d for %s %s, got %d", http.StatusOK, method, url, resp.StatusCode)
+	}
+
+	if value == nil {
+		return
+	}
+
+	response := struct {
+		Value interface{} `json:"value"`
+	}{Value: value}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pageTitle opens the page in a new session of the browser, returning its title
+func pageTitle(t *testing.T, webDriverURL string, pageURL string) string {
+	t.Helper()
+
+	var session struct {
+		SessionID string `json:"sessionId"`
+	}
+	webDriverRequest(t, http.MethodPost, webDriverURL+"/session", map[string]interface{}{
+		"capabilities": map[string]interface{}{},
+	}, &session)
+
+	sessionURL := webDriverURL + "/session/" + session.SessionID
+	defer webDriverRequest(t, http.MethodDelete, sessionURL, nil, nil)
+
+	webDriverRequest(t, http.MethodPost, sessionURL+"/url", map[string]string{"url": pageURL}, nil)
+
+	var title string
+	webDriverRequest(t, http.MethodGet, sessionURL+"/title", nil, &title)
+
+	return title
+}
+
+func TestSelenium(t *testing.T) {
+	ctx := context.Background()
+
+	network, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{Name: "selenium-network", CheckDuplicate: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := network.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	app, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:          "nginx:1.23.4-alpine",
+			ExposedPorts:   []string{"80/tcp"},
+			Networks:       []string{"selenium-network"},
+			NetworkAliases: map[string][]string{"selenium-network": {"app"}},
+			WaitingFor:     wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := app.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	for _, browser := range []Browser{Chrome, Firefox} {
+		browser := browser
+		t.Run(string(browser), func(t *testing.T) {
+			// startContainer {
+			container, err := StartContainer(ctx, WithBrowser(browser), testcontainers.WithNetwork("selenium-network"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// }
+
+			// Clean up the container after the test is complete
+			t.Cleanup(func() {
+				if err := container.Terminate(ctx); err != nil {
+					t.Fatalf("failed to terminate container: %s", err)
+				}
+			})
+
+			// webDriverURL {
+			webDriverURL, err := container.WebDriverURL(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// }
+
+			if title := pageTitle(t, webDriverURL, "http://app"); title != "Welcome to nginx!" {
+				t.Fatalf("expected the title of the nginx page, got %q", title)
+			}
+
+			vncURL, err := container.VNCURL(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.HasPrefix(vncURL, "vnc://") {
+				t.Fatalf("expected a VNC URL, got %s", vncURL)
+			}
+		})
+	}
+}
+
+func TestSeleniumWithRecording(t *testing.T) {
+	ctx := context.Background()
+
+	outputDir := t.TempDir()
+
+	// startRecording {
+	container, err := StartContainer(ctx, WithRecording(outputDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	webDriverURL, err := container.WebDriverURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if title := pageTitle(t, webDriverURL, "data:text/html,<title>recorded</title>"); title != "recorded" {
+		t.Fatalf("expected the title of the page, got %q", title)
+	}
+
+	// the video is finalized once the container is terminated
+	if err := container.Terminate(ctx); err != nil {
+		t.Fatalf("failed to terminate container: %s", err)
+	}
+
+	recordingPath := container.RecordingPath()
+	if filepath.Dir(recordingPath) != outputDir {
+		t.Fatalf("expected the video in %s, got %s", outputDir, recordingPath)
+	}
+
+	info, err := os.Stat(recordingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Size() == 0 {
+		t.Fatal("expected a non-empty video")
+	}
+}
+
+func TestSeleniumWithHostPorts(t *testing.T) {
+	ctx := context.Background()
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "<html><head><title>host</title></head></html>")
+		}),
+	}
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	// startWithHostPorts {
+	container, err := StartContainer(ctx, WithHostPorts(port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	webDriverURL, err := container.WebDriverURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pageURL := fmt.Sprintf("http://%s:%d", testcontainers.HostInternal, port)
+	if title := pageTitle(t, webDriverURL, pageURL); title != "host" {
+		t.Fatalf("expected the title of the page served by the host, got %q", title)
+	}
+}
+
+func TestSeleniumWithUnsupportedBrowser(t *testing.T) {
+	_, err := StartContainer(context.Background(), WithBrowser("safari"))
+	if err == nil || !strings.Contains(err.Error(), `unsupported browser "safari"`) {
+		t.Fatalf("expected an unsupported browser error, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package starrocks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/starrocks/allin1-ubuntu:3.1.2"
+	// }
+
+	// queryPort is the port of the frontend speaking the MySQL protocol
+	queryPort = "9030/tcp"
+	// feHTTPPort is the port of the HTTP API of the frontend, e.g. for the stream loads
+	feHTTPPort = "8030/tcp"
+
+	rootUser = "root"
+
+	scriptsContainerPath = "/tmp/testcontainers-scripts"
+
+	// mysqlClient is the command of the MySQL client of the image, connected to the frontend as root
+	mysqlClient = "mysql -h127.0.0.1 -P9030 -uroot"
+)
+
+// StarRocksContainer represents the StarRocks container type used in the module
+type StarRocksContainer struct {
+	testcontainers.Container
+	database string
+}
+
+// Option is a function that configures the StarRocks module, e.g. the database created when it starts.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	database string
+	scripts  []string
+}
+
+// WithDatabase creates the given database when the container starts. It's the database of the connection string,
+// and of the scripts.
+func WithDatabase(database string) Option {
+	return func(o *options) {
+		o.database = database
+	}
+}
+
+// WithScripts executes the given SQL scripts, in order, once the database is created, e.g. to create the tables
+// loaded by the pipeline under test. It can be called multiple times.
+func WithScripts(scripts ...string) Option {
+	return func(o *options) {
+		o.scripts = append(o.scripts, scripts...)
+	}
+}
+
+// StartContainer creates an instance of the StarRocks container type, running the frontend and the backend
+// of StarRocks in the same container. It waits until the backend is registered and alive in the frontend,
+// then creates the database and executes the scripts of the options.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*StarRocksContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	files := make([]testcontainers.ContainerFile, 0, len(settings.scripts))
+	for i, script := range settings.scripts {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      script,
+			ContainerFilePath: scriptPath(i, script),
+			FileMode:          0o644,
+		})
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{queryPort, feHTTPPort},
+		Files:        files,
+		// the frontend accepts the queries before the backend is registered, which stores the tables
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(queryPort),
+			wait.ForExec([]string{"sh", "-c", mysqlClient + ` -e "SHOW BACKENDS\G" | grep -q "Alive: true"`}),
+		).WithDeadline(3 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	c := &StarRocksContainer{Container: container, database: settings.database}
+	if err != nil {
+		return c, err
+	}
+
+	if settings.database != "" {
+		if err := c.execSQL(ctx, "", "-e", "'CREATE DATABASE IF NOT EXISTS `"+settings.database+"`'"); err != nil {
+			return c, fmt.Errorf("%w: could not create the database %s", err, settings.database)
+		}
+	}
+
+	for i, script := range settings.scripts {
+		if err := c.execSQL(ctx, settings.database, "<", scriptPath(i, script)); err != nil {
+			return c, fmt.Errorf("%w: could not execute the script %s", err, script)
+		}
+	}
+
+	return c, nil
+}
+
+// scriptPath returns the path of the script in the container, prefixed by its index to keep the names unique
+func scriptPath(i int, script string) string {
+	return scriptsContainerPath + "/" + strconv.Itoa(i) + "-" + filepath.Base(script)
+}
+
+// execSQL executes the MySQL client of the image, in the given database if not empty, with the given shell arguments
+func (c *StarRocksContainer) execSQL(ctx context.Context, database string, args ...string) error {
+	cmd := mysqlClient
+	if database != "" {
+		cmd += " -D" + database
+	}
+
+	code, output, err := c.Exec(ctx, []string{"sh", "-c", cmd + " " + strings.Join(args, " ")}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		out, _ := io.ReadAll(output)
+		return fmt.Errorf("exit code %d: %s", code, out)
+	}
+
+	return nil
+}
+
+// Database returns the database created when the container started, empty if none
+func (c *StarRocksContainer) Database() string {
+	return c.database
+}
+
+// ConnectionString returns the connection string of the frontend, as a DSN of the go-sql-driver/mysql driver,
+// with the format root@tcp(<host>:<port>)/<database>. The given arguments are appended as parameters
+// of the DSN, e.g. "parseTime=true". The root user has no password.
+func (c *StarRocksContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, queryPort)
+	if err != nil {
+		return "", err
+	}
+
+	dsn := fmt.Sprintf("%s@tcp(%s:%s)/%s", rootUser, host, mappedPort.Port(), c.database)
+	if len(args) > 0 {
+		dsn += "?" + strings.Join(args, "&")
+	}
+
+	return dsn, nil
+}
+
+// FrontendHTTPURL returns the URL of the HTTP API of the frontend, e.g. http://localhost:49153
+func (c *StarRocksContainer) FrontendHTTPURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, feHTTPPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
@@ -0,0 +1,72 @@
+package starrocks
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func TestStarRocks(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithDatabase("analytics"),
+		WithScripts(filepath.Join("testdata", "schema.sql")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("mysql", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// }
+
+	// the views of the same page and date are aggregated by the table
+	if _, err := db.ExecContext(ctx, `INSERT INTO page_views VALUES ('2023-05-01', '/home', 2), ('2023-05-01', '/home', 3)`); err != nil {
+		t.Fatal(err)
+	}
+
+	var views int
+	if err := db.QueryRowContext(ctx, `SELECT views FROM page_views WHERE page = '/home'`).Scan(&views); err != nil {
+		t.Fatal(err)
+	}
+
+	if views != 5 {
+		t.Fatalf("expected 5 aggregated views, got %d", views)
+	}
+}
+
+func TestScriptPath(t *testing.T) {
+	// the scripts with the same name in different directories must not overwrite each other
+	first := scriptPath(0, filepath.Join("a", "schema.sql"))
+	second := scriptPath(1, filepath.Join("b", "schema.sql"))
+
+	if first == second {
+		t.Fatalf("expected different paths, got %s", first)
+	}
+
+	if expected := "/tmp/testcontainers-scripts/0-schema.sql"; first != expected {
+		t.Fatalf("expected %s, got %s", expected, first)
+	}
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS page_views (
+    view_date DATE NOT NULL,
+    page VARCHAR(255) NOT NULL,
+    views BIGINT SUM DEFAULT "0"
+)
+AGGREGATE KEY(view_date, page)
+DISTRIBUTED BY HASH(page) BUCKETS 1
+PROPERTIES ("replication_num" = "1");
@@ -0,0 +1,124 @@
+package temporal
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/temporalio/temporal:1.1.2"
+	// }
+
+	frontendPort = "7233/tcp"
+	uiPort       = "8233/tcp"
+
+	// DefaultNamespace is the namespace created by the dev server, used by the clients without a namespace
+	DefaultNamespace = "default"
+
+	// frontendService is the service of the frontend reported by its gRPC health server
+	frontendService = "temporal.api.workflowservice.v1.WorkflowService"
+)
+
+// TemporalContainer represents the Temporal container type used in the module
+type TemporalContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the Temporal module, e.g. the namespaces created when the server starts.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	namespaces []string
+}
+
+// WithNamespaces creates the given namespaces when the server starts, in addition to the default namespace.
+// It can be called multiple times.
+func WithNamespaces(namespaces ...string) Option {
+	return func(o *options) {
+		o.namespaces = append(o.namespaces, namespaces...)
+	}
+}
+
+// StartContainer creates an instance of the Temporal container type, running the dev server of the Temporal CLI,
+// which stores its state in memory, with its web UI. It waits until the frontend service reports it's serving
+// on its gRPC health endpoint.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*TemporalContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	cmd := []string{"server", "start-dev", "--ip", "0.0.0.0"}
+	for _, namespace := range settings.namespaces {
+		cmd = append(cmd, "--namespace", namespace)
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{frontendPort, uiPort},
+		Cmd:          cmd,
+		WaitingFor:   &grpcHealthStrategy{timeout: time.Minute},
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &TemporalContainer{Container: container}, err
+}
+
+// FrontendHostPort returns the address of the frontend service, e.g. localhost:49153,
+// to be used as the HostPort of the options of the clients of the Go SDK
+func (c *TemporalContainer) FrontendHostPort(ctx context.Context) (string, error) {
+	return hostPort(ctx, c, frontendPort)
+}
+
+// UIURL returns the URL of the web UI of the server, e.g. http://localhost:49154
+func (c *TemporalContainer) UIURL(ctx context.Context) (string, error) {
+	address, err := hostPort(ctx, c, uiPort)
+	if err != nil {
+		return "", err
+	}
+
+	return "http://" + address, nil
+}
+
+func hostPort(ctx context.Context, target wait.StrategyTarget, port nat.Port) (string, error) {
+	host, err := target.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := target.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, mappedPort.Port()), nil
+}
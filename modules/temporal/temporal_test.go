@@ -0,0 +1,90 @@
+package temporal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func greet(ctx workflow.Context, name string) (string, error) {
+	return "Hello, " + name + "!", nil
+}
+
+func TestTemporal(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx, WithNamespaces("orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// dialClient {
+	hostPort, err := container.FrontendHostPort(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := client.Dial(client.Options{
+		HostPort:  hostPort,
+		Namespace: "orders",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	// }
+
+	namespaces, err := c.WorkflowService().ListNamespaces(ctx, &workflowservice.ListNamespacesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]bool{}
+	for _, ns := range namespaces.Namespaces {
+		found[ns.NamespaceInfo.Name] = true
+	}
+
+	for _, expected := range []string{DefaultNamespace, "orders"} {
+		if !found[expected] {
+			t.Fatalf("expected the namespace %s to be created, got %v", expected, found)
+		}
+	}
+
+	w := worker.New(c, "greetings", worker.Options{})
+	w.RegisterWorkflow(greet)
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	run, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{TaskQueue: "greetings"}, greet, "Temporal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var greeting string
+	if err := run.Get(ctx, &greeting); err != nil {
+		t.Fatal(err)
+	}
+
+	if greeting != "Hello, Temporal!" {
+		t.Fatalf("expected the greeting of the workflow, got %s", greeting)
+	}
+}
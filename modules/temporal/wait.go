@@ -0,0 +1,61 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// grpcHealthStrategy waits until the frontend service reports it's serving on the gRPC health endpoint of the server.
+// The server listens on the frontend port before its services are started, so the port is not enough.
+type grpcHealthStrategy struct {
+	timeout time.Duration
+}
+
+var _ wait.Strategy = (*grpcHealthStrategy)(nil)
+
+func (s *grpcHealthStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: the frontend service of the Temporal server is not serving", ctx.Err())
+		case <-time.After(time.Second):
+		}
+
+		address, err := hostPort(ctx, target, frontendPort)
+		if err != nil {
+			continue
+		}
+
+		if isServing(ctx, address) {
+			return nil
+		}
+	}
+}
+
+// isServing returns true if the gRPC health server listening on the given address reports the frontend service is serving
+func isServing(ctx context.Context, address string) bool {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: frontendService})
+	if err != nil {
+		return false
+	}
+
+	return resp.Status == grpc_health_v1.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,177 @@
+package toxiproxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Proxy is a proxy of Toxiproxy, forwarding the connections received on its endpoint to its upstream address,
+// through its toxics
+type Proxy struct {
+	// Name is the name of the proxy, identifying it in the HTTP API of Toxiproxy
+	Name string
+	// Upstream is the address the connections are forwarded to, resolved inside the container of Toxiproxy
+	Upstream string
+	// Host and Port are the address of the proxy on the host running the tests
+	Host string
+	Port string
+
+	container *ToxiproxyContainer
+}
+
+// Endpoint returns the address of the proxy on the host running the tests, e.g. localhost:49153,
+// used by the tests instead of the upstream address
+func (p *Proxy) Endpoint() string {
+	return p.Host + ":" + p.Port
+}
+
+// stream is the direction of the data affected by a toxic
+type stream string
+
+// streams {
+const (
+	// Downstream is the data sent by the upstream to the client, which is affected by the toxics by default
+	Downstream stream = "downstream"
+	// Upstream is the data sent by the client to the upstream
+	Upstream stream = "upstream"
+)
+
+// }
+
+// toxic is a fault of the network added to a proxy, e.g. a latency
+type toxic struct {
+	name       string
+	kind       string
+	stream     stream
+	toxicity   float32
+	attributes map[string]interface{}
+}
+
+// Latency creates a toxic delaying the data by the given latency, plus or minus the given jitter.
+func Latency(latency time.Duration, jitter time.Duration) toxic {
+	return newToxic("latency", map[string]interface{}{
+		"latency": latency.Milliseconds(),
+		"jitter":  jitter.Milliseconds(),
+	})
+}
+
+// Bandwidth creates a toxic limiting the bandwidth to the given rate, in KB/s.
+func Bandwidth(rate int64) toxic {
+	return newToxic("bandwidth", map[string]interface{}{
+		"rate": rate,
+	})
+}
+
+// Timeout creates a toxic stopping all the data, and closing the connection after the given timeout,
+// or never if the timeout is 0.
+func Timeout(timeout time.Duration) toxic {
+	return newToxic("timeout", map[string]interface{}{
+		"timeout": timeout.Milliseconds(),
+	})
+}
+
+// ResetPeer creates a toxic resetting the connections after the given timeout, simulating a peer crashing.
+func ResetPeer(timeout time.Duration) toxic {
+	return newToxic("reset_peer", map[string]interface{}{
+		"timeout": timeout.Milliseconds(),
+	})
+}
+
+func newToxic(kind string, attributes map[string]interface{}) toxic {
+	return toxic{
+		kind:       kind,
+		stream:     Downstream,
+		toxicity:   1,
+		attributes: attributes,
+	}
+}
+
+// WithName sets the name of the toxic, which defaults to <type>_<stream>, e.g. latency_downstream.
+func (t toxic) WithName(name string) toxic {
+	t.name = name
+	return t
+}
+
+// WithStream sets the direction of the data affected by the toxic, Downstream by default.
+func (t toxic) WithStream(stream stream) toxic {
+	t.stream = stream
+	return t
+}
+
+// WithToxicity sets the probability of the toxic to apply to a connection, from 0 to 1, which is the default.
+func (t toxic) WithToxicity(toxicity float32) toxic {
+	if toxicity < 0 {
+		toxicity = 0
+	} else if toxicity > 1 {
+		toxicity = 1
+	}
+
+	t.toxicity = toxicity
+	return t
+}
+
+// Name returns the name of the toxic, identifying it in the proxy
+func (t toxic) Name() string {
+	if t.name != "" {
+		return t.name
+	}
+
+	return t.kind + "_" + string(t.stream)
+}
+
+// body returns the body of the request adding the toxic to a proxy
+func (t toxic) body() map[string]interface{} {
+	return map[string]interface{}{
+		"name":       t.Name(),
+		"type":       t.kind,
+		"stream":     t.stream,
+		"toxicity":   t.toxicity,
+		"attributes": t.attributes,
+	}
+}
+
+// AddToxic adds the given toxic to the proxy, affecting the current and the new connections, e.g. in the middle of a test:
+//
+//	err := proxy.AddToxic(ctx, toxiproxy.Latency(time.Second, 100*time.Millisecond))
+func (p *Proxy) AddToxic(ctx context.Context, t toxic) error {
+	if err := p.container.doRequest(ctx, http.MethodPost, p.path()+"/toxics", t.body()); err != nil {
+		return fmt.Errorf("%w: could not add the toxic %s to the proxy %s", err, t.Name(), p.Name)
+	}
+
+	return nil
+}
+
+// RemoveToxic removes the toxic with the given name from the proxy
+func (p *Proxy) RemoveToxic(ctx context.Context, name string) error {
+	if err := p.container.doRequest(ctx, http.MethodDelete, p.path()+"/toxics/"+url.PathEscape(name), nil); err != nil {
+		return fmt.Errorf("%w: could not remove the toxic %s from the proxy %s", err, name, p.Name)
+	}
+
+	return nil
+}
+
+// Disable disables the proxy, closing all its connections and refusing the new ones, as if the upstream was down
+func (p *Proxy) Disable(ctx context.Context) error {
+	return p.setEnabled(ctx, false)
+}
+
+// Enable enables the proxy again, after it was disabled
+func (p *Proxy) Enable(ctx context.Context) error {
+	return p.setEnabled(ctx, true)
+}
+
+func (p *Proxy) setEnabled(ctx context.Context, enabled bool) error {
+	body := map[string]interface{}{"enabled": enabled}
+	if err := p.container.doRequest(ctx, http.MethodPost, p.path(), body); err != nil {
+		return fmt.Errorf("%w: could not update the proxy %s", err, p.Name)
+	}
+
+	return nil
+}
+
+func (p *Proxy) path() string {
+	return "/proxies/" + url.PathEscape(p.Name)
+}
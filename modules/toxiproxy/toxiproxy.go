@@ -0,0 +1,246 @@
+package toxiproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "ghcr.io/shopify/toxiproxy:2.5.0"
+	// }
+
+	// controlPort is the port of the HTTP API of Toxiproxy, used to configure the proxies and their toxics
+	controlPort = "8474/tcp"
+
+	// firstProxyPort is the first of the ports exposed by the container for the proxies
+	firstProxyPort = 8666
+	// proxyPortCount is the number of ports exposed by the container for the proxies, which is the maximum number of proxies
+	proxyPortCount = 31
+)
+
+// ToxiproxyContainer represents the Toxiproxy container type used in the module
+type ToxiproxyContainer struct {
+	testcontainers.Container
+
+	mtx sync.Mutex
+	// nextPort is the index of the next port exposed for the proxies which is not used by a proxy
+	nextPort int
+}
+
+// StartContainer creates an instance of the Toxiproxy container type, without any proxy.
+// The container exposes 31 ports for the proxies, from 8666 to 8696, which are assigned to the proxies
+// created afterwards with the CreateProxy and ProxyContainer methods.
+// It accepts the generic options of the testcontainers package, e.g. testcontainers.WithNetwork,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ToxiproxyContainer, error) {
+	exposedPorts := []string{controlPort}
+	for i := 0; i < proxyPortCount; i++ {
+		exposedPorts = append(exposedPorts, proxyPort(i))
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: exposedPorts,
+		WaitingFor:   wait.ForHTTP("/version").WithPort(controlPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &ToxiproxyContainer{Container: container}, err
+}
+
+// WithNetwork attaches the container to the given network, created e.g. with testcontainers.SharedNetwork.
+//
+// Deprecated: use testcontainers.WithNetwork instead, which also accepts the aliases of the container in the network.
+func WithNetwork(network string) testcontainers.CustomizeRequestOption {
+	return testcontainers.WithNetwork(network)
+}
+
+// proxyPort returns the i-th port exposed by the container for the proxies
+func proxyPort(i int) string {
+	return strconv.Itoa(firstProxyPort+i) + "/tcp"
+}
+
+// URI returns the URL of the HTTP API of Toxiproxy, e.g. http://localhost:49153
+func (c *ToxiproxyContainer) URI(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, controlPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// CreateProxy creates a proxy with the given name, forwarding the connections to the given upstream address,
+// which is resolved inside the container, e.g. redis:6379 for a container with the redis alias on the same network.
+// The proxy listens on the next port exposed by the container, and the connections of the tests must use
+// the endpoint of the returned proxy instead of the upstream address.
+func (c *ToxiproxyContainer) CreateProxy(ctx context.Context, name string, upstream string) (*Proxy, error) {
+	port, err := c.reservePort()
+	if err != nil {
+		return nil, err
+	}
+
+	listen := nat.Port(port)
+
+	body := map[string]interface{}{
+		"name":     name,
+		"listen":   "0.0.0.0:" + listen.Port(),
+		"upstream": upstream,
+		"enabled":  true,
+	}
+	if err := c.doRequest(ctx, http.MethodPost, "/proxies", body); err != nil {
+		return nil, fmt.Errorf("%w: could not create the proxy %s", err, name)
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, listen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Proxy{
+		Name:      name,
+		Upstream:  upstream,
+		Host:      host,
+		Port:      mappedPort.Port(),
+		container: c,
+	}, nil
+}
+
+// ProxyContainer creates a proxy with the given name in front of the given port of the target container, e.g. the container
+// of another module, so the faults of the network between the tests and the target can be simulated with toxics.
+// Both containers must be attached to a common user-defined network, e.g. with the testcontainers.WithNetwork option,
+// where the target is reached by its name.
+func (c *ToxiproxyContainer) ProxyContainer(ctx context.Context, name string, target testcontainers.Container, port nat.Port) (*Proxy, error) {
+	networks, err := c.Networks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetNetworks, err := target.Networks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := commonNetwork(networks, targetNetworks); !ok {
+		return nil, fmt.Errorf("the target container must share a user-defined network with Toxiproxy, got the networks %v and %v", targetNetworks, networks)
+	}
+
+	targetName, err := target.Name(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream := strings.TrimPrefix(targetName, "/") + ":" + port.Port()
+
+	return c.CreateProxy(ctx, name, upstream)
+}
+
+// commonNetwork returns the first of the given networks the target is attached to, except the default networks,
+// where the containers can't be resolved by their names
+func commonNetwork(networks []string, targetNetworks []string) (string, bool) {
+	for _, network := range networks {
+		if network == "bridge" || network == "host" || network == "none" {
+			continue
+		}
+
+		for _, targetNetwork := range targetNetworks {
+			if network == targetNetwork {
+				return network, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// reservePort returns the next port exposed for the proxies which is not used by a proxy
+func (c *ToxiproxyContainer) reservePort() (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.nextPort >= proxyPortCount {
+		return "", fmt.Errorf("all the %d ports of the proxies are used", proxyPortCount)
+	}
+
+	port := proxyPort(c.nextPort)
+	c.nextPort++
+
+	return port, nil
+}
+
+// doRequest sends the given request, in JSON format if the body is not nil, to the HTTP API of Toxiproxy,
+// returning the error reported by Toxiproxy if it's rejected
+func (c *ToxiproxyContainer) doRequest(ctx context.Context, method string, path string, body interface{}) error {
+	uri, err := c.URI(ctx)
+	if err != nil {
+		return err
+	}
+
+	return doRequest(ctx, method, uri+path, body)
+}
+
+func doRequest(ctx context.Context, method string, url string, body interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package toxiproxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestToxiproxy(t *testing.T) {
+	ctx := context.Background()
+
+	network, err := testcontainers.GenericNetwork(ctx, testcontainers.GenericNetworkRequest{
+		NetworkRequest: testcontainers.NetworkRequest{Name: "toxiproxy-network", CheckDuplicate: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := network.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "docker.io/nginx:alpine",
+			Networks:   []string{"toxiproxy-network"},
+			WaitingFor: wait.ForHTTP("/"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := nginx.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// startContainer {
+	container, err := StartContainer(ctx, testcontainers.WithNetwork("toxiproxy-network"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// proxyContainer {
+	proxy, err := container.ProxyContainer(ctx, "nginx", nginx, "80/tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url := "http://" + proxy.Endpoint()
+	// }
+
+	if elapsed := get(t, url); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the request to be fast without toxics, took %s", elapsed)
+	}
+
+	// addToxic {
+	if err := proxy.AddToxic(ctx, Latency(time.Second, 0)); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if elapsed := get(t, url); elapsed < time.Second {
+		t.Fatalf("expected the request to be delayed by the latency, took %s", elapsed)
+	}
+
+	if err := proxy.RemoveToxic(ctx, "latency_downstream"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := proxy.Disable(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := http.Get(url); err == nil {
+		t.Fatal("expected the requests to fail through a disabled proxy")
+	}
+}
+
+// get sends a request to the given URL, returning its duration
+func get(t *testing.T, url string) time.Duration {
+	t.Helper()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	start := time.Now()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	return time.Since(start)
+}
+
+func TestToxicBody(t *testing.T) {
+	toxic := Latency(time.Second, 100*time.Millisecond).WithStream(Upstream).WithToxicity(0.5)
+
+	want := map[string]interface{}{
+		"name":     "latency_upstream",
+		"type":     "latency",
+		"stream":   Upstream,
+		"toxicity": float32(0.5),
+		"attributes": map[string]interface{}{
+			"latency": int64(1000),
+			"jitter":  int64(100),
+		},
+	}
+	if got := toxic.body(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if name := Timeout(0).WithName("blackhole").Name(); name != "blackhole" {
+		t.Fatalf("expected the name blackhole, got %s", name)
+	}
+}
+
+func TestCommonNetwork(t *testing.T) {
+	if network, ok := commonNetwork([]string{"bridge", "tests"}, []string{"bridge", "tests"}); !ok || network != "tests" {
+		t.Fatalf("expected the tests network, got %s", network)
+	}
+
+	if network, ok := commonNetwork([]string{"bridge"}, []string{"bridge"}); ok {
+		t.Fatalf("expected no common network, got %s", network)
+	}
+}
+
+func TestDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if body["name"] == "existing" {
+			http.Error(w, `{"error":"proxy already exists","status":409}`, http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	if err := doRequest(context.Background(), http.MethodPost, server.URL, map[string]interface{}{"name": "new"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := doRequest(context.Background(), http.MethodPost, server.URL, map[string]interface{}{"name": "existing"})
+	if err == nil || !strings.Contains(err.Error(), "proxy already exists") {
+		t.Fatalf("expected the error of Toxiproxy, got %v", err)
+	}
+}
+
+func TestReservePort(t *testing.T) {
+	container := &ToxiproxyContainer{}
+
+	for i := 0; i < proxyPortCount; i++ {
+		if _, err := container.reservePort(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := container.reservePort(); err == nil {
+		t.Fatal("expected an error once all the ports are used")
+	}
+}
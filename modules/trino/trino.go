@@ -0,0 +1,208 @@
+package trino
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/trinodb/trino:430"
+	// }
+
+	httpPort = "8080/tcp"
+
+	defaultUser = "test"
+
+	// catalogContainerDir is the directory of the catalogs of Trino, one properties file per catalog
+	catalogContainerDir = "/etc/trino/catalog"
+)
+
+// TrinoContainer represents the Trino container type used in the module
+type TrinoContainer struct {
+	testcontainers.Container
+	user string
+}
+
+// Option is a function that configures the Trino module, e.g. its catalogs.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	user         string
+	catalogs     map[string]map[string]string
+	catalogFiles []string
+}
+
+// WithUser sets the user of the connection string. Trino accepts any user without a password, "test" by default.
+func WithUser(user string) Option {
+	return func(o *options) {
+		o.user = user
+	}
+}
+
+// WithCatalog adds a catalog with the given name and properties, e.g. {"connector.name": "postgresql", "connection-url": "..."}
+// to query a database of another container on the same network, replacing the catalog of the image with the same name, if any.
+// It can be called multiple times.
+func WithCatalog(name string, properties map[string]string) Option {
+	return func(o *options) {
+		if o.catalogs == nil {
+			o.catalogs = map[string]map[string]string{}
+		}
+		o.catalogs[name] = properties
+	}
+}
+
+// WithCatalogFiles copies the given properties files to the catalogs of Trino, the name of a catalog being
+// the name of its file without the .properties extension. It can be called multiple times.
+func WithCatalogFiles(files ...string) Option {
+	return func(o *options) {
+		o.catalogFiles = append(o.catalogFiles, files...)
+	}
+}
+
+// StartContainer creates an instance of the Trino container type, running a single node acting as coordinator and worker,
+// with the catalogs of the image, e.g. tpch and memory, and the catalogs of the options.
+// It waits until the node is not starting anymore, so the queries can be executed.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*TrinoContainer, error) {
+	settings := options{
+		user: defaultUser,
+	}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	files := make([]testcontainers.ContainerFile, 0, len(settings.catalogFiles)+len(settings.catalogs))
+	for _, file := range settings.catalogFiles {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      file,
+			ContainerFilePath: catalogContainerDir + "/" + filepath.Base(file),
+			FileMode:          0o644,
+		})
+	}
+
+	if len(settings.catalogs) > 0 {
+		dir, err := os.MkdirTemp("", "trino-catalogs-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+
+		for name, properties := range settings.catalogs {
+			file := filepath.Join(dir, name+".properties")
+			if err := os.WriteFile(file, []byte(catalogProperties(properties)), 0o644); err != nil {
+				return nil, fmt.Errorf("%w: could not write the catalog %s", err, name)
+			}
+
+			files = append(files, testcontainers.ContainerFile{
+				HostFilePath:      file,
+				ContainerFilePath: catalogContainerDir + "/" + name + ".properties",
+				FileMode:          0o644,
+			})
+		}
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{httpPort},
+		Files:        files,
+		// the HTTP API is served while the node is starting, rejecting the queries until then
+		WaitingFor: wait.ForHTTP("/v1/info").
+			WithPort(httpPort).
+			WithResponseMatcher(isStarted).
+			WithStartupTimeout(2 * time.Minute),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &TrinoContainer{Container: container, user: settings.user}, err
+}
+
+// catalogProperties returns the content of the properties file of a catalog, sorted by key
+func catalogProperties(properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		sb.WriteString(key + "=" + properties[key] + "\n")
+	}
+
+	return sb.String()
+}
+
+// isStarted checks the body of the /v1/info endpoint, reporting whether the node is starting
+func isStarted(body io.Reader) bool {
+	var info struct {
+		Starting bool `json:"starting"`
+	}
+	if err := json.NewDecoder(body).Decode(&info); err != nil {
+		return false
+	}
+
+	return !info.Starting
+}
+
+// User returns the user of the connection string
+func (c *TrinoContainer) User() string {
+	return c.user
+}
+
+// ConnectionString returns the connection string of Trino for the trinodb/trino-go-client driver,
+// with the format http://<user>@<host>:<port>. The given arguments are appended as parameters
+// of the URL, e.g. "catalog=tpch" and "schema=tiny".
+func (c *TrinoContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{
+		Scheme:   "http",
+		User:     url.User(c.user),
+		Host:     host + ":" + mappedPort.Port(),
+		RawQuery: strings.Join(args, "&"),
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,95 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	// Import the Trino driver into the scope of this package (required)
+	_ "github.com/trinodb/trino-go-client/trino"
+)
+
+func TestTrino(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithCatalog("scratch", map[string]string{"connector.name": "memory"}),
+		WithCatalogFiles(filepath.Join("testdata", "federated.properties")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	// connectionString {
+	connectionString, err := container.ConnectionString(ctx, "catalog=federated", "schema=tiny")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("trino", connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// }
+
+	var nations int
+	if err := db.QueryRowContext(ctx, "SELECT count(*) FROM nation").Scan(&nations); err != nil {
+		t.Fatal(err)
+	}
+
+	if nations != 25 {
+		t.Fatalf("expected the 25 nations of the tpch catalog, got %d", nations)
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE scratch.default.nation AS SELECT * FROM federated.tiny.nation"); err != nil {
+		t.Fatal(err)
+	}
+
+	var copied int
+	if err := db.QueryRowContext(ctx, "SELECT count(*) FROM scratch.default.nation").Scan(&copied); err != nil {
+		t.Fatal(err)
+	}
+
+	if copied != nations {
+		t.Fatalf("expected the nations copied to the memory catalog, got %d", copied)
+	}
+}
+
+func TestCatalogProperties(t *testing.T) {
+	properties := catalogProperties(map[string]string{
+		"connection-url":  "jdbc:postgresql://postgres:5432/test",
+		"connector.name":  "postgresql",
+		"connection-user": "test",
+	})
+
+	want := "connection-url=jdbc:postgresql://postgres:5432/test\nconnection-user=test\nconnector.name=postgresql\n"
+	if properties != want {
+		t.Fatalf("expected %q, got %q", want, properties)
+	}
+}
+
+func TestIsStarted(t *testing.T) {
+	if isStarted(strings.NewReader(`{"nodeVersion":{"version":"430"},"starting":true}`)) {
+		t.Fatal("expected a starting node")
+	}
+
+	if !isStarted(strings.NewReader(`{"nodeVersion":{"version":"430"},"starting":false}`)) {
+		t.Fatal("expected a started node")
+	}
+
+	if isStarted(strings.NewReader("<html>")) {
+		t.Fatal("expected an invalid body to be a starting node")
+	}
+}
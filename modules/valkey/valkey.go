@@ -0,0 +1,201 @@
+package valkey
+
+import (
+	"context"
+	"crypto/tls"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/rediscompat"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/valkey/valkey:8.0.1"
+	// }
+
+	configContainerPath = "/usr/local/etc/valkey/valkey.conf"
+)
+
+// LogLevel is the verbosity of the logs of Valkey
+type LogLevel string
+
+// logLevels {
+const (
+	LogLevelDebug   LogLevel = "debug"
+	LogLevelVerbose LogLevel = "verbose"
+	LogLevelNotice  LogLevel = "notice"
+	LogLevelWarning LogLevel = "warning"
+)
+
+// }
+
+// ValkeyContainer represents the Valkey container type used in the module
+type ValkeyContainer struct {
+	testcontainers.Container
+	settings  rediscompat.Settings
+	tlsConfig *tls.Config
+}
+
+var _ rediscompat.Container = (*ValkeyContainer)(nil)
+
+// Option is a function that configures the Valkey module, e.g. its snapshotting or its password.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	configFile string
+	logLevel   LogLevel
+	// snapshotting saves the dataset every seconds if at least changedKeys changed, if seconds is positive
+	snapshotSeconds     int
+	snapshotChangedKeys int
+	compat              rediscompat.Settings
+	ioThreads           int
+	extendedRedisCompat bool
+}
+
+// WithConfigFile starts Valkey with the given configuration file, copied into the container.
+// The options of the module take precedence over the configuration file.
+func WithConfigFile(configFile string) Option {
+	return func(o *options) {
+		o.configFile = configFile
+	}
+}
+
+// WithLogLevel sets the verbosity of the logs of Valkey, using the --loglevel flag
+func WithLogLevel(level LogLevel) Option {
+	return func(o *options) {
+		o.logLevel = level
+	}
+}
+
+// WithSnapshotting saves the dataset on disk after the given number of seconds if at least the given number
+// of keys changed, using the --save flag
+func WithSnapshotting(seconds int, changedKeys int) Option {
+	return func(o *options) {
+		o.snapshotSeconds = seconds
+		o.snapshotChangedKeys = changedKeys
+	}
+}
+
+// WithPassword sets the password required to authenticate the clients, using the --requirepass flag
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.compat.Password = password
+	}
+}
+
+// WithTLS serves the Redis protocol over TLS only, with the given PEM encoded certificate and private key files,
+// using the --tls-port flag. The CA certificate file verifies the certificate of the server, which is used instead if empty,
+// e.g. for a self-signed certificate. The certificate must be valid for the host of the Docker daemon, e.g. localhost.
+func WithTLS(certFile, keyFile, caCertFile string) Option {
+	return func(o *options) {
+		o.compat.TLS = &rediscompat.TLS{CertFile: certFile, KeyFile: keyFile, CACertFile: caCertFile}
+	}
+}
+
+// WithIOThreads sets the number of threads handling the reads and the writes of the clients, using the --io-threads flag.
+// The threads are offloaded from the main thread since Valkey 8.0, which improves the throughput of the multi-core hosts.
+func WithIOThreads(threads int) Option {
+	return func(o *options) {
+		o.ioThreads = threads
+	}
+}
+
+// WithExtendedRedisCompatibility makes Valkey report itself as Redis 7.2.4, e.g. in the INFO command, for the clients and the tools
+// checking the name or the version of the server, using the --extended-redis-compatibility flag. Only available since Valkey 8.0.
+func WithExtendedRedisCompatibility() Option {
+	return func(o *options) {
+		o.extendedRedisCompat = true
+	}
+}
+
+// StartContainer creates an instance of the Valkey container type
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*ValkeyContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	tlsConfig, err := settings.compat.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := []string{"valkey-server"}
+	files := settings.compat.Files()
+	if settings.configFile != "" {
+		cmd = append(cmd, configContainerPath)
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      settings.configFile,
+			ContainerFilePath: configContainerPath,
+			FileMode:          0o644,
+		})
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		Cmd:          append(cmd, settings.flags()...),
+		Files:        files,
+		ExposedPorts: []string{rediscompat.Port},
+		WaitingFor:   rediscompat.WaitStrategy(),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	return &ValkeyContainer{Container: container, settings: settings.compat, tlsConfig: tlsConfig}, err
+}
+
+// ConnectionString returns the Redis compatible connection string of Valkey,
+// with the format redis://[:password@]<host>:<port>, or rediss:// over TLS, which can be parsed by the Redis clients
+func (c *ValkeyContainer) ConnectionString(ctx context.Context) (string, error) {
+	return rediscompat.ConnectionString(ctx, c, c.settings)
+}
+
+// TLSConfig returns the TLS configuration of the clients, trusting the certificate of Valkey, nil if TLS is not enabled
+func (c *ValkeyContainer) TLSConfig() *tls.Config {
+	return c.tlsConfig
+}
+
+// flags returns the command line flags of Valkey for the options of the module,
+// which take precedence over the configuration file
+func (o options) flags() []string {
+	var flags []string
+
+	if o.logLevel != "" {
+		flags = append(flags, "--loglevel", string(o.logLevel))
+	}
+	if o.snapshotSeconds > 0 {
+		flags = append(flags, "--save", strconv.Itoa(o.snapshotSeconds)+" "+strconv.Itoa(o.snapshotChangedKeys))
+	}
+	flags = append(flags, o.compat.Flags()...)
+	if o.ioThreads > 0 {
+		flags = append(flags, "--io-threads", strconv.Itoa(o.ioThreads))
+	}
+	if o.extendedRedisCompat {
+		flags = append(flags, "--extended-redis-compatibility", "yes")
+	}
+
+	return flags
+}
@@ -0,0 +1,255 @@
+package valkey
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/testcontainers/testcontainers-go/internal/rediscompat"
+)
+
+func TestValkey(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithPassword("secret"),
+		WithSnapshotting(10, 1),
+		WithLogLevel(LogLevelVerbose),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	client := newClient(t, container)
+
+	if err := client.Set(ctx, "greeting", "hello", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := client.Get(ctx, "greeting").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "hello" {
+		t.Fatalf("expected hello, got %s", value)
+	}
+
+	save, err := client.ConfigGet(ctx, "save").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(save) != 2 || save[1] != "10 1" {
+		t.Fatalf("expected the snapshotting to be configured, got %v", save)
+	}
+}
+
+func TestValkeyWithExtendedRedisCompatibility(t *testing.T) {
+	ctx := context.Background()
+
+	// withExtendedRedisCompatibility {
+	container, err := StartContainer(ctx, WithExtendedRedisCompatibility(), WithIOThreads(2))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	info, err := newClient(t, container).Info(ctx, "server").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(info, "redis_version:7.2.4") {
+		t.Fatalf("expected Valkey to report itself as Redis 7.2.4, got:\n%s", info)
+	}
+}
+
+func TestValkeyWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	certFile, keyFile := writeSelfSignedCert(t)
+
+	// withTLS {
+	container, err := StartContainer(ctx, WithTLS(certFile, keyFile, ""), WithPassword("secret"))
+	// }
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connectionString, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(connectionString, "rediss://:secret@") {
+		t.Fatalf("expected a TLS connection string, got %s", connectionString)
+	}
+
+	if err := newClient(t, container).Ping(ctx).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeSelfSignedCert writes a self-signed certificate valid for localhost, and its private key, in PEM format
+func writeSelfSignedCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func newClient(t *testing.T, container rediscompat.Container) *redis.Client {
+	// connectionString {
+	connectionString, err := container.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := redis.ParseURL(connectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the TLS configuration trusts the certificate of the server, if TLS is enabled
+	if tlsConfig := container.TLSConfig(); tlsConfig != nil {
+		options.TLSConfig = tlsConfig
+	}
+
+	client := redis.NewClient(options)
+	// }
+
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return client
+}
+
+func TestFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings options
+		want     []string
+	}{
+		{
+			name:     "default flags",
+			settings: options{},
+			want:     nil,
+		},
+		{
+			name: "all the flags",
+			settings: options{
+				logLevel:            LogLevelDebug,
+				snapshotSeconds:     10,
+				snapshotChangedKeys: 1,
+				compat:              rediscompat.Settings{Password: "secret"},
+				ioThreads:           4,
+				extendedRedisCompat: true,
+			},
+			want: []string{
+				"--loglevel", "debug",
+				"--save", "10 1",
+				"--requirepass", "secret",
+				"--io-threads", "4",
+				"--extended-redis-compatibility", "yes",
+			},
+		},
+		{
+			name: "TLS flags",
+			settings: options{
+				compat: rediscompat.Settings{TLS: &rediscompat.TLS{CertFile: "server.crt", KeyFile: "server.key"}},
+			},
+			want: []string{
+				"--port", "0",
+				"--tls-port", "6379",
+				"--tls-cert-file", "/tls/server.crt",
+				"--tls-key-file", "/tls/server.key",
+				"--tls-ca-cert-file", "/tls/ca.crt",
+				"--tls-auth-clients", "no",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.flags(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
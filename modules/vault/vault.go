@@ -6,7 +6,6 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 	"strings"
 
-	"github.com/docker/docker/api/types/container"
 	"github.com/testcontainers/testcontainers-go"
 )
 
@@ -15,41 +14,49 @@ const (
 	defaultImageName = "hashicorp/vault:1.13.0"
 )
 
-// ContainerOptions is a function that can be used to configure the Vault container
+// ContainerOptions is a function that can be used to configure the Vault container.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
 type ContainerOptions func(req *testcontainers.ContainerRequest)
 
+// Customize applies the option to the container request of the Vault container
+func (o ContainerOptions) Customize(req *testcontainers.GenericContainerRequest) {
+	o(&req.ContainerRequest)
+}
+
 // VaultContainer represents the vault container type used in the module
 type VaultContainer struct {
 	testcontainers.Container
 }
 
-// StartContainer creates an instance of the vault container type
-func StartContainer(ctx context.Context, opts ...ContainerOptions) (*VaultContainer, error) {
+// StartContainer creates an instance of the vault container type.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithNetwork,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*VaultContainer, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultImageName,
 		ExposedPorts: []string{defaultPort + "/tcp"},
-		HostConfigModifier: func(hc *container.HostConfig) {
-			hc.CapAdd = []string{"IPC_LOCK"}
-		},
-		WaitingFor: wait.ForHTTP("/v1/sys/health").WithPort(defaultPort),
+		CapAdd:       []string{"IPC_LOCK"},
+		WaitingFor:   wait.ForHTTP("/v1/sys/health").WithPort(defaultPort),
 		Env: map[string]string{
 			"VAULT_ADDR": "http://0.0.0.0:" + defaultPort,
 		},
 	}
 
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
 	for _, opt := range opts {
-		opt(&req)
+		opt.Customize(&genericContainerReq)
 	}
 
-	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: req,
-		Started:          true,
-	})
-	if err != nil {
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
 		return nil, err
 	}
 
-	return &VaultContainer{container}, nil
+	return &VaultContainer{container}, err
 }
 
 // WithImageName is an option function that sets the Docker image name for the Vault
@@ -12,6 +12,7 @@ import (
 	vaultClient "github.com/hashicorp/vault-client-go"
 	"github.com/hashicorp/vault-client-go/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
 	testcontainervault "github.com/testcontainers/testcontainers-go/modules/vault"
 	"github.com/tidwall/gjson"
 )
@@ -27,7 +28,7 @@ var (
 
 func TestMain(m *testing.M) {
 	var err error
-	opts := []testcontainervault.ContainerOptions{
+	opts := []testcontainers.ContainerCustomizer{
 		// WithImageName {
 		testcontainervault.WithImageName("hashicorp/vault:1.13.0"),
 		// }
@@ -0,0 +1,117 @@
+package wiremock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Stub is a stub mapping of WireMock, returning the response to the requests matching the request pattern
+type Stub struct {
+	Request  RequestPattern `json:"request"`
+	Response Response       `json:"response"`
+	// Priority orders the stubs matching the same request, 1 being the highest priority
+	Priority int `json:"priority,omitempty"`
+}
+
+// RequestPattern matches the requests received by WireMock. The matchers of the headers, the query parameters
+// and the body use the format of WireMock, e.g. {"equalTo": "application/json"} or {"matches": "[a-z]+"}.
+type RequestPattern struct {
+	// Method is the method of the requests, any method if empty
+	Method string `json:"method,omitempty"`
+	// URL is the path and the query of the requests, URLPath only their path, and URLPattern a regular expression
+	// matching their path and query. Only one of them must be set.
+	URL        string `json:"url,omitempty"`
+	URLPath    string `json:"urlPath,omitempty"`
+	URLPattern string `json:"urlPattern,omitempty"`
+
+	Headers         map[string]Matcher `json:"headers,omitempty"`
+	QueryParameters map[string]Matcher `json:"queryParameters,omitempty"`
+	BodyPatterns    []Matcher          `json:"bodyPatterns,omitempty"`
+}
+
+// Matcher is a matcher of WireMock, e.g. {"equalTo": "application/json"} or {"equalToJson": {"id": 1}}
+type Matcher map[string]interface{}
+
+// Response is the response of a stub
+type Response struct {
+	// Status is the status code of the response, 200 if 0
+	Status  int               `json:"status,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is the body of the response as a string, and JSONBody a value encoded as the JSON body of the response.
+	// Only one of them must be set.
+	Body     string      `json:"body,omitempty"`
+	JSONBody interface{} `json:"jsonBody,omitempty"`
+	// FixedDelayMilliseconds delays the response, e.g. to test the timeouts of the code under test
+	FixedDelayMilliseconds int `json:"fixedDelayMilliseconds,omitempty"`
+}
+
+// StubFor creates the given stub, returning its ID. The stub takes precedence over the stubs created before it,
+// which match the same requests with the same priority.
+func (c *WireMockContainer) StubFor(ctx context.Context, stub Stub) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := c.doRequest(ctx, http.MethodPost, "/__admin/mappings", stub, &created); err != nil {
+		return "", fmt.Errorf("%w: could not create the stub", err)
+	}
+
+	return created.ID, nil
+}
+
+// RequestCount returns the number of requests received by WireMock which match the given pattern
+func (c *WireMockContainer) RequestCount(ctx context.Context, pattern RequestPattern) (int, error) {
+	var count struct {
+		Count int `json:"count"`
+	}
+	if err := c.doRequest(ctx, http.MethodPost, "/__admin/requests/count", pattern, &count); err != nil {
+		return 0, fmt.Errorf("%w: could not count the requests", err)
+	}
+
+	return count.Count, nil
+}
+
+// VerifyRequest checks that WireMock received exactly the given number of requests matching the given pattern,
+// e.g. that the code under test called the stubbed service once
+func (c *WireMockContainer) VerifyRequest(ctx context.Context, pattern RequestPattern, expected int) error {
+	count, err := c.RequestCount(ctx, pattern)
+	if err != nil {
+		return err
+	}
+
+	if count != expected {
+		return fmt.Errorf("expected %d requests matching %s %s, got %d", expected, pattern.method(), pattern.url(), count)
+	}
+
+	return nil
+}
+
+// ResetRequests clears the requests received by WireMock, keeping the stubs, e.g. between the cases of a test
+func (c *WireMockContainer) ResetRequests(ctx context.Context) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/__admin/requests", nil, nil); err != nil {
+		return fmt.Errorf("%w: could not reset the requests", err)
+	}
+
+	return nil
+}
+
+func (p RequestPattern) method() string {
+	if p.Method == "" {
+		return "ANY"
+	}
+
+	return p.Method
+}
+
+func (p RequestPattern) url() string {
+	switch {
+	case p.URL != "":
+		return p.URL
+	case p.URLPath != "":
+		return p.URLPath
+	case p.URLPattern != "":
+		return p.URLPattern
+	default:
+		return "any URL"
+	}
+}
@@ -0,0 +1,202 @@
+package wiremock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// defaultImage {
+	defaultImage = "docker.io/wiremock/wiremock:3.2.0"
+	// }
+
+	httpPort = "8080/tcp"
+
+	// mappingsContainerDir is the directory of the stub mappings loaded by WireMock when it starts
+	mappingsContainerDir = "/home/wiremock/mappings"
+)
+
+// WireMockContainer represents the WireMock container type used in the module
+type WireMockContainer struct {
+	testcontainers.Container
+}
+
+// Option is a function that configures the WireMock module, e.g. the stub mappings loaded when it starts.
+// It implements the testcontainers.ContainerCustomizer interface, so it can be composed with the generic options.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface,
+// as the options of the module are applied to the configuration of the module.
+func (o Option) Customize(req *testcontainers.GenericContainerRequest) {}
+
+type options struct {
+	mappingsDirs []string
+	mappings     [][]byte
+}
+
+// WithMappingsDir loads the stub mappings of the JSON files of the given directory when WireMock starts,
+// with the format of the mappings directory of WireMock. It can be called multiple times.
+func WithMappingsDir(dir string) Option {
+	return func(o *options) {
+		o.mappingsDirs = append(o.mappingsDirs, dir)
+	}
+}
+
+// WithMappings creates the given stub mappings, in JSON format, once WireMock is started,
+// e.g. the mappings embedded in the test binary with go:embed. It can be called multiple times.
+func WithMappings(mappings ...[]byte) Option {
+	return func(o *options) {
+		o.mappings = append(o.mappings, mappings...)
+	}
+}
+
+// StartContainer creates an instance of the WireMock container type, loading the stub mappings of the options.
+// It accepts the options of the module, and the generic options of the testcontainers package, e.g. testcontainers.WithImage,
+// which are applied after the default configuration of the container.
+func StartContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*WireMockContainer, error) {
+	settings := options{}
+
+	for _, opt := range opts {
+		if o, ok := opt.(Option); ok {
+			o(&settings)
+		}
+	}
+
+	var files []testcontainers.ContainerFile
+	for i, dir := range settings.mappingsDirs {
+		dirFiles, err := mappingFiles(i, dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dirFiles...)
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        defaultImage,
+		ExposedPorts: []string{httpPort},
+		Files:        files,
+		WaitingFor:   wait.ForHTTP("/__admin/mappings").WithPort(httpPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&genericContainerReq)
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil {
+		return nil, err
+	}
+
+	c := &WireMockContainer{Container: container}
+	if err != nil {
+		return c, err
+	}
+
+	for _, mapping := range settings.mappings {
+		if err := c.doRequest(ctx, http.MethodPost, "/__admin/mappings", json.RawMessage(mapping), nil); err != nil {
+			return c, fmt.Errorf("%w: could not create the stub mapping", err)
+		}
+	}
+
+	return c, nil
+}
+
+// mappingFiles returns the JSON files of the given directory, copied to the mappings directory of WireMock.
+// They are prefixed by the index of the directory, to keep the names unique.
+func mappingFiles(i int, dir string) ([]testcontainers.ContainerFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []testcontainers.ContainerFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      filepath.Join(dir, entry.Name()),
+			ContainerFilePath: fmt.Sprintf("%s/%d-%s", mappingsContainerDir, i, entry.Name()),
+			FileMode:          0o644,
+		})
+	}
+
+	return files, nil
+}
+
+// BaseURL returns the base URL of WireMock, with the format http://<host>:<port>, which the code under test
+// must call instead of the stubbed service
+func (c *WireMockContainer) BaseURL(ctx context.Context) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, httpPort)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s:%s", host, mappedPort.Port()), nil
+}
+
+// doRequest sends the given request, in JSON format if the body is not nil, to the admin API of WireMock,
+// decoding the JSON response into the given value if not nil
+func (c *WireMockContainer) doRequest(ctx context.Context, method string, path string, body interface{}, v interface{}) error {
+	baseURL, err := c.BaseURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	return doRequest(ctx, method, baseURL+path, body, v)
+}
+
+func doRequest(ctx context.Context, method string, url string, body interface{}, v interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,177 @@
+package wiremock
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//go:embed testdata/greeting.json
+var greetingMapping []byte
+
+func TestWireMock(t *testing.T) {
+	ctx := context.Background()
+
+	// startContainer {
+	container, err := StartContainer(ctx,
+		WithMappingsDir(filepath.Join("testdata", "mappings")),
+		WithMappings(greetingMapping),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	// Clean up the container after the test is complete
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	baseURL, err := container.BaseURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body := get(t, baseURL+"/hello"); body != "Hello, world!" {
+		t.Fatalf("expected the body of the mappings directory, got %s", body)
+	}
+
+	if body := get(t, baseURL+"/greeting"); !strings.Contains(body, `"hi"`) {
+		t.Fatalf("expected the body of the embedded mapping, got %s", body)
+	}
+
+	// stubFor {
+	_, err = container.StubFor(ctx, Stub{
+		Request: RequestPattern{Method: http.MethodGet, URLPath: "/users/1"},
+		Response: Response{
+			Status:   http.StatusOK,
+			JSONBody: map[string]interface{}{"id": 1, "name": "John"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if body := get(t, baseURL+"/users/1"); !strings.Contains(body, `"John"`) {
+		t.Fatalf("expected the body of the stub, got %s", body)
+	}
+
+	// verifyRequest {
+	if err := container.VerifyRequest(ctx, RequestPattern{Method: http.MethodGet, URLPath: "/users/1"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	// }
+
+	if err := container.ResetRequests(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := container.VerifyRequest(ctx, RequestPattern{URLPath: "/users/1"}, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// get sends a GET request to the given URL, returning the body of the response
+func get(t *testing.T, url string) string {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(body)
+}
+
+func TestMappingFiles(t *testing.T) {
+	files, err := mappingFiles(1, filepath.Join("testdata", "mappings"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected only the JSON file of the directory, got %v", files)
+	}
+
+	if files[0].ContainerFilePath != mappingsContainerDir+"/1-hello.json" {
+		t.Fatalf("expected the file in the mappings directory, got %s", files[0].ContainerFilePath)
+	}
+
+	if _, err := mappingFiles(0, filepath.Join("testdata", "missing")); err == nil {
+		t.Fatal("expected an error for a missing directory")
+	}
+}
+
+func TestStubJSON(t *testing.T) {
+	stub := Stub{
+		Request: RequestPattern{
+			Method:  http.MethodPost,
+			URLPath: "/users",
+			Headers: map[string]Matcher{"Content-Type": {"equalTo": "application/json"}},
+		},
+		Response: Response{Status: http.StatusCreated},
+	}
+
+	b, err := json.Marshal(stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"request":{"method":"POST","urlPath":"/users","headers":{"Content-Type":{"equalTo":"application/json"}}},"response":{"status":201}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pattern RequestPattern
+		if err := json.NewDecoder(r.Body).Decode(&pattern); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if pattern.URL == "" {
+			http.Error(w, `{"errors":[{"title":"missing URL"}]}`, http.StatusUnprocessableEntity)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"count":2}`))
+	}))
+	defer server.Close()
+
+	var count struct {
+		Count int `json:"count"`
+	}
+	if err := doRequest(context.Background(), http.MethodPost, server.URL, RequestPattern{URL: "/hello"}, &count); err != nil {
+		t.Fatal(err)
+	}
+
+	if count.Count != 2 {
+		t.Fatalf("expected the count of the response, got %d", count.Count)
+	}
+
+	err := doRequest(context.Background(), http.MethodPost, server.URL, RequestPattern{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "missing URL") {
+		t.Fatalf("expected the error of WireMock, got %v", err)
+	}
+}
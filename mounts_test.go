@@ -5,6 +5,8 @@ import (
 
 	"github.com/docker/docker/api/types/mount"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
 )
 
 func TestContainerMounts_PrepareMounts(t *testing.T) {
@@ -165,6 +167,20 @@ func TestContainerMounts_PrepareMounts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Single tmpfs mount - with size and mode",
+			mounts: ContainerMounts{TmpfsMount("/cache", 64*1024*1024, 0o1777)},
+			want: []mount.Mount{
+				{
+					Type:   mount.TypeTmpfs,
+					Target: "/cache",
+					TmpfsOptions: &mount.TmpfsOptions{
+						SizeBytes: 64 * 1024 * 1024,
+						Mode:      0o1777,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -174,3 +190,41 @@ func TestContainerMounts_PrepareMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelVolumeMounts(t *testing.T) {
+	labels := map[string]string{
+		TestcontainerLabel:                  "true",
+		testcontainersdocker.LabelSessionID: "session",
+		"com.example.label":                 "value",
+	}
+
+	mounts := mapToDockerMounts(ContainerMounts{
+		BindMount("/var/lib/app/data", "/data"),
+		VolumeMount("app-data", "/volume"),
+		{
+			Source: DockerVolumeMountSource{
+				Name: "app-data-with-options",
+				VolumeOptions: &mount.VolumeOptions{
+					Labels: map[string]string{
+						"com.example.volume":                "volume",
+						testcontainersdocker.LabelSessionID: "custom",
+					},
+				},
+			},
+			Target: "/volume-with-options",
+		},
+	})
+
+	labelVolumeMounts(mounts, labels)
+
+	assert.Nil(t, mounts[0].VolumeOptions, "bind mounts must not be labeled")
+	assert.Equal(t, map[string]string{
+		TestcontainerLabel:                  "true",
+		testcontainersdocker.LabelSessionID: "session",
+	}, mounts[1].VolumeOptions.Labels)
+	assert.Equal(t, map[string]string{
+		TestcontainerLabel:                  "true",
+		testcontainersdocker.LabelSessionID: "custom",
+		"com.example.volume":                "volume",
+	}, mounts[2].VolumeOptions.Labels)
+}
@@ -0,0 +1,142 @@
+package testcontainers
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// ContainerCustomizer is an interface that can be used to configure the Testcontainers container request.
+// Modules accept it in their entrypoint functions, so their own options can be composed with the generic
+// options defined in this package.
+type ContainerCustomizer interface {
+	Customize(req *GenericContainerRequest)
+}
+
+// CustomizeRequestOption is a type that can be used to configure the Testcontainers container request.
+type CustomizeRequestOption func(req *GenericContainerRequest)
+
+// Customize implements ContainerCustomizer.Customize
+func (opt CustomizeRequestOption) Customize(req *GenericContainerRequest) {
+	opt(req)
+}
+
+// WithImage sets the image for a container
+func WithImage(image string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Image = image
+	}
+}
+
+// WithEnv sets the environment variables for a container, merging them with the existing ones.
+// If the environment variable already exists, it will be overridden.
+func WithEnv(envs map[string]string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if req.Env == nil {
+			req.Env = map[string]string{}
+		}
+
+		for key, val := range envs {
+			req.Env[key] = val
+		}
+	}
+}
+
+// WithExposedPorts appends the ports to the exposed ports for a container
+func WithExposedPorts(ports ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.ExposedPorts = append(req.ExposedPorts, ports...)
+	}
+}
+
+// WithCmd completely replaces the command for a container
+func WithCmd(cmd ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Cmd = cmd
+	}
+}
+
+// WithEntrypoint completely replaces the entrypoint of a container
+func WithEntrypoint(entrypoint ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Entrypoint = entrypoint
+	}
+}
+
+// WithWaitStrategy sets the wait strategy for a container. If more than one strategy is passed,
+// the container will wait for all of them, in order.
+func WithWaitStrategy(strategies ...wait.Strategy) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		if len(strategies) == 1 {
+			req.WaitingFor = strategies[0]
+			return
+		}
+
+		req.WaitingFor = wait.ForAll(strategies...)
+	}
+}
+
+// WithNetwork attaches the container to the given network, reachable from the other containers
+// of the network with the given aliases
+func WithNetwork(network string, aliases ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Networks = append(req.Networks, network)
+
+		if len(aliases) == 0 {
+			return
+		}
+
+		if req.NetworkAliases == nil {
+			req.NetworkAliases = map[string][]string{}
+		}
+		req.NetworkAliases[network] = append(req.NetworkAliases[network], aliases...)
+	}
+}
+
+// WithHostConfigModifier adds a modifier of the host config of a container. The modifier is chained
+// after the one already defined in the request, e.g. by a module, so both of them are applied.
+func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		previous := req.HostConfigModifier
+		if previous == nil {
+			// keep the deprecated fields of the request, applied by default when there are no modifiers
+			previous = defaultHostConfigModifier(req.ContainerRequest)
+		}
+
+		req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+			previous(hostConfig)
+			modifier(hostConfig)
+		}
+	}
+}
+
+// WithConfigModifier adds a modifier of the config of a container, e.g. to set its healthcheck or its stop signal.
+// The modifier is chained after the one already defined in the request, e.g. by a module, so both of them are applied.
+func WithConfigModifier(modifier func(config *container.Config)) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		previous := req.ConfigModifier
+
+		req.ConfigModifier = func(config *container.Config) {
+			if previous != nil {
+				previous(config)
+			}
+			modifier(config)
+		}
+	}
+}
+
+// WithEndpointSettingsModifier adds a modifier of the endpoint settings of the networks of a container, e.g. to set
+// its static IP address. The modifier is chained after the one already defined in the request, so both of them are applied.
+func WithEndpointSettingsModifier(modifier func(settings map[string]*network.EndpointSettings)) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		previous := req.EndpointSettingsModifier
+
+		req.EndpointSettingsModifier = func(settings map[string]*network.EndpointSettings) {
+			if previous != nil {
+				previous(settings)
+			}
+			modifier(settings)
+		}
+	}
+}
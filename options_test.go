@@ -0,0 +1,153 @@
+package testcontainers
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestCustomizeRequestOptions(t *testing.T) {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        "redis:6",
+			Env:          map[string]string{"FOO": "foo", "BAR": "bar"},
+			ExposedPorts: []string{"6379/tcp"},
+			Cmd:          []string{"redis-server"},
+		},
+	}
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	// customizers {
+	opts := []ContainerCustomizer{
+		WithImage("redis:7"),
+		WithEnv(map[string]string{"FOO": "baz", "QUX": "qux"}),
+		WithExposedPorts("6380/tcp"),
+		WithCmd("redis-server", "--port", "6380"),
+		WithEntrypoint("/entrypoint.sh"),
+		WithWaitStrategy(wait.ForLog("Ready to accept connections")),
+		WithLogger(logger),
+	}
+
+	for _, opt := range opts {
+		opt.Customize(&req)
+	}
+	// }
+
+	assert.Equal(t, "redis:7", req.Image)
+	assert.Equal(t, map[string]string{"FOO": "baz", "BAR": "bar", "QUX": "qux"}, req.Env)
+	assert.Equal(t, []string{"6379/tcp", "6380/tcp"}, req.ExposedPorts)
+	assert.Equal(t, []string{"redis-server", "--port", "6380"}, req.Cmd)
+	assert.Equal(t, []string{"/entrypoint.sh"}, req.Entrypoint)
+	assert.IsType(t, &wait.LogStrategy{}, req.WaitingFor)
+	assert.Equal(t, logger, req.Logger)
+}
+
+func TestWithEnvOnEmptyRequest(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	WithEnv(map[string]string{"FOO": "foo"}).Customize(&req)
+
+	assert.Equal(t, map[string]string{"FOO": "foo"}, req.Env)
+}
+
+func TestWithWaitStrategyWithMultipleStrategies(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	WithWaitStrategy(wait.ForLog("started"), wait.ForListeningPort("80/tcp")).Customize(&req)
+
+	assert.IsType(t, &wait.MultiStrategy{}, req.WaitingFor)
+}
+
+func TestWithNetwork(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	WithNetwork("backend", "db", "postgres").Customize(&req)
+	WithNetwork("frontend").Customize(&req)
+
+	assert.Equal(t, []string{"backend", "frontend"}, req.Networks)
+	assert.Equal(t, map[string][]string{"backend": {"db", "postgres"}}, req.NetworkAliases)
+}
+
+func TestWithHostConfigModifier(t *testing.T) {
+	t.Run("chained with the modifier of the request", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				HostConfigModifier: func(hostConfig *container.HostConfig) {
+					hostConfig.CapAdd = []string{"IPC_LOCK"}
+				},
+			},
+		}
+
+		WithHostConfigModifier(func(hostConfig *container.HostConfig) {
+			hostConfig.Privileged = true
+		}).Customize(&req)
+
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+
+		assert.Equal(t, []string{"IPC_LOCK"}, []string(hostConfig.CapAdd))
+		assert.True(t, hostConfig.Privileged)
+	})
+
+	t.Run("keeps the deprecated fields of the request", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Binds: []string{"/tmp:/data"},
+			},
+		}
+
+		WithHostConfigModifier(func(hostConfig *container.HostConfig) {
+			hostConfig.ShmSize = 1024
+		}).Customize(&req)
+
+		hostConfig := &container.HostConfig{}
+		req.HostConfigModifier(hostConfig)
+
+		assert.Equal(t, []string{"/tmp:/data"}, hostConfig.Binds)
+		assert.Equal(t, int64(1024), hostConfig.ShmSize)
+	})
+}
+
+func TestWithConfigModifier(t *testing.T) {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			ConfigModifier: func(config *container.Config) {
+				config.StopSignal = "SIGINT"
+			},
+		},
+	}
+
+	WithConfigModifier(func(config *container.Config) {
+		config.Healthcheck = &container.HealthConfig{Test: []string{"CMD", "true"}}
+	}).Customize(&req)
+
+	config := &container.Config{}
+	req.ConfigModifier(config)
+
+	assert.Equal(t, "SIGINT", config.StopSignal)
+	assert.Equal(t, []string{"CMD", "true"}, config.Healthcheck.Test)
+}
+
+func TestWithEndpointSettingsModifier(t *testing.T) {
+	req := GenericContainerRequest{}
+
+	WithEndpointSettingsModifier(func(settings map[string]*network.EndpointSettings) {
+		settings["backend"] = &network.EndpointSettings{Aliases: []string{"db"}}
+	}).Customize(&req)
+	WithEndpointSettingsModifier(func(settings map[string]*network.EndpointSettings) {
+		settings["backend"].Links = []string{"cache"}
+	}).Customize(&req)
+
+	settings := map[string]*network.EndpointSettings{}
+	req.EndpointSettingsModifier(settings)
+
+	assert.Equal(t, []string{"db"}, settings["backend"].Aliases)
+	assert.Equal(t, []string{"cache"}, settings["backend"].Links)
+}
@@ -0,0 +1,46 @@
+package testcontainers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedPlatform is returned when the image of a container has no manifest for the requested platform,
+// e.g. an image only built for linux/amd64 requested for linux/arm64, instead of the error of the Docker daemon.
+type ErrUnsupportedPlatform struct {
+	// Image is the image of the container
+	Image string
+	// Platform is the platform of the request, e.g. linux/arm64, empty for the platform of the daemon
+	Platform string
+	// Err is the error returned by the Docker daemon
+	Err error
+}
+
+func (e *ErrUnsupportedPlatform) Error() string {
+	platform := e.Platform
+	if platform == "" {
+		platform = "the platform of the Docker daemon"
+	}
+
+	return fmt.Sprintf("the image %s is not available for %s, set the ImagePlatform or the ImagePlatformFallback of the request to a platform of the image: %s",
+		e.Image, platform, e.Err)
+}
+
+func (e *ErrUnsupportedPlatform) Unwrap() error {
+	return e.Err
+}
+
+// platformError returns an ErrUnsupportedPlatform error if the given error of the Docker daemon reports that the image
+// has no manifest for the platform, when pulling the image or creating the container, otherwise the error as is
+func platformError(err error, image string, platform string) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "no matching manifest for") && !strings.Contains(msg, "does not match the specified platform") {
+		return err
+	}
+
+	return &ErrUnsupportedPlatform{Image: image, Platform: platform, Err: err}
+}
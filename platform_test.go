@@ -0,0 +1,36 @@
+package testcontainers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPlatformError(t *testing.T) {
+	t.Run("no matching manifest", func(t *testing.T) {
+		daemonErr := errors.New("Error response from daemon: no matching manifest for linux/s390x in the manifest list entries")
+
+		err := platformError(daemonErr, "nginx:alpine", "linux/s390x")
+
+		var unsupported *ErrUnsupportedPlatform
+		if !errors.As(err, &unsupported) {
+			t.Fatalf("expected an ErrUnsupportedPlatform error, got %v", err)
+		}
+
+		if unsupported.Image != "nginx:alpine" || unsupported.Platform != "linux/s390x" {
+			t.Fatalf("expected the image and the platform of the request, got %+v", unsupported)
+		}
+
+		if !errors.Is(err, daemonErr) || !strings.Contains(err.Error(), "ImagePlatform") {
+			t.Fatalf("expected the error of the daemon, and how to fix the request, got %v", err)
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		daemonErr := errors.New("Error response from daemon: pull access denied")
+
+		if err := platformError(daemonErr, "nginx:alpine", ""); err != daemonErr {
+			t.Fatalf("expected the error of the daemon as is, got %v", err)
+		}
+	})
+}
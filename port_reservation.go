@@ -0,0 +1,89 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// PortReservation represents a set of free host ports, which are kept bound by listeners until they
+// are released. It allows to pre-compute the addresses a container advertises to its clients
+// (e.g. the Kafka advertised.listeners or the Couchbase alternate addresses) before the container is created,
+// without any other process on the host taking the ports in the meantime.
+type PortReservation struct {
+	mtx       sync.Mutex
+	listeners []net.Listener
+	ports     []int
+}
+
+// ReservePorts reserves n free host ports at once. The ports are held by listeners bound to all the
+// interfaces of the host, in the same manner the Docker daemon binds the published ports of a container.
+// The reservation must be passed to the container request using the ReservedPorts field, so the listeners
+// are handed over to Docker right before the container is started; otherwise it's a responsibility of the
+// caller to release it.
+func ReservePorts(n int) (*PortReservation, error) {
+	if n <= 0 {
+		return nil, errors.New("the number of ports to reserve must be greater than zero")
+	}
+
+	r := &PortReservation{
+		listeners: make([]net.Listener, 0, n),
+		ports:     make([]int, 0, n),
+	}
+
+	for i := 0; i < n; i++ {
+		l, err := net.Listen("tcp", ":0")
+		if err != nil {
+			_ = r.Release()
+			return nil, fmt.Errorf("%w: could not reserve a free port", err)
+		}
+
+		r.listeners = append(r.listeners, l)
+		r.ports = append(r.ports, l.Addr().(*net.TCPAddr).Port)
+	}
+
+	return r, nil
+}
+
+// Ports returns the reserved host ports, in the order they were reserved
+func (r *PortReservation) Ports() []int {
+	ports := make([]int, len(r.ports))
+	copy(ports, r.ports)
+
+	return ports
+}
+
+// PortSpec returns the port specification binding the i-th reserved host port to the given container port,
+// e.g. "49153:9092/tcp", to be used in the ExposedPorts field of a container request.
+func (r *PortReservation) PortSpec(i int, containerPort string) string {
+	return fmt.Sprintf("%d:%s", r.ports[i], containerPort)
+}
+
+// Release closes the listeners holding the reserved ports, so they can be bound by the Docker daemon.
+// It's safe to call it more than once.
+func (r *PortReservation) Release() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var err error
+	for _, l := range r.listeners {
+		if closeErr := l.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	r.listeners = nil
+
+	return err
+}
+
+// releasePorts releases all the given reservations, returning the first error found
+func releasePorts(reservations []*PortReservation) error {
+	for _, r := range reservations {
+		if err := r.Release(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservePorts(t *testing.T) {
+	t.Run("invalid number of ports", func(t *testing.T) {
+		_, err := ReservePorts(0)
+		require.Error(t, err)
+	})
+
+	t.Run("ports are held until released", func(t *testing.T) {
+		r, err := ReservePorts(3)
+		require.NoError(t, err)
+
+		ports := r.Ports()
+		require.Len(t, ports, 3)
+
+		seen := map[int]bool{}
+		for _, p := range ports {
+			assert.False(t, seen[p], "port %d reserved twice", p)
+			seen[p] = true
+
+			_, err := net.Listen("tcp", fmt.Sprintf(":%d", p))
+			require.Error(t, err, "port %d must be held by the reservation", p)
+		}
+
+		assert.Equal(t, fmt.Sprintf("%d:9092/tcp", ports[1]), r.PortSpec(1, "9092/tcp"))
+
+		require.NoError(t, r.Release())
+		// releasing twice must be harmless
+		require.NoError(t, r.Release())
+
+		for _, p := range ports {
+			l, err := net.Listen("tcp", fmt.Sprintf(":%d", p))
+			require.NoError(t, err, "port %d must be free once released", p)
+			require.NoError(t, l.Close())
+		}
+	})
+}
+
+func TestReservedPortsHandedOverToContainer(t *testing.T) {
+	ctx := context.Background()
+
+	// reservePorts {
+	reservation, err := ReservePorts(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostPort := reservation.Ports()[0]
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:         nginxAlpineImage,
+			ExposedPorts:  []string{reservation.PortSpec(0, nginxDefaultPort)},
+			ReservedPorts: []*PortReservation{reservation},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	port, err := nginxC.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d", hostPort), port.Port())
+}
@@ -0,0 +1,327 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+)
+
+// PortTunnelMode defines when the mapped ports of the containers are forwarded to the host running the tests,
+// for the Docker hosts whose mapped ports are not reachable from it, e.g. a remote daemon behind a firewall
+type PortTunnelMode string
+
+const (
+	// PortTunnelNever never forwards the mapped ports, the default
+	PortTunnelNever PortTunnelMode = "never"
+	// PortTunnelAuto forwards the mapped ports of a container when the Docker host is remote,
+	// and they are not reachable from the host running the tests
+	PortTunnelAuto PortTunnelMode = "auto"
+	// PortTunnelAlways always forwards the mapped ports of the containers
+	PortTunnelAlways PortTunnelMode = "always"
+)
+
+// portTunnelDialTimeout is the timeout of the connectivity check of a mapped port
+const portTunnelDialTimeout = 2 * time.Second
+
+// portTunnelHost is the host of the local listeners of the tunnels
+const portTunnelHost = "127.0.0.1"
+
+func validatePortTunnelMode(mode PortTunnelMode) error {
+	switch mode {
+	case "", PortTunnelNever, PortTunnelAuto, PortTunnelAlways:
+		return nil
+	}
+
+	return fmt.Errorf("invalid port tunnel mode %s, it must be one of %s, %s or %s", mode, PortTunnelNever, PortTunnelAuto, PortTunnelAlways)
+}
+
+// portTunnel forwards local listeners to the TCP ports of a container through the Docker API, so it only needs
+// the connection to the daemon: every accepted connection executes socat in a sidecar container sharing
+// the network namespace of the target container, and its standard streams are piped to the connection.
+type portTunnel struct {
+	sidecar   Container
+	client    execClient
+	ports     map[nat.Port]nat.Port
+	complete  bool // true if all the mapped ports of the container are forwarded, e.g. none of them is UDP
+	listeners []net.Listener
+	logger    Logging
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// execClient is the subset of the Docker API used by the tunnels
+type execClient interface {
+	ContainerExecCreate(ctx context.Context, container string, config types.ExecConfig) (types.IDResponse, error)
+	ContainerExecAttach(ctx context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error)
+}
+
+// tunneledPorts returns the TCP ports of the container mapped on the Docker host
+func tunneledPorts(inspect *types.ContainerJSON) []nat.Port {
+	if inspect.HostConfig != nil && inspect.HostConfig.NetworkMode.IsHost() {
+		return nil
+	}
+
+	ports := []nat.Port{}
+	for port, bindings := range inspect.NetworkSettings.Ports {
+		if port.Proto() == "tcp" && len(bindings) > 0 {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+// allPortsTunneled returns true if the given ports are all the ports of the container mapped on the Docker host
+func allPortsTunneled(inspect *types.ContainerJSON, ports []nat.Port) bool {
+	mapped := 0
+	for _, bindings := range inspect.NetworkSettings.Ports {
+		if len(bindings) > 0 {
+			mapped++
+		}
+	}
+
+	return mapped == len(ports)
+}
+
+// isPortReachable returns true if the given address answers from the host running the tests. A refused connection
+// is an answer of the Docker host, e.g. a service not listening yet, while a timeout means the port is filtered.
+func isPortReachable(address string, timeout time.Duration) bool {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return errors.Is(err, syscall.ECONNREFUSED)
+	}
+
+	_ = conn.Close()
+	return true
+}
+
+// needsPortTunnel returns true if the mapped ports of the container must be forwarded, depending on the mode
+func (c *DockerContainer) needsPortTunnel(ctx context.Context, mode PortTunnelMode, ports []nat.Port) (bool, error) {
+	switch mode {
+	case PortTunnelAlways:
+		return true, nil
+	case PortTunnelAuto:
+	default:
+		return false, nil
+	}
+
+	if !isRemoteDockerHost(c.provider.host) {
+		return false, nil
+	}
+
+	host, err := c.provider.DaemonHost(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, port := range ports {
+		mappedPort, err := c.MappedPort(ctx, port)
+		if err != nil {
+			return false, err
+		}
+
+		if !isPortReachable(net.JoinHostPort(host, mappedPort.Port()), portTunnelDialTimeout) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// startPortTunnel forwards the mapped ports of the container to local listeners if the port tunnel mode
+// of the configuration requires it. An existing tunnel is closed first, as a restarted container gets
+// a new network namespace.
+func (c *DockerContainer) startPortTunnel(ctx context.Context) error {
+	c.closePortTunnel(ctx)
+
+	mode := c.provider.config.PortTunnel
+	if mode == "" || mode == PortTunnelNever {
+		return nil
+	}
+
+	inspect, err := c.inspectContainer(ctx)
+	if err != nil {
+		return err
+	}
+
+	ports := tunneledPorts(inspect)
+	if len(ports) == 0 {
+		return nil
+	}
+
+	needed, err := c.needsPortTunnel(ctx, mode, ports)
+	if err != nil || !needed {
+		return err
+	}
+
+	c.logger.Printf("🚇 Forwarding the mapped ports of the container id: %s image: %s through the Docker API", c.ID[:12], c.Image)
+
+	sidecar, err := c.provider.RunContainer(ctx, ContainerRequest{
+		Image:      SocatDefaultImage,
+		Entrypoint: []string{"tail"},
+		Cmd:        []string{"-f", "/dev/null"},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + c.ID)
+		},
+	})
+	if err != nil {
+		if sidecar != nil {
+			_ = sidecar.Terminate(ctx)
+		}
+		return fmt.Errorf("%w: could not start the sidecar container of the tunnel", err)
+	}
+
+	tunnel, err := newPortTunnel(sidecar, c.provider.client, ports, c.logger)
+	if err != nil {
+		_ = sidecar.Terminate(ctx)
+		return err
+	}
+
+	tunnel.complete = allPortsTunneled(inspect, ports)
+
+	c.tunnel = tunnel
+	return nil
+}
+
+// closePortTunnel closes the tunnel of the container, if any
+func (c *DockerContainer) closePortTunnel(ctx context.Context) {
+	if c.tunnel == nil {
+		return
+	}
+
+	if err := c.tunnel.close(ctx); err != nil {
+		c.logger.Printf("%s: could not close the port tunnel of the container id: %s", err, c.ID[:12])
+	}
+	c.tunnel = nil
+}
+
+// newPortTunnel listens on a random local port for each given port, forwarding the connections
+// to the port of the container through the given sidecar
+func newPortTunnel(sidecar Container, client execClient, ports []nat.Port, logger Logging) (*portTunnel, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t := &portTunnel{
+		sidecar: sidecar,
+		client:  client,
+		ports:   make(map[nat.Port]nat.Port, len(ports)),
+		logger:  logger,
+		cancel:  cancel,
+	}
+
+	for _, port := range ports {
+		listener, err := net.Listen("tcp", net.JoinHostPort(portTunnelHost, "0"))
+		if err != nil {
+			_ = t.closeListeners()
+			return nil, fmt.Errorf("%w: could not listen to forward the port %s", err, port)
+		}
+
+		localPort, err := nat.NewPort("tcp", fmt.Sprint(listener.Addr().(*net.TCPAddr).Port))
+		if err != nil {
+			_ = listener.Close()
+			_ = t.closeListeners()
+			return nil, err
+		}
+
+		t.listeners = append(t.listeners, listener)
+		t.ports[port] = localPort
+
+		t.wg.Add(1)
+		go t.serve(ctx, listener, port)
+	}
+
+	return t, nil
+}
+
+// localPort returns the local port forwarded to the given port of the container
+func (t *portTunnel) localPort(port nat.Port) (nat.Port, bool) {
+	for p, localPort := range t.ports {
+		if p.Port() == port.Port() && (port.Proto() == "" || p.Proto() == port.Proto()) {
+			return localPort, true
+		}
+	}
+
+	return "", false
+}
+
+// serve accepts the connections of the listener until it's closed
+func (t *portTunnel) serve(ctx context.Context, listener net.Listener, port nat.Port) {
+	defer t.wg.Done()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			if err := t.forward(ctx, conn, port); err != nil {
+				t.logger.Printf("%s: could not forward a connection to the port %s", err, port)
+			}
+		}()
+	}
+}
+
+// forward pipes the connection to the port of the container, executing socat in the sidecar
+func (t *portTunnel) forward(ctx context.Context, conn net.Conn, port nat.Port) error {
+	defer conn.Close()
+
+	response, err := t.client.ContainerExecCreate(ctx, t.sidecar.GetContainerID(), types.ExecConfig{
+		Cmd:          []string{"socat", "STDIO", "TCP:localhost:" + port.Port()},
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	hijack, err := t.client.ContainerExecAttach(ctx, response.ID, types.ExecStartCheck{})
+	if err != nil {
+		return err
+	}
+	defer hijack.Close()
+
+	go func() {
+		_, _ = io.Copy(hijack.Conn, conn)
+		_ = hijack.CloseWrite()
+	}()
+
+	// the output of the execution is multiplexed, as the sidecar doesn't allocate a TTY
+	_, err = stdcopy.StdCopy(conn, io.Discard, hijack.Reader)
+	return err
+}
+
+// closeListeners closes the local listeners, stopping to accept new connections
+func (t *portTunnel) closeListeners() error {
+	var err error
+	for _, listener := range t.listeners {
+		if e := listener.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	t.cancel()
+	return err
+}
+
+// close stops the tunnel and terminates its sidecar container
+func (t *portTunnel) close(ctx context.Context) error {
+	err := t.closeListeners()
+	t.wg.Wait()
+
+	if e := t.sidecar.Terminate(ctx); e != nil {
+		return e
+	}
+
+	return err
+}
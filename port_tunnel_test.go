@@ -0,0 +1,183 @@
+package testcontainers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// echoExecClient simulates the executions of socat in the sidecar, echoing the input on the multiplexed stdout
+type echoExecClient struct {
+	cmd []string
+}
+
+func (e *echoExecClient) ContainerExecCreate(_ context.Context, _ string, config types.ExecConfig) (types.IDResponse, error) {
+	e.cmd = config.Cmd
+	return types.IDResponse{ID: "exec"}, nil
+}
+
+func (e *echoExecClient) ContainerExecAttach(_ context.Context, _ string, _ types.ExecStartCheck) (types.HijackedResponse, error) {
+	client, server := net.Pipe()
+
+	go func() {
+		defer server.Close()
+
+		line, err := bufio.NewReader(server).ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		_, _ = stdcopy.NewStdWriter(server, stdcopy.Stdout).Write([]byte("echo: " + line))
+	}()
+
+	return types.NewHijackedResponse(client, ""), nil
+}
+
+func TestValidatePortTunnelMode(t *testing.T) {
+	for _, mode := range []PortTunnelMode{"", PortTunnelNever, PortTunnelAuto, PortTunnelAlways} {
+		assert.NoError(t, validatePortTunnelMode(mode))
+	}
+
+	assert.Error(t, validatePortTunnelMode("sometimes"))
+}
+
+func TestIsPortReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+	assert.True(t, isPortReachable(address, portTunnelDialTimeout))
+
+	// a refused connection is an answer of the host
+	require.NoError(t, listener.Close())
+	assert.True(t, isPortReachable(address, portTunnelDialTimeout))
+}
+
+func TestTunneledPorts(t *testing.T) {
+	inspect := &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{HostConfig: &container.HostConfig{}},
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{
+				Ports: nat.PortMap{
+					"80/tcp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "49153"}},
+					"53/udp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "49154"}},
+					"8080/tcp": nil,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []nat.Port{"80/tcp"}, tunneledPorts(inspect))
+	assert.False(t, allPortsTunneled(inspect, tunneledPorts(inspect)), "the UDP port is not forwarded")
+
+	delete(inspect.NetworkSettings.Ports, "53/udp")
+	assert.True(t, allPortsTunneled(inspect, tunneledPorts(inspect)))
+
+	inspect.HostConfig.NetworkMode = "host"
+	assert.Empty(t, tunneledPorts(inspect))
+}
+
+func TestPortTunnelForward(t *testing.T) {
+	client := &echoExecClient{}
+
+	tunnel, err := newPortTunnel(&DockerContainer{ID: "sidecar"}, client, []nat.Port{"80/tcp"}, Logger)
+	require.NoError(t, err)
+	defer func() {
+		_ = tunnel.closeListeners()
+	}()
+
+	localPort, ok := tunnel.localPort("80")
+	require.True(t, ok)
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(portTunnelHost, localPort.Port()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintln(conn, "hello")
+	require.NoError(t, err)
+
+	response, err := io.ReadAll(conn)
+	require.NoError(t, err)
+
+	assert.Equal(t, "echo: hello\n", string(response))
+	assert.Equal(t, []string{"socat", "STDIO", "TCP:localhost:80"}, client.cmd)
+
+	_, ok = tunnel.localPort("8080/tcp")
+	assert.False(t, ok)
+}
+
+func TestPortTunnelHost(t *testing.T) {
+	ctx := context.Background()
+
+	tunnel, err := newPortTunnel(&DockerContainer{ID: "sidecar"}, &echoExecClient{}, []nat.Port{"80/tcp"}, Logger)
+	require.NoError(t, err)
+	defer func() {
+		_ = tunnel.closeListeners()
+	}()
+
+	c := &DockerContainer{
+		provider: &DockerProvider{hostCache: "10.0.0.5"},
+		tunnel:   tunnel,
+	}
+
+	// the UDP port is not forwarded, so the container is reached on the Docker host, except its forwarded ports
+	host, err := c.Host(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", host)
+
+	host, err = c.HostForPort(ctx, "80/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, portTunnelHost, host)
+
+	host, err = c.HostForPort(ctx, "53/udp")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", host)
+
+	tunnel.complete = true
+
+	host, err = c.Host(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, portTunnelHost, host)
+}
+
+func TestPortTunnel(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	provider.config.PortTunnel = PortTunnelAlways
+
+	nginxC, err := provider.RunContainer(ctx, ContainerRequest{
+		Image:        nginxAlpineImage,
+		ExposedPorts: []string{nginxDefaultPort},
+		WaitingFor:   wait.ForHTTP("/").WithPort(nginxDefaultPort),
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	host, err := nginxC.Host(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, portTunnelHost, host)
+
+	endpoint, err := nginxC.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
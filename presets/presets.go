@@ -0,0 +1,72 @@
+// Package presets provides sets of host config modifiers, encoding the kernel and resource tweaks
+// commonly needed to run certain kinds of services in containers.
+package presets
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+const (
+	// DatabaseShmSize is the amount of memory shared with the host by the database defaults (1GB)
+	DatabaseShmSize int64 = 1024 * 1024 * 1024
+	// DatabaseNoFile is the limit of open file descriptors set by the database defaults
+	DatabaseNoFile int64 = 262144
+)
+
+// DatabaseDefaults returns a host config modifier with the settings usually needed by databases:
+// a shared memory of 1GB, a limit of 262144 open file descriptors, and the OOM killer disabled.
+func DatabaseDefaults() func(*container.HostConfig) {
+	return func(hostConfig *container.HostConfig) {
+		oomKillDisable := true
+
+		hostConfig.ShmSize = DatabaseShmSize
+		hostConfig.OomKillDisable = &oomKillDisable
+		hostConfig.Ulimits = setUlimit(hostConfig.Ulimits, &units.Ulimit{
+			Name: "nofile",
+			Soft: DatabaseNoFile,
+			Hard: DatabaseNoFile,
+		})
+	}
+}
+
+// JVMDefaults returns a host config modifier with the settings usually needed by JVM based services,
+// such as Elasticsearch or Kafka: an unlimited amount of locked memory.
+func JVMDefaults() func(*container.HostConfig) {
+	return func(hostConfig *container.HostConfig) {
+		hostConfig.Ulimits = setUlimit(hostConfig.Ulimits, &units.Ulimit{
+			Name: "memlock",
+			Soft: -1,
+			Hard: -1,
+		})
+	}
+}
+
+// Combine returns a host config modifier applying all the given modifiers, in order.
+// It allows to apply more than one preset, or a preset and a custom modifier, as the HostConfigModifier of a container request:
+//
+//	req := testcontainers.ContainerRequest{
+//		Image:              "docker.io/elasticsearch:8.6.2",
+//		HostConfigModifier: presets.Combine(presets.DatabaseDefaults(), presets.JVMDefaults()),
+//	}
+func Combine(modifiers ...func(*container.HostConfig)) func(*container.HostConfig) {
+	return func(hostConfig *container.HostConfig) {
+		for _, modifier := range modifiers {
+			if modifier != nil {
+				modifier(hostConfig)
+			}
+		}
+	}
+}
+
+// setUlimit adds the given ulimit, replacing the existing one with the same name
+func setUlimit(ulimits []*units.Ulimit, ulimit *units.Ulimit) []*units.Ulimit {
+	for i, u := range ulimits {
+		if u.Name == ulimit.Name {
+			ulimits[i] = ulimit
+			return ulimits
+		}
+	}
+
+	return append(ulimits, ulimit)
+}
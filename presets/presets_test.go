@@ -0,0 +1,52 @@
+package presets
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseDefaults(t *testing.T) {
+	hostConfig := &container.HostConfig{}
+	hostConfig.Ulimits = []*units.Ulimit{
+		{Name: "nofile", Soft: 1024, Hard: 1024},
+		{Name: "nproc", Soft: 2048, Hard: 2048},
+	}
+
+	DatabaseDefaults()(hostConfig)
+
+	assert.Equal(t, DatabaseShmSize, hostConfig.ShmSize)
+	require.NotNil(t, hostConfig.OomKillDisable)
+	assert.True(t, *hostConfig.OomKillDisable)
+	assert.Equal(t, []*units.Ulimit{
+		{Name: "nofile", Soft: DatabaseNoFile, Hard: DatabaseNoFile},
+		{Name: "nproc", Soft: 2048, Hard: 2048},
+	}, hostConfig.Ulimits)
+}
+
+func TestJVMDefaults(t *testing.T) {
+	hostConfig := &container.HostConfig{}
+
+	JVMDefaults()(hostConfig)
+
+	assert.Equal(t, []*units.Ulimit{
+		{Name: "memlock", Soft: -1, Hard: -1},
+	}, hostConfig.Ulimits)
+}
+
+func TestCombine(t *testing.T) {
+	hostConfig := &container.HostConfig{}
+
+	Combine(DatabaseDefaults(), nil, JVMDefaults(), func(hc *container.HostConfig) {
+		hc.ShmSize = 512
+	})(hostConfig)
+
+	// the modifiers are applied in order
+	assert.Equal(t, int64(512), hostConfig.ShmSize)
+	assert.Len(t, hostConfig.Ulimits, 2)
+	assert.Equal(t, "nofile", hostConfig.Ulimits[0].Name)
+	assert.Equal(t, "memlock", hostConfig.Ulimits[1].Name)
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+
+	"github.com/docker/docker/client"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 // possible provider types
 const (
-	ProviderDocker ProviderType = iota // Docker is default = 0
+	ProviderDefault ProviderType = iota // default will auto-detect the provider from the Docker host
+	ProviderDocker
 	ProviderPodman
 )
 
@@ -18,8 +24,9 @@ type (
 
 	// GenericProviderOptions defines options applicable to all providers
 	GenericProviderOptions struct {
-		Logger         Logging
-		DefaultNetwork string
+		Logger              Logging
+		DefaultNetwork      string
+		DefaultWaitStrategy wait.Strategy // used by the containers whose request does not define a wait strategy
 	}
 
 	// GenericProviderOption defines a common interface to modify GenericProviderOptions
@@ -34,6 +41,10 @@ type (
 	// DockerProviderOptions defines options applicable to DockerProvider
 	DockerProviderOptions struct {
 		defaultBridgeNetworkName string
+		// dockerHost is the Docker daemon targeted by the provider, the one of the Testcontainers configuration if empty
+		dockerHost string
+		// dockerCertPath is the directory of the TLS client certificates of the Docker daemon, if it uses TLS
+		dockerCertPath string
 		*GenericProviderOptions
 	}
 
@@ -73,10 +84,70 @@ func WithDefaultBridgeNetwork(bridgeNetworkName string) DockerProviderOption {
 	})
 }
 
+// WithDockerHost targets the Docker daemon at the given host, e.g. tcp://localhost:2376, instead of the one of the
+// Testcontainers configuration, e.g. the nested daemon of a Docker-in-Docker container. The certPath is the directory
+// of the TLS client certificates of the daemon, ca.pem, cert.pem and key.pem, or empty if the daemon doesn't use TLS.
+func WithDockerHost(host string, certPath string) DockerProviderOption {
+	return DockerProviderOptionFunc(func(opts *DockerProviderOptions) {
+		opts.dockerHost = host
+		opts.dockerCertPath = certPath
+	})
+}
+
 func (f GenericProviderOptionFunc) ApplyGenericTo(opts *GenericProviderOptions) {
 	f(opts)
 }
 
+var (
+	defaultWaitStrategiesMx sync.RWMutex
+	defaultWaitStrategies   = map[ProviderType]wait.Strategy{}
+)
+
+// SetDefaultWaitStrategy sets the wait strategy used by all the containers created with the given provider type,
+// if their request does not define a wait strategy. It allows to configure, only once, a sane readiness behaviour
+// for large test suites, e.g. waiting at least for the first exposed port of every container.
+// Passing a nil strategy removes the default wait strategy of the provider type.
+func SetDefaultWaitStrategy(providerType ProviderType, strategy wait.Strategy) {
+	defaultWaitStrategiesMx.Lock()
+	defer defaultWaitStrategiesMx.Unlock()
+
+	providerType = providerType.UnderlyingProviderType()
+
+	if strategy == nil {
+		delete(defaultWaitStrategies, providerType)
+		return
+	}
+
+	defaultWaitStrategies[providerType] = strategy
+}
+
+func defaultWaitStrategy(providerType ProviderType) wait.Strategy {
+	defaultWaitStrategiesMx.RLock()
+	defer defaultWaitStrategiesMx.RUnlock()
+
+	return defaultWaitStrategies[providerType.UnderlyingProviderType()]
+}
+
+// WithDefaultWaitStrategy is a generic option that implements GenericProviderOption, DockerProviderOption
+// It sets the wait strategy used by the containers created by the provider, if their request does not define one
+func WithDefaultWaitStrategy(strategy wait.Strategy) DefaultWaitStrategyOption {
+	return DefaultWaitStrategyOption{
+		strategy: strategy,
+	}
+}
+
+type DefaultWaitStrategyOption struct {
+	strategy wait.Strategy
+}
+
+func (o DefaultWaitStrategyOption) ApplyGenericTo(opts *GenericProviderOptions) {
+	opts.DefaultWaitStrategy = o.strategy
+}
+
+func (o DefaultWaitStrategyOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.DefaultWaitStrategy = o.strategy
+}
+
 // ContainerProvider allows the creation of containers on an arbitrary system
 type ContainerProvider interface {
 	Close() error                                                                // close the provider
@@ -89,6 +160,11 @@ type ContainerProvider interface {
 
 // GetProvider provides the provider implementation for a certain type
 func (t ProviderType) GetProvider(opts ...GenericProviderOption) (GenericProvider, error) {
+	if strategy := defaultWaitStrategy(t); strategy != nil {
+		// the default wait strategy of the provider type can be overridden by the options
+		opts = append([]GenericProviderOption{WithDefaultWaitStrategy(strategy)}, opts...)
+	}
+
 	opt := &GenericProviderOptions{
 		Logger: Logger,
 	}
@@ -97,7 +173,7 @@ func (t ProviderType) GetProvider(opts ...GenericProviderOption) (GenericProvide
 		o.ApplyGenericTo(opt)
 	}
 
-	switch t {
+	switch providerType := t.UnderlyingProviderType(); providerType {
 	case ProviderDocker:
 		providerOptions := append(Generic2DockerOptions(opts...), WithDefaultBridgeNetwork(Bridge))
 		provider, err := NewDockerProvider(providerOptions...)
@@ -112,10 +188,51 @@ func (t ProviderType) GetProvider(opts ...GenericProviderOption) (GenericProvide
 			return nil, fmt.Errorf("%w, failed to create Docker provider", err)
 		}
 		return provider, nil
+	default:
+		if p, ok := registeredProviderFor(providerType); ok {
+			provider, err := p.factory(opts...)
+			if err != nil {
+				return nil, fmt.Errorf("%w, failed to create %s provider", err, p.name)
+			}
+			return provider, nil
+		}
 	}
 	return nil, errors.New("unknown provider")
 }
 
+// UnderlyingProviderType returns the provider type to be used: for the default provider type,
+// it's the provider selected in the Testcontainers configuration, if any; else Podman if the Docker host,
+// read from the DOCKER_HOST environment variable, the properties file, or auto-detected, points to a Podman socket;
+// and Docker otherwise.
+func (t ProviderType) UnderlyingProviderType() ProviderType {
+	switch t {
+	case ProviderDocker:
+		return ProviderDocker
+	case ProviderPodman:
+		return ProviderPodman
+	case ProviderDefault:
+		config := ReadConfig()
+
+		if config.ProviderType != "" {
+			providerType, err := providerTypeByName(config.ProviderType)
+			if err == nil {
+				return providerType
+			}
+			Logger.Printf("ignoring the provider type of the Testcontainers configuration: %v", err)
+		}
+
+		if testcontainersdocker.IsPodmanHost(config.Host) {
+			return ProviderPodman
+		}
+	default:
+		if _, ok := registeredProviderFor(t); ok {
+			return t
+		}
+	}
+
+	return ProviderDocker
+}
+
 // NewDockerProvider creates a Docker provider with the EnvClient
 func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error) {
 	o := &DockerProviderOptions{
@@ -128,17 +245,24 @@ func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error
 		provOpts[idx].ApplyDockerTo(o)
 	}
 
-	c, err := NewDockerClient()
+	tcConfig := ReadConfig()
+
+	var c *client.Client
+	var err error
+	if o.dockerHost != "" {
+		c, err = newDockerClientForHost(o.dockerHost, o.dockerCertPath)
+		tcConfig.Host = o.dockerHost
+	} else {
+		c, err = NewDockerClient()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	tcConfig := ReadConfig()
-
 	p := &DockerProvider{
 		DockerProviderOptions: o,
 		host:                  tcConfig.Host,
-		client:                c,
+		client:                withRetry(withRateLimit(c, sessionAPILimiter(tcConfig)), tcConfig.DockerAPIMaxRetries),
 		config:                tcConfig,
 	}
 
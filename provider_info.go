@@ -0,0 +1,130 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/versions"
+)
+
+// ProviderInfo describes the daemon of a provider, and the version of the Docker API negotiated with it
+type ProviderInfo struct {
+	// Host is the Docker host of the daemon, e.g. unix:///var/run/docker.sock
+	Host string
+	// ServerVersion is the version of the daemon, e.g. 23.0.1
+	ServerVersion string
+	// APIVersion is the version of the Docker API used to talk to the daemon, negotiated between the client and the daemon
+	APIVersion string
+	// MaxAPIVersion and MinAPIVersion are the range of the versions of the Docker API supported by the daemon
+	MaxAPIVersion string
+	MinAPIVersion string
+	// OS and Arch are the operating system and the architecture of the daemon, e.g. linux and amd64
+	OS   string
+	Arch string
+}
+
+// InfoProvider is implemented by the providers able to describe their daemon
+type InfoProvider interface {
+	ProviderInfo(ctx context.Context) (ProviderInfo, error)
+}
+
+var _ InfoProvider = (*DockerProvider)(nil)
+
+// ProviderInfo returns the description of the daemon of the provider of the given type
+func (t ProviderType) ProviderInfo(ctx context.Context) (ProviderInfo, error) {
+	provider, err := t.GetProvider()
+	if err != nil {
+		return ProviderInfo{}, err
+	}
+	defer provider.Close()
+
+	infoProvider, ok := provider.(InfoProvider)
+	if !ok {
+		return ProviderInfo{}, fmt.Errorf("the provider %T does not describe its daemon", provider)
+	}
+
+	return infoProvider.ProviderInfo(ctx)
+}
+
+// ProviderInfo returns the description of the Docker daemon, negotiating the version of the Docker API if needed
+func (p *DockerProvider) ProviderInfo(ctx context.Context) (ProviderInfo, error) {
+	version, err := p.serverVersion(ctx)
+	if err != nil {
+		return ProviderInfo{}, err
+	}
+
+	return ProviderInfo{
+		Host:          p.client.DaemonHost(),
+		ServerVersion: version.Version,
+		APIVersion:    p.apiVersion(ctx),
+		MaxAPIVersion: version.APIVersion,
+		MinAPIVersion: version.MinAPIVersion,
+		OS:            version.Os,
+		Arch:          version.Arch,
+	}, nil
+}
+
+// apiVersion returns the version of the Docker API negotiated with the daemon
+func (p *DockerProvider) apiVersion(ctx context.Context) string {
+	// the negotiation is a NOOP for the clients created with a fixed version
+	p.client.NegotiateAPIVersion(ctx)
+
+	return p.client.ClientVersion()
+}
+
+// ErrUnsupportedDaemon is returned when a request uses a feature requiring a more recent version of the Docker API
+// than the version negotiated with the daemon, instead of letting the daemon reject the request.
+type ErrUnsupportedDaemon struct {
+	// Feature is the feature used by the request, e.g. the host-gateway extra host
+	Feature string
+	// RequiredAPIVersion is the minimal version of the Docker API supporting the feature
+	RequiredAPIVersion string
+	// APIVersion is the version of the Docker API negotiated with the daemon
+	APIVersion string
+}
+
+func (e *ErrUnsupportedDaemon) Error() string {
+	return fmt.Sprintf("the Docker daemon does not support %s: it requires the API version %s, but the negotiated API version is %s",
+		e.Feature, e.RequiredAPIVersion, e.APIVersion)
+}
+
+// apiFeature is a feature of the Docker API, available from the given version
+type apiFeature struct {
+	name          string
+	minAPIVersion string
+}
+
+var (
+	// featurePlatform is the selection of the platform of the images and the containers, added in Docker 20.10
+	featurePlatform = apiFeature{name: "the platform of the images", minAPIVersion: "1.41"}
+	// featureHostGateway is the host-gateway value of the extra hosts, resolving to the IP of the host, added in Docker 20.10
+	featureHostGateway = apiFeature{name: "the host-gateway extra host", minAPIVersion: "1.41"}
+)
+
+// requireAPIVersion returns an ErrUnsupportedDaemon error if the Docker API negotiated with the daemon
+// does not support the given feature
+func (p *DockerProvider) requireAPIVersion(ctx context.Context, feature apiFeature) error {
+	apiVersion := p.apiVersion(ctx)
+	if apiVersion == "" || !versions.LessThan(apiVersion, feature.minAPIVersion) {
+		return nil
+	}
+
+	return &ErrUnsupportedDaemon{
+		Feature:            feature.name,
+		RequiredAPIVersion: feature.minAPIVersion,
+		APIVersion:         apiVersion,
+	}
+}
+
+// usesHostGateway returns true if one of the extra hosts of the container resolves to the host-gateway
+func usesHostGateway(hostConfig *container.HostConfig) bool {
+	for _, host := range hostConfig.ExtraHosts {
+		if strings.HasSuffix(host, ":host-gateway") {
+			return true
+		}
+	}
+
+	return false
+}
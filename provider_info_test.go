@@ -0,0 +1,90 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedClient negotiates the given API version with a daemon, without a Docker daemon
+type versionedClient struct {
+	client.APIClient
+	apiVersion string
+	version    types.Version
+}
+
+func (c *versionedClient) NegotiateAPIVersion(_ context.Context) {}
+
+func (c *versionedClient) ClientVersion() string {
+	return c.apiVersion
+}
+
+func (c *versionedClient) DaemonHost() string {
+	return "unix:///var/run/docker.sock"
+}
+
+func (c *versionedClient) ServerVersion(_ context.Context) (types.Version, error) {
+	return c.version, nil
+}
+
+func TestDockerProviderInfo(t *testing.T) {
+	resetRuntimeInfoCache(t)
+
+	provider := &DockerProvider{
+		client: &versionedClient{
+			apiVersion: "1.41",
+			version: types.Version{
+				Version:       "23.0.1",
+				APIVersion:    "1.42",
+				MinAPIVersion: "1.12",
+				Os:            "linux",
+				Arch:          "amd64",
+			},
+		},
+	}
+
+	info, err := provider.ProviderInfo(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, ProviderInfo{
+		Host:          "unix:///var/run/docker.sock",
+		ServerVersion: "23.0.1",
+		APIVersion:    "1.41",
+		MaxAPIVersion: "1.42",
+		MinAPIVersion: "1.12",
+		OS:            "linux",
+		Arch:          "amd64",
+	}, info)
+}
+
+func TestRequireAPIVersion(t *testing.T) {
+	t.Run("old daemon", func(t *testing.T) {
+		provider := &DockerProvider{client: &versionedClient{apiVersion: "1.40"}}
+
+		err := provider.requireAPIVersion(context.Background(), featureHostGateway)
+
+		var unsupported *ErrUnsupportedDaemon
+		require.True(t, errors.As(err, &unsupported))
+		assert.Equal(t, "1.41", unsupported.RequiredAPIVersion)
+		assert.Equal(t, "1.40", unsupported.APIVersion)
+		assert.Contains(t, err.Error(), "host-gateway")
+	})
+
+	t.Run("recent daemon", func(t *testing.T) {
+		provider := &DockerProvider{client: &versionedClient{apiVersion: "1.43"}}
+
+		assert.NoError(t, provider.requireAPIVersion(context.Background(), featurePlatform))
+	})
+}
+
+func TestUsesHostGateway(t *testing.T) {
+	assert.True(t, usesHostGateway(&container.HostConfig{ExtraHosts: []string{"host.docker.internal:host-gateway"}}))
+	assert.False(t, usesHostGateway(&container.HostConfig{ExtraHosts: []string{"host.docker.internal:10.0.0.1"}}))
+	assert.False(t, usesHostGateway(&container.HostConfig{}))
+}
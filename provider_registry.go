@@ -0,0 +1,96 @@
+package testcontainers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ProviderFactory creates a provider, receiving the generic options of the provider, e.g. its logger
+// or its default wait strategy
+type ProviderFactory func(opts ...GenericProviderOption) (GenericProvider, error)
+
+// registeredProvider is a provider registered by a third party, out of this module
+type registeredProvider struct {
+	name    string
+	factory ProviderFactory
+}
+
+var (
+	providersMx sync.RWMutex
+	// providers are the registered providers, indexed by their provider type
+	providers = map[ProviderType]registeredProvider{}
+	// nextProviderType is the provider type assigned to the next registered provider
+	nextProviderType = ProviderPodman + 1
+)
+
+// builtinProviders are the names of the providers of this module, which can't be registered again
+var builtinProviders = map[string]ProviderType{
+	"docker": ProviderDocker,
+	"podman": ProviderPodman,
+}
+
+// RegisterProvider registers a custom provider with the given name, e.g. a remote Podman or a corporate container farm,
+// returning its provider type. The provider can be used setting the returned type in the ProviderType field of the requests,
+// or selected for the requests using the default provider type with the provider.type property of the Testcontainers
+// configuration, or the TESTCONTAINERS_PROVIDER_TYPE environment variable, without code changes.
+// It's meant to be called from the init function of the package of the provider, and it panics if the name is empty,
+// if the factory is nil, or if a provider with the same name is already registered.
+func RegisterProvider(name string, factory ProviderFactory) ProviderType {
+	name = strings.ToLower(name)
+
+	if name == "" {
+		panic("testcontainers: the name of the provider cannot be empty")
+	}
+	if factory == nil {
+		panic("testcontainers: the factory of the provider " + name + " cannot be nil")
+	}
+
+	providersMx.Lock()
+	defer providersMx.Unlock()
+
+	if _, ok := builtinProviders[name]; ok {
+		panic("testcontainers: the provider " + name + " is already registered")
+	}
+	for _, p := range providers {
+		if p.name == name {
+			panic("testcontainers: the provider " + name + " is already registered")
+		}
+	}
+
+	providerType := nextProviderType
+	nextProviderType++
+
+	providers[providerType] = registeredProvider{name: name, factory: factory}
+
+	return providerType
+}
+
+// registeredProviderFor returns the registered provider of the given provider type
+func registeredProviderFor(providerType ProviderType) (registeredProvider, bool) {
+	providersMx.RLock()
+	defer providersMx.RUnlock()
+
+	p, ok := providers[providerType]
+	return p, ok
+}
+
+// providerTypeByName returns the provider type of the given name, built-in or registered
+func providerTypeByName(name string) (ProviderType, error) {
+	name = strings.ToLower(name)
+
+	if providerType, ok := builtinProviders[name]; ok {
+		return providerType, nil
+	}
+
+	providersMx.RLock()
+	defer providersMx.RUnlock()
+
+	for providerType, p := range providers {
+		if p.name == name {
+			return providerType, nil
+		}
+	}
+
+	return ProviderDefault, fmt.Errorf("unknown provider %q", name)
+}
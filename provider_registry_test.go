@@ -0,0 +1,93 @@
+package testcontainers
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// farmProvider is a custom provider, out of this module, recording the options it was created with
+type farmProvider struct {
+	GenericProvider
+	opts *GenericProviderOptions
+}
+
+func TestRegisterProvider(t *testing.T) {
+	// reset the configuration singleton, so the provider type is read again
+	resetConfig := func() {
+		tcConfigOnce = new(sync.Once)
+	}
+	resetConfig()
+	t.Cleanup(resetConfig)
+
+	// registerProvider {
+	providerFarm := RegisterProvider("farm", func(opts ...GenericProviderOption) (GenericProvider, error) {
+		o := &GenericProviderOptions{}
+		for _, opt := range opts {
+			opt.ApplyGenericTo(o)
+		}
+
+		return &farmProvider{opts: o}, nil
+	})
+	// }
+
+	t.Run("Explicit provider type", func(t *testing.T) {
+		assert.Equal(t, providerFarm, providerFarm.UnderlyingProviderType())
+
+		strategy := wait.ForLog("ready")
+		provider, err := providerFarm.GetProvider(WithDefaultWaitStrategy(strategy))
+		require.NoError(t, err)
+
+		farm, ok := provider.(*farmProvider)
+		require.True(t, ok)
+		assert.Equal(t, strategy, farm.opts.DefaultWaitStrategy)
+	})
+
+	t.Run("Default provider type selected in the configuration", func(t *testing.T) {
+		t.Cleanup(resetConfig)
+		t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+		t.Setenv("TESTCONTAINERS_PROVIDER_TYPE", "Farm")
+
+		assert.Equal(t, providerFarm, ProviderDefault.UnderlyingProviderType())
+
+		provider, err := ProviderDefault.GetProvider()
+		require.NoError(t, err)
+		assert.IsType(t, &farmProvider{}, provider)
+	})
+
+	t.Run("Built-in provider type selected in the configuration", func(t *testing.T) {
+		t.Cleanup(resetConfig)
+		t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+		t.Setenv("TESTCONTAINERS_PROVIDER_TYPE", "podman")
+
+		assert.Equal(t, ProviderPodman, ProviderDefault.UnderlyingProviderType())
+	})
+
+	t.Run("Unknown provider type selected in the configuration, which is ignored", func(t *testing.T) {
+		t.Cleanup(resetConfig)
+		t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+		t.Setenv("TESTCONTAINERS_PROVIDER_TYPE", "unknown")
+
+		assert.Equal(t, ProviderDocker, ProviderDefault.UnderlyingProviderType())
+	})
+
+	t.Run("Provider already registered", func(t *testing.T) {
+		factory := func(opts ...GenericProviderOption) (GenericProvider, error) {
+			return nil, nil
+		}
+
+		assert.Panics(t, func() { RegisterProvider("farm", factory) })
+		assert.Panics(t, func() { RegisterProvider("docker", factory) })
+	})
+
+	t.Run("Invalid provider", func(t *testing.T) {
+		assert.Panics(t, func() {
+			RegisterProvider("", func(opts ...GenericProviderOption) (GenericProvider, error) { return nil, nil })
+		})
+		assert.Panics(t, func() { RegisterProvider("nil", nil) })
+	})
+}
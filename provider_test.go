@@ -0,0 +1,110 @@
+package testcontainers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestUnderlyingProviderType(t *testing.T) {
+	// reset the configuration singleton, so the Docker host is read again
+	resetConfig := func() {
+		tcConfigOnce = new(sync.Once)
+	}
+	resetConfig()
+	t.Cleanup(resetConfig)
+
+	t.Run("Explicit provider types", func(t *testing.T) {
+		assert.Equal(t, ProviderDocker, ProviderDocker.UnderlyingProviderType())
+		assert.Equal(t, ProviderPodman, ProviderPodman.UnderlyingProviderType())
+	})
+
+	t.Run("Default provider type with a Docker host", func(t *testing.T) {
+		t.Cleanup(resetConfig)
+		t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+
+		assert.Equal(t, ProviderDocker, ProviderDefault.UnderlyingProviderType())
+	})
+
+	t.Run("Default provider type with a Podman host", func(t *testing.T) {
+		t.Cleanup(resetConfig)
+		t.Setenv("DOCKER_HOST", "unix:///run/user/1000/podman/podman.sock")
+
+		assert.Equal(t, ProviderPodman, ProviderDefault.UnderlyingProviderType())
+	})
+}
+
+func TestSetDefaultWaitStrategy(t *testing.T) {
+	t.Cleanup(func() {
+		SetDefaultWaitStrategy(ProviderDocker, nil)
+	})
+
+	assert.Nil(t, defaultWaitStrategy(ProviderDocker))
+
+	strategy := wait.ForExposedPort()
+	SetDefaultWaitStrategy(ProviderDocker, strategy)
+	assert.Equal(t, strategy, defaultWaitStrategy(ProviderDocker))
+	assert.Nil(t, defaultWaitStrategy(ProviderPodman))
+
+	SetDefaultWaitStrategy(ProviderDocker, nil)
+	assert.Nil(t, defaultWaitStrategy(ProviderDocker))
+}
+
+func TestWithDefaultWaitStrategy(t *testing.T) {
+	strategy := wait.ForLog("ready")
+
+	genericOpts := &GenericProviderOptions{}
+	WithDefaultWaitStrategy(strategy).ApplyGenericTo(genericOpts)
+	assert.Equal(t, strategy, genericOpts.DefaultWaitStrategy)
+
+	dockerOpts := &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}}
+	for _, o := range Generic2DockerOptions(WithDefaultWaitStrategy(strategy)) {
+		o.ApplyDockerTo(dockerOpts)
+	}
+	assert.Equal(t, strategy, dockerOpts.DefaultWaitStrategy)
+}
+
+func TestContainerWithDefaultWaitStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	// defaultWaitStrategy {
+	SetDefaultWaitStrategy(ProviderDocker, wait.ForExposedPort())
+	defer SetDefaultWaitStrategy(ProviderDocker, nil)
+
+	// the container waits for its first exposed port, as it does not define a wait strategy
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: ProviderDocker,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	dc, ok := nginxC.(*DockerContainer)
+	require.True(t, ok)
+	assert.IsType(t, &wait.HostPortStrategy{}, dc.WaitingFor)
+}
+
+func TestWithDockerHost(t *testing.T) {
+	opts := &DockerProviderOptions{GenericProviderOptions: &GenericProviderOptions{}}
+	WithDockerHost("tcp://localhost:2376", "/certs/client").ApplyDockerTo(opts)
+
+	assert.Equal(t, "tcp://localhost:2376", opts.dockerHost)
+	assert.Equal(t, "/certs/client", opts.dockerCertPath)
+}
+
+func TestNewDockerProviderWithUnreachableDockerHost(t *testing.T) {
+	// nothing listens on the port 1, so the daemon is not reachable
+	_, err := NewDockerProvider(WithDockerHost("tcp://127.0.0.1:1", ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tcp://127.0.0.1:1")
+}
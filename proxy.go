@@ -0,0 +1,140 @@
+package testcontainers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// validateProxyURL checks that a proxy of the configuration is an HTTP, HTTPS or SOCKS5 URL, if not empty
+func validateProxyURL(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy %s: %w", proxy, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy %s, it must be an http, https or socks5 URL", proxy)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy %s, it has no host", proxy)
+	}
+
+	return nil
+}
+
+// proxyConfig returns the proxies of the Testcontainers configuration, falling back to the proxies of the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+func proxyConfig(config TestcontainersConfig) *httpproxy.Config {
+	proxies := httpproxy.FromEnvironment()
+
+	if config.HTTPProxy != "" {
+		proxies.HTTPProxy = config.HTTPProxy
+	}
+	if config.HTTPSProxy != "" {
+		proxies.HTTPSProxy = config.HTTPSProxy
+	}
+	if config.NoProxy != "" {
+		proxies.NoProxy = config.NoProxy
+	}
+
+	return proxies
+}
+
+// withProxy is the option of the Docker clients sending the requests to the remote Docker daemons through the proxies
+// of the Testcontainers configuration. The clients of the remote daemons already use the proxies of the environment,
+// so the clients are not changed if the configuration has no proxy.
+func withProxy(config TestcontainersConfig) client.Opt {
+	return func(c *client.Client) error {
+		if config.HTTPProxy == "" && config.HTTPSProxy == "" {
+			return nil
+		}
+
+		if !isRemoteDockerHost(c.DaemonHost()) {
+			return nil
+		}
+
+		// the HTTP client is a copy sharing the transport of the client
+		transport, ok := c.HTTPClient().Transport.(*http.Transport)
+		if !ok {
+			return nil
+		}
+
+		proxyFunc := proxyConfig(config).ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+
+		return nil
+	}
+}
+
+// proxyBuildArgs adds the proxies to the build args of an image, as the build args predefined by Docker, so the RUN
+// instructions of the Dockerfile reach the network through the proxies. The build args of the request are not overridden.
+func proxyBuildArgs(config TestcontainersConfig, buildArgs map[string]*string) map[string]*string {
+	proxies := proxyConfig(config)
+
+	args := make(map[string]*string, len(buildArgs))
+	for k, v := range buildArgs {
+		args[k] = v
+	}
+
+	for name, value := range map[string]string{
+		"HTTP_PROXY":  proxies.HTTPProxy,
+		"HTTPS_PROXY": proxies.HTTPSProxy,
+		"NO_PROXY":    proxies.NoProxy,
+	} {
+		if value == "" {
+			continue
+		}
+
+		for _, arg := range []string{name, strings.ToLower(name)} {
+			if _, ok := args[arg]; !ok {
+				v := value
+				args[arg] = &v
+			}
+		}
+	}
+
+	return args
+}
+
+// pullNetworkErrors are the messages of the errors of the pulls failing to reach the registry
+var pullNetworkErrors = []string{
+	"proxyconnect",
+	"dial tcp",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"no such host",
+	"Client.Timeout exceeded",
+}
+
+// proxyPullHint returns a hint about the proxy of the Docker daemon when a pull failed to reach the registry while
+// the tests use a proxy, since the images are pulled by the daemon, which doesn't use the proxies of the tests
+func proxyPullHint(config TestcontainersConfig, err error) string {
+	proxies := proxyConfig(config)
+	if proxies.HTTPProxy == "" && proxies.HTTPSProxy == "" {
+		return ""
+	}
+
+	msg := err.Error()
+	for _, networkErr := range pullNetworkErrors {
+		if strings.Contains(msg, networkErr) {
+			return "the images are pulled by the Docker daemon, which doesn't use the proxies of the tests: configure the proxy of the Docker daemon, " +
+				"see https://docs.docker.com/config/daemon/systemd/#httphttps-proxy"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,164 @@
+package testcontainers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/go-units"
+)
+
+// defaultPullProgressLogInterval is the interval between the progress logs of the pulls of the requests
+// without their own progress handler
+const defaultPullProgressLogInterval = 10 * time.Second
+
+// PullProgress is the progress of the pull of an image, reported each time the Docker daemon reports
+// the progress of one of its layers
+type PullProgress struct {
+	Image string // the image being pulled
+	// Layers is the number of layers of the image known so far, as the daemon reports them once their download is scheduled
+	Layers int
+	// LayersDone is the number of layers downloaded and extracted, or already present
+	LayersDone int
+	// Current is the number of bytes downloaded
+	Current int64
+	// Total is the number of bytes to download, for the layers whose size is known so far
+	Total int64
+	// Done is true once the image is pulled
+	Done bool
+}
+
+// Percent returns the percentage of the bytes downloaded, 100 once the image is pulled
+func (p PullProgress) Percent() float64 {
+	if p.Done {
+		return 100
+	}
+	if p.Total == 0 {
+		return 0
+	}
+
+	return float64(p.Current) * 100 / float64(p.Total)
+}
+
+// String returns the progress in a human-readable form, e.g. "3/7 layers, 120MB/1.2GB (10%)"
+func (p PullProgress) String() string {
+	return fmt.Sprintf("%d/%d layers, %s/%s (%.0f%%)", p.LayersDone, p.Layers,
+		units.HumanSize(float64(p.Current)), units.HumanSize(float64(p.Total)), p.Percent())
+}
+
+// PullProgressLogger returns a progress handler logging the progress of the pull with the given logger,
+// at most once per interval and once the image is pulled, so the long pulls don't look like a hung test.
+// It's the progress handler of the requests without their own handler.
+func PullProgressLogger(logger Logging, interval time.Duration) func(PullProgress) {
+	var mx sync.Mutex
+	var last time.Time
+
+	return func(p PullProgress) {
+		mx.Lock()
+		defer mx.Unlock()
+
+		now := time.Now()
+		if last.IsZero() {
+			// the first report only starts the interval, so the fast pulls are not logged
+			last = now
+			return
+		}
+
+		if !p.Done && now.Sub(last) < interval {
+			return
+		}
+		last = now
+
+		if p.Done {
+			logger.Printf("🐳 Pulled image %s: %s", p.Image, p)
+			return
+		}
+		logger.Printf("🐳 Pulling image %s: %s", p.Image, p)
+	}
+}
+
+// layerProgress is the progress of the download of a layer
+type layerProgress struct {
+	current int64
+	total   int64
+	done    bool
+}
+
+// readPullProgress reads the stream of the messages of the pull of an image until its end, reporting the progress
+// to the handler. The errors of the registry, e.g. a 503 Service Unavailable while downloading a layer,
+// are only reported in the stream, so they are returned.
+func readPullProgress(image string, stream io.Reader, handler func(PullProgress)) error {
+	layers := map[string]*layerProgress{}
+	// the order of the layers, so the progress is computed in the same order every time
+	var ids []string
+
+	progress := func() PullProgress {
+		p := PullProgress{Image: image, Layers: len(ids)}
+		for _, id := range ids {
+			l := layers[id]
+			p.Current += l.current
+			p.Total += l.total
+			if l.done {
+				p.LayersDone++
+			}
+		}
+		return p
+	}
+
+	decoder := json.NewDecoder(stream)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+
+		if msg.Error != nil {
+			return msg.Error
+		}
+
+		// the messages without ID are about the image, e.g. its digest, as the first one, whose ID is the tag
+		if msg.ID == "" || handler == nil || strings.HasPrefix(msg.Status, "Pulling from") {
+			continue
+		}
+
+		l, ok := layers[msg.ID]
+		if !ok {
+			l = &layerProgress{}
+			layers[msg.ID] = l
+			ids = append(ids, msg.ID)
+		}
+
+		switch msg.Status {
+		case "Downloading":
+			if msg.Progress != nil {
+				l.current = msg.Progress.Current
+				l.total = msg.Progress.Total
+			}
+		case "Download complete", "Verifying Checksum":
+			l.current = l.total
+		case "Pull complete", "Already exists":
+			l.current = l.total
+			l.done = true
+		default:
+			// e.g. Pulling fs layer, Waiting or Extracting, which don't change the downloaded bytes
+		}
+
+		handler(progress())
+	}
+
+	if handler != nil {
+		p := progress()
+		p.Done = true
+		handler(p)
+	}
+
+	return nil
+}
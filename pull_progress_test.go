@@ -0,0 +1,124 @@
+package testcontainers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pullStream = `{"status":"Pulling from library/couchbase","id":"7.1.3"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"layer1"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"layer2"}
+{"status":"Downloading","progressDetail":{"current":100,"total":400},"id":"layer1"}
+{"status":"Downloading","progressDetail":{"current":50,"total":100},"id":"layer2"}
+{"status":"Download complete","progressDetail":{},"id":"layer2"}
+{"status":"Pull complete","progressDetail":{},"id":"layer2"}
+{"status":"Verifying Checksum","progressDetail":{},"id":"layer1"}
+{"status":"Extracting","progressDetail":{"current":400,"total":400},"id":"layer1"}
+{"status":"Pull complete","progressDetail":{},"id":"layer1"}
+{"status":"Digest: sha256:0123456789abcdef"}
+{"status":"Status: Downloaded newer image for couchbase:7.1.3"}
+`
+
+func TestReadPullProgress(t *testing.T) {
+	var reports []PullProgress
+	err := readPullProgress("couchbase:7.1.3", strings.NewReader(pullStream), func(p PullProgress) {
+		reports = append(reports, p)
+	})
+	require.NoError(t, err)
+
+	// one report per layer message, and the final report
+	require.Len(t, reports, 10)
+
+	assert.Equal(t, PullProgress{Image: "couchbase:7.1.3", Layers: 2, Current: 150, Total: 500}, reports[3])
+	assert.InDelta(t, 30, reports[3].Percent(), 0.01)
+
+	assert.Equal(t, PullProgress{Image: "couchbase:7.1.3", Layers: 2, LayersDone: 1, Current: 200, Total: 500}, reports[5])
+
+	last := reports[len(reports)-1]
+	assert.Equal(t, PullProgress{Image: "couchbase:7.1.3", Layers: 2, LayersDone: 2, Current: 500, Total: 500, Done: true}, last)
+	assert.Equal(t, float64(100), last.Percent())
+	assert.Equal(t, "2/2 layers, 500B/500B (100%)", last.String())
+}
+
+func TestReadPullProgressWithoutHandler(t *testing.T) {
+	require.NoError(t, readPullProgress("couchbase:7.1.3", strings.NewReader(pullStream), nil))
+}
+
+func TestReadPullProgressWithError(t *testing.T) {
+	stream := `{"status":"Pulling fs layer","progressDetail":{},"id":"layer1"}
+{"errorDetail":{"message":"received unexpected HTTP status: 503 Service Unavailable"},"error":"received unexpected HTTP status: 503 Service Unavailable"}
+`
+
+	err := readPullProgress("couchbase:7.1.3", strings.NewReader(stream), func(PullProgress) {})
+	require.EqualError(t, err, "received unexpected HTTP status: 503 Service Unavailable")
+}
+
+func TestPullProgressPercent(t *testing.T) {
+	assert.Equal(t, float64(0), PullProgress{}.Percent())
+	assert.Equal(t, float64(25), PullProgress{Current: 25, Total: 100}.Percent())
+	assert.Equal(t, float64(100), PullProgress{Current: 25, Total: 100, Done: true}.Percent())
+}
+
+func TestPullProgressLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := PullProgressLogger(logger, 50*time.Millisecond)
+
+	progress := PullProgress{Image: "couchbase:7.1.3", Layers: 2, Current: 100, Total: 400}
+
+	// the first report starts the interval, and the next ones are throttled
+	handler(progress)
+	handler(progress)
+	assert.Empty(t, logger.messages)
+
+	time.Sleep(60 * time.Millisecond)
+	handler(progress)
+	handler(progress)
+	require.Len(t, logger.messages, 1)
+	assert.Equal(t, "🐳 Pulling image couchbase:7.1.3: 0/2 layers, 100B/400B (25%)", logger.messages[0])
+
+	progress.Done = true
+	handler(progress)
+	require.Len(t, logger.messages, 2)
+	assert.Equal(t, "🐳 Pulled image couchbase:7.1.3: 0/2 layers, 100B/400B (100%)", logger.messages[1])
+}
+
+func TestImagePullProgress(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	// the image must be pulled to report its progress
+	_, _ = provider.client.ImageRemove(ctx, "docker.io/alpine:3.16", types.ImageRemoveOptions{Force: true})
+
+	var mx sync.Mutex
+	var last PullProgress
+	// imagePullProgress {
+	req := ContainerRequest{
+		Image: "docker.io/alpine:3.16",
+		ImagePullProgress: func(p PullProgress) {
+			mx.Lock()
+			defer mx.Unlock()
+			last = p
+		},
+	}
+	// }
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{ContainerRequest: req})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	mx.Lock()
+	defer mx.Unlock()
+	assert.True(t, last.Done)
+	assert.Equal(t, "docker.io/alpine:3.16", last.Image)
+	assert.Positive(t, last.Layers)
+}
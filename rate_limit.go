@@ -0,0 +1,75 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"golang.org/x/time/rate"
+)
+
+var (
+	// dockerAPILimiter is shared by all the providers of the session, so the limit applies to the whole test process
+	dockerAPILimiter     *rate.Limiter
+	dockerAPILimiterOnce sync.Once
+)
+
+// Implement interface
+var _ client.APIClient = (*rateLimitedClient)(nil)
+
+// rateLimitedClient is a Docker client throttling the calls polled by the wait strategies and the log producers,
+// i.e. the inspections and the logs of the containers, so constrained daemons are not overwhelmed
+// by many strategies polling at the same time. The other calls are not throttled.
+type rateLimitedClient struct {
+	client.APIClient
+	limiter *rate.Limiter
+}
+
+// sessionAPILimiter returns the limiter of the session, created from the first configuration read,
+// or nil if the rate of the Docker API calls is not limited
+func sessionAPILimiter(config TestcontainersConfig) *rate.Limiter {
+	dockerAPILimiterOnce.Do(func() {
+		if config.DockerAPIRateLimit <= 0 {
+			return
+		}
+
+		burst := config.DockerAPIRateBurst
+		if burst <= 0 {
+			burst = int(math.Ceil(config.DockerAPIRateLimit))
+		}
+
+		dockerAPILimiter = rate.NewLimiter(rate.Limit(config.DockerAPIRateLimit), burst)
+	})
+
+	return dockerAPILimiter
+}
+
+// withRateLimit wraps the client with the given limiter, returning the client as is if there is no limiter
+func withRateLimit(c client.APIClient, limiter *rate.Limiter) client.APIClient {
+	if limiter == nil {
+		return c
+	}
+
+	return &rateLimitedClient{APIClient: c, limiter: limiter}
+}
+
+// ContainerInspect waits for the limiter before inspecting the container
+func (c *rateLimitedClient) ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return types.ContainerJSON{}, err
+	}
+
+	return c.APIClient.ContainerInspect(ctx, container)
+}
+
+// ContainerLogs waits for the limiter before reading the logs of the container
+func (c *rateLimitedClient) ContainerLogs(ctx context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.APIClient.ContainerLogs(ctx, container, options)
+}
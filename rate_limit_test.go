@@ -0,0 +1,63 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// inspectCountingClient counts the inspections of the containers, without a Docker daemon
+type inspectCountingClient struct {
+	client.APIClient
+	inspections int
+}
+
+func (c *inspectCountingClient) ContainerInspect(_ context.Context, id string) (types.ContainerJSON, error) {
+	c.inspections++
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: id}}, nil
+}
+
+func TestRateLimitedClient(t *testing.T) {
+	t.Run("without limiter the client is not wrapped", func(t *testing.T) {
+		c := &inspectCountingClient{}
+
+		assert.Same(t, c, withRateLimit(c, nil))
+	})
+
+	t.Run("the inspections wait for the limiter", func(t *testing.T) {
+		c := &inspectCountingClient{}
+		limited := withRateLimit(c, rate.NewLimiter(rate.Limit(20), 1))
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			_, err := limited.ContainerInspect(context.Background(), "id")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 5, c.inspections)
+		// the first call uses the burst, the other ones wait 50ms each
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+
+	t.Run("the context cancels the wait", func(t *testing.T) {
+		c := &inspectCountingClient{}
+		limiter := rate.NewLimiter(rate.Limit(0.1), 1)
+		limited := withRateLimit(c, limiter)
+
+		_, err := limited.ContainerInspect(context.Background(), "id")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = limited.ContainerInspect(ctx, "id")
+		require.Error(t, err)
+		assert.Equal(t, 1, c.inspections)
+	})
+}
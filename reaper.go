@@ -12,7 +12,6 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 
-	"github.com/testcontainers/testcontainers-go/internal"
 	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -112,7 +111,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider, o
 
 	// include reaper-specific labels to the reaper container
 	for k, v := range reaper.Labels() {
-		if k == TestcontainerLabelSessionID || k == testcontainersdocker.LabelSessionID {
+		if k == TestcontainerLabelSessionID || k == testcontainersdocker.LabelSessionID || k == testcontainersdocker.LabelReap {
 			continue
 		}
 		req.Labels[k] = v
@@ -194,15 +193,15 @@ func (r *Reaper) Connect() (chan bool, error) {
 	return terminationSignal, nil
 }
 
-// Labels returns the container labels to use so that this Reaper cleans them up
+// Labels returns the container labels to use so that this Reaper cleans them up.
+// The resources without the testcontainersdocker.LabelReap label are not removed, even if they belong to the session.
 func (r *Reaper) Labels() map[string]string {
-	return map[string]string{
-		TestcontainerLabel:                  "true",
-		TestcontainerLabelSessionID:         r.SessionID,
-		testcontainersdocker.LabelLang:      "go",
-		testcontainersdocker.LabelVersion:   internal.Version,
-		testcontainersdocker.LabelSessionID: r.SessionID,
-	}
+	labels := testcontainersdocker.DefaultLabels(r.SessionID)
+	labels[TestcontainerLabel] = "true"
+	labels[TestcontainerLabelSessionID] = r.SessionID
+	labels[testcontainersdocker.LabelReap] = "true"
+
+	return labels
 }
 
 func reaperImage(reaperImageName string) string {
@@ -34,8 +34,8 @@ func (m *mockReaperProvider) RunContainer(ctx context.Context, req ContainerRequ
 	}
 	req.HostConfigModifier(m.hostConfig)
 
-	if req.EnpointSettingsModifier != nil {
-		req.EnpointSettingsModifier(m.enpointSettings)
+	if req.EndpointSettingsModifier != nil {
+		req.EndpointSettingsModifier(m.enpointSettings)
 	}
 
 	// we're only interested in the request, so instead of mocking the Docker client
@@ -54,6 +54,7 @@ func createContainerRequest(customize func(ContainerRequest) ContainerRequest) C
 		ReaperImage:  "reaperImage",
 		ExposedPorts: []string{"8080/tcp"},
 		Labels: map[string]string{
+			testcontainersdocker.LabelBase:    "true",
 			TestcontainerLabel:                "true",
 			TestcontainerLabelIsReaper:        "true",
 			testcontainersdocker.LabelReaper:  "true",
@@ -0,0 +1,125 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// retryInitialInterval is the time waited before the first retry of a call to the Docker API, doubled for the next ones
+const retryInitialInterval = 100 * time.Millisecond
+
+// Implement interface
+var _ client.APIClient = (*retryClient)(nil)
+
+// retryClient is a Docker client retrying the calls failing with a transient error of the daemon, e.g. an unexpected EOF
+// or a connection reset, common on loaded CI machines. Only the idempotent calls polled by the wait strategies are retried:
+// the inspections and the logs of the containers, and the creation and the inspection of the executions.
+type retryClient struct {
+	client.APIClient
+	maxRetries uint64
+}
+
+// withRetry wraps the client retrying the transient errors up to the given number of times,
+// returning the client as is if the calls are not retried
+func withRetry(c client.APIClient, maxRetries int) client.APIClient {
+	if maxRetries <= 0 {
+		return c
+	}
+
+	return &retryClient{APIClient: c, maxRetries: uint64(maxRetries)}
+}
+
+// isTransientError returns true if the error is caused by the connection to the daemon, and not by the call itself
+func isTransientError(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		client.IsErrConnectionFailed(err)
+}
+
+// retry calls the operation until it succeeds, fails with a non transient error, or the retries are exhausted,
+// in which case the number of retries is added to the last error
+func (c *retryClient) retry(ctx context.Context, operation func() error) error {
+	retries := 0
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = retryInitialInterval
+
+	err := backoff.RetryNotify(func() error {
+		err := operation()
+		if err != nil && !isTransientError(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(backoff.WithMaxRetries(b, c.maxRetries), ctx), func(err error, _ time.Duration) {
+		retries++
+	})
+
+	if err != nil && retries > 0 {
+		return fmt.Errorf("%w: the call to the Docker API failed after %d retries", err, retries)
+	}
+
+	return err
+}
+
+// ContainerInspect retries the inspection of the container on transient errors
+func (c *retryClient) ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error) {
+	var response types.ContainerJSON
+
+	err := c.retry(ctx, func() error {
+		var err error
+		response, err = c.APIClient.ContainerInspect(ctx, container)
+		return err
+	})
+
+	return response, err
+}
+
+// ContainerLogs retries the request of the logs of the container on transient errors.
+// The errors reading the returned logs are not retried.
+func (c *retryClient) ContainerLogs(ctx context.Context, container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	var logs io.ReadCloser
+
+	err := c.retry(ctx, func() error {
+		var err error
+		logs, err = c.APIClient.ContainerLogs(ctx, container, options)
+		return err
+	})
+
+	return logs, err
+}
+
+// ContainerExecCreate retries the creation of the execution on transient errors, as it's not started until it's attached
+func (c *retryClient) ContainerExecCreate(ctx context.Context, container string, config types.ExecConfig) (types.IDResponse, error) {
+	var response types.IDResponse
+
+	err := c.retry(ctx, func() error {
+		var err error
+		response, err = c.APIClient.ContainerExecCreate(ctx, container, config)
+		return err
+	})
+
+	return response, err
+}
+
+// ContainerExecInspect retries the inspection of the execution on transient errors
+func (c *retryClient) ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error) {
+	var response types.ContainerExecInspect
+
+	err := c.retry(ctx, func() error {
+		var err error
+		response, err = c.APIClient.ContainerExecInspect(ctx, execID)
+		return err
+	})
+
+	return response, err
+}
@@ -0,0 +1,83 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyClient fails the inspections of the containers with the given errors, before succeeding
+type flakyClient struct {
+	client.APIClient
+	errs        []error
+	inspections int
+}
+
+func (c *flakyClient) ContainerInspect(_ context.Context, id string) (types.ContainerJSON, error) {
+	defer func() { c.inspections++ }()
+
+	if c.inspections < len(c.errs) {
+		return types.ContainerJSON{}, c.errs[c.inspections]
+	}
+
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: id}}, nil
+}
+
+func TestRetryClient(t *testing.T) {
+	t.Run("without retries the client is not wrapped", func(t *testing.T) {
+		c := &flakyClient{}
+
+		assert.Same(t, c, withRetry(c, 0))
+	})
+
+	t.Run("the transient errors are retried", func(t *testing.T) {
+		c := &flakyClient{errs: []error{io.ErrUnexpectedEOF, syscall.ECONNRESET}}
+
+		response, err := withRetry(c, 3).ContainerInspect(context.Background(), "id")
+		require.NoError(t, err)
+
+		assert.Equal(t, "id", response.ID)
+		assert.Equal(t, 3, c.inspections)
+	})
+
+	t.Run("the retries are reported when exhausted", func(t *testing.T) {
+		c := &flakyClient{errs: []error{io.EOF, io.EOF, io.EOF}}
+
+		_, err := withRetry(c, 2).ContainerInspect(context.Background(), "id")
+		require.ErrorIs(t, err, io.EOF)
+
+		assert.Contains(t, err.Error(), "failed after 2 retries")
+		assert.Equal(t, 3, c.inspections)
+	})
+
+	t.Run("the other errors are not retried", func(t *testing.T) {
+		notFound := errdefs.NotFound(errors.New("no such container"))
+		c := &flakyClient{errs: []error{notFound}}
+
+		_, err := withRetry(c, 3).ContainerInspect(context.Background(), "id")
+		require.True(t, errdefs.IsNotFound(err))
+
+		assert.NotContains(t, err.Error(), "retries")
+		assert.Equal(t, 1, c.inspections)
+	})
+
+	t.Run("the context cancels the retries", func(t *testing.T) {
+		c := &flakyClient{errs: []error{io.EOF, io.EOF, io.EOF}}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := withRetry(c, 3).ContainerInspect(ctx, "id")
+		require.Error(t, err)
+
+		assert.Equal(t, 1, c.inspections)
+	})
+}
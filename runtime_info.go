@@ -0,0 +1,170 @@
+package testcontainers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// ContainerRuntimeInfo describes the container runtime of a Docker host, as it's cached for the session
+type ContainerRuntimeInfo struct {
+	ProviderInfo
+	// DaemonHost is the host where the ports of the containers are exposed, e.g. localhost
+	DaemonHost string
+	// Info is the information reported by the daemon, as with docker info
+	Info types.Info
+}
+
+// runtimeInfoCache caches for the session the information about the container runtimes, by Docker host,
+// so it's not queried again for every container and every poll of a wait strategy
+var runtimeInfoCache = &runtimeCache{entries: map[string]*runtimeCacheEntry{}}
+
+// runtimeCacheEntry is the information cached about the container runtime of a Docker host.
+// Only the successful queries are cached, so a daemon starting after the first query is not considered unreachable.
+type runtimeCacheEntry struct {
+	apiVersion string // the version of the Docker API negotiated with the daemon
+	daemonHost string // the host where the ports are exposed, inferred from the Docker host
+	info       *types.Info
+	version    *types.Version
+}
+
+type runtimeCache struct {
+	mx      sync.Mutex
+	entries map[string]*runtimeCacheEntry
+}
+
+// get returns a copy of the entry of the Docker host, empty if the host is not cached
+func (c *runtimeCache) get(dockerHost string) runtimeCacheEntry {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if e, ok := c.entries[dockerHost]; ok {
+		return *e
+	}
+
+	return runtimeCacheEntry{}
+}
+
+// update updates the entry of the Docker host
+func (c *runtimeCache) update(dockerHost string, fn func(*runtimeCacheEntry)) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	e, ok := c.entries[dockerHost]
+	if !ok {
+		e = &runtimeCacheEntry{}
+		c.entries[dockerHost] = e
+	}
+
+	fn(e)
+}
+
+// reset removes all the entries, e.g. in the tests switching the Docker host
+func (c *runtimeCache) reset() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.entries = map[string]*runtimeCacheEntry{}
+}
+
+// GetContainerRuntimeInfo returns the description of the container runtime of the default Docker provider,
+// e.g. to log it when diagnosing a failing test
+func GetContainerRuntimeInfo(ctx context.Context) (ContainerRuntimeInfo, error) {
+	p, err := NewDockerProvider()
+	if err != nil {
+		return ContainerRuntimeInfo{}, err
+	}
+	defer p.Close()
+
+	return p.ContainerRuntimeInfo(ctx)
+}
+
+// ContainerRuntimeInfo returns the description of the container runtime of the provider. The daemon is only
+// queried the first time in the session, the next calls returning the cached information.
+func (p *DockerProvider) ContainerRuntimeInfo(ctx context.Context) (ContainerRuntimeInfo, error) {
+	providerInfo, err := p.ProviderInfo(ctx)
+	if err != nil {
+		return ContainerRuntimeInfo{}, err
+	}
+
+	info, err := p.daemonInfo(ctx)
+	if err != nil {
+		return ContainerRuntimeInfo{}, err
+	}
+
+	host, err := p.DaemonHost(ctx)
+	if err != nil {
+		return ContainerRuntimeInfo{}, err
+	}
+
+	return ContainerRuntimeInfo{
+		ProviderInfo: providerInfo,
+		DaemonHost:   host,
+		Info:         info,
+	}, nil
+}
+
+// daemonInfo returns the information reported by the daemon, cached for the session
+func (p *DockerProvider) daemonInfo(ctx context.Context) (types.Info, error) {
+	dockerHost := p.client.DaemonHost()
+	if cached := runtimeInfoCache.get(dockerHost); cached.info != nil {
+		return *cached.info, nil
+	}
+
+	info, err := p.client.Info(ctx)
+	if err != nil {
+		return types.Info{}, err
+	}
+
+	runtimeInfoCache.update(dockerHost, func(e *runtimeCacheEntry) {
+		e.info = &info
+	})
+
+	return info, nil
+}
+
+// serverVersion returns the version of the daemon, cached for the session
+func (p *DockerProvider) serverVersion(ctx context.Context) (types.Version, error) {
+	dockerHost := p.client.DaemonHost()
+	if cached := runtimeInfoCache.get(dockerHost); cached.version != nil {
+		return *cached.version, nil
+	}
+
+	version, err := p.client.ServerVersion(ctx)
+	if err != nil {
+		return types.Version{}, err
+	}
+
+	runtimeInfoCache.update(dockerHost, func(e *runtimeCacheEntry) {
+		e.version = &version
+	})
+
+	return version, nil
+}
+
+// useNegotiatedAPIVersion sets the version of the Docker API of the client to the version already negotiated
+// with its daemon in the session, if any, so the client doesn't ping the daemon to negotiate it again
+func useNegotiatedAPIVersion(cli *client.Client) (bool, error) {
+	apiVersion := runtimeInfoCache.get(cli.DaemonHost()).apiVersion
+	if apiVersion == "" {
+		return false, nil
+	}
+
+	if err := client.WithVersion(apiVersion)(cli); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// cacheNegotiatedAPIVersion negotiates the version of the Docker API of the client with the response of the ping
+// of its daemon, and caches it for the session
+func cacheNegotiatedAPIVersion(cli *client.Client, ping types.Ping) {
+	cli.NegotiateAPIVersionPing(ping)
+
+	runtimeInfoCache.update(cli.DaemonHost(), func(e *runtimeCacheEntry) {
+		e.apiVersion = cli.ClientVersion()
+	})
+}
@@ -0,0 +1,133 @@
+package testcontainers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetRuntimeInfoCache resets the cache of the container runtimes before and after the test,
+// so the tests with clients not backed by a Docker daemon don't share their information
+func resetRuntimeInfoCache(tb testing.TB) {
+	tb.Helper()
+
+	runtimeInfoCache.reset()
+	tb.Cleanup(runtimeInfoCache.reset)
+}
+
+// countingClient counts the queries of the information of the daemon, without a Docker daemon
+type countingClient struct {
+	client.APIClient
+	infoCalls    int
+	versionCalls int
+}
+
+func (c *countingClient) DaemonHost() string {
+	return "tcp://docker.example.com:2376"
+}
+
+func (c *countingClient) ClientVersion() string {
+	return "1.41"
+}
+
+func (c *countingClient) NegotiateAPIVersion(_ context.Context) {}
+
+func (c *countingClient) Info(_ context.Context) (types.Info, error) {
+	c.infoCalls++
+	return types.Info{ServerVersion: "23.0.1", OperatingSystem: "Docker Desktop", NCPU: 4}, nil
+}
+
+func (c *countingClient) ServerVersion(_ context.Context) (types.Version, error) {
+	c.versionCalls++
+	return types.Version{Version: "23.0.1", APIVersion: "1.42", MinAPIVersion: "1.12", Os: "linux", Arch: "arm64"}, nil
+}
+
+func (c *countingClient) Close() error {
+	return nil
+}
+
+func TestContainerRuntimeInfoIsCached(t *testing.T) {
+	resetRuntimeInfoCache(t)
+	if _, ok := os.LookupEnv("TC_HOST"); ok {
+		t.Skip("the daemon host is not inferred from the Docker host when TC_HOST is set")
+	}
+
+	cli := &countingClient{}
+	newProvider := func() *DockerProvider {
+		return &DockerProvider{client: cli}
+	}
+
+	info, err := newProvider().ContainerRuntimeInfo(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "tcp://docker.example.com:2376", info.Host)
+	assert.Equal(t, "docker.example.com", info.DaemonHost)
+	assert.Equal(t, "23.0.1", info.ServerVersion)
+	assert.Equal(t, "1.41", info.APIVersion)
+	assert.Equal(t, "arm64", info.Arch)
+	assert.Equal(t, 4, info.Info.NCPU)
+
+	// the other providers of the session reuse the information
+	again, err := newProvider().ContainerRuntimeInfo(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, info, again)
+	assert.Equal(t, 1, cli.infoCalls)
+	assert.Equal(t, 1, cli.versionCalls)
+
+	// after a reset, the daemon is queried again
+	runtimeInfoCache.reset()
+	_, err = newProvider().ContainerRuntimeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, cli.infoCalls)
+}
+
+func TestNegotiatedAPIVersionIsReused(t *testing.T) {
+	resetRuntimeInfoCache(t)
+
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://docker.example.com:2376"), client.WithAPIVersionNegotiation())
+	require.NoError(t, err)
+
+	reused, err := useNegotiatedAPIVersion(cli)
+	require.NoError(t, err)
+	assert.False(t, reused)
+
+	cacheNegotiatedAPIVersion(cli, types.Ping{APIVersion: "1.40"})
+	assert.Equal(t, "1.40", cli.ClientVersion())
+
+	other, err := client.NewClientWithOpts(client.WithHost("tcp://docker.example.com:2376"), client.WithAPIVersionNegotiation())
+	require.NoError(t, err)
+
+	reused, err = useNegotiatedAPIVersion(other)
+	require.NoError(t, err)
+	assert.True(t, reused)
+	assert.Equal(t, "1.40", other.ClientVersion())
+
+	// the clients of the other daemons negotiate their own version
+	remote, err := client.NewClientWithOpts(client.WithHost("tcp://other.example.com:2376"), client.WithAPIVersionNegotiation())
+	require.NoError(t, err)
+
+	reused, err = useNegotiatedAPIVersion(remote)
+	require.NoError(t, err)
+	assert.False(t, reused)
+}
+
+func TestGetContainerRuntimeInfo(t *testing.T) {
+	// containerRuntimeInfo {
+	info, err := GetContainerRuntimeInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("Docker %s (API %s) on %s, %d CPUs, ports exposed on %s",
+		info.ServerVersion, info.APIVersion, info.Info.OperatingSystem, info.Info.NCPU, info.DaemonHost)
+	// }
+
+	assert.NotEmpty(t, info.ServerVersion)
+	assert.NotEmpty(t, info.DaemonHost)
+}
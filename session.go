@@ -0,0 +1,10 @@
+package testcontainers
+
+import "github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+
+// SessionID returns the identifier of the current test session, which is shared by all the resources created
+// by the test process. It's added as the org.testcontainers.sessionId label to all the containers, networks
+// and volumes, so they can be attributed to a test session, e.g. by the garbage collector or by ops teams in CI.
+func SessionID() string {
+	return testcontainerssession.String()
+}
@@ -0,0 +1,119 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// sharedNetworks holds the number of references to each shared network held by the current process
+var sharedNetworks = struct {
+	sync.Mutex
+	refs map[string]int
+}{
+	refs: map[string]int{},
+}
+
+// sharedNetworkRef is a reference to a shared network, which is released when it's removed
+type sharedNetworkRef struct {
+	name     string
+	provider *DockerProvider
+	once     sync.Once
+}
+
+// SharedNetwork returns a reference to the Docker network with the given name, creating it if it does not exist yet.
+// It allows containers of different tests, running in parallel or in different test packages, to be attached to a common
+// network, using its name in the Networks field of their requests. Every reference must be released calling the Remove
+// method of the returned network: the network is removed when the last reference of the process is released, unless
+// containers of other processes are still attached to it, in which case the last of those processes will remove it.
+func SharedNetwork(ctx context.Context, name string) (Network, error) {
+	if name == "" {
+		return nil, errors.New("the name of the shared network cannot be empty")
+	}
+
+	provider, err := ProviderDefault.GetProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := provider.(*DockerProvider)
+	if !ok {
+		return nil, errors.New("shared networks are only supported by the Docker provider")
+	}
+
+	sharedNetworks.Lock()
+	defer sharedNetworks.Unlock()
+
+	if sharedNetworks.refs[name] == 0 {
+		if err := ensureSharedNetwork(ctx, p, name); err != nil {
+			return nil, err
+		}
+	}
+
+	sharedNetworks.refs[name]++
+
+	return &sharedNetworkRef{name: name, provider: p}, nil
+}
+
+// ensureSharedNetwork creates the network with the given name, unless it was already created by another process
+func ensureSharedNetwork(ctx context.Context, p *DockerProvider, name string) error {
+	_, err := p.GetNetwork(ctx, NetworkRequest{Name: name})
+	if err == nil {
+		return nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return fmt.Errorf("%w: could not inspect the shared network %s", err, name)
+	}
+
+	_, err = p.CreateNetwork(ctx, NetworkRequest{
+		Name:           name,
+		CheckDuplicate: true,
+		Attachable:     true,
+	})
+	// another process could have created it in the meantime
+	if err != nil && !errdefs.IsConflict(err) {
+		return fmt.Errorf("%w: could not create the shared network %s", err, name)
+	}
+
+	return nil
+}
+
+// Remove releases the reference to the shared network, removing the network if it was the last reference of the process.
+// It's safe to call it more than once.
+func (r *sharedNetworkRef) Remove(ctx context.Context) error {
+	var err error
+	r.once.Do(func() {
+		err = r.release(ctx)
+	})
+
+	return err
+}
+
+func (r *sharedNetworkRef) release(ctx context.Context) error {
+	sharedNetworks.Lock()
+	defer sharedNetworks.Unlock()
+
+	sharedNetworks.refs[r.name]--
+	if sharedNetworks.refs[r.name] > 0 {
+		return nil
+	}
+	delete(sharedNetworks.refs, r.name)
+
+	err := r.provider.client.NetworkRemove(ctx, r.name)
+	if err == nil || errdefs.IsNotFound(err) || isNetworkInUseError(err) {
+		// the network was removed by another process, or it's still in use by containers of another process
+		return nil
+	}
+
+	return fmt.Errorf("%w: could not remove the shared network %s", err, r.name)
+}
+
+// isNetworkInUseError returns true if the error was returned by the Docker daemon
+// because there are containers attached to the network
+func isNetworkInUseError(err error) bool {
+	return strings.Contains(err.Error(), "has active endpoints")
+}
@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := ProviderDefault.GetProvider()
+	require.NoError(t, err)
+
+	// sharedNetwork {
+	network, err := SharedNetwork(ctx, "shared-network")
+	require.NoError(t, err)
+	// the network is removed when the last reference of the process is released
+	defer func() {
+		require.NoError(t, network.Remove(ctx))
+	}()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:    nginxAlpineImage,
+			Networks: []string{"shared-network"},
+		},
+		Started: true,
+	})
+	// }
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	// a second reference, e.g. from a parallel test, does not create a new network
+	other, err := SharedNetwork(ctx, "shared-network")
+	require.NoError(t, err)
+
+	// releasing it keeps the network, as the first reference is still held
+	require.NoError(t, other.Remove(ctx))
+	require.NoError(t, other.Remove(ctx))
+
+	_, err = provider.GetNetwork(ctx, NetworkRequest{Name: "shared-network"})
+	require.NoError(t, err)
+
+	networks, err := nginxC.Networks(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, networks, "shared-network")
+}
+
+func TestSharedNetworkIsRemovedWithTheLastReference(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := ProviderDefault.GetProvider()
+	require.NoError(t, err)
+
+	first, err := SharedNetwork(ctx, "shared-network-refs")
+	require.NoError(t, err)
+
+	second, err := SharedNetwork(ctx, "shared-network-refs")
+	require.NoError(t, err)
+
+	require.NoError(t, first.Remove(ctx))
+
+	_, err = provider.GetNetwork(ctx, NetworkRequest{Name: "shared-network-refs"})
+	require.NoError(t, err)
+
+	require.NoError(t, second.Remove(ctx))
+
+	_, err = provider.GetNetwork(ctx, NetworkRequest{Name: "shared-network-refs"})
+	require.True(t, errdefs.IsNotFound(err), "the network should have been removed: %v", err)
+}
+
+func TestSharedNetworkWithoutName(t *testing.T) {
+	_, err := SharedNetwork(context.Background(), "")
+	require.Error(t, err)
+}
+
+func TestIsNetworkInUseError(t *testing.T) {
+	assert.True(t, isNetworkInUseError(errors.New("Error response from daemon: error while removing network: network shared-network id 1234 has active endpoints")))
+	assert.False(t, isNetworkInUseError(errors.New("Error response from daemon: network shared-network not found")))
+}
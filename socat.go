@@ -0,0 +1,156 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// SocatDefaultImage is the image used by the sidecar container forwarding ports to an already running container
+const SocatDefaultImage = "docker.io/alpine/socat:1.7.4.4"
+
+// SocatContainer represents a sidecar container that publishes ports of a target container
+// which were not exposed when the target was created
+type SocatContainer struct {
+	Container
+	target Container
+	ports  []nat.Port
+}
+
+// Target returns the container whose ports are forwarded by the sidecar
+func (s *SocatContainer) Target() Container {
+	return s.target
+}
+
+// ForwardedPorts returns the ports of the target container forwarded by the sidecar
+func (s *SocatContainer) ForwardedPorts() []nat.Port {
+	return s.ports
+}
+
+// ExposePorts publishes ports of a running container, without recreating it. It's useful for services
+// that open ports only after runtime configuration, e.g. a service enabled in the middle of a test.
+// A socat sidecar container, attached to the first network of the target container by name, will listen on the
+// given ports and forward the traffic to the target container. The returned SocatContainer must be used
+// to get the mapped ports, as in:
+//
+//	sidecar, err := ExposePorts(ctx, container, "8095/tcp")
+//	port, err := sidecar.MappedPort(ctx, "8095/tcp")
+//
+// It's a responsibility of the caller to terminate the sidecar container.
+func ExposePorts(ctx context.Context, target Container, ports ...string) (*SocatContainer, error) {
+	if len(ports) == 0 {
+		return nil, errors.New("at least one port must be exposed")
+	}
+
+	networks, err := target.Networks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get networks of the target container", err)
+	}
+	if len(networks) == 0 {
+		return nil, errors.New("the target container is not attached to any network")
+	}
+
+	// the networks are not ordered, so the sidecar joins the first one by name, reaching the target in that network
+	sort.Strings(networks)
+	network := networks[0]
+
+	targetAddress, err := networkAddress(ctx, target, network)
+	if err != nil {
+		return nil, err
+	}
+
+	exposedPorts := make([]nat.Port, 0, len(ports))
+	commands := make([]string, 0, len(ports))
+	strategies := make([]wait.Strategy, 0, len(ports))
+
+	for _, p := range ports {
+		port, err := nat.NewPort(nat.SplitProtoPort(p))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid port %s", err, p)
+		}
+
+		proto := port.Proto()
+		exposedPorts = append(exposedPorts, port)
+		commands = append(commands, fmt.Sprintf("socat %s-listen:%s,fork,reuseaddr %s-connect:%s:%s", proto, port.Port(), proto, targetAddress, port.Port()))
+
+		if proto == "tcp" {
+			strategies = append(strategies, wait.ForListeningPort(port))
+		}
+	}
+
+	portSpecs := make([]string, 0, len(exposedPorts))
+	for _, p := range exposedPorts {
+		portSpecs = append(portSpecs, string(p))
+	}
+
+	req := ContainerRequest{
+		Image:        SocatDefaultImage,
+		Entrypoint:   []string{"/bin/sh"},
+		Cmd:          []string{"-c", strings.Join(commands, " & ") + " & wait"},
+		ExposedPorts: portSpecs,
+		Networks:     []string{network},
+	}
+
+	if len(strategies) > 0 {
+		req.WaitingFor = wait.ForAll(strategies...)
+	}
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if c == nil {
+		return nil, err
+	}
+
+	return &SocatContainer{
+		Container: c,
+		target:    target,
+		ports:     exposedPorts,
+	}, err
+}
+
+// networkIPResolver is implemented by the containers able to return their IP in a given network
+type networkIPResolver interface {
+	networkIP(ctx context.Context, network string) (string, error)
+}
+
+var _ networkIPResolver = (*DockerContainer)(nil)
+
+// networkAddress returns the address reaching the container in the given network: its IP in the network,
+// else its first alias in the network, else its IP in the default network
+func networkAddress(ctx context.Context, c Container, network string) (string, error) {
+	if resolver, ok := c.(networkIPResolver); ok {
+		ip, err := resolver.networkIP(ctx, network)
+		if err != nil {
+			return "", fmt.Errorf("%w: could not get the IP of the target container in the network %s", err, network)
+		}
+		if ip != "" {
+			return ip, nil
+		}
+	}
+
+	aliases, err := c.NetworkAliases(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: could not get the aliases of the target container", err)
+	}
+	if len(aliases[network]) > 0 {
+		return aliases[network][0], nil
+	}
+
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: could not get the IP of the target container", err)
+	}
+	if ip == "" {
+		return "", fmt.Errorf("the target container has no address in the network %s", network)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,131 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestExposePorts(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			WaitingFor: wait.ForLog("start worker process"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	// exposePorts {
+	sidecar, err := ExposePorts(ctx, nginxC, nginxDefaultPort)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, sidecar)
+
+	host, err := sidecar.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := sidecar.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	// }
+
+	resp, err := http.Get(fmt.Sprintf("http://%s:%s", host, port.Port()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, nginxC, sidecar.Target())
+}
+
+func TestExposePortsWithSeveralNetworks(t *testing.T) {
+	ctx := context.Background()
+
+	networkNames := []string{"socat-network-b", "socat-network-a"}
+	for _, name := range networkNames {
+		nw, err := GenericNetwork(ctx, GenericNetworkRequest{
+			ProviderType:   providerType,
+			NetworkRequest: NetworkRequest{Name: name, CheckDuplicate: true},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, nw.Remove(ctx))
+		})
+	}
+
+	// the container has no IP in the default network, and one IP in each of its networks
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			Networks:   networkNames,
+			WaitingFor: wait.ForLog("start worker process"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, nginxC)
+
+	sidecar, err := ExposePorts(ctx, nginxC, nginxDefaultPort)
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, sidecar)
+
+	networks, err := sidecar.Networks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"socat-network-a"}, networks)
+
+	host, err := sidecar.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := sidecar.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s:%s", host, port.Port()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// addressedContainer is a container without a network IP resolver, reporting the given aliases and default IP
+type addressedContainer struct {
+	Container
+	aliases map[string][]string
+	ip      string
+}
+
+func (c *addressedContainer) NetworkAliases(_ context.Context) (map[string][]string, error) {
+	return c.aliases, nil
+}
+
+func (c *addressedContainer) ContainerIP(_ context.Context) (string, error) {
+	return c.ip, nil
+}
+
+func TestNetworkAddress(t *testing.T) {
+	ctx := context.Background()
+
+	address, err := networkAddress(ctx, &addressedContainer{aliases: map[string][]string{"a": {"nginx"}}, ip: "172.17.0.2"}, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", address)
+
+	address, err = networkAddress(ctx, &addressedContainer{aliases: map[string][]string{}, ip: "172.17.0.2"}, "bridge")
+	require.NoError(t, err)
+	assert.Equal(t, "172.17.0.2", address)
+
+	_, err = networkAddress(ctx, &addressedContainer{aliases: map[string][]string{}}, "a")
+	require.Error(t, err)
+}
+
+func TestExposePortsWithoutPorts(t *testing.T) {
+	_, err := ExposePorts(context.Background(), nil)
+	require.Error(t, err)
+}
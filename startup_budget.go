@@ -0,0 +1,253 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxStartupTimings is the number of ended operations kept by a startup budget, the longest ones,
+// so the memory of a budget doesn't grow with the number of containers started in a long session
+const maxStartupTimings = 100
+
+// StartupBudget is a deadline shared by the creation and the start of all the containers of the session, including
+// the pulls of their images and their wait strategies, set with WithGlobalDeadline. It records the time consumed
+// by each operation, so the operations exceeding the budget are reported, e.g. to diagnose a slow stage of a CI pipeline.
+type StartupBudget struct {
+	budget   time.Duration
+	started  time.Time
+	deadline time.Time
+
+	mx        sync.Mutex
+	nextID    int
+	running   map[int]runningOperation
+	timings   []OperationTiming // the longest ended operations, sorted by decreasing duration
+	omitted   int               // the number of ended operations not kept in timings, being shorter
+	exceeded  bool
+	reportLog func(format string, v ...interface{})
+}
+
+// runningOperation is an operation not ended yet
+type runningOperation struct {
+	op      Operation
+	started time.Time
+}
+
+// OperationTiming is the time consumed by an operation of the lifecycle of a container
+type OperationTiming struct {
+	Operation
+	// Duration is the time consumed by the operation, until now if it's still running
+	Duration time.Duration
+	// Running is true if the operation was still running when the report was made
+	Running bool
+	// Err is the error of the operation, nil if it succeeded or if it's still running
+	Err error
+}
+
+// StartupReport is the report of the operations consumed by a startup budget
+type StartupReport struct {
+	// Budget is the duration of the budget
+	Budget time.Duration
+	// Elapsed is the time elapsed since the budget was set
+	Elapsed time.Duration
+	// Operations are the operations started since the budget was set, sorted by decreasing duration.
+	// The nested operations are reported too, e.g. the wait strategy of a container, included in its start.
+	// Only the 100 longest ended operations are kept, with the running ones.
+	Operations []OperationTiming
+	// Omitted is the number of ended operations not reported, as they are shorter than the reported ones
+	Omitted int
+}
+
+// String returns the report as a table, one operation per line
+func (r StartupReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "startup budget of %s, %s elapsed:", r.Budget, r.Elapsed.Round(time.Millisecond))
+
+	for _, t := range r.Operations {
+		fmt.Fprintf(&sb, "\n  %12s  %-16s %s", t.Duration.Round(time.Millisecond), t.Name, t.Image)
+		if t.ContainerID != "" {
+			fmt.Fprintf(&sb, " (%.12s)", t.ContainerID)
+		}
+		if t.Strategy != "" {
+			fmt.Fprintf(&sb, " %s", t.Strategy)
+		}
+
+		switch {
+		case t.Running:
+			sb.WriteString(": running")
+		case t.Err != nil:
+			fmt.Fprintf(&sb, ": %s", t.Err)
+		}
+	}
+
+	if r.Omitted > 0 {
+		fmt.Fprintf(&sb, "\n  and %d shorter operations", r.Omitted)
+	}
+
+	return sb.String()
+}
+
+// ErrStartupBudgetExceeded is returned when an operation fails after the deadline of the startup budget,
+// with the report of the operations which consumed the budget
+type ErrStartupBudgetExceeded struct {
+	Report StartupReport
+	Err    error
+}
+
+func (e *ErrStartupBudgetExceeded) Error() string {
+	return fmt.Sprintf("%s: the %s", e.Err, e.Report)
+}
+
+func (e *ErrStartupBudgetExceeded) Unwrap() error {
+	return e.Err
+}
+
+var (
+	startupBudgetMx sync.RWMutex
+	startupBudget   *StartupBudget
+)
+
+// WithGlobalDeadline sets a startup budget of the given duration, shared by all the containers created afterwards
+// in the session, e.g. in the TestMain function of the tests. The pulls of the images, the creations and the starts of
+// the containers, and their wait strategies, fail once the budget is consumed, with an ErrStartupBudgetExceeded error
+// reporting where the time was spent, which is also logged once. A zero duration removes the budget.
+// It returns the budget, whose report can be read at any time.
+func WithGlobalDeadline(d time.Duration) *StartupBudget {
+	var b *StartupBudget
+	if d > 0 {
+		now := time.Now()
+		b = &StartupBudget{
+			budget:    d,
+			started:   now,
+			deadline:  now.Add(d),
+			running:   map[int]runningOperation{},
+			reportLog: Logger.Printf,
+		}
+	}
+
+	startupBudgetMx.Lock()
+	defer startupBudgetMx.Unlock()
+
+	startupBudget = b
+
+	return b
+}
+
+// currentStartupBudget returns the startup budget of the session, nil if there is none
+func currentStartupBudget() *StartupBudget {
+	startupBudgetMx.RLock()
+	defer startupBudgetMx.RUnlock()
+
+	return startupBudget
+}
+
+// Deadline returns the deadline of the budget
+func (b *StartupBudget) Deadline() time.Time {
+	return b.deadline
+}
+
+// Remaining returns the time remaining before the deadline of the budget, zero once it's exceeded
+func (b *StartupBudget) Remaining() time.Duration {
+	remaining := time.Until(b.deadline)
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// Report returns the report of the operations started since the budget was set
+func (b *StartupBudget) Report() StartupReport {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	return b.report(time.Now())
+}
+
+// report returns the report of the operations at the given time. The mutex must be held by the caller.
+func (b *StartupBudget) report(now time.Time) StartupReport {
+	operations := make([]OperationTiming, 0, len(b.timings)+len(b.running))
+	operations = append(operations, b.timings...)
+	for _, r := range b.running {
+		operations = append(operations, OperationTiming{Operation: r.op, Duration: now.Sub(r.started), Running: true})
+	}
+
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].Duration > operations[j].Duration
+	})
+
+	return StartupReport{
+		Budget:     b.budget,
+		Elapsed:    now.Sub(b.started),
+		Operations: operations,
+		Omitted:    b.omitted,
+	}
+}
+
+// recordTiming records the timing of an ended operation, keeping only the maxStartupTimings longest ones.
+// The mutex must be held by the caller.
+func (b *StartupBudget) recordTiming(timing OperationTiming) {
+	i := sort.Search(len(b.timings), func(i int) bool {
+		return b.timings[i].Duration < timing.Duration
+	})
+	if i == maxStartupTimings {
+		b.omitted++
+		return
+	}
+
+	if len(b.timings) == maxStartupTimings {
+		b.timings = b.timings[:len(b.timings)-1]
+		b.omitted++
+	}
+
+	b.timings = append(b.timings, OperationTiming{})
+	copy(b.timings[i+1:], b.timings[i:])
+	b.timings[i] = timing
+}
+
+// startOperation records the start of the operation, bounding its context by the deadline of the budget.
+// The returned function records its end, returning its error wrapped into an ErrStartupBudgetExceeded error
+// if it failed after the deadline.
+func (b *StartupBudget) startOperation(ctx context.Context, op Operation) (context.Context, func(err error) error) {
+	if b == nil {
+		return ctx, func(err error) error { return err }
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, b.deadline)
+
+	b.mx.Lock()
+	id := b.nextID
+	b.nextID++
+	started := time.Now()
+	b.running[id] = runningOperation{op: op, started: started}
+	b.mx.Unlock()
+
+	return ctx, func(err error) error {
+		defer cancel()
+
+		now := time.Now()
+
+		b.mx.Lock()
+		defer b.mx.Unlock()
+
+		delete(b.running, id)
+		b.recordTiming(OperationTiming{Operation: op, Duration: now.Sub(started), Err: err})
+
+		var exceeded *ErrStartupBudgetExceeded
+		if err == nil || now.Before(b.deadline) || errors.As(err, &exceeded) {
+			return err
+		}
+
+		report := b.report(now)
+		if !b.exceeded {
+			b.exceeded = true
+			b.reportLog("⏰ The %s", report)
+		}
+
+		return &ErrStartupBudgetExceeded{Report: report, Err: err}
+	}
+}
@@ -0,0 +1,120 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Without budget", func(t *testing.T) {
+		require.Nil(t, WithGlobalDeadline(0))
+
+		opCtx, end := startOperation(ctx, Operation{Name: OperationContainerStart})
+		_, ok := opCtx.Deadline()
+		assert.False(t, ok)
+
+		failure := errors.New("failure")
+		assert.Equal(t, failure, end(failure))
+	})
+
+	t.Run("Bounds the operations by the deadline", func(t *testing.T) {
+		budget := WithGlobalDeadline(time.Minute)
+		defer WithGlobalDeadline(0)
+
+		opCtx, end := startOperation(ctx, Operation{Name: OperationContainerStart, Image: "nginx:alpine"})
+		deadline, ok := opCtx.Deadline()
+		require.True(t, ok)
+		assert.Equal(t, budget.Deadline(), deadline)
+		assert.True(t, budget.Remaining() > 0)
+
+		report := budget.Report()
+		require.Len(t, report.Operations, 1)
+		assert.True(t, report.Operations[0].Running)
+
+		require.NoError(t, end(nil))
+
+		report = budget.Report()
+		require.Len(t, report.Operations, 1)
+		assert.False(t, report.Operations[0].Running)
+		assert.Equal(t, OperationContainerStart, report.Operations[0].Name)
+	})
+
+	t.Run("Reports the operations once exceeded", func(t *testing.T) {
+		budget := WithGlobalDeadline(20 * time.Millisecond)
+		defer WithGlobalDeadline(0)
+
+		var logged []string
+		budget.reportLog = func(format string, v ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, v...))
+		}
+
+		_, endPull := startOperation(ctx, Operation{Name: OperationImagePull, Image: "postgres:15"})
+		require.NoError(t, endPull(nil))
+
+		startCtx, endStart := startOperation(ctx, Operation{Name: OperationContainerStart, Image: "postgres:15", ContainerID: "0123456789abcdef"})
+		waitCtx, endWait := startOperation(startCtx, Operation{Name: OperationWait, Image: "postgres:15", ContainerID: "0123456789abcdef", Strategy: "*wait.LogStrategy"})
+
+		<-waitCtx.Done()
+		require.ErrorIs(t, waitCtx.Err(), context.DeadlineExceeded)
+
+		err := endWait(waitCtx.Err())
+		var exceeded *ErrStartupBudgetExceeded
+		require.ErrorAs(t, err, &exceeded)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// the running start of the container is reported, as the longest operation
+		require.Len(t, exceeded.Report.Operations, 3)
+		assert.Equal(t, OperationContainerStart, exceeded.Report.Operations[0].Name)
+		assert.True(t, exceeded.Report.Operations[0].Running)
+		assert.Equal(t, OperationWait, exceeded.Report.Operations[1].Name)
+		assert.Equal(t, OperationImagePull, exceeded.Report.Operations[2].Name)
+		assert.Contains(t, err.Error(), "postgres:15 (0123456789ab) *wait.LogStrategy: context deadline exceeded")
+
+		// the error is not wrapped again by the enclosing operations, and the report is logged once
+		assert.Same(t, err, endStart(err))
+		require.Len(t, logged, 1)
+		assert.Contains(t, logged[0], "startup budget of 20ms")
+	})
+}
+
+func TestStartupBudgetKeepsTheLongestOperations(t *testing.T) {
+	b := &StartupBudget{}
+
+	for i := 1; i <= maxStartupTimings+10; i++ {
+		b.recordTiming(OperationTiming{Operation: Operation{Name: OperationWait}, Duration: time.Duration(i) * time.Millisecond})
+	}
+	b.recordTiming(OperationTiming{Operation: Operation{Name: OperationImagePull}, Duration: time.Minute})
+
+	report := b.report(time.Now())
+	require.Len(t, report.Operations, maxStartupTimings)
+	assert.Equal(t, 11, report.Omitted)
+	assert.Equal(t, OperationImagePull, report.Operations[0].Name)
+	assert.Equal(t, time.Duration(maxStartupTimings+10)*time.Millisecond, report.Operations[1].Duration)
+	assert.Equal(t, 12*time.Millisecond, report.Operations[maxStartupTimings-1].Duration)
+}
+
+func TestStartupReportString(t *testing.T) {
+	report := StartupReport{
+		Budget:  time.Minute,
+		Elapsed: 61 * time.Second,
+		Operations: []OperationTiming{
+			{Operation: Operation{Name: OperationWait, Image: "kafka:7", ContainerID: "0123456789abcdef", Strategy: "*wait.LogStrategy"}, Duration: 50 * time.Second, Running: true},
+			{Operation: Operation{Name: OperationImagePull, Image: "kafka:7"}, Duration: 10 * time.Second, Err: errors.New("unexpected EOF")},
+		},
+		Omitted: 3,
+	}
+
+	assert.Equal(t, "startup budget of 1m0s, 1m1s elapsed:"+
+		"\n           50s  container.wait   kafka:7 (0123456789ab) *wait.LogStrategy: running"+
+		"\n           10s  image.pull       kafka:7: unexpected EOF"+
+		"\n  and 3 shorter operations",
+		report.String())
+}
@@ -0,0 +1,206 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+)
+
+const (
+	// defaultStartupRetryInitialInterval is the time waited before the first retry of the creation of a container
+	defaultStartupRetryInitialInterval = time.Second
+	// defaultStartupRetryMaxInterval is the maximum time waited between two attempts to create a container
+	defaultStartupRetryMaxInterval = 30 * time.Second
+)
+
+// StartupRetryPolicy defines how the creation of a container is retried by GenericContainer, when it fails with
+// a transient error of the registry or of the daemon, e.g. a registry answering 503 Service Unavailable while the image
+// is pulled, or with a conflict with the name of a stale container, which is removed before the next attempt.
+// The time waited between two attempts is doubled after each retry, up to MaxInterval.
+type StartupRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts to create the container, including the first one.
+	// The creation is not retried if it's lower than 2.
+	MaxAttempts int
+	// InitialInterval is the time waited before the first retry, one second if zero
+	InitialInterval time.Duration
+	// MaxInterval is the maximum time waited between two attempts, 30 seconds if zero
+	MaxInterval time.Duration
+}
+
+// startupRetryPolicy returns the retry policy of the request, falling back to the given number of attempts
+// of the Testcontainers configuration
+func (r GenericContainerRequest) startupRetryPolicy(defaultAttempts int) StartupRetryPolicy {
+	policy := StartupRetryPolicy{MaxAttempts: defaultAttempts}
+	if r.StartupRetryPolicy != nil {
+		policy = *r.StartupRetryPolicy
+	}
+
+	if policy.InitialInterval <= 0 {
+		policy.InitialInterval = defaultStartupRetryInitialInterval
+	}
+
+	if policy.MaxInterval <= 0 {
+		policy.MaxInterval = defaultStartupRetryMaxInterval
+	}
+
+	return policy
+}
+
+// backOff returns the exponential backoff of the policy, capped to its maximum interval
+func (p StartupRetryPolicy) backOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	// the attempts are limited by their number, not by the elapsed time
+	b.MaxElapsedTime = 0
+
+	retries := p.MaxAttempts - 1
+	if retries < 0 {
+		retries = 0
+	}
+
+	return backoff.WithMaxRetries(b, uint64(retries))
+}
+
+// transientStartupErrors are the messages of the errors of the registries and of the daemons worth retrying,
+// as they are returned as generic errors by the Docker API, e.g. while the image is pulled
+var transientStartupErrors = []string{
+	"429 Too Many Requests",
+	"500 Internal Server Error",
+	"502 Bad Gateway",
+	"503 Service Unavailable",
+	"504 Gateway Timeout",
+	"TLS handshake timeout",
+	"i/o timeout",
+	"connection reset by peer",
+	"connection refused",
+	"unexpected EOF",
+}
+
+// isTransientStartupError returns true if the creation of a container failed with a transient error,
+// which could succeed if it's attempted again
+func isTransientStartupError(err error) bool {
+	if isTransientError(err) || errdefs.IsUnavailable(err) {
+		return true
+	}
+
+	msg := err.Error()
+	for _, transient := range transientStartupErrors {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNameConflictError returns true if the container could not be created because its name is already used
+func isNameConflictError(err error) bool {
+	return errdefs.IsConflict(err) || strings.Contains(err.Error(), "is already in use by container")
+}
+
+// staleContainerRemover is implemented by the providers able to remove a container blocking the name of a request
+type staleContainerRemover interface {
+	removeStaleContainer(ctx context.Context, name string) (bool, error)
+}
+
+var _ staleContainerRemover = (*DockerProvider)(nil)
+
+// removeStaleContainer removes the container with the given name if it was created by Testcontainers, and if it's
+// not running, e.g. left by an aborted test, or created by this session during a failed attempt.
+// It returns false if there is no container to remove, or if it's not safe to remove it.
+func (p *DockerProvider) removeStaleContainer(ctx context.Context, name string) (bool, error) {
+	containers, err := p.client.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("name", fmt.Sprintf("^/?%s$", strings.TrimPrefix(name, "/")))),
+	})
+	if err != nil {
+		return false, err
+	}
+	defer p.Close()
+
+	if len(containers) == 0 {
+		return false, nil
+	}
+
+	c := containers[0]
+	if c.Labels[testcontainersdocker.LabelBase] != "true" {
+		return false, nil
+	}
+
+	ownSession := c.Labels[testcontainersdocker.LabelSessionID] == testcontainerssession.String()
+	if c.State == "running" && !ownSession {
+		return false, nil
+	}
+
+	err = p.client.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createWithRetry calls create until it succeeds, fails with an error not worth retrying, or the attempts of the policy
+// are exhausted, in which case the number of attempts is added to the last error.
+// The stale containers blocking the name of the request are removed before the next attempt.
+func createWithRetry(ctx context.Context, provider GenericProvider, req GenericContainerRequest, policy StartupRetryPolicy, logging Logging, create func() (Container, error)) (Container, error) {
+	if policy.MaxAttempts < 2 {
+		return create()
+	}
+
+	var c Container
+	attempts := 0
+
+	err := backoff.RetryNotify(func() error {
+		attempts++
+
+		var err error
+		c, err = create()
+		if err == nil {
+			return nil
+		}
+
+		var exceeded *ErrStartupBudgetExceeded
+		switch {
+		case errors.As(err, &exceeded):
+			// the next attempts would fail too, the startup budget being consumed
+			return backoff.Permanent(err)
+		case isNameConflictError(err):
+			remover, ok := provider.(staleContainerRemover)
+			if !ok || req.Name == "" {
+				return backoff.Permanent(err)
+			}
+
+			removed, rmErr := remover.removeStaleContainer(ctx, req.Name)
+			if rmErr != nil || !removed {
+				return backoff.Permanent(err)
+			}
+
+			logging.Printf("🧹 Removed the stale container named %s, blocking the creation of the container", req.Name)
+			return err
+		case isTransientStartupError(err):
+			return err
+		default:
+			return backoff.Permanent(err)
+		}
+	}, backoff.WithContext(policy.backOff(), ctx), func(err error, next time.Duration) {
+		logging.Printf("🔁 Attempt %d of %d to create the container failed, retrying in %s: %s", attempts, policy.MaxAttempts, next, err)
+	})
+
+	if err != nil && attempts > 1 {
+		return c, fmt.Errorf("%w: the container could not be created after %d attempts", err, attempts)
+	}
+
+	return c, err
+}
@@ -0,0 +1,143 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staleNameProvider is a provider removing the stale containers blocking the names of the requests
+type staleNameProvider struct {
+	GenericProvider
+	removed []string
+}
+
+func (p *staleNameProvider) removeStaleContainer(_ context.Context, name string) (bool, error) {
+	p.removed = append(p.removed, name)
+	return true, nil
+}
+
+// fastRetryPolicy is a retry policy waiting a few milliseconds between the attempts
+var fastRetryPolicy = StartupRetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func TestIsTransientStartupError(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{err: errors.New("received unexpected HTTP status: 503 Service Unavailable"), transient: true},
+		{err: errors.New("Get \"https://registry-1.docker.io/v2/\": net/http: TLS handshake timeout"), transient: true},
+		{err: fmt.Errorf("%w: failed to create container", io.ErrUnexpectedEOF), transient: true},
+		{err: errdefs.Unavailable(errors.New("the daemon is restarting")), transient: true},
+		{err: errors.New("pull access denied for private/image, repository does not exist"), transient: false},
+		{err: errdefs.NotFound(errors.New("No such image: nginx:unknown")), transient: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.transient, isTransientStartupError(tt.err))
+		})
+	}
+}
+
+func TestStartupRetryPolicy(t *testing.T) {
+	t.Run("Defaults of the configuration", func(t *testing.T) {
+		policy := GenericContainerRequest{}.startupRetryPolicy(4)
+
+		assert.Equal(t, StartupRetryPolicy{
+			MaxAttempts:     4,
+			InitialInterval: defaultStartupRetryInitialInterval,
+			MaxInterval:     defaultStartupRetryMaxInterval,
+		}, policy)
+	})
+
+	t.Run("Policy of the request", func(t *testing.T) {
+		policy := GenericContainerRequest{StartupRetryPolicy: &StartupRetryPolicy{MaxAttempts: 2, InitialInterval: time.Minute}}.startupRetryPolicy(4)
+
+		assert.Equal(t, StartupRetryPolicy{
+			MaxAttempts:     2,
+			InitialInterval: time.Minute,
+			MaxInterval:     defaultStartupRetryMaxInterval,
+		}, policy)
+	})
+}
+
+func TestCreateWithRetry(t *testing.T) {
+	ctx := context.Background()
+	container := &DockerContainer{ID: "created"}
+
+	t.Run("Transient errors are retried", func(t *testing.T) {
+		attempts := 0
+		c, err := createWithRetry(ctx, nil, GenericContainerRequest{}, fastRetryPolicy, Logger, func() (Container, error) {
+			attempts++
+			if attempts < 3 {
+				return nil, errors.New("received unexpected HTTP status: 503 Service Unavailable")
+			}
+			return container, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, container, c)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("The attempts are capped", func(t *testing.T) {
+		attempts := 0
+		_, err := createWithRetry(ctx, nil, GenericContainerRequest{}, fastRetryPolicy, Logger, func() (Container, error) {
+			attempts++
+			return nil, errors.New("received unexpected HTTP status: 502 Bad Gateway")
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 3 attempts")
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("Other errors are not retried", func(t *testing.T) {
+		attempts := 0
+		_, err := createWithRetry(ctx, nil, GenericContainerRequest{}, fastRetryPolicy, Logger, func() (Container, error) {
+			attempts++
+			return nil, errdefs.NotFound(errors.New("No such image: nginx:unknown"))
+		})
+
+		require.Error(t, err)
+		assert.True(t, errdefs.IsNotFound(err))
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("Stale containers blocking the name are removed", func(t *testing.T) {
+		provider := &staleNameProvider{}
+		req := GenericContainerRequest{ContainerRequest: ContainerRequest{Name: "db"}}
+
+		attempts := 0
+		c, err := createWithRetry(ctx, provider, req, fastRetryPolicy, Logger, func() (Container, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errdefs.Conflict(errors.New(`Conflict. The container name "/db" is already in use by container "3c1a9f0e2b7d"`))
+			}
+			return container, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, container, c)
+		assert.Equal(t, []string{"db"}, provider.removed)
+	})
+
+	t.Run("Without a policy", func(t *testing.T) {
+		attempts := 0
+		_, err := createWithRetry(ctx, nil, GenericContainerRequest{}, StartupRetryPolicy{}, Logger, func() (Container, error) {
+			attempts++
+			return nil, errors.New("received unexpected HTTP status: 503 Service Unavailable")
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
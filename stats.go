@@ -0,0 +1,144 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerStats is a sample of the resource usage of a container, as reported by the Docker daemon
+type ContainerStats struct {
+	// Read is the time the sample was read by the daemon
+	Read time.Time
+	// CPUPercent is the CPU usage of the container since the previous sample, in percent of one CPU,
+	// e.g. 200 for two CPUs fully used. It's zero for the first sample of a stream.
+	CPUPercent float64
+	// CPUUsage is the total CPU time consumed by the container
+	CPUUsage time.Duration
+	// MemoryUsage is the memory used by the container in bytes, without the page cache, like docker stats
+	MemoryUsage uint64
+	// MemoryLimit is the memory limit of the container in bytes, the memory of the host if it's not limited
+	MemoryLimit uint64
+	// MemoryPercent is the memory usage in percent of the memory limit
+	MemoryPercent float64
+	// NetworkRxBytes is the number of bytes received on all the networks of the container
+	NetworkRxBytes uint64
+	// NetworkTxBytes is the number of bytes sent on all the networks of the container
+	NetworkTxBytes uint64
+	// BlockReadBytes is the number of bytes read from the block devices
+	BlockReadBytes uint64
+	// BlockWriteBytes is the number of bytes written to the block devices
+	BlockWriteBytes uint64
+	// PIDs is the number of processes or threads of the container
+	PIDs uint64
+}
+
+// Stats returns a sample of the resource usage of the container, e.g. to assert on the memory consumed by a service
+// during a test. The daemon waits for two samples to compute the CPU usage, so it takes about a second.
+func (c *DockerContainer) Stats(ctx context.Context) (*ContainerStats, error) {
+	response, err := c.provider.client.ContainerStats(ctx, c.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var stats types.StatsJSON
+	if err := json.NewDecoder(response.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("%w: could not decode the stats of the container", err)
+	}
+
+	s := toContainerStats(stats)
+	return &s, nil
+}
+
+// StatsStream streams the samples of the resource usage of the container, about every second.
+// The samples are delivered in the returned channel, which is closed when the context is done,
+// or when the container is removed.
+func (c *DockerContainer) StatsStream(ctx context.Context) (<-chan ContainerStats, error) {
+	response, err := c.provider.client.ContainerStats(ctx, c.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ContainerStats)
+	go func() {
+		defer close(ch)
+		defer response.Body.Close()
+
+		decoder := json.NewDecoder(response.Body)
+		for {
+			var stats types.StatsJSON
+			if err := decoder.Decode(&stats); err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					c.logger.Printf("The stats stream of the container %s failed: %v", c.ID[:12], err)
+				}
+				return
+			}
+
+			select {
+			case ch <- toContainerStats(stats):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// toContainerStats computes the resource usage of the container from the stats of the daemon,
+// with the same formulas as the docker stats command
+func toContainerStats(stats types.StatsJSON) ContainerStats {
+	s := ContainerStats{
+		Read:        stats.Read,
+		CPUUsage:    time.Duration(stats.CPUStats.CPUUsage.TotalUsage),
+		MemoryLimit: stats.MemoryStats.Limit,
+		PIDs:        stats.PidsStats.Current,
+	}
+
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		s.CPUPercent = cpuDelta / systemDelta * onlineCPUs * 100
+	}
+
+	s.MemoryUsage = stats.MemoryStats.Usage
+	// the page cache is reclaimable, so it's not counted: total_inactive_file with cgroup v1 and inactive_file with cgroup v2
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if cache, ok := stats.MemoryStats.Stats[key]; ok {
+			if cache < s.MemoryUsage {
+				s.MemoryUsage -= cache
+			}
+			break
+		}
+	}
+	if s.MemoryLimit > 0 {
+		s.MemoryPercent = float64(s.MemoryUsage) / float64(s.MemoryLimit) * 100
+	}
+
+	for _, network := range stats.Networks {
+		s.NetworkRxBytes += network.RxBytes
+		s.NetworkTxBytes += network.TxBytes
+	}
+
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			s.BlockReadBytes += entry.Value
+		case "write":
+			s.BlockWriteBytes += entry.Value
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,108 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestToContainerStats(t *testing.T) {
+	stats := types.StatsJSON{
+		Stats: types.Stats{
+			CPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{TotalUsage: 3_000_000_000},
+				SystemUsage: 20_000_000_000,
+				OnlineCPUs:  2,
+			},
+			PreCPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{TotalUsage: 2_000_000_000},
+				SystemUsage: 10_000_000_000,
+			},
+			MemoryStats: types.MemoryStats{
+				Usage: 150 * 1024 * 1024,
+				Limit: 400 * 1024 * 1024,
+				Stats: map[string]uint64{"inactive_file": 50 * 1024 * 1024},
+			},
+			PidsStats: types.PidsStats{Current: 4},
+			BlkioStats: types.BlkioStats{
+				IoServiceBytesRecursive: []types.BlkioStatEntry{
+					{Op: "Read", Value: 10},
+					{Op: "read", Value: 5},
+					{Op: "Write", Value: 20},
+					{Op: "Total", Value: 35},
+				},
+			},
+		},
+		Networks: map[string]types.NetworkStats{
+			"eth0": {RxBytes: 100, TxBytes: 200},
+			"eth1": {RxBytes: 1, TxBytes: 2},
+		},
+	}
+
+	s := toContainerStats(stats)
+
+	assert.Equal(t, 20.0, s.CPUPercent)
+	assert.Equal(t, 3*time.Second, s.CPUUsage)
+	assert.Equal(t, uint64(100*1024*1024), s.MemoryUsage)
+	assert.Equal(t, uint64(400*1024*1024), s.MemoryLimit)
+	assert.Equal(t, 25.0, s.MemoryPercent)
+	assert.Equal(t, uint64(101), s.NetworkRxBytes)
+	assert.Equal(t, uint64(202), s.NetworkTxBytes)
+	assert.Equal(t, uint64(15), s.BlockReadBytes)
+	assert.Equal(t, uint64(20), s.BlockWriteBytes)
+	assert.Equal(t, uint64(4), s.PIDs)
+
+	// the first sample of a stream has no previous CPU usage
+	first := toContainerStats(types.StatsJSON{Stats: types.Stats{PreCPUStats: types.CPUStats{}}})
+	assert.Zero(t, first.CPUPercent)
+}
+
+func TestContainerStats(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+			Memory:     64 * 1024 * 1024,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	terminateContainerOnEnd(t, ctx, c)
+
+	// containerStats {
+	stats, err := c.Stats(ctx)
+	require.NoError(t, err)
+
+	assert.Greater(t, stats.MemoryUsage, uint64(0))
+	assert.Less(t, stats.MemoryUsage, uint64(64*1024*1024))
+	// }
+	assert.Equal(t, uint64(64*1024*1024), stats.MemoryLimit)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	samples, err := c.StatsStream(streamCtx)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case sample := <-samples:
+			assert.Greater(t, sample.PIDs, uint64(0))
+		case <-time.After(10 * time.Second):
+			t.Fatal("no stats sample received")
+		}
+	}
+
+	cancel()
+	for range samples {
+		// the channel is closed once the context is done
+	}
+}
@@ -0,0 +1,49 @@
+package testcontainers
+
+import (
+	"time"
+)
+
+// TerminateOptions are the options of the Terminate method of the containers
+type TerminateOptions struct {
+	stopTimeout      *time.Duration
+	volumes          []string
+	removeBuiltImage bool
+}
+
+// TerminateOption is a function that configures how a container is terminated
+type TerminateOption func(*TerminateOptions)
+
+// newTerminateOptions returns the options of the termination, removing the images built by the library by default
+func newTerminateOptions(opts ...TerminateOption) TerminateOptions {
+	options := TerminateOptions{removeBuiltImage: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithTerminateStopTimeout stops the container before removing it, giving its processes the given time
+// to shut down cleanly before they are killed. The container is killed right away by default.
+func WithTerminateStopTimeout(timeout time.Duration) TerminateOption {
+	return func(o *TerminateOptions) {
+		o.stopTimeout = &timeout
+	}
+}
+
+// WithTerminateRemoveVolumes removes the given named volumes once the container is removed, e.g. the volumes
+// of the volume mounts of the request. The anonymous volumes of the container are always removed with it.
+func WithTerminateRemoveVolumes(volumes ...string) TerminateOption {
+	return func(o *TerminateOptions) {
+		o.volumes = append(o.volumes, volumes...)
+	}
+}
+
+// WithTerminateRemoveBuiltImage defines if the image built from the Dockerfile of the request is removed
+// with the container, which is the default. Keeping it speeds up the next builds of the same Dockerfile.
+func WithTerminateRemoveBuiltImage(remove bool) TerminateOption {
+	return func(o *TerminateOptions) {
+		o.removeBuiltImage = remove
+	}
+}
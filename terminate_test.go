@@ -0,0 +1,70 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNewTerminateOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		options := newTerminateOptions()
+
+		assert.Nil(t, options.stopTimeout)
+		assert.Empty(t, options.volumes)
+		assert.True(t, options.removeBuiltImage)
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		options := newTerminateOptions(
+			WithTerminateStopTimeout(10*time.Second),
+			WithTerminateRemoveVolumes("data"),
+			WithTerminateRemoveVolumes("logs", "cache"),
+			WithTerminateRemoveBuiltImage(false),
+		)
+
+		require.NotNil(t, options.stopTimeout)
+		assert.Equal(t, 10*time.Second, *options.stopTimeout)
+		assert.Equal(t, []string{"data", "logs", "cache"}, options.volumes)
+		assert.False(t, options.removeBuiltImage)
+	})
+}
+
+func TestTerminateWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	volumeName := "testcontainers-terminate-" + randomString()
+
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			Mounts:       Mounts(VolumeMount(volumeName, "/data")),
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	// terminateWithOptions {
+	err = container.Terminate(ctx,
+		WithTerminateStopTimeout(5*time.Second),
+		WithTerminateRemoveVolumes(volumeName),
+	)
+	// }
+	require.NoError(t, err)
+
+	cli, err := NewDockerClient()
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.VolumeInspect(ctx, volumeName)
+	assert.True(t, client.IsErrNotFound(err), "expected the volume to be removed, got %v", err)
+}
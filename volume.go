@@ -0,0 +1,342 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/internal/testcontainersdocker"
+	"github.com/testcontainers/testcontainers-go/internal/testcontainerssession"
+)
+
+// VolumeHelperDefaultImage is the image of the helper container copying the content of a volume when it's created.
+// The helper container is never started.
+const VolumeHelperDefaultImage = "docker.io/alpine:3.17"
+
+// volumeHelperMountPath is the path of the volume in the helper container populating it
+const volumeHelperMountPath = "/volume"
+
+// ErrReuseEmptyVolumeName is returned when a volume is reused without a name
+var ErrReuseEmptyVolumeName = errors.New("with reuse option a volume name mustn't be empty")
+
+// VolumeProvider allows the creation of volumes on an arbitrary system
+type VolumeProvider interface {
+	CreateVolume(context.Context, VolumeRequest) (Volume, error) // create a volume
+}
+
+// Volume allows getting info about a single named volume instance, and mounting it in the containers
+type Volume interface {
+	Name() string                                     // the name of the volume
+	Mount(target ContainerMountTarget) ContainerMount // the mount of the volume at the given path of a container
+	Remove(context.Context) error                     // removes the volume and its content
+}
+
+// VolumeRequest represents the parameters used to create a volume
+type VolumeRequest struct {
+	Name       string            // the name of the volume, generated if empty
+	Driver     string            // the driver of the volume, the local driver if empty
+	DriverOpts map[string]string // the options of the driver
+	Labels     map[string]string
+	// Reuse reuses the volume with the same name if it exists, keeping its content, e.g. a dataset prepared by a previous run.
+	// The reused volumes are not removed by the reaper at the end of the session, so the name mustn't be empty.
+	Reuse bool
+	// HostDir is a directory of the host whose content is copied to the root of the volume when it's created
+	HostDir string
+	// Content is a tar archive, optionally compressed with gzip, bzip2 or xz, extracted to the root of the volume
+	// when it's created, after the content of HostDir
+	Content io.Reader
+
+	ReaperOptions []ContainerOption // Reaper options to use for this volume
+}
+
+// GenericVolumeRequest represents parameters to a generic volume
+type GenericVolumeRequest struct {
+	VolumeRequest              // embedded request for provider
+	ProviderType  ProviderType // which provider to use, Docker if empty
+}
+
+// GenericVolume creates a generic named volume with parameters, populated with the content of the request,
+// so a dataset can be shared by several containers
+func GenericVolume(ctx context.Context, req GenericVolumeRequest) (Volume, error) {
+	if req.Reuse && req.Name == "" {
+		return nil, ErrReuseEmptyVolumeName
+	}
+
+	provider, err := req.ProviderType.GetProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := provider.(VolumeProvider)
+	if !ok {
+		return nil, errors.New("the provider does not support volumes")
+	}
+
+	v, err := p.CreateVolume(ctx, req.VolumeRequest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create volume", err)
+	}
+
+	return v, nil
+}
+
+// WithVolume mounts the named volume at the given path of the container
+func WithVolume(volume Volume, target ContainerMountTarget) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) {
+		req.Mounts = append(req.Mounts, volume.Mount(target))
+	}
+}
+
+// DockerVolume represents a named volume of a Docker daemon
+type DockerVolume struct {
+	name              string
+	Driver            string
+	provider          *DockerProvider
+	terminationSignal chan bool
+}
+
+var _ Volume = (*DockerVolume)(nil)
+
+// Name returns the name of the volume
+func (v *DockerVolume) Name() string {
+	return v.name
+}
+
+// Mount returns the mount of the volume at the given path of a container
+func (v *DockerVolume) Mount(target ContainerMountTarget) ContainerMount {
+	return VolumeMount(v.name, target)
+}
+
+// Remove is used to remove the volume and its content. It is usually triggered by as defer function.
+// The volume must not be used by any container anymore.
+func (v *DockerVolume) Remove(ctx context.Context) error {
+	select {
+	// close reaper if it was created
+	case v.terminationSignal <- true:
+	default:
+	}
+
+	err := v.provider.client.VolumeRemove(ctx, v.name, false)
+	if err != nil {
+		return err
+	}
+	defer v.provider.Close()
+
+	return nil
+}
+
+// CreateVolume creates a named volume, or reuses the existing volume with the same name if the request reuses it,
+// and copies the content of the request to the volume when it's created
+func (p *DockerProvider) CreateVolume(ctx context.Context, req VolumeRequest) (Volume, error) {
+	if req.Reuse && req.Name == "" {
+		return nil, ErrReuseEmptyVolumeName
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "testcontainers-" + testcontainerssession.NewUUID().String()
+	}
+
+	if req.Reuse {
+		existing, err := p.client.VolumeInspect(ctx, name)
+		if err == nil {
+			return &DockerVolume{name: existing.Name, Driver: existing.Driver, provider: p}, nil
+		}
+		if !client.IsErrNotFound(err) {
+			return nil, fmt.Errorf("%w: could not inspect the volume %s", err, name)
+		}
+	}
+
+	labels := make(map[string]string, len(req.Labels))
+	for k, v := range req.Labels {
+		labels[k] = v
+	}
+
+	var termSignal chan bool
+	// the reused volumes outlive the session
+	if !req.Reuse {
+		testcontainersdocker.AddDefaultLabels(labels, testcontainerssession.String())
+
+		if !p.Config().RyukDisabled {
+			r, err := reuseOrCreateReaper(context.WithValue(ctx, testcontainersdocker.DockerHostContextKey, p.host), testcontainerssession.String(), p, req.ReaperOptions...)
+			if err != nil {
+				return nil, fmt.Errorf("%w: creating volume reaper failed", err)
+			}
+			termSignal, err = r.Connect()
+			if err != nil {
+				return nil, fmt.Errorf("%w: connecting to volume reaper failed", err)
+			}
+			for k, v := range r.Labels() {
+				if _, ok := labels[k]; !ok {
+					labels[k] = v
+				}
+			}
+		}
+	}
+
+	created, err := p.client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       name,
+		Driver:     req.Driver,
+		DriverOpts: req.DriverOpts,
+		Labels:     labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v := &DockerVolume{
+		name:              created.Name,
+		Driver:            created.Driver,
+		provider:          p,
+		terminationSignal: termSignal,
+	}
+
+	if err := p.populateVolume(ctx, v.name, req); err != nil {
+		_ = p.client.VolumeRemove(ctx, v.name, true)
+		return nil, fmt.Errorf("%w: could not populate the volume %s", err, v.name)
+	}
+
+	return v, nil
+}
+
+// populateVolume copies the content of the request to the volume, through a helper container mounting it,
+// which is created but never started
+func (p *DockerProvider) populateVolume(ctx context.Context, name string, req VolumeRequest) error {
+	var archives []io.Reader
+	if req.HostDir != "" {
+		archive, err := tarDirContent(req.HostDir)
+		if err != nil {
+			return err
+		}
+		archives = append(archives, archive)
+	}
+	if req.Content != nil {
+		archives = append(archives, req.Content)
+	}
+
+	if len(archives) == 0 {
+		return nil
+	}
+
+	if err := p.ensureVolumeHelperImage(ctx); err != nil {
+		return err
+	}
+
+	helperLabels := map[string]string{}
+	testcontainersdocker.AddDefaultLabels(helperLabels, testcontainerssession.String())
+
+	helper, err := p.client.ContainerCreate(ctx, &container.Config{
+		Image:  VolumeHelperDefaultImage,
+		Labels: helperLabels,
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: name, Target: volumeHelperMountPath}},
+	}, nil, nil, "")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = p.client.ContainerRemove(ctx, helper.ID, types.ContainerRemoveOptions{Force: true})
+	}()
+
+	for _, archive := range archives {
+		if err := p.client.CopyToContainer(ctx, helper.ID, volumeHelperMountPath, archive, types.CopyToContainerOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureVolumeHelperImage pulls the image of the helper container populating the volumes, if it's not present
+func (p *DockerProvider) ensureVolumeHelperImage(ctx context.Context) error {
+	_, _, err := p.client.ImageInspectWithRaw(ctx, VolumeHelperDefaultImage)
+	if err == nil {
+		return nil
+	}
+	if !client.IsErrNotFound(err) {
+		return err
+	}
+
+	return p.attemptToPullImage(ctx, VolumeHelperDefaultImage, types.ImagePullOptions{}, PullProgressLogger(p.Logger, defaultPullProgressLogInterval))
+}
+
+// tarDirContent archives the content of a directory, with the paths relative to the directory,
+// keeping the modes of the files, so it's extracted at the root of the destination
+func tarDirContent(src string) (*bytes.Buffer, error) {
+	dir, err := isDir(src)
+	if err != nil {
+		return nil, err
+	}
+	if !dir {
+		return nil, fmt.Errorf("path %s is not a directory", src)
+	}
+
+	buffer := &bytes.Buffer{}
+	tw := tar.NewWriter(buffer)
+
+	err = filepath.Walk(src, func(file string, fi os.FileInfo, errFn error) error {
+		if errFn != nil {
+			return fmt.Errorf("error traversing the file system: %w", errFn)
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		link := ""
+		if fi.Mode().Type() == os.ModeSymlink {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return fmt.Errorf("error getting file info header: %w", err)
+		}
+		header.Name = filepath.ToSlash(rel)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("error opening file: %w", err)
+		}
+		defer data.Close()
+
+		if _, err := io.Copy(tw, data); err != nil {
+			return fmt.Errorf("error archiving file: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("error closing tar file: %w", err)
+	}
+
+	return buffer, nil
+}
@@ -0,0 +1,169 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestTarDirContent(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dataset.csv"), []byte("id,name\n1,alice\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "run.sh"), []byte("#!/bin/sh\n"), 0o755))
+
+	archive, err := tarDirContent(dir)
+	require.NoError(t, err)
+
+	entries := map[string]*tar.Header{}
+	contents := map[string]string{}
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		entries[header.Name] = header
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[header.Name] = string(content)
+	}
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, "id,name\n1,alice\n", contents["dataset.csv"])
+	assert.Equal(t, int64(0o755), entries["nested/run.sh"].Mode&0o777)
+	assert.Equal(t, byte(tar.TypeDir), entries["nested"].Typeflag)
+}
+
+func TestTarDirContentOfAFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "dataset.csv")
+	require.NoError(t, os.WriteFile(file, []byte("id,name\n"), 0o644))
+
+	_, err := tarDirContent(file)
+	require.Error(t, err)
+}
+
+func TestGenericVolumeReuseWithoutName(t *testing.T) {
+	_, err := GenericVolume(context.Background(), GenericVolumeRequest{
+		VolumeRequest: VolumeRequest{Reuse: true},
+	})
+	require.ErrorIs(t, err, ErrReuseEmptyVolumeName)
+}
+
+func TestWithVolume(t *testing.T) {
+	v := &DockerVolume{name: "dataset"}
+
+	req := &GenericContainerRequest{}
+	WithVolume(v, "/data")(req)
+
+	require.Len(t, req.Mounts, 1)
+	assert.Equal(t, "dataset", req.Mounts[0].Source.Source())
+	assert.Equal(t, MountTypeVolume, req.Mounts[0].Source.Type())
+	assert.Equal(t, ContainerMountTarget("/data"), req.Mounts[0].Target)
+}
+
+func TestGenericVolumeSharedByContainers(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dataset.csv"), []byte("id,name\n1,alice\n"), 0o644))
+
+	var content bytes.Buffer
+	tw := tar.NewWriter(&content)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "README", Mode: 0o644, Size: 5}))
+	_, err := tw.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	// createVolume {
+	volume, err := GenericVolume(ctx, GenericVolumeRequest{
+		VolumeRequest: VolumeRequest{
+			HostDir: dir,
+			Content: &content,
+		},
+	})
+	require.NoError(t, err)
+	// }
+
+	for i := 0; i < 2; i++ {
+		// mountVolume {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "docker.io/alpine",
+				Cmd:        []string{"cat", "/data/dataset.csv", "/data/README"},
+				Mounts:     Mounts(volume.Mount("/data")),
+				WaitingFor: wait.ForExit(),
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		// }
+
+		logs, err := c.Logs(ctx)
+		require.NoError(t, err)
+		output, err := io.ReadAll(logs)
+		require.NoError(t, err)
+		assert.Contains(t, string(output), "1,alice")
+		assert.Contains(t, string(output), "hello")
+
+		require.NoError(t, c.Terminate(ctx))
+	}
+
+	require.NoError(t, volume.Remove(ctx))
+}
+
+func TestGenericVolumeReuse(t *testing.T) {
+	ctx := context.Background()
+
+	name := "testcontainers-reused-volume"
+	create := func(content string) Volume {
+		var archive bytes.Buffer
+		tw := tar.NewWriter(&archive)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "version", Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+
+		v, err := GenericVolume(ctx, GenericVolumeRequest{
+			VolumeRequest: VolumeRequest{Name: name, Reuse: true, Content: &archive},
+		})
+		require.NoError(t, err)
+		return v
+	}
+
+	first := create("v1")
+	// the content is only copied when the volume is created
+	second := create("v2")
+	assert.Equal(t, first.Name(), second.Name())
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Cmd:        []string{"cat", "/data/version"},
+			Mounts:     Mounts(second.Mount("/data")),
+			WaitingFor: wait.ForExit(),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	logs, err := c.Logs(ctx)
+	require.NoError(t, err)
+	output, err := io.ReadAll(logs)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "v1")
+
+	require.NoError(t, c.Terminate(ctx))
+	require.NoError(t, second.Remove(ctx))
+}
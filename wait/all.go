@@ -59,6 +59,9 @@ func (ms *MultiStrategy) WaitUntilReady(ctx context.Context, target StrategyTarg
 		return fmt.Errorf("no wait strategy supplied")
 	}
 
+	// the strategies share the results of the calls to the target, polled by each of them
+	target = NewCachedTarget(target, defaultTargetCacheTTL())
+
 	for _, strategy := range ms.Strategies {
 		strategyCtx := ctx
 
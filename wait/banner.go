@@ -0,0 +1,202 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var _ Strategy = (*BannerStrategy)(nil)
+var _ StrategyTimeout = (*BannerStrategy)(nil)
+
+// defaultBannerReadTimeout is the time given to the server to send its banner, once connected
+const defaultBannerReadTimeout = time.Second
+
+// BannerStrategy waits until the server listening to a TCP port greets its clients with the expected banner,
+// e.g. "220" for SMTP or "SSH-2.0" for SSH. Unlike the host port strategy, it catches the services crash-looping
+// right after opening their port, as they close the connections before sending the banner.
+type BannerStrategy struct {
+	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	Port         nat.Port
+	Prefix       string
+	PollInterval time.Duration
+	// ReadTimeout is the time given to the server to send its banner, once connected
+	ReadTimeout time.Duration
+	// greeting is sent to the server before reading the banner, for the protocols where the client speaks first
+	greeting []byte
+}
+
+// ForBanner constructs a banner strategy, waiting until the server listening to the given port sends a banner
+// starting with the given prefix when a client connects
+func ForBanner(port nat.Port, prefix string) *BannerStrategy {
+	return &BannerStrategy{
+		Port:         port,
+		Prefix:       prefix,
+		PollInterval: defaultPollInterval(),
+		ReadTimeout:  defaultBannerReadTimeout,
+	}
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *BannerStrategy) WithStartupTimeout(startupTimeout time.Duration) *BannerStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *BannerStrategy) WithPollInterval(pollInterval time.Duration) *BannerStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithReadTimeout can be used to override the default time of 1 second given to the server to send its banner
+func (ws *BannerStrategy) WithReadTimeout(readTimeout time.Duration) *BannerStrategy {
+	ws.ReadTimeout = readTimeout
+	return ws
+}
+
+// WithGreeting sets the payload sent to the server once connected, before reading its banner,
+// for the protocols where the server answers the client instead of greeting it, e.g. "PING\r\n" for Redis,
+// expecting the "+PONG" prefix
+func (ws *BannerStrategy) WithGreeting(greeting string) *BannerStrategy {
+	ws.greeting = []byte(greeting)
+	return ws
+}
+
+func (ws *BannerStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *BannerStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
+	timeout := defaultStartupTimeout(ctx)
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host, err := targetHost(ctx, target, ws.Port)
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(ws.PollInterval)
+	defer ticker.Stop()
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, ws.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s:%w", ctx.Err(), err)
+		case <-ticker.C:
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, ws.Port)
+		}
+	}
+
+	address := net.JoinHostPort(host, port.Port())
+
+	// the last unexpected banner is reported if the expected one is not received before the timeout,
+	// as it's more helpful than the connection errors, and than the errors caused by the timeout itself
+	var mismatch error
+
+	for {
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		bannerErr := ws.readBanner(ctx, address)
+		if bannerErr == nil {
+			return nil
+		}
+
+		var unexpected *unexpectedBannerError
+		switch {
+		case errors.As(bannerErr, &unexpected):
+			mismatch = bannerErr
+		case err == nil || !deadlineReached(ctx):
+			err = bannerErr
+		}
+
+		select {
+		case <-ctx.Done():
+			if mismatch != nil {
+				err = mismatch
+			}
+			return fmt.Errorf("%s:%w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// deadlineReached returns true if the deadline of the context is reached, even if the context is not done yet,
+// as the connections time out at the deadline of the context, right before it's done
+func deadlineReached(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
+
+// unexpectedBannerError is returned when the banner sent by the server doesn't start with the expected prefix
+type unexpectedBannerError struct {
+	banner []byte
+	prefix string
+}
+
+func (e *unexpectedBannerError) Error() string {
+	return fmt.Sprintf("unexpected banner %q, expected the prefix %q", e.banner, e.prefix)
+}
+
+// readBanner connects to the server and checks that its banner starts with the expected prefix
+func (ws *BannerStrategy) readBanner(ctx context.Context, address string) error {
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(ws.ReadTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	if len(ws.greeting) > 0 {
+		if _, err := conn.Write(ws.greeting); err != nil {
+			return fmt.Errorf("%w: could not send the greeting", err)
+		}
+	}
+
+	banner := make([]byte, len(ws.Prefix))
+	n, err := io.ReadFull(conn, banner)
+	if err != nil {
+		return fmt.Errorf("%w: could not read the banner, got %q", err, banner[:n])
+	}
+
+	if !strings.HasPrefix(string(banner), ws.Prefix) {
+		return &unexpectedBannerError{banner: banner, prefix: ws.Prefix}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package wait
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+)
+
+// serveBanner starts a TCP server handling each connection with the given function, returning its port
+func serveBanner(t *testing.T, handle func(conn net.Conn, attempt int)) nat.Port {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for attempt := 0; ; attempt++ {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			handle(conn, attempt)
+			_ = conn.Close()
+		}
+	}()
+
+	port, err := nat.NewPort("tcp", strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return port
+}
+
+func bannerTarget(port nat.Port) *MockStrategyTarget {
+	return &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return port, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}
+
+func TestWaitForBannerSucceeds(t *testing.T) {
+	// the first connections are closed without a banner, as a crash-looping service would do
+	port := serveBanner(t, func(conn net.Conn, attempt int) {
+		if attempt < 2 {
+			return
+		}
+
+		_, _ = conn.Write([]byte("220 smtp.example.com ESMTP ready\r\n"))
+	})
+
+	wg := ForBanner("25/tcp", "220").
+		WithStartupTimeout(2 * time.Second).
+		WithPollInterval(10 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), bannerTarget(port)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForBannerWithGreeting(t *testing.T) {
+	port := serveBanner(t, func(conn net.Conn, _ int) {
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil || line != "PING\r\n" {
+			return
+		}
+
+		_, _ = conn.Write([]byte("+PONG\r\n"))
+	})
+
+	wg := ForBanner("6379/tcp", "+PONG").
+		WithGreeting("PING\r\n").
+		WithStartupTimeout(2 * time.Second)
+
+	if err := wg.WaitUntilReady(context.Background(), bannerTarget(port)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForBannerFailsWithUnexpectedBanner(t *testing.T) {
+	port := serveBanner(t, func(conn net.Conn, _ int) {
+		_, _ = conn.Write([]byte("421 service not available\r\n"))
+	})
+
+	wg := ForBanner("25/tcp", "220").
+		WithStartupTimeout(500 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), bannerTarget(port))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), `unexpected banner "421"`) {
+		t.Fatalf("expected the unexpected banner to be reported, got %s", err)
+	}
+}
+
+func TestWaitForBannerReportsUnexpectedBannerOverTimeouts(t *testing.T) {
+	// the server sends an unexpected banner, then stops answering, so the next attempts time out
+	port := serveBanner(t, func(conn net.Conn, attempt int) {
+		if attempt == 0 {
+			_, _ = conn.Write([]byte("421 service not available\r\n"))
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	wg := ForBanner("25/tcp", "220").
+		WithStartupTimeout(500 * time.Millisecond).
+		WithPollInterval(10 * time.Millisecond).
+		WithReadTimeout(50 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), bannerTarget(port))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), `unexpected banner "421"`) {
+		t.Fatalf("expected the unexpected banner to be reported, got %s", err)
+	}
+}
+
+func TestWaitForBannerFailsWithSilentServer(t *testing.T) {
+	// the server accepts the connections, but never sends its banner
+	port := serveBanner(t, func(conn net.Conn, _ int) {
+		time.Sleep(200 * time.Millisecond)
+	})
+
+	wg := ForBanner("22/tcp", "SSH-2.0").
+		WithStartupTimeout(500 * time.Millisecond).
+		WithReadTimeout(50 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), bannerTarget(port))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
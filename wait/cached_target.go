@@ -0,0 +1,183 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var _ StrategyTarget = (*CachedTarget)(nil)
+
+// CachedTarget is a StrategyTarget caching the host, the ports and the state of the wrapped target for a short TTL,
+// so the strategies polling the same target, e.g. the ones composed with ForAll, share the results of the calls
+// to the Docker API instead of inspecting the container every time. The errors, the logs and the executions
+// are not cached.
+type CachedTarget struct {
+	target StrategyTarget
+	ttl    time.Duration
+
+	mtx      sync.Mutex
+	host     string
+	hostAt   time.Time
+	ports    nat.PortMap
+	portsAt  time.Time
+	mapped   map[nat.Port]nat.Port
+	mappedAt map[nat.Port]time.Time
+	state    *types.ContainerState
+	stateAt  time.Time
+	// os is the operating system of the container, which doesn't change, so it's cached once known
+	os string
+}
+
+// NewCachedTarget wraps the given target, caching its results for the given TTL.
+// If the target is already a CachedTarget, it's returned as is.
+func NewCachedTarget(target StrategyTarget, ttl time.Duration) StrategyTarget {
+	if cached, ok := target.(*CachedTarget); ok {
+		return cached
+	}
+
+	return &CachedTarget{
+		target:   target,
+		ttl:      ttl,
+		mapped:   map[nat.Port]nat.Port{},
+		mappedAt: map[nat.Port]time.Time{},
+	}
+}
+
+func defaultTargetCacheTTL() time.Duration {
+	return 250 * time.Millisecond
+}
+
+// fresh returns true if a value cached at the given time can still be used
+func (c *CachedTarget) fresh(at time.Time) bool {
+	return !at.IsZero() && time.Since(at) < c.ttl
+}
+
+func (c *CachedTarget) Host(ctx context.Context) (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.fresh(c.hostAt) {
+		return c.host, nil
+	}
+
+	host, err := c.target.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.host, c.hostAt = host, time.Now()
+
+	return host, nil
+}
+
+// HostForPort returns the host reaching the given port of the wrapped target, which is not cached,
+// as it doesn't inspect the container
+func (c *CachedTarget) HostForPort(ctx context.Context, port nat.Port) (string, error) {
+	return targetHost(ctx, c.target, port)
+}
+
+func (c *CachedTarget) Ports(ctx context.Context) (nat.PortMap, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.fresh(c.portsAt) {
+		return c.ports, nil
+	}
+
+	ports, err := c.target.Ports(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.ports, c.portsAt = ports, time.Now()
+
+	return ports, nil
+}
+
+func (c *CachedTarget) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.fresh(c.mappedAt[port]) {
+		return c.mapped[port], nil
+	}
+
+	mapped, err := c.target.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+	c.mapped[port], c.mappedAt[port] = mapped, time.Now()
+
+	return mapped, nil
+}
+
+func (c *CachedTarget) State(ctx context.Context) (*types.ContainerState, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.fresh(c.stateAt) {
+		return c.state, nil
+	}
+
+	state, err := c.target.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.state, c.stateAt = state, time.Now()
+
+	return state, nil
+}
+
+func (c *CachedTarget) Logs(ctx context.Context) (io.ReadCloser, error) {
+	return c.target.Logs(ctx)
+}
+
+func (c *CachedTarget) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	return c.target.Exec(ctx, cmd, options...)
+}
+
+// CopyFileFromContainer reads the file of the container of the wrapped target, not cached as the content can change
+func (c *CachedTarget) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	content, err := readTargetFile(ctx, c.target, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// MountPoints returns the mounts of the container of the wrapped target, not cached as they are only read once
+func (c *CachedTarget) MountPoints(ctx context.Context) ([]types.MountPoint, error) {
+	t, ok := c.target.(mountsTarget)
+	if !ok {
+		return nil, Permanent(errors.New("the target doesn't report the mounts of its container"))
+	}
+
+	return t.MountPoints(ctx)
+}
+
+// OS returns the operating system of the container of the wrapped target, linux if the target doesn't report it
+func (c *CachedTarget) OS(ctx context.Context) (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.os != "" {
+		return c.os, nil
+	}
+
+	os, err := targetOS(ctx, c.target)
+	if err != nil {
+		return "", err
+	}
+	c.os = os
+
+	return os, nil
+}
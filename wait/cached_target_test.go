@@ -0,0 +1,145 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// countingTarget counts the calls to the Docker API done through it
+type countingTarget struct {
+	hostCalls   int32
+	portsCalls  int32
+	mappedCalls int32
+	stateCalls  int32
+	stateErr    error
+}
+
+func (st *countingTarget) Host(_ context.Context) (string, error) {
+	atomic.AddInt32(&st.hostCalls, 1)
+	return "localhost", nil
+}
+
+func (st *countingTarget) Ports(_ context.Context) (nat.PortMap, error) {
+	atomic.AddInt32(&st.portsCalls, 1)
+	return nat.PortMap{"8080/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "49153"}}}, nil
+}
+
+func (st *countingTarget) MappedPort(_ context.Context, n nat.Port) (nat.Port, error) {
+	atomic.AddInt32(&st.mappedCalls, 1)
+	return nat.Port("49153/" + n.Proto()), nil
+}
+
+func (st *countingTarget) Logs(_ context.Context) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (st *countingTarget) Exec(_ context.Context, _ []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	return 0, nil, nil
+}
+
+func (st *countingTarget) State(_ context.Context) (*types.ContainerState, error) {
+	atomic.AddInt32(&st.stateCalls, 1)
+	if st.stateErr != nil {
+		return nil, st.stateErr
+	}
+	return &types.ContainerState{Running: true}, nil
+}
+
+func TestCachedTarget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("results are cached for the TTL", func(t *testing.T) {
+		target := &countingTarget{}
+		cached := NewCachedTarget(target, time.Minute)
+
+		for i := 0; i < 10; i++ {
+			_, err := cached.Host(ctx)
+			require.NoError(t, err)
+			_, err = cached.Ports(ctx)
+			require.NoError(t, err)
+			_, err = cached.State(ctx)
+			require.NoError(t, err)
+
+			port, err := cached.MappedPort(ctx, "8080/tcp")
+			require.NoError(t, err)
+			assert.Equal(t, nat.Port("49153/tcp"), port)
+		}
+
+		assert.Equal(t, int32(1), target.hostCalls)
+		assert.Equal(t, int32(1), target.portsCalls)
+		assert.Equal(t, int32(1), target.mappedCalls)
+		assert.Equal(t, int32(1), target.stateCalls)
+
+		// every port is cached on its own
+		port, err := cached.MappedPort(ctx, "8080/udp")
+		require.NoError(t, err)
+		assert.Equal(t, nat.Port("49153/udp"), port)
+		assert.Equal(t, int32(2), target.mappedCalls)
+	})
+
+	t.Run("results expire after the TTL", func(t *testing.T) {
+		target := &countingTarget{}
+		cached := NewCachedTarget(target, 10*time.Millisecond)
+
+		_, err := cached.State(ctx)
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, err = cached.State(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), target.stateCalls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		target := &countingTarget{stateErr: errors.New("inspect failed")}
+		cached := NewCachedTarget(target, time.Minute)
+
+		_, err := cached.State(ctx)
+		require.Error(t, err)
+		_, err = cached.State(ctx)
+		require.Error(t, err)
+
+		assert.Equal(t, int32(2), target.stateCalls)
+	})
+
+	t.Run("a cached target is not wrapped again", func(t *testing.T) {
+		cached := NewCachedTarget(&countingTarget{}, time.Minute)
+
+		assert.Same(t, cached, NewCachedTarget(cached, time.Second))
+	})
+}
+
+func TestForAllSharesCachedTarget(t *testing.T) {
+	target := &countingTarget{}
+
+	err := ForAll(
+		&mappedPortStrategy{port: "8080/tcp"},
+		&mappedPortStrategy{port: "8080/tcp"},
+	).WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), target.mappedCalls)
+}
+
+// mappedPortStrategy is a strategy only reading the mapped port of the target
+type mappedPortStrategy struct {
+	port nat.Port
+}
+
+func (s *mappedPortStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	_, err := target.MappedPort(ctx, s.port)
+	return err
+}
@@ -2,9 +2,17 @@ package wait
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
+const (
+	// commandNotExecutableExitCode is the exit code of the shells when the command is not executable
+	commandNotExecutableExitCode = 126
+	// commandNotFoundExitCode is the exit code of the shells when the command is not found
+	commandNotFoundExitCode = 127
+)
+
 // Implement interface
 var _ Strategy = (*ExecStrategy)(nil)
 var _ StrategyTimeout = (*ExecStrategy)(nil)
@@ -59,7 +67,7 @@ func (ws *ExecStrategy) Timeout() *time.Duration {
 }
 
 func (ws *ExecStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
+	timeout := defaultStartupTimeout(ctx)
 	if ws.timeout != nil {
 		timeout = *ws.timeout
 	}
@@ -77,6 +85,9 @@ func (ws *ExecStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 				return err
 			}
 			if !ws.ExitCodeMatcher(exitCode) {
+				if exitCode == commandNotExecutableExitCode || exitCode == commandNotFoundExitCode {
+					return Permanent(fmt.Errorf("the command %v is not found or not executable in the container, exit code %d", ws.cmd, exitCode))
+				}
 				continue
 			}
 
@@ -139,3 +139,19 @@ func TestExecStrategyWaitUntilReady_CustomExitCode(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestExecStrategyWaitUntilReady_CommandNotFound(t *testing.T) {
+	target := mockExecTarget{
+		exitCode: 127,
+	}
+	wg := wait.NewExecStrategy([]string{"pg_isready"}).WithStartupTimeout(5 * time.Second)
+
+	start := time.Now()
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !wait.IsPermanent(err) {
+		t.Fatalf("expected a permanent error, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("expected the wait to be aborted immediately")
+	}
+}
@@ -60,9 +60,17 @@ func (ws *ExitStrategy) Timeout() *time.Duration {
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
-	if ws.timeout != nil {
+	timeout := ws.timeout
+	if timeout == nil {
+		// the exit strategy has no default timeout, unless the container request sets one
+		if t, ok := ctx.Value(startupTimeoutKey{}).(time.Duration); ok && t > 0 {
+			timeout = &t
+		}
+	}
+
+	if timeout != nil {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, *ws.timeout)
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
 		defer cancel()
 	}
 
@@ -2,6 +2,7 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"io"
 	"testing"
 	"time"
@@ -49,3 +50,20 @@ func TestWaitForExit(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWaitForExitInheritsDefaultStartupTimeout(t *testing.T) {
+	target := exitStrategyTarget{
+		isRunning: true,
+	}
+
+	ctx := WithDefaultStartupTimeout(context.Background(), 200*time.Millisecond)
+
+	start := time.Now()
+	err := NewExitStrategy().WaitUntilReady(ctx, target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the default startup timeout was not inherited, waited %s", elapsed)
+	}
+}
@@ -61,7 +61,7 @@ func (ws *HealthStrategy) Timeout() *time.Duration {
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
-	timeout := defaultStartupTimeout()
+	timeout := defaultStartupTimeout(ctx)
 	if ws.timeout != nil {
 		timeout = *ws.timeout
 	}
@@ -23,6 +23,8 @@ type HostPortStrategy struct {
 	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
 	timeout      *time.Duration
 	PollInterval time.Duration
+	// skipInternalCheck skips the check of the port executed inside the container
+	skipInternalCheck bool
 }
 
 // NewHostPortStrategy constructs a default host port strategy
@@ -61,13 +63,21 @@ func (hp *HostPortStrategy) WithPollInterval(pollInterval time.Duration) *HostPo
 	return hp
 }
 
+// SkipInternalCheck skips the check of the port executed inside the container, waiting only until the mapped port
+// accepts connections. It's meant for the images without a shell, or the providers not supporting the execution
+// of commands in the containers.
+func (hp *HostPortStrategy) SkipInternalCheck() *HostPortStrategy {
+	hp.skipInternalCheck = true
+	return hp
+}
+
 func (hp *HostPortStrategy) Timeout() *time.Duration {
 	return hp.timeout
 }
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
-	timeout := defaultStartupTimeout()
+	timeout := defaultStartupTimeout(ctx)
 	if hp.timeout != nil {
 		timeout = *hp.timeout
 	}
@@ -75,11 +85,6 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	ipAddress, err := target.Host(ctx)
-	if err != nil {
-		return
-	}
-
 	var waitInterval = hp.PollInterval
 
 	internalPort := hp.Port
@@ -102,13 +107,18 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		return
 	}
 
+	ipAddress, err := targetHost(ctx, target, internalPort)
+	if err != nil {
+		return
+	}
+
 	var port nat.Port
 	port, err = target.MappedPort(ctx, internalPort)
-	var i = 0
+	if IsPermanent(err) {
+		return err
+	}
 
 	for port == "" {
-		i++
-
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("%s:%w", ctx.Err(), err)
@@ -116,9 +126,10 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
+			// the last error is reported if the port is not mapped before the timeout
 			port, err = target.MappedPort(ctx, internalPort)
-			if err != nil {
-				fmt.Printf("(%d) [%s] %s\n", i, port, err)
+			if IsPermanent(err) {
+				return err
 			}
 		}
 	}
@@ -151,6 +162,19 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		}
 	}
 
+	if hp.skipInternalCheck {
+		return nil
+	}
+
+	// the internal check runs with /bin/sh, which is not available in the Windows containers
+	os, err := targetOS(ctx, target)
+	if err != nil {
+		return err
+	}
+	if os == "windows" {
+		return nil
+	}
+
 	//internal check
 	command := buildInternalCheckCommand(internalPort.Int())
 	for {
@@ -167,8 +191,8 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 
 		if exitCode == 0 {
 			break
-		} else if exitCode == 126 {
-			return errors.New("/bin/sh command not executable")
+		} else if exitCode == commandNotExecutableExitCode {
+			return Permanent(errors.New("/bin/sh command not executable"))
 		}
 	}
 
@@ -2,6 +2,7 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"strconv"
@@ -61,6 +62,97 @@ func TestWaitForListeningPortSucceeds(t *testing.T) {
 	}
 }
 
+func TestWaitForListeningPortSkippingInternalCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return port, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+		ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			t.Fatal("the internal check must not be executed")
+			return 1, nil, nil
+		},
+	}
+
+	wg := ForListeningPort("80").
+		SkipInternalCheck().
+		WithStartupTimeout(500 * time.Millisecond).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// windowsTarget is a target reporting a Windows container
+type windowsTarget struct {
+	*MockStrategyTarget
+}
+
+func (windowsTarget) OS(_ context.Context) (string, error) {
+	return "windows", nil
+}
+
+func TestWaitForListeningPortWithWindowsContainer(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	rawPort := listener.Addr().(*net.TCPAddr).Port
+	port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := windowsTarget{&MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return port, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+		ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+			t.Fatal("the internal check must not be executed in a Windows container")
+			return 1, nil, nil
+		},
+	}}
+
+	wg := ForListeningPort("80").
+		WithStartupTimeout(500 * time.Millisecond).
+		WithPollInterval(100 * time.Millisecond)
+
+	// the OS of the target is also reported through the cache of the strategies
+	if err := wg.WaitUntilReady(context.Background(), NewCachedTarget(target, time.Second)); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestWaitForExposedPortSucceeds(t *testing.T) {
 	listener, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
@@ -119,6 +211,41 @@ func TestWaitForExposedPortSucceeds(t *testing.T) {
 	}
 }
 
+func TestHostPortStrategyFailsWithPermanentMappedPortError(t *testing.T) {
+	var mappedPortCount int
+	target := &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			mappedPortCount++
+			return "", Permanent(errors.New("the port is not exposed by the container: 80/tcp"))
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+	}
+
+	wg := NewHostPortStrategy("80").
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(100 * time.Millisecond)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	if err == nil {
+		t.Fatal("no error")
+	}
+
+	expected := "the port is not exposed by the container: 80/tcp"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+	if mappedPortCount != 1 {
+		t.Fatalf("expected the strategy to stop after the first mapped port, got %d calls", mappedPortCount)
+	}
+}
+
 func TestHostPortStrategyFailsWhileGettingPortDueToOOMKilledContainer(t *testing.T) {
 	var mappedPortCount int
 	target := &MockStrategyTarget{
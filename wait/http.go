@@ -35,7 +35,13 @@ type HTTPStrategy struct {
 	Method            string      // http method
 	Body              io.Reader   // http request body
 	PollInterval      time.Duration
+	PerRequestTimeout time.Duration // timeout of each HTTP request, so a hanging request can't consume the startup timeout
 	UserInfo          *url.Userinfo
+	// PermanentStatusCodeMatcher matches the status codes aborting the wait, e.g. a 403 Forbidden. If it's nil,
+	// a 401 Unauthorized is permanent when the basic auth is set, as the credentials are wrong.
+	PermanentStatusCodeMatcher func(status int) bool
+
+	jsonPathMatchers []jsonPathMatcher
 }
 
 // NewHTTPStrategy constructs a HTTP strategy waiting on port 80 and status code 200
@@ -50,10 +56,15 @@ func NewHTTPStrategy(path string) *HTTPStrategy {
 		Method:            http.MethodGet,
 		Body:              nil,
 		PollInterval:      defaultPollInterval(),
+		PerRequestTimeout: defaultPerRequestTimeout(),
 		UserInfo:          nil,
 	}
 }
 
+func defaultPerRequestTimeout() time.Duration {
+	return time.Second
+}
+
 func defaultStatusCodeMatcher(status int) bool {
 	return status == http.StatusOK
 }
@@ -83,6 +94,15 @@ func (ws *HTTPStrategy) WithResponseMatcher(matcher func(body io.Reader) bool) *
 	return ws
 }
 
+// WithJSONPathMatcher matches the response only if its JSON body holds the expected value at the given path,
+// e.g. WithJSONPathMatcher("nodes.0.status", "healthy"). The keys are separated by dots, escaped with a backslash
+// in the keys, and the numeric segments index the arrays. The values other than strings are compared in their JSON form,
+// e.g. true or 3. It can be called several times, all the paths having to match, along with the response matcher.
+func (ws *HTTPStrategy) WithJSONPathMatcher(path string, expected string) *HTTPStrategy {
+	ws.jsonPathMatchers = append(ws.jsonPathMatchers, jsonPathMatcher{path: parseJSONPath(path), expected: expected})
+	return ws
+}
+
 func (ws *HTTPStrategy) WithTLS(useTLS bool, tlsconf ...*tls.Config) *HTTPStrategy {
 	ws.UseTLS = useTLS
 	if useTLS && len(tlsconf) > 0 {
@@ -111,12 +131,26 @@ func (ws *HTTPStrategy) WithBasicAuth(username, password string) *HTTPStrategy {
 	return ws
 }
 
+// WithPermanentStatusCodeMatcher sets the matcher of the status codes aborting the wait immediately,
+// instead of retrying until the startup timeout. They are only checked if the status code matcher doesn't match them.
+func (ws *HTTPStrategy) WithPermanentStatusCodeMatcher(matcher func(status int) bool) *HTTPStrategy {
+	ws.PermanentStatusCodeMatcher = matcher
+	return ws
+}
+
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *HTTPStrategy) WithPollInterval(pollInterval time.Duration) *HTTPStrategy {
 	ws.PollInterval = pollInterval
 	return ws
 }
 
+// WithPerRequestTimeout can be used to override the default timeout of 1 second for each HTTP request.
+// It's distinct from the startup timeout, which limits the whole wait, including all the attempts.
+func (ws *HTTPStrategy) WithPerRequestTimeout(timeout time.Duration) *HTTPStrategy {
+	ws.PerRequestTimeout = timeout
+	return ws
+}
+
 // ForHTTP is a convenience method similar to Wait.java
 // https://github.com/testcontainers/testcontainers-java/blob/1d85a3834bd937f80aad3a4cec249c027f31aeb4/core/src/main/java/org/testcontainers/containers/wait/strategy/Wait.java
 func ForHTTP(path string) *HTTPStrategy {
@@ -127,9 +161,43 @@ func (ws *HTTPStrategy) Timeout() *time.Duration {
 	return ws.timeout
 }
 
+// matchesResponse returns true if the body of the response matches the response matcher and the JSON paths
+func (ws *HTTPStrategy) matchesResponse(body io.Reader) bool {
+	if len(ws.jsonPathMatchers) == 0 {
+		return ws.ResponseMatcher == nil || ws.ResponseMatcher(body)
+	}
+
+	// the body is read once, to be matched by the response matcher and the JSON paths
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return false
+	}
+
+	if ws.ResponseMatcher != nil && !ws.ResponseMatcher(bytes.NewReader(content)) {
+		return false
+	}
+
+	for _, matcher := range ws.jsonPathMatchers {
+		if !matcher.matches(content) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isPermanentStatusCode returns true if the status code aborts the wait
+func (ws *HTTPStrategy) isPermanentStatusCode(status int) bool {
+	if ws.PermanentStatusCodeMatcher != nil {
+		return ws.PermanentStatusCodeMatcher(status)
+	}
+
+	return ws.UserInfo != nil && status == http.StatusUnauthorized
+}
+
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
-	timeout := defaultStartupTimeout()
+	timeout := defaultStartupTimeout(ctx)
 	if ws.timeout != nil {
 		timeout = *ws.timeout
 	}
@@ -137,13 +205,16 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	ipAddress, err := target.Host(ctx)
+	ipAddress, err := targetHost(ctx, target, ws.Port)
 	if err != nil {
 		return
 	}
 
 	var port nat.Port
 	port, err = target.MappedPort(ctx, ws.Port)
+	if IsPermanent(err) {
+		return err
+	}
 
 	for port == "" {
 		select {
@@ -155,6 +226,9 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			}
 
 			port, err = target.MappedPort(ctx, ws.Port)
+			if IsPermanent(err) {
+				return err
+			}
 		}
 	}
 
@@ -202,7 +276,12 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		proto = "http"
 	}
 
-	client := http.Client{Transport: tripper, Timeout: time.Second}
+	perRequestTimeout := ws.PerRequestTimeout
+	if perRequestTimeout <= 0 {
+		perRequestTimeout = defaultPerRequestTimeout()
+	}
+
+	client := http.Client{Transport: tripper, Timeout: perRequestTimeout}
 	address := net.JoinHostPort(ipAddress, strconv.Itoa(port.Int()))
 
 	endpoint := url.URL{
@@ -238,13 +317,19 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			}
 			resp, err := client.Do(req)
 			if err != nil {
+				if IsPermanent(err) {
+					return err
+				}
 				continue
 			}
 			if ws.StatusCodeMatcher != nil && !ws.StatusCodeMatcher(resp.StatusCode) {
 				_ = resp.Body.Close()
+				if ws.isPermanentStatusCode(resp.StatusCode) {
+					return Permanent(fmt.Errorf("%s %s answered the status code %d, which won't change by waiting", ws.Method, ws.Path, resp.StatusCode))
+				}
 				continue
 			}
-			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp.Body) {
+			if !ws.matchesResponse(resp.Body) {
 				_ = resp.Body.Close()
 				continue
 			}
@@ -5,13 +5,16 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -458,3 +461,181 @@ func TestHttpStrategyFailsWhileRequestSendingDueToUnexpectedContainerStatus(t *t
 		}
 	}
 }
+
+func TestHTTPStrategyWithPerRequestTimeout(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first request hangs, simulating a server which accepts connections but never responds during init
+		if atomic.AddInt32(&requests, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	_, rawPort, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &wait.MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "127.0.0.1", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", rawPort)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+	}
+
+	wg := wait.ForHTTP("/").
+		WithStartupTimeout(2 * time.Second).
+		WithPerRequestTimeout(200 * time.Millisecond).
+		WithPollInterval(100 * time.Millisecond)
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&requests) < 2 {
+		t.Fatalf("expected the hanging request to be retried, got %d requests", requests)
+	}
+}
+
+func TestHTTPStrategyAbortsOnPermanentStatusCode(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "admin" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	_, rawPort, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &wait.MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "127.0.0.1", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", rawPort)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+	}
+
+	t.Run("wrong credentials", func(t *testing.T) {
+		wg := wait.ForHTTP("/health").
+			WithBasicAuth("admin", "wrong").
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(10 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if !wait.IsPermanent(err) {
+			t.Fatalf("expected a permanent error, got %v", err)
+		}
+	})
+
+	t.Run("custom permanent status codes", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		wg := wait.ForHTTP("/health").
+			WithBasicAuth("admin", "admin").
+			WithPermanentStatusCodeMatcher(func(status int) bool {
+				return status == http.StatusForbidden
+			}).
+			WithStartupTimeout(5 * time.Second).
+			WithPollInterval(10 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if !wait.IsPermanent(err) {
+			t.Fatalf("expected a permanent error, got %v", err)
+		}
+		if n := atomic.LoadInt32(&requests); n != 1 {
+			t.Fatalf("expected a single request, got %d", n)
+		}
+	})
+
+	t.Run("unauthorized without basic auth is retried", func(t *testing.T) {
+		wg := wait.ForHTTP("/health").
+			WithStartupTimeout(200 * time.Millisecond).
+			WithPollInterval(10 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the deadline to be exceeded, got %v", err)
+		}
+	})
+}
+
+func TestHTTPStrategyWithJSONPathMatcher(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := "warmup"
+		if atomic.AddInt32(&requests, 1) >= 3 {
+			status = "healthy"
+		}
+		_, _ = fmt.Fprintf(w, `{"nodes": [{"status": %q, "version": "7.2.0"}]}`, status)
+	}))
+	defer srv.Close()
+
+	_, rawPort, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &wait.MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "127.0.0.1", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", rawPort)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{
+				Running: true,
+			}, nil
+		},
+	}
+
+	var matched bool
+	// waitForJSONPath {
+	wg := wait.ForHTTP("/pools/default").
+		WithJSONPathMatcher("nodes.0.status", "healthy").
+		WithJSONPathMatcher("nodes.0.version", "7.2.0").
+		WithResponseMatcher(func(body io.Reader) bool {
+			matched = true
+			return true
+		}).
+		WithStartupTimeout(5 * time.Second).
+		WithPollInterval(10 * time.Millisecond)
+	// }
+
+	if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Fatalf("expected the strategy to wait for the healthy status, got %d requests", n)
+	}
+	if !matched {
+		t.Fatal("expected the response matcher to be called too")
+	}
+}
@@ -0,0 +1,85 @@
+package wait
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// jsonPathMatcher matches the value at a path of a JSON document
+type jsonPathMatcher struct {
+	path     []string
+	expected string
+}
+
+// parseJSONPath splits a path into its segments, separated by dots. The dots of the keys are escaped with a backslash,
+// e.g. "labels.app\.kubernetes\.io/name".
+func parseJSONPath(path string) []string {
+	var segments []string
+	var segment strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			segment.WriteByte('.')
+			i++
+		case path[i] == '.':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(path[i])
+		}
+	}
+
+	return append(segments, segment.String())
+}
+
+// matches returns true if the document holds the expected value at the path of the matcher
+func (m jsonPathMatcher) matches(document []byte) bool {
+	value, ok := lookupJSONPath(document, m.path)
+	return ok && value == m.expected
+}
+
+// lookupJSONPath returns the value at the given path of the JSON document, as a string: the strings as is,
+// and the other values in their JSON form, e.g. true, 3 or {"a":1}. The numeric segments index the arrays.
+func lookupJSONPath(document []byte, path []string) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+
+	for _, segment := range path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[segment]
+			if !ok {
+				return "", false
+			}
+			value = child
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return "", false
+			}
+			value = v[index]
+		default:
+			return "", false
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(raw), true
+	}
+}
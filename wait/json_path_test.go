@@ -0,0 +1,51 @@
+package wait
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	got := parseJSONPath(`metadata.labels.app\.kubernetes\.io/name`)
+	expected := []string{"metadata", "labels", "app.kubernetes.io/name"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestJSONPathMatcher(t *testing.T) {
+	document := []byte(`{
+		"nodes": [{"status": "healthy", "ports": {"http": 8091}}, {"status": "warmup"}],
+		"balanced": true,
+		"rebalance": null,
+		"labels": {"app.kubernetes.io/name": "couchbase"}
+	}`)
+
+	testCases := []struct {
+		path     string
+		expected string
+		matches  bool
+	}{
+		{path: "nodes.0.status", expected: "healthy", matches: true},
+		{path: "nodes.1.status", expected: "healthy", matches: false},
+		{path: "nodes.0.ports.http", expected: "8091", matches: true},
+		{path: "balanced", expected: "true", matches: true},
+		{path: "rebalance", expected: "null", matches: true},
+		{path: "nodes.1", expected: `{"status":"warmup"}`, matches: true},
+		{path: `labels.app\.kubernetes\.io/name`, expected: "couchbase", matches: true},
+		{path: "nodes.2.status", expected: "healthy", matches: false},
+		{path: "nodes.first.status", expected: "healthy", matches: false},
+		{path: "balanced.value", expected: "true", matches: false},
+	}
+
+	for _, tc := range testCases {
+		matcher := jsonPathMatcher{path: parseJSONPath(tc.path), expected: tc.expected}
+		if got := matcher.matches(document); got != tc.matches {
+			t.Errorf("%s == %q: expected %t, got %t", tc.path, tc.expected, tc.matches, got)
+		}
+	}
+
+	if (jsonPathMatcher{path: []string{"status"}, expected: "ok"}).matches([]byte("not json")) {
+		t.Error("expected an invalid document not to match")
+	}
+}
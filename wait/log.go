@@ -1,9 +1,9 @@
 package wait
 
 import (
+	"bytes"
 	"context"
 	"io"
-	"strings"
 	"time"
 )
 
@@ -73,7 +73,7 @@ func (ws *LogStrategy) Timeout() *time.Duration {
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *LogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
-	timeout := defaultStartupTimeout()
+	timeout := defaultStartupTimeout(ctx)
 	if ws.timeout != nil {
 		timeout = *ws.timeout
 	}
@@ -91,20 +91,24 @@ LOOP:
 
 			reader, err := target.Logs(ctx)
 			if err != nil {
+				if IsPermanent(err) {
+					return err
+				}
 				time.Sleep(ws.PollInterval)
 				continue
 			}
 
-			b, err := io.ReadAll(reader)
+			// the logs are streamed instead of being read at once, so the chatty containers don't fill the memory
+			occurrences, read, err := countOccurrences(reader, ws.Log, ws.Occurrence)
+			_ = reader.Close()
 			if err != nil {
 				time.Sleep(ws.PollInterval)
 				continue
 			}
 
-			logs := string(b)
-			if logs == "" && checkErr != nil {
+			if read == 0 && checkErr != nil {
 				return checkErr
-			} else if strings.Count(logs, ws.Log) >= ws.Occurrence {
+			} else if occurrences >= ws.Occurrence {
 				break LOOP
 			} else {
 				time.Sleep(ws.PollInterval)
@@ -115,3 +119,59 @@ LOOP:
 
 	return nil
 }
+
+// logChunkSize is the size of the chunks of the logs read by the log strategy
+const logChunkSize = 32 * 1024
+
+// countOccurrences counts the non-overlapping occurrences of the log in the logs, reading them in chunks
+// and keeping only the end of the previous chunk, which can hold the beginning of an occurrence.
+// It stops reading once the given number of occurrences is reached, and returns the number of bytes read.
+func countOccurrences(logs io.Reader, log string, limit int) (int, int64, error) {
+	needle := []byte(log)
+	if len(needle) == 0 {
+		return limit, 0, nil
+	}
+
+	var (
+		occurrences int
+		read        int64
+		window      []byte
+	)
+
+	chunk := make([]byte, logChunkSize)
+	for {
+		n, err := logs.Read(chunk)
+		read += int64(n)
+		window = append(window, chunk[:n]...)
+
+		// the window is searched from the end of the last occurrence, so the occurrences don't overlap
+		end := 0
+		for {
+			i := bytes.Index(window[end:], needle)
+			if i < 0 {
+				break
+			}
+			occurrences++
+			end += i + len(needle)
+		}
+
+		if occurrences >= limit {
+			return occurrences, read, nil
+		}
+
+		// only the bytes which can start an occurrence completed by the next chunk are kept
+		keep := len(window) - (len(needle) - 1)
+		if keep < end {
+			keep = end
+		}
+		if keep > 0 {
+			window = append(window[:0], window[keep:]...)
+		}
+
+		if err == io.EOF {
+			return occurrences, read, nil
+		} else if err != nil {
+			return occurrences, read, err
+		}
+	}
+}
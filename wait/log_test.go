@@ -85,6 +85,9 @@ func TestWaitForLogFailsDueToOOMKilledContainer(t *testing.T) {
 		if err.Error() != expected {
 			t.Fatalf("expected %q, got %q", expected, err.Error())
 		}
+		if !IsPermanent(err) {
+			t.Fatal("expected a permanent error")
+		}
 	}
 }
 
@@ -144,3 +147,119 @@ func TestWaitForLogFailsDueToUnexpectedContainerStatus(t *testing.T) {
 		}
 	}
 }
+
+// chunkReader returns the chunks one by one, as the logs streamed by the daemon
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.chunks[0])
+	r.chunks[0] = r.chunks[0][n:]
+	if r.chunks[0] == "" {
+		r.chunks = r.chunks[1:]
+	}
+
+	return n, nil
+}
+
+func TestCountOccurrences(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		log    string
+		limit  int
+		want   int
+	}{
+		{
+			name:   "occurrence in a chunk",
+			chunks: []string{"starting\nready\n"},
+			log:    "ready",
+			limit:  2,
+			want:   1,
+		},
+		{
+			name:   "occurrence across chunks",
+			chunks: []string{"starting\nre", "ad", "y\n"},
+			log:    "ready",
+			limit:  2,
+			want:   1,
+		},
+		{
+			name:   "non-overlapping occurrences",
+			chunks: []string{"aa", "a", "a"},
+			log:    "aa",
+			limit:  5,
+			want:   2,
+		},
+		{
+			name:   "stops at the limit",
+			chunks: []string{"ready\n", "ready\n", "ready\n"},
+			log:    "ready",
+			limit:  2,
+			want:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := countOccurrences(&chunkReader{chunks: tt.chunks}, tt.log, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected %d occurrences, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCountOccurrencesLargeLogs(t *testing.T) {
+	// the logs of a chatty container, larger than the chunks, with the log at the end
+	logs := io.MultiReader(
+		bytes.NewReader(bytes.Repeat([]byte("[INFO] still starting\n"), 100000)),
+		bytes.NewReader([]byte("[INFO] started\n")),
+	)
+
+	got, read, err := countOccurrences(logs, "started\n", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 1 {
+		t.Fatalf("expected 1 occurrence, got %d", got)
+	}
+
+	if read <= logChunkSize {
+		t.Fatalf("expected the logs to be read in several chunks, read %d bytes", read)
+	}
+}
+
+func TestWaitForLogInheritsDefaultStartupTimeout(t *testing.T) {
+	target := NopStrategyTarget{
+		ReaderCloser: io.NopCloser(bytes.NewReader([]byte("kubernetes"))),
+	}
+
+	ctx := WithDefaultStartupTimeout(context.Background(), 200*time.Millisecond)
+
+	start := time.Now()
+	err := NewLogStrategy("docker").WaitUntilReady(ctx, target)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the default startup timeout was not inherited, waited %s", elapsed)
+	}
+
+	// the timeout of the strategy takes precedence
+	ctx = WithDefaultStartupTimeout(context.Background(), time.Hour)
+	err = NewLogStrategy("docker").WithStartupTimeout(100*time.Millisecond).WaitUntilReady(ctx, target)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
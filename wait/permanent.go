@@ -0,0 +1,33 @@
+package wait
+
+import "errors"
+
+// PermanentError is returned by the strategies on a condition which can't be recovered by waiting, e.g. the container
+// exited, or the command of an exec strategy is not found, so the wait is aborted immediately instead of retrying until
+// the startup timeout. The targets and the custom strategies can return it too, wrapping their errors with Permanent.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps the given error into a PermanentError, unless it's nil or already permanent
+func Permanent(err error) error {
+	if err == nil || IsPermanent(err) {
+		return err
+	}
+
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent returns true if the error, or any error it wraps, is a PermanentError
+func IsPermanent(err error) bool {
+	var permanentErr *PermanentError
+	return errors.As(err, &permanentErr)
+}
@@ -17,24 +17,22 @@ const defaultForSqlQuery = "SELECT 1"
 // ForSQL constructs a new waitForSql strategy for the given driver
 func ForSQL(port nat.Port, driver string, url func(host string, port nat.Port) string) *waitForSql {
 	return &waitForSql{
-		Port:           port,
-		URL:            url,
-		Driver:         driver,
-		startupTimeout: defaultStartupTimeout(),
-		PollInterval:   defaultPollInterval(),
-		query:          defaultForSqlQuery,
+		Port:         port,
+		URL:          url,
+		Driver:       driver,
+		PollInterval: defaultPollInterval(),
+		query:        defaultForSqlQuery,
 	}
 }
 
 type waitForSql struct {
 	timeout *time.Duration
 
-	URL            func(host string, port nat.Port) string
-	Driver         string
-	Port           nat.Port
-	startupTimeout time.Duration
-	PollInterval   time.Duration
-	query          string
+	URL          func(host string, port nat.Port) string
+	Driver       string
+	Port         nat.Port
+	PollInterval time.Duration
+	query        string
 }
 
 // WithStartupTimeout can be used to change the default startup timeout
@@ -63,7 +61,7 @@ func (w *waitForSql) Timeout() *time.Duration {
 //
 // If it doesn't succeed until the timeout value which defaults to 60 seconds, it will return an error.
 func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget) (err error) {
-	timeout := defaultStartupTimeout()
+	timeout := defaultStartupTimeout(ctx)
 	if w.timeout != nil {
 		timeout = *w.timeout
 	}
@@ -71,7 +69,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	host, err := target.Host(ctx)
+	host, err := targetHost(ctx, target, w.Port)
 	if err != nil {
 		return
 	}
@@ -81,6 +79,9 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 
 	var port nat.Port
 	port, err = target.MappedPort(ctx, w.Port)
+	if IsPermanent(err) {
+		return err
+	}
 
 	for port == "" {
 		select {
@@ -91,6 +92,9 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 				return err
 			}
 			port, err = target.MappedPort(ctx, w.Port)
+			if IsPermanent(err) {
+				return err
+			}
 		}
 	}
 
@@ -0,0 +1,207 @@
+package wait
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var _ Strategy = (*TLSStrategy)(nil)
+var _ StrategyTimeout = (*TLSStrategy)(nil)
+
+// TLSStrategy waits until the certificate files generated by a container are available, e.g. the certificates
+// self-generated at first boot by Couchbase or Elasticsearch, and builds the TLS configuration trusting them
+type TLSStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	certPath     string
+	keyPath      string
+	rootCAPaths  []string
+	serverName   string
+	PollInterval time.Duration
+
+	tlsConfig *tls.Config
+}
+
+// NewTLSStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
+func NewTLSStrategy(certPath string, keyPath string) *TLSStrategy {
+	return &TLSStrategy{
+		certPath:     certPath,
+		keyPath:      keyPath,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForTLSCert is the default construction for the fluid interface. It waits for the PEM certificate at the given path
+// in the container, and its private key if the key path is not empty, to be present and valid.
+//
+// For Example:
+//
+//	wait.
+//		ForTLSCert("/certs/server.crt", "/certs/server.key").
+//		WithPollInterval(1 * time.Second)
+func ForTLSCert(certPath string, keyPath string) *TLSStrategy {
+	return NewTLSStrategy(certPath, keyPath)
+}
+
+// fluent builders for each property
+// since go has neither covariance nor generics, the return type must be the type of the concrete implementation
+// this is true for all properties, even the "shared" ones like startupTimeout
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *TLSStrategy) WithStartupTimeout(startupTimeout time.Duration) *TLSStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *TLSStrategy) WithPollInterval(pollInterval time.Duration) *TLSStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+// WithRootCAs adds the PEM certificates of the authorities at the given paths in the container to the trusted ones,
+// e.g. when the certificate of the server is signed by a CA generated by the container
+func (ws *TLSStrategy) WithRootCAs(paths ...string) *TLSStrategy {
+	ws.rootCAPaths = append(ws.rootCAPaths, paths...)
+	return ws
+}
+
+// WithServerName sets the server name verified by the TLS configuration, e.g. the host name the certificate was issued for
+func (ws *TLSStrategy) WithServerName(serverName string) *TLSStrategy {
+	ws.serverName = serverName
+	return ws
+}
+
+func (ws *TLSStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// TLSConfig returns the TLS configuration built from the certificates of the container once it's ready, nil before.
+// It trusts the certificate and the root CAs, and presents the certificate as client certificate if the key path is set.
+func (ws *TLSStrategy) TLSConfig() *tls.Config {
+	return ws.tlsConfig
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *TLSStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout(ctx)
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("%w: %s", ctx.Err(), lastErr)
+			}
+			return ctx.Err()
+		case <-time.After(ws.PollInterval):
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+
+			config, err := ws.loadTLSConfig(ctx, target)
+			if IsPermanent(err) {
+				return err
+			} else if err != nil {
+				// the files could not exist yet, or be partially written
+				lastErr = err
+				continue
+			}
+
+			ws.tlsConfig = config
+			return nil
+		}
+	}
+}
+
+// loadTLSConfig reads the certificates of the container and builds the TLS configuration
+func (ws *TLSStrategy) loadTLSConfig(ctx context.Context, target StrategyTarget) (*tls.Config, error) {
+	certPEM, err := readTargetFile(ctx, target, ws.certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(certPEM) {
+		return nil, fmt.Errorf("no valid PEM certificate in %s", ws.certPath)
+	}
+
+	for _, path := range ws.rootCAPaths {
+		caPEM, err := readTargetFile(ctx, target, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid PEM certificate in %s", path)
+		}
+	}
+
+	config := &tls.Config{
+		RootCAs:    roots,
+		ServerName: ws.serverName,
+	}
+
+	if ws.keyPath != "" {
+		keyPEM, err := readTargetFile(ctx, target, ws.keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid key pair %s and %s", err, ws.certPath, ws.keyPath)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// fileTarget is implemented by the targets copying the files of their container, e.g. the Docker containers
+type fileTarget interface {
+	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
+}
+
+// readTargetFile reads a file of the container of the target, copying it if the target supports it,
+// or with the cat command otherwise
+func readTargetFile(ctx context.Context, target StrategyTarget, path string) ([]byte, error) {
+	if t, ok := target.(fileTarget); ok {
+		r, err := t.CopyFileFromContainer(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		return io.ReadAll(r)
+	}
+
+	exitCode, r, err := target.Exec(ctx, []string{"cat", path}, tcexec.Multiplexed())
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, fmt.Errorf("could not read %s, exit code %d", path, exitCode)
+	}
+	if r == nil {
+		return nil, errors.New("the target returned no output")
+	}
+
+	return io.ReadAll(r)
+}
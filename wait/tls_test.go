@@ -0,0 +1,125 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// fileStrategyTarget is a target whose files are only available after the given number of reads
+type fileStrategyTarget struct {
+	MockStrategyTarget
+	files       map[string][]byte
+	availableAt int
+	reads       int
+}
+
+func (st *fileStrategyTarget) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	st.reads++
+	content, ok := st.files[filePath]
+	if !ok || st.reads < st.availableAt {
+		return nil, errors.New("Could not find the file " + filePath)
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func testdataFiles(t *testing.T, names ...string) map[string][]byte {
+	files := map[string][]byte{}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files["/certs/"+name] = content
+	}
+
+	return files
+}
+
+func runningState(context.Context) (*types.ContainerState, error) {
+	return &types.ContainerState{Running: true}, nil
+}
+
+func TestWaitForTLSCert(t *testing.T) {
+	target := &fileStrategyTarget{
+		MockStrategyTarget: MockStrategyTarget{StateImpl: runningState},
+		files:              testdataFiles(t, "tls.pem", "tls-key.pem", "root.pem"),
+		availableAt:        3,
+	}
+
+	strategy := ForTLSCert("/certs/tls.pem", "/certs/tls-key.pem").
+		WithRootCAs("/certs/root.pem").
+		WithServerName("testcontainer.go.test").
+		WithPollInterval(10 * time.Millisecond).
+		WithStartupTimeout(5 * time.Second)
+
+	if strategy.TLSConfig() != nil {
+		t.Fatal("expected no TLS config before the strategy is ready")
+	}
+
+	if err := strategy.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+
+	config := strategy.TLSConfig()
+	if config == nil {
+		t.Fatal("expected a TLS config")
+	}
+	if len(config.Certificates) != 1 {
+		t.Fatalf("expected the key pair as client certificate, got %d certificates", len(config.Certificates))
+	}
+	if config.RootCAs == nil || config.ServerName != "testcontainer.go.test" {
+		t.Fatalf("unexpected TLS config %+v", config)
+	}
+}
+
+func TestWaitForTLSCertWithExec(t *testing.T) {
+	files := testdataFiles(t, "tls.pem")
+	target := MockStrategyTarget{
+		StateImpl: runningState,
+		ExecImpl: func(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+			content, ok := files[cmd[1]]
+			if !ok {
+				return 1, bytes.NewReader(nil), nil
+			}
+			return 0, bytes.NewReader(content), nil
+		},
+	}
+
+	strategy := ForTLSCert("/certs/tls.pem", "").WithPollInterval(10 * time.Millisecond)
+	if err := strategy.WaitUntilReady(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+
+	if config := strategy.TLSConfig(); config == nil || config.RootCAs == nil || len(config.Certificates) != 0 {
+		t.Fatalf("expected a TLS config trusting the certificate only, got %+v", config)
+	}
+}
+
+func TestWaitForTLSCertInvalidKeyPair(t *testing.T) {
+	files := testdataFiles(t, "tls.pem", "root.pem")
+	files["/certs/tls-key.pem"] = files["/certs/root.pem"]
+
+	target := &fileStrategyTarget{
+		MockStrategyTarget: MockStrategyTarget{StateImpl: runningState},
+		files:              files,
+	}
+
+	err := ForTLSCert("/certs/tls.pem", "/certs/tls-key.pem").
+		WithPollInterval(10*time.Millisecond).
+		WithStartupTimeout(200*time.Millisecond).
+		WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+// Implement interface
+var _ Strategy = (*VolumeStrategy)(nil)
+var _ StrategyTimeout = (*VolumeStrategy)(nil)
+
+// VolumeStrategy waits until a path exists in a named volume mounted in the container, e.g. the artifacts produced
+// by an init job for another container. The path is checked with the files of the container, so it's also checked
+// once the container has exited, as the init jobs do.
+type VolumeStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	Volume       string
+	Path         string
+	PollInterval time.Duration
+}
+
+// NewVolumeStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
+func NewVolumeStrategy(volume string, path string) *VolumeStrategy {
+	return &VolumeStrategy{
+		Volume:       volume,
+		Path:         path,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForVolume is the default construction for the fluid interface. The path is relative to the root of the volume.
+//
+// For Example:
+//
+//	wait.
+//		ForVolume("artifacts", "build/app.jar").
+//		WithPollInterval(1 * time.Second)
+func ForVolume(volume string, path string) *VolumeStrategy {
+	return NewVolumeStrategy(volume, path)
+}
+
+// fluent builders for each property
+// since go has neither covariance nor generics, the return type must be the type of the concrete implementation
+// this is true for all properties, even the "shared" ones like startupTimeout
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *VolumeStrategy) WithStartupTimeout(startupTimeout time.Duration) *VolumeStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *VolumeStrategy) WithPollInterval(pollInterval time.Duration) *VolumeStrategy {
+	ws.PollInterval = pollInterval
+	return ws
+}
+
+func (ws *VolumeStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *VolumeStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	timeout := defaultStartupTimeout(ctx)
+	if ws.timeout != nil {
+		timeout = *ws.timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	t, ok := target.(mountsTarget)
+	if !ok {
+		return Permanent(errors.New("the target doesn't report the mounts of its container"))
+	}
+
+	mounts, err := t.MountPoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	destination, err := volumeDestination(mounts, ws.Volume)
+	if err != nil {
+		return err
+	}
+	filePath := path.Join(destination, strings.TrimPrefix(ws.Path, "/"))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s was not found in the volume %s", ctx.Err(), ws.Path, ws.Volume)
+		case <-time.After(ws.PollInterval):
+			if targetPathExists(ctx, target, filePath) {
+				return nil
+			}
+
+			// the path won't appear once the container has exited
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// mountsTarget is implemented by the targets reporting the mounts of their container, e.g. the Docker containers
+type mountsTarget interface {
+	MountPoints(ctx context.Context) ([]types.MountPoint, error)
+}
+
+// volumeDestination returns the path the named volume is mounted at in the container,
+// or a permanent error if it's not mounted
+func volumeDestination(mounts []types.MountPoint, volume string) (string, error) {
+	for _, m := range mounts {
+		if m.Type == mount.TypeVolume && m.Name == volume {
+			return m.Destination, nil
+		}
+	}
+
+	return "", Permanent(fmt.Errorf("the volume %s is not mounted in the container", volume))
+}
+
+// targetPathExists returns true if the path exists in the container of the target, copying it if the target supports it,
+// which also works once the container has exited, or with the test command otherwise
+func targetPathExists(ctx context.Context, target StrategyTarget, filePath string) bool {
+	if t, ok := target.(fileTarget); ok {
+		r, err := t.CopyFileFromContainer(ctx, filePath)
+		if err != nil {
+			return false
+		}
+		_ = r.Close()
+		return true
+	}
+
+	exitCode, _, err := target.Exec(ctx, []string{"test", "-e", filePath})
+	return err == nil && exitCode == 0
+}
@@ -0,0 +1,94 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+// volumeStrategyTarget is a target mounting volumes, whose files are only available after the given number of reads
+type volumeStrategyTarget struct {
+	fileStrategyTarget
+	mounts []types.MountPoint
+}
+
+func (st *volumeStrategyTarget) MountPoints(ctx context.Context) ([]types.MountPoint, error) {
+	return st.mounts, nil
+}
+
+func TestWaitForVolume(t *testing.T) {
+	target := &volumeStrategyTarget{
+		fileStrategyTarget: fileStrategyTarget{
+			MockStrategyTarget: MockStrategyTarget{StateImpl: runningState},
+			files:              map[string][]byte{"/artifacts/build/app.jar": []byte("jar")},
+			availableAt:        3,
+		},
+		mounts: []types.MountPoint{
+			{Type: mount.TypeBind, Source: "/tmp", Destination: "/tmp"},
+			{Type: mount.TypeVolume, Name: "artifacts", Destination: "/artifacts"},
+		},
+	}
+
+	err := ForVolume("artifacts", "/build/app.jar").
+		WithPollInterval(10*time.Millisecond).
+		WithStartupTimeout(5*time.Second).
+		WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.reads != 3 {
+		t.Fatalf("expected the strategy to wait for the file, got %d reads", target.reads)
+	}
+}
+
+func TestWaitForVolumeFails(t *testing.T) {
+	t.Run("volume not mounted", func(t *testing.T) {
+		target := &volumeStrategyTarget{
+			fileStrategyTarget: fileStrategyTarget{MockStrategyTarget: MockStrategyTarget{StateImpl: runningState}},
+		}
+
+		err := ForVolume("artifacts", "app.jar").WaitUntilReady(context.Background(), target)
+		if !IsPermanent(err) {
+			t.Fatalf("expected a permanent error, got %v", err)
+		}
+	})
+
+	t.Run("container exited without the file", func(t *testing.T) {
+		target := &volumeStrategyTarget{
+			fileStrategyTarget: fileStrategyTarget{
+				MockStrategyTarget: MockStrategyTarget{
+					StateImpl: func(context.Context) (*types.ContainerState, error) {
+						return &types.ContainerState{Status: "exited", ExitCode: 1}, nil
+					},
+				},
+			},
+			mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "artifacts", Destination: "/artifacts"}},
+		}
+
+		err := ForVolume("artifacts", "app.jar").
+			WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		if !IsPermanent(err) {
+			t.Fatalf("expected a permanent error, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		target := &volumeStrategyTarget{
+			fileStrategyTarget: fileStrategyTarget{MockStrategyTarget: MockStrategyTarget{StateImpl: runningState}},
+			mounts:             []types.MountPoint{{Type: mount.TypeVolume, Name: "artifacts", Destination: "/artifacts"}},
+		}
+
+		err := ForVolume("artifacts", "app.jar").
+			WithPollInterval(10*time.Millisecond).
+			WithStartupTimeout(100*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the deadline to be exceeded, got %v", err)
+		}
+	})
+}
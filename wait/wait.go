@@ -31,6 +31,37 @@ type StrategyTarget interface {
 	State(context.Context) (*types.ContainerState, error)
 }
 
+// osTarget is implemented by the targets reporting the operating system of their container, e.g. windows,
+// so the strategies can skip the checks executed with the shell of the Linux containers
+type osTarget interface {
+	OS(ctx context.Context) (string, error)
+}
+
+// targetOS returns the operating system of the container of the target, linux if the target doesn't report it
+func targetOS(ctx context.Context, target StrategyTarget) (string, error) {
+	t, ok := target.(osTarget)
+	if !ok {
+		return "linux", nil
+	}
+
+	return t.OS(ctx)
+}
+
+// portHostTarget is implemented by the targets reaching some of their ports on another host than the others,
+// e.g. the ports forwarded by a port tunnel, while the UDP ports are reached on the Docker host
+type portHostTarget interface {
+	HostForPort(ctx context.Context, port nat.Port) (string, error)
+}
+
+// targetHost returns the host reaching the given port of the target, the host of the target if it doesn't report it per port
+func targetHost(ctx context.Context, target StrategyTarget, port nat.Port) (string, error) {
+	if t, ok := target.(portHostTarget); ok && port != "" {
+		return t.HostForPort(ctx, port)
+	}
+
+	return target.Host(ctx)
+}
+
 func checkTarget(ctx context.Context, target StrategyTarget) error {
 	state, err := target.State(ctx)
 	if err != nil {
@@ -40,20 +71,35 @@ func checkTarget(ctx context.Context, target StrategyTarget) error {
 	return checkState(state)
 }
 
+// checkState returns a permanent error if the container is not running, as it won't become ready
 func checkState(state *types.ContainerState) error {
 	switch {
 	case state.Running:
 		return nil
 	case state.OOMKilled:
-		return errors.New("container crashed with out-of-memory (OOMKilled)")
+		return Permanent(errors.New("container crashed with out-of-memory (OOMKilled)"))
 	case state.Status == "exited":
-		return fmt.Errorf("container exited with code %d", state.ExitCode)
+		return Permanent(fmt.Errorf("container exited with code %d", state.ExitCode))
 	default:
-		return fmt.Errorf("unexpected container status %q", state.Status)
+		return Permanent(fmt.Errorf("unexpected container status %q", state.Status))
 	}
 }
 
-func defaultStartupTimeout() time.Duration {
+// startupTimeoutKey is the key of the default startup timeout in the context passed to the strategies
+type startupTimeoutKey struct{}
+
+// WithDefaultStartupTimeout returns a context setting the startup timeout of the strategies which don't define their own,
+// instead of 60 seconds, e.g. the StartupTimeout of a container request
+func WithDefaultStartupTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, startupTimeoutKey{}, timeout)
+}
+
+// defaultStartupTimeout returns the startup timeout set in the context, 60 seconds if there is none
+func defaultStartupTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(startupTimeoutKey{}).(time.Duration); ok && timeout > 0 {
+		return timeout
+	}
+
 	return 60 * time.Second
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"testing"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/go-connections/nat"
@@ -44,3 +45,52 @@ func (st MockStrategyTarget) Exec(ctx context.Context, cmd []string, options ...
 func (st MockStrategyTarget) State(ctx context.Context) (*types.ContainerState, error) {
 	return st.StateImpl(ctx)
 }
+
+// portHostMockTarget is a MockStrategyTarget reaching its TCP ports on another host, as with a port tunnel
+type portHostMockTarget struct {
+	MockStrategyTarget
+}
+
+func (st portHostMockTarget) HostForPort(ctx context.Context, port nat.Port) (string, error) {
+	if port.Proto() == "tcp" {
+		return "127.0.0.1", nil
+	}
+
+	return st.Host(ctx)
+}
+
+func TestTargetHost(t *testing.T) {
+	ctx := context.Background()
+
+	target := MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "10.0.0.5", nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target StrategyTarget
+		port   nat.Port
+		want   string
+	}{
+		{name: "target without host per port", target: target, port: "80/tcp", want: "10.0.0.5"},
+		{name: "port reached on another host", target: portHostMockTarget{target}, port: "80/tcp", want: "127.0.0.1"},
+		{name: "port reached on the host of the target", target: portHostMockTarget{target}, port: "53/udp", want: "10.0.0.5"},
+		{name: "unknown port", target: portHostMockTarget{target}, want: "10.0.0.5"},
+		{name: "cached target", target: NewCachedTarget(portHostMockTarget{target}, defaultTargetCacheTTL()), port: "80/tcp", want: "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, err := targetHost(ctx, tt.target, tt.port)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if host != tt.want {
+				t.Fatalf("expected the host %s, got %s", tt.want, host)
+			}
+		})
+	}
+}